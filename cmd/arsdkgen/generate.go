@@ -0,0 +1,509 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// maxLineLength is the length, counting each tab as one character, that lines
+// of generated code are kept within where possible. Since tabs are single
+// bytes, this can be compared directly to len(line).
+const maxLineLength = 80
+
+// generator accumulates the source of a single generated file.
+type generator struct {
+	project     project
+	packageName string
+	source      string
+	buf         bytes.Buffer
+}
+
+// generate returns the formatted source of a Go file in the named package
+// that implements the given project. source is the name of the xml file the
+// project was read from and is mentioned in the generated file's header.
+func generate(p project, packageName, source string) ([]byte, error) {
+	g := &generator{
+		project:     p,
+		packageName: packageName,
+		source:      source,
+	}
+	g.header()
+	for _, c := range p.Classes {
+		if len(c.Commands) == 0 {
+			continue
+		}
+		g.enums(c)
+		if c.isD2C() {
+			g.d2cClass(c)
+		} else {
+			g.c2dClass(c)
+		}
+	}
+	formatted, err := format.Source(g.buf.Bytes())
+	if err != nil {
+		return nil, errors.Wrap(err, "error formatting generated code")
+	}
+	return formatted, nil
+}
+
+func (g *generator) printf(format string, args ...interface{}) {
+	fmt.Fprintf(&g.buf, format, args...)
+}
+
+// comment prints text as a comment, wrapped to fit within maxLineLength.
+// Lines after the first are indented by contIndent.
+func (g *generator) comment(indent, text, contIndent string) {
+	for _, line := range wrap(indent+"// ", text, indent+"// "+contIndent) {
+		g.printf("%s\n", line)
+	}
+}
+
+func (g *generator) header() {
+	g.printf("// Code generated by arsdkgen from %s. DO NOT EDIT.\n\n", g.source)
+	g.printf("package %s\n\n", g.packageName)
+	imports := []string{}
+	if g.hasEnums() {
+		imports = append(imports, `"fmt"`, "")
+	}
+	if g.hasD2CClasses() {
+		imports = append(imports, `log "github.com/Sirupsen/logrus"`)
+	}
+	if g.hasD2CClasses() || g.hasC2DClasses() {
+		imports = append(
+			imports,
+			`"github.com/krancour/go-parrot/protocols/arcommands"`,
+		)
+	}
+	if g.hasAckedC2DCommands() {
+		imports = append(
+			imports,
+			`"github.com/krancour/go-parrot/protocols/arnetwork"`,
+		)
+	}
+	if g.hasC2DClasses() {
+		imports = append(imports, `"github.com/pkg/errors"`)
+	}
+	if len(imports) == 0 {
+		return
+	}
+	g.printf("import (\n")
+	for _, imp := range imports {
+		g.printf("%s\n", imp)
+	}
+	g.printf(")\n")
+}
+
+func (g *generator) hasEnums() bool {
+	for _, c := range g.project.Classes {
+		for _, cmd := range c.Commands {
+			for _, a := range cmd.Args {
+				if len(a.Enums) > 0 {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+func (g *generator) hasD2CClasses() bool {
+	for _, c := range g.project.Classes {
+		if c.isD2C() && len(c.Commands) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func (g *generator) hasC2DClasses() bool {
+	for _, c := range g.project.Classes {
+		if !c.isD2C() && len(c.Commands) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// hasAckedC2DCommands returns a bool indicating whether any c2d command is
+// sent over the ack buffer, for which a function that also returns a receipt
+// is generated.
+func (g *generator) hasAckedC2DCommands() bool {
+	for _, c := range g.project.Classes {
+		if c.isD2C() {
+			continue
+		}
+		for _, cmd := range c.Commands {
+			if cmd.isAcked() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// enumTypeName returns the name of the type generated for an enum argument.
+func enumTypeName(c class, cmd command, a arg) string {
+	return exportedName(c.Name) + exportedName(cmd.Name) + exportedName(a.Name)
+}
+
+// argType returns the Go type of an argument. This is the generated enum type
+// for enum arguments.
+func argType(c class, cmd command, a arg) string {
+	if len(a.Enums) > 0 {
+		return enumTypeName(c, cmd, a)
+	}
+	t, _ := goType(a.Type)
+	return t
+}
+
+// enums generates a type, constants, a name table, and String() and IsValid()
+// functions for each enum argument of each of the class's commands. The
+// resulting types implement arcommands.Enum.
+func (g *generator) enums(c class) {
+	for _, cmd := range c.Commands {
+		for _, a := range cmd.Args {
+			if len(a.Enums) == 0 {
+				continue
+			}
+			typeName := enumTypeName(c, cmd, a)
+			namesVar := unexportedName(typeName) + "Names"
+			g.printf("\n")
+			g.comment(
+				"",
+				fmt.Sprintf(
+					"%s is a type for constants used as the %s argument of the %s %s "+
+						"command. %s",
+					typeName,
+					a.Name,
+					c.Name,
+					cmd.Name,
+					sentence(a.Description),
+				),
+				"",
+			)
+			g.printf("type %s int32\n\n", typeName)
+			g.printf("const (\n")
+			for i, e := range a.Enums {
+				constName := typeName + exportedName(e.Name)
+				g.comment(
+					"\t",
+					fmt.Sprintf("%s is %s: %s", constName, e.Name, e.Description),
+					"",
+				)
+				// Values are numbered in the order they are declared
+				if i == 0 {
+					g.printf("\t%s %s = iota\n", constName, typeName)
+				} else {
+					g.printf("\t%s\n", constName)
+				}
+			}
+			g.printf(")\n\n")
+			g.comment(
+				"",
+				fmt.Sprintf(
+					"%s maps each %s value to its name as defined by the device SDK.",
+					namesVar,
+					typeName,
+				),
+				"",
+			)
+			g.printf("var %s = map[%s]string{\n", namesVar, typeName)
+			for _, e := range a.Enums {
+				g.printf("\t%s%s: %q,\n", typeName, exportedName(e.Name), e.Name)
+			}
+			g.printf("}\n\n")
+			g.printf("func (e %s) String() string {\n", typeName)
+			g.printf("\tif name, ok := %s[e]; ok {\n", namesVar)
+			g.printf("\t\treturn name\n")
+			g.printf("\t}\n")
+			g.printf("\treturn fmt.Sprintf(\"%s(%%d)\", int32(e))\n", typeName)
+			g.printf("}\n\n")
+			g.comment(
+				"",
+				fmt.Sprintf(
+					"IsValid returns true if the %s is one defined by the device SDK.",
+					typeName,
+				),
+				"",
+			)
+			g.printf("func (e %s) IsValid() bool {\n", typeName)
+			g.printf("\t_, ok := %s[e]\n", namesVar)
+			g.printf("\treturn ok\n")
+			g.printf("}\n")
+		}
+	}
+}
+
+// d2cClass generates, for a class of commands sent from the device to the
+// client, an interface listing the class's handlers, a struct implementing
+// every handler by logging, and a function returning the class's commands.
+func (g *generator) d2cClass(c class) {
+	baseName := unexportedName(exportedName(c.Name))
+	handlersType := baseName + "Handlers"
+	defaultsType := baseName + "Defaults"
+	commandsFunc := baseName + "D2CCommands"
+
+	g.printf("\n")
+	g.comment(
+		"",
+		fmt.Sprintf(
+			"%s is implemented by the %s class, which handles each of its "+
+				"commands. %s",
+			handlersType,
+			c.Name,
+			sentence(c.Description),
+		),
+		"",
+	)
+	g.printf("type %s interface {\n", handlersType)
+	for _, cmd := range c.Commands {
+		g.printf("\t%s(args []interface{}) error\n", handlerName(cmd.Name))
+	}
+	g.printf("}\n\n")
+
+	g.comment(
+		"",
+		fmt.Sprintf(
+			"%s implements every handler of the %s class by logging that the "+
+				"command was received. The %s class can embed it and implement only "+
+				"the handlers for commands it acts upon.",
+			defaultsType,
+			c.Name,
+			c.Name,
+		),
+		"",
+	)
+	g.printf("type %s struct{}\n\n", defaultsType)
+
+	g.comment(
+		"",
+		fmt.Sprintf(
+			"%s returns the commands of the %s class, each dispatched to the "+
+				"corresponding handler.",
+			commandsFunc,
+			c.Name,
+		),
+		"",
+	)
+	g.printf("func %s(\n\thandlers %s,\n) []arcommands.D2CCommand {\n",
+		commandsFunc, handlersType)
+	g.printf("\treturn []arcommands.D2CCommand{\n")
+	for _, cmd := range c.Commands {
+		g.printf("\t\tarcommands.NewD2CCommand(\n")
+		g.printf("\t\t\t%d,\n", cmd.ID)
+		g.printf("\t\t\t%q,\n", cmd.Name)
+		g.printf("\t\t\t[]interface{}{\n")
+		for _, a := range cmd.Args {
+			g.printf("\t\t\t\t%s, // %s,\n", zeroValue(argType(c, cmd, a)), a.Name)
+		}
+		g.printf("\t\t\t},\n")
+		g.printf("\t\t\thandlers.%s,\n", handlerName(cmd.Name))
+		g.printf("\t\t),\n")
+	}
+	g.printf("\t}\n")
+	g.printf("}\n")
+
+	for _, cmd := range c.Commands {
+		g.printf("\n")
+		g.commandComment(cmd)
+		signature := fmt.Sprintf(
+			"func (%s) %s(args []interface{}) error {",
+			defaultsType,
+			handlerName(cmd.Name),
+		)
+		if len(signature) > maxLineLength {
+			signature = fmt.Sprintf(
+				"func (%s) %s(\n\targs []interface{},\n) error {",
+				defaultsType,
+				handlerName(cmd.Name),
+			)
+		}
+		g.printf("%s\n", signature)
+		for i, a := range cmd.Args {
+			g.printf("\t// %s := args[%d].(%s)\n", a.Name, i, argType(c, cmd, a))
+			g.argComment(a)
+		}
+		g.printf(
+			"\tlog.Info(\"%s.%s() called\")\n",
+			g.project.Name,
+			handlerName(cmd.Name),
+		)
+		g.printf("\treturn nil\n")
+		g.printf("}\n")
+	}
+}
+
+// c2dClass generates a function for sending each of the class's commands from
+// the client to the device.
+func (g *generator) c2dClass(c class) {
+	for _, cmd := range c.Commands {
+		funcName := "send" + exportedName(c.Name) + exportedName(cmd.Name)
+		bufferID, _ := bufferIDName(cmd.Buffer)
+		g.printf("\n")
+		g.comment(
+			"",
+			fmt.Sprintf(
+				"%s sends the %s %s command to the device.",
+				funcName,
+				c.Name,
+				cmd.Name,
+			),
+			"",
+		)
+		g.commandComment(cmd)
+		g.printf("func %s(\n", funcName)
+		g.printf("\tc2dCommandClient arcommands.C2DCommandClient,\n")
+		for _, a := range cmd.Args {
+			g.printf("\t%s %s,\n", paramName(a.Name), argType(c, cmd, a))
+		}
+		g.printf(") error {\n")
+		for _, a := range cmd.Args {
+			g.printf("\t// %s:\n", paramName(a.Name))
+			g.argComment(a)
+		}
+		g.printf("\tif err := c2dCommandClient.SendCommand(\n")
+		g.printf("\t\t%s,\n", bufferID)
+		g.printf("\t\t%d, // %s\n", g.project.ID, g.project.Name)
+		g.printf("\t\t%d, // %s\n", c.ID, c.Name)
+		g.printf("\t\t%d, // %s\n", cmd.ID, cmd.Name)
+		for _, a := range cmd.Args {
+			g.printf("\t\t%s,\n", paramName(a.Name))
+		}
+		g.printf("\t); err != nil {\n")
+		g.printf(
+			"\t\treturn errors.Wrap(err, %q)\n",
+			fmt.Sprintf("error sending %s command", cmd.Name),
+		)
+		g.printf("\t}\n")
+		g.printf("\treturn nil\n")
+		g.printf("}\n")
+		if cmd.isAcked() {
+			g.c2dCommandWithReceipt(c, cmd, funcName)
+		}
+	}
+}
+
+// c2dCommandWithReceipt generates a variant of the named function for sending
+// a command over the ack buffer that also returns a receipt for the outcome of
+// delivering the command.
+func (g *generator) c2dCommandWithReceipt(
+	c class,
+	cmd command,
+	funcName string,
+) {
+	g.printf("\n")
+	g.comment(
+		"",
+		fmt.Sprintf(
+			"%sWithReceipt is like %s, but also returns an arnetwork.Receipt "+
+				"that is resolved with the outcome of delivering the command.",
+			funcName,
+			funcName,
+		),
+		"",
+	)
+	g.printf("func %sWithReceipt(\n", funcName)
+	g.printf("\tc2dCommandClient arcommands.C2DCommandClient,\n")
+	for _, a := range cmd.Args {
+		g.printf("\t%s %s,\n", paramName(a.Name), argType(c, cmd, a))
+	}
+	g.printf(") (*arnetwork.Receipt, error) {\n")
+	g.printf("\treceipt, err := c2dCommandClient.SendCommandWithReceipt(\n")
+	g.printf("\t\tarcommands.C2DAckBufferID,\n")
+	g.printf("\t\t%d, // %s\n", g.project.ID, g.project.Name)
+	g.printf("\t\t%d, // %s\n", c.ID, c.Name)
+	g.printf("\t\t%d, // %s\n", cmd.ID, cmd.Name)
+	for _, a := range cmd.Args {
+		g.printf("\t\t%s,\n", paramName(a.Name))
+	}
+	g.printf("\t)\n")
+	g.printf("\tif err != nil {\n")
+	g.printf(
+		"\t\treturn nil, errors.Wrap(err, %q)\n",
+		fmt.Sprintf("error sending %s command", cmd.Name),
+	)
+	g.printf("\t}\n")
+	g.printf("\treturn receipt, nil\n")
+	g.printf("}\n")
+}
+
+// commandComment prints the structured documentation of a command in the same
+// form used by hand-written handlers. Unlike in hand-written handlers,
+// continuation lines are not indented, since gofmt would otherwise reformat
+// them as code blocks.
+func (g *generator) commandComment(cmd command) {
+	g.comment("", "Title: "+cmd.Comment.Title, "")
+	g.comment("", "Description: "+cmd.Comment.Desc, "")
+	g.comment("", "Support: "+cmd.Comment.Support, "")
+	if cmd.Comment.Triggered != "" {
+		g.comment("", "Triggered: "+cmd.Comment.Triggered, "")
+	}
+	g.comment("", "Result: "+cmd.Comment.Result, "")
+	if cmd.Deprecated {
+		g.printf("//\n")
+		g.printf("// Deprecated: This command is deprecated by the device SDK.\n")
+	}
+}
+
+// argComment prints the description of an argument and, for enum arguments,
+// each of its values.
+func (g *generator) argComment(a arg) {
+	g.comment("\t", "  "+a.Description, "  ")
+	for i, e := range a.Enums {
+		prefix := fmt.Sprintf("%d: ", i)
+		g.comment(
+			"\t",
+			fmt.Sprintf("  %s%s: %s", prefix, e.Name, e.Description),
+			"  "+strings.Repeat(" ", len(prefix)),
+		)
+	}
+}
+
+// sentence returns text terminated with a period, unless it is already
+// terminated with some other punctuation.
+func sentence(text string) string {
+	if text == "" || strings.HasSuffix(text, ".") ||
+		strings.HasSuffix(text, "!") || strings.HasSuffix(text, "?") {
+		return text
+	}
+	return text + "."
+}
+
+// zeroValue returns an expression for the zero value of the given type, as
+// used in argument templates.
+func zeroValue(t string) string {
+	if t == "string" {
+		return `""`
+	}
+	return t + "(0)"
+}
+
+// wrap greedily wraps text to fit within maxLineLength. The first line is
+// prefixed with firstPrefix and subsequent lines with contPrefix. A single
+// word longer than the available width is never broken.
+func wrap(firstPrefix, text, contPrefix string) []string {
+	lines := []string{}
+	line := firstPrefix
+	// Leading spaces are significant for indented comments, so they are
+	// preserved rather than treated as separators
+	trimmed := strings.TrimLeft(text, " ")
+	line += text[:len(text)-len(trimmed)]
+	empty := true
+	for _, word := range strings.Fields(trimmed) {
+		if !empty && len(line)+1+len(word) > maxLineLength {
+			lines = append(lines, line)
+			line = contPrefix
+			empty = true
+		}
+		if !empty {
+			line += " "
+		}
+		line += word
+		empty = false
+	}
+	return append(lines, strings.TrimRight(line, " "))
+}
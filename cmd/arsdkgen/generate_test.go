@@ -0,0 +1,84 @@
+package main
+
+import (
+	"flag"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+func TestGenerate(t *testing.T) {
+	testCases := []string{
+		"common",
+		"ardrone3",
+	}
+	for _, projectName := range testCases {
+		t.Run(projectName, func(t *testing.T) {
+			xmlFile, err := os.Open(filepath.Join("testdata", projectName+".xml"))
+			require.NoError(t, err)
+			defer xmlFile.Close() // nolint: errcheck
+			p, err := parseProject(xmlFile)
+			require.NoError(t, err)
+			source, err := generate(p, projectName, projectName+".xml")
+			require.NoError(t, err)
+			goldenPath := filepath.Join("testdata", projectName+".golden")
+			if *update {
+				require.NoError(t, ioutil.WriteFile(goldenPath, source, 0644))
+			}
+			golden, err := ioutil.ReadFile(goldenPath)
+			require.NoError(t, err)
+			require.Equal(t, string(golden), string(source))
+		})
+	}
+}
+
+func TestWrap(t *testing.T) {
+	testCases := []struct {
+		name          string
+		firstPrefix   string
+		text          string
+		contPrefix    string
+		expectedLines []string
+	}{
+		{
+			name:          "short text",
+			firstPrefix:   "// ",
+			text:          "Battery state.",
+			contPrefix:    "// ",
+			expectedLines: []string{"// Battery state."},
+		},
+		{
+			name:        "long text",
+			firstPrefix: "\t// ",
+			text: "  State of the x axis (roll) calibration : 1 if calibration is " +
+				"done, 0 otherwise",
+			contPrefix: "\t//   ",
+			expectedLines: []string{
+				"\t//   State of the x axis (roll) calibration : 1 if calibration is " +
+					"done, 0",
+				"\t//   otherwise",
+			},
+		},
+		{
+			name:          "empty text",
+			firstPrefix:   "// ",
+			text:          "Result: ",
+			contPrefix:    "// ",
+			expectedLines: []string{"// Result:"},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			lines := wrap(testCase.firstPrefix, testCase.text, testCase.contPrefix)
+			require.Equal(t, testCase.expectedLines, lines)
+			for _, line := range lines {
+				require.True(t, len(line) <= maxLineLength)
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+// Command arsdkgen generates arcommands code for a feature from one of
+// Parrot's arsdk-xml project files-- e.g. common.xml or ardrone3.xml. It is
+// intended to be run using go generate from within a feature package, against
+// the project files vendored under third_party/arsdk-xml:
+//
+//	//go:generate go run ../../cmd/arsdkgen -xml ../../third_party/arsdk-xml/xml/common.xml -out zz_generated.go
+//
+// By default, the generated file is written to standard output. Use -out to
+// write it to a file instead-- conventionally zz_generated.go.
+//
+// For each class of commands sent from the device to the client, the
+// generated file contains an interface listing the class's handlers, a struct
+// implementing every handler by logging (which a hand-written state class can
+// embed, implementing only the handlers for commands it acts upon), and a
+// function returning the class's commands and argument templates. For each
+// class of commands sent from the client to the device, it contains a function
+// that encodes and sends each command over the appropriate buffer. Every enum
+// argument is given a type implementing arcommands.Enum, constants, and a
+// table of names. Each generated function is documented using the comments
+// found in the xml.
+//
+// A small excerpt of the arsdk-xml project files is kept under testdata for
+// arsdkgen's own tests.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+func main() {
+	xmlPath := flag.String("xml", "", "path to an arsdk-xml project file")
+	packageName := flag.String(
+		"package",
+		"",
+		"name of the generated package; defaults to the name of the project",
+	)
+	outPath := flag.String(
+		"out",
+		"",
+		"path of the generated file; defaults to standard output",
+	)
+	flag.Parse()
+	if *xmlPath == "" {
+		fmt.Fprintln(os.Stderr, "-xml is required")
+		flag.Usage()
+		os.Exit(2)
+	}
+	if err := run(*xmlPath, *packageName, *outPath); err != nil {
+		fmt.Fprintf(os.Stderr, "arsdkgen: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(xmlPath, packageName, outPath string) error {
+	xmlFile, err := os.Open(xmlPath)
+	if err != nil {
+		return err
+	}
+	defer xmlFile.Close() // nolint: errcheck
+	p, err := parseProject(xmlFile)
+	if err != nil {
+		return err
+	}
+	if packageName == "" {
+		packageName = p.Name
+	}
+	source, err := generate(p, packageName, filepath.Base(xmlPath))
+	if err != nil {
+		return err
+	}
+	if outPath == "" {
+		_, err = os.Stdout.Write(source)
+		return err
+	}
+	return ioutil.WriteFile(outPath, source, 0644)
+}
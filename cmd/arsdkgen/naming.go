@@ -0,0 +1,71 @@
+package main
+
+import (
+	"go/token"
+	"strings"
+	"unicode"
+)
+
+// exportedName converts an arsdk-xml name in either CamelCase or snake_case
+// to an exported Go identifier-- e.g. "vertical_camera" becomes
+// "VerticalCamera", "HEADING_START" becomes "HeadingStart", and "IMU" is
+// unchanged.
+func exportedName(name string) string {
+	parts := strings.Split(name, "_")
+	for i, part := range parts {
+		if part == "" {
+			continue
+		}
+		if strings.ToLower(part) == "id" {
+			parts[i] = "ID"
+			continue
+		}
+		// Upper case snake_case names are SCREAMING_CASE rather than initialisms
+		if len(parts) > 1 && part == strings.ToUpper(part) {
+			part = strings.ToLower(part)
+		}
+		runes := []rune(part)
+		runes[0] = unicode.ToUpper(runes[0])
+		parts[i] = string(runes)
+	}
+	return strings.Join(parts, "")
+}
+
+// unexportedName converts an exported Go identifier to an unexported one,
+// lowering any leading initialism-- e.g. "GPSSettingsState" becomes
+// "gpsSettingsState". This matches how state classes are named by hand.
+func unexportedName(name string) string {
+	runes := []rune(name)
+	upper := 0
+	for upper < len(runes) && unicode.IsUpper(runes[upper]) {
+		upper++
+	}
+	// If the initialism is followed by another word, the last upper case letter
+	// begins that word
+	if upper > 1 && upper < len(runes) {
+		upper--
+	}
+	for i := 0; i < upper; i++ {
+		runes[i] = unicode.ToLower(runes[i])
+	}
+	return string(runes)
+}
+
+// handlerName returns the name of the method that handles the named d2c
+// command. Only the first letter is lowered-- e.g. "GPSFixStateChanged"
+// becomes "gPSFixStateChanged". This matches existing handlers.
+func handlerName(commandName string) string {
+	runes := []rune(commandName)
+	runes[0] = unicode.ToLower(runes[0])
+	return string(runes)
+}
+
+// paramName converts an arsdk-xml argument name to a Go parameter name--
+// e.g. "mass_storage_id" becomes "massStorageID".
+func paramName(argName string) string {
+	name := unexportedName(exportedName(argName))
+	if token.Lookup(name).IsKeyword() {
+		return name + "Arg"
+	}
+	return name
+}
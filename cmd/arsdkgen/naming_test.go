@@ -0,0 +1,81 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNaming(t *testing.T) {
+	testCases := []struct {
+		name               string
+		input              string
+		expectedExported   string
+		expectedUnexported string
+		expectedParam      string
+	}{
+		{
+			name:               "camel case",
+			input:              "sensorName",
+			expectedExported:   "SensorName",
+			expectedUnexported: "sensorName",
+			expectedParam:      "sensorName",
+		},
+		{
+			name:               "snake case",
+			input:              "vertical_camera",
+			expectedExported:   "VerticalCamera",
+			expectedUnexported: "verticalCamera",
+			expectedParam:      "verticalCamera",
+		},
+		{
+			name:               "screaming snake case",
+			input:              "HEADING_START",
+			expectedExported:   "HeadingStart",
+			expectedUnexported: "headingStart",
+			expectedParam:      "headingStart",
+		},
+		{
+			name:               "initialism",
+			input:              "IMU",
+			expectedExported:   "IMU",
+			expectedUnexported: "imu",
+			expectedParam:      "imu",
+		},
+		{
+			name:               "leading initialism",
+			input:              "GPSSettingsState",
+			expectedExported:   "GPSSettingsState",
+			expectedUnexported: "gpsSettingsState",
+			expectedParam:      "gpsSettingsState",
+		},
+		{
+			name:               "id",
+			input:              "mass_storage_id",
+			expectedExported:   "MassStorageID",
+			expectedUnexported: "massStorageID",
+			expectedParam:      "massStorageID",
+		},
+		{
+			name:               "keyword",
+			input:              "type",
+			expectedExported:   "Type",
+			expectedUnexported: "type",
+			expectedParam:      "typeArg",
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			exported := exportedName(testCase.input)
+			require.Equal(t, testCase.expectedExported, exported)
+			require.Equal(t, testCase.expectedUnexported, unexportedName(exported))
+			require.Equal(t, testCase.expectedParam, paramName(testCase.input))
+		})
+	}
+}
+
+func TestHandlerName(t *testing.T) {
+	require.Equal(t, "batteryStateChanged", handlerName("BatteryStateChanged"))
+	// This matches existing, hand-written handlers
+	require.Equal(t, "gPSFixStateChanged", handlerName("GPSFixStateChanged"))
+}
@@ -0,0 +1,191 @@
+package main
+
+import (
+	"encoding/xml"
+	"io"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// project represents a single arsdk-xml project file-- e.g. common.xml or
+// ardrone3.xml. Each project corresponds to one arcommands feature.
+type project struct {
+	Name        string  `xml:"name,attr"`
+	ID          uint8   `xml:"id,attr"`
+	Description string  `xml:",chardata"`
+	Classes     []class `xml:"class"`
+}
+
+// class represents a class of commands within a project.
+type class struct {
+	Name        string    `xml:"name,attr"`
+	ID          uint8     `xml:"id,attr"`
+	Description string    `xml:",chardata"`
+	Commands    []command `xml:"cmd"`
+}
+
+// command represents a single command within a class.
+type command struct {
+	Name string `xml:"name,attr"`
+	ID   uint16 `xml:"id,attr"`
+	// Buffer is the name of the buffer the command is sent over. It is only
+	// meaningful for c2d commands. If empty, the ack buffer is implied.
+	Buffer     string  `xml:"buffer,attr"`
+	Deprecated bool    `xml:"deprecated,attr"`
+	Comment    comment `xml:"comment"`
+	Args       []arg   `xml:"arg"`
+}
+
+// comment represents the structured documentation of a command.
+type comment struct {
+	Title     string `xml:"title,attr"`
+	Desc      string `xml:"desc,attr"`
+	Support   string `xml:"support,attr"`
+	Triggered string `xml:"triggered,attr"`
+	Result    string `xml:"result,attr"`
+}
+
+// arg represents a single argument of a command.
+type arg struct {
+	Name        string      `xml:"name,attr"`
+	Type        string      `xml:"type,attr"`
+	Description string      `xml:",chardata"`
+	Enums       []enumValue `xml:"enum"`
+}
+
+// enumValue represents one value of an enum argument. Values are numbered in
+// the order they are declared, starting from zero.
+type enumValue struct {
+	Name        string `xml:"name,attr"`
+	Description string `xml:",chardata"`
+}
+
+// d2cClassSuffixes are the suffixes that, by arsdk-xml convention, identify
+// classes of commands sent from the device to the client.
+var d2cClassSuffixes = []string{"State", "Event"}
+
+// isD2C returns true if the class's commands are sent from the device to the
+// client and false if they are sent from the client to the device.
+func (c class) isD2C() bool {
+	for _, suffix := range d2cClassSuffixes {
+		if strings.HasSuffix(c.Name, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// isAcked returns a bool indicating whether the command is sent over the ack
+// buffer.
+func (c command) isAcked() bool {
+	return c.Buffer == "" || c.Buffer == "ACK"
+}
+
+// parseProject reads and validates an arsdk-xml project file.
+func parseProject(r io.Reader) (project, error) {
+	p := project{}
+	decoder := xml.NewDecoder(r)
+	if err := decoder.Decode(&p); err != nil {
+		return p, errors.Wrap(err, "error decoding project xml")
+	}
+	if p.Name == "" {
+		return p, errors.New("project has no name")
+	}
+	p.Description = normalizeText(p.Description)
+	for i := range p.Classes {
+		c := &p.Classes[i]
+		c.Description = normalizeText(c.Description)
+		for j := range c.Commands {
+			cmd := &c.Commands[j]
+			if _, err := bufferIDName(cmd.Buffer); err != nil {
+				return p, errors.Wrapf(err, "error in command %s.%s", c.Name, cmd.Name)
+			}
+			for k := range cmd.Args {
+				a := &cmd.Args[k]
+				a.Description = normalizeText(a.Description)
+				if _, err := goType(a.Type); err != nil {
+					return p, errors.Wrapf(
+						err,
+						"error in argument %s of command %s.%s",
+						a.Name,
+						c.Name,
+						cmd.Name,
+					)
+				}
+				if a.Type == "enum" && len(a.Enums) == 0 {
+					return p, errors.Errorf(
+						"enum argument %s of command %s.%s has no values",
+						a.Name,
+						c.Name,
+						cmd.Name,
+					)
+				}
+				for l := range a.Enums {
+					a.Enums[l].Description = normalizeText(a.Enums[l].Description)
+				}
+			}
+		}
+	}
+	return p, nil
+}
+
+// normalizeText collapses the whitespace xml character data is typically
+// indented with.
+func normalizeText(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
+
+// goType returns the Go type used to decode or encode an argument of the
+// given arsdk-xml type.
+func goType(argType string) (string, error) {
+	// Bitfields are encoded as their underlying integer type-- e.g.
+	// "bitfield:u8:list_flags"
+	if strings.HasPrefix(argType, "bitfield:") {
+		tokens := strings.Split(argType, ":")
+		if len(tokens) != 3 {
+			return "", errors.Errorf("malformed bitfield type %q", argType)
+		}
+		argType = tokens[1]
+	}
+	switch argType {
+	case "u8":
+		return "uint8", nil
+	case "i8":
+		return "int8", nil
+	case "u16":
+		return "uint16", nil
+	case "i16":
+		return "int16", nil
+	case "u32":
+		return "uint32", nil
+	case "i32", "enum":
+		// Enums are always encoded as 32 bit signed integers
+		return "int32", nil
+	case "u64":
+		return "uint64", nil
+	case "i64":
+		return "int64", nil
+	case "float":
+		return "float32", nil
+	case "double":
+		return "float64", nil
+	case "string":
+		return "string", nil
+	}
+	return "", errors.Errorf("unknown argument type %q", argType)
+}
+
+// bufferIDName returns the name of the arcommands constant identifying the c2d
+// buffer for the given arsdk-xml buffer name.
+func bufferIDName(buffer string) (string, error) {
+	switch buffer {
+	case "", "ACK":
+		return "arcommands.C2DAckBufferID", nil
+	case "NON_ACK":
+		return "arcommands.C2DNonAckBufferID", nil
+	case "HIGH_PRIO":
+		return "arcommands.C2DEmergencyBufferID", nil
+	}
+	return "", errors.Errorf("unknown buffer %q", buffer)
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseProject(t *testing.T) {
+	p, err := parseProject(strings.NewReader(`
+<project name="common" id="0">
+	All common commands shared between all projects
+	<class name="Calibration" id="13">
+		Calibration commands
+		<cmd name="StartOrAbortMagnetoCalib" id="0">
+			<comment title="Start/Abort magnetometer calibration"/>
+			<arg name="calibrate" type="u8">
+				1 to start the calibration,
+				0 to abort it
+			</arg>
+		</cmd>
+	</class>
+	<class name="CalibrationState" id="14">
+		Status of the calibration
+	</class>
+</project>`,
+	))
+	require.NoError(t, err)
+	require.Equal(t, "common", p.Name)
+	require.Equal(t, uint8(0), p.ID)
+	require.Len(t, p.Classes, 2)
+	c := p.Classes[0]
+	require.Equal(t, "Calibration", c.Name)
+	require.Equal(t, uint8(13), c.ID)
+	require.Equal(t, "Calibration commands", c.Description)
+	require.False(t, c.isD2C())
+	require.True(t, p.Classes[1].isD2C())
+	require.Len(t, c.Commands, 1)
+	cmd := c.Commands[0]
+	require.Equal(t, "StartOrAbortMagnetoCalib", cmd.Name)
+	require.Equal(t, "Start/Abort magnetometer calibration", cmd.Comment.Title)
+	require.Len(t, cmd.Args, 1)
+	require.Equal(
+		t,
+		"1 to start the calibration, 0 to abort it",
+		cmd.Args[0].Description,
+	)
+}
+
+func TestParseProjectErrors(t *testing.T) {
+	testCases := []struct {
+		name string
+		xml  string
+	}{
+		{
+			name: "malformed xml",
+			xml:  `<project name="common" id="0">`,
+		},
+		{
+			name: "no project name",
+			xml:  `<project id="0"></project>`,
+		},
+		{
+			name: "unknown argument type",
+			xml: `<project name="common" id="0"><class name="Common" id="4">` +
+				`<cmd name="Foo" id="0"><arg name="bar" type="u128"/></cmd>` +
+				`</class></project>`,
+		},
+		{
+			name: "malformed bitfield",
+			xml: `<project name="common" id="0"><class name="Common" id="4">` +
+				`<cmd name="Foo" id="0"><arg name="bar" type="bitfield:u8"/></cmd>` +
+				`</class></project>`,
+		},
+		{
+			name: "enum without values",
+			xml: `<project name="common" id="0"><class name="Common" id="4">` +
+				`<cmd name="Foo" id="0"><arg name="bar" type="enum"/></cmd>` +
+				`</class></project>`,
+		},
+		{
+			name: "unknown buffer",
+			xml: `<project name="common" id="0"><class name="Common" id="4">` +
+				`<cmd name="Foo" id="0" buffer="SOMETIMES_ACK"/>` +
+				`</class></project>`,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			_, err := parseProject(strings.NewReader(testCase.xml))
+			require.Error(t, err)
+		})
+	}
+}
@@ -9,7 +9,9 @@ import (
 )
 
 func main() {
-	frameSender, frameReceiver, err := arnetworkal.Connect()
+	frameSender, frameReceiver, _, err := arnetworkal.Connect(
+		arnetworkal.ConnectOptions{},
+	)
 	if err != nil {
 		log.Fatal(err)
 	}
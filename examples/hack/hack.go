@@ -6,6 +6,7 @@ import (
 
 	log "github.com/Sirupsen/logrus"
 	"github.com/krancour/go-parrot/products/bebop2"
+	"github.com/krancour/go-parrot/protocols/arnetworkal/wifi"
 )
 
 func main() {
@@ -13,7 +14,7 @@ func main() {
 		log.Println(http.ListenAndServe("localhost:6060", nil))
 	}()
 	log.SetLevel(log.InfoLevel)
-	_, err := bebop2.NewController()
+	_, err := bebop2.NewController(wifi.ConnectOptions{})
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -24,8 +24,8 @@ package ardrone3
 // 			[]interface{}{
 // 				uint8(0),  // id,
 // 				int32(0),  // accessory_type,
-// 				string(0), // uid,
-// 				string(0), // swVersion,
+// 				"",        // uid,
+// 				"",        // swVersion,
 // 				uint8(0),  // list_flags,
 // 			},
 // 			a.connectedAccessories,
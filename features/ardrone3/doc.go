@@ -1,3 +1,5 @@
 package ardrone3
 
+//go:generate go run ../../cmd/arsdkgen -xml ../../third_party/arsdk-xml/xml/ardrone3.xml -out zz_generated.go
+
 // TODO: Document this
@@ -1,13 +1,19 @@
 package ardrone3
 
 import (
+	"github.com/krancour/go-parrot/features/subscriptions"
 	"github.com/krancour/go-parrot/protocols/arcommands"
 )
 
+// featureID is the ID of the ardrone3 feature.
+const featureID uint8 = 1
+
 // Feature ...
 // TODO: Document this
 type Feature interface {
 	arcommands.D2CFeature
+	Piloting() Piloting
+	MediaStreaming() MediaStreaming
 	// AccessoryState() AccessoryState
 	AntiflickeringState() AntiflickeringState
 	CameraState() CameraState
@@ -29,6 +35,8 @@ type Feature interface {
 }
 
 type feature struct {
+	piloting       *piloting
+	mediaStreaming *mediaStreaming
 	// accessoryState        *accessoryState
 	antiflickeringState   *antiflickeringState
 	cameraState           *cameraState
@@ -51,13 +59,24 @@ type feature struct {
 
 // NewFeature ...
 // TODO: Document this
-func NewFeature() Feature {
+func NewFeature(c2dCommandClient arcommands.C2DCommandClient) Feature {
 	return &feature{
+		piloting: &piloting{
+			c2dCommandClient: c2dCommandClient,
+		},
+		mediaStreaming: &mediaStreaming{
+			c2dCommandClient: c2dCommandClient,
+		},
 		// accessoryState:        &accessoryState{},
-		antiflickeringState:   &antiflickeringState{},
-		cameraState:           &cameraState{},
-		gpsSettingsState:      &gpsSettingsState{},
-		gpsState:              &gpsState{},
+		antiflickeringState: &antiflickeringState{},
+		cameraState:         &cameraState{},
+		gpsSettingsState:    &gpsSettingsState{},
+		gpsState: &gpsState{
+			Publisher: subscriptions.NewPublisher(
+				"GPSState",
+				GPSStateAttributeNumberOfSatellites,
+			),
+		},
 		mediaRecordEvent:      &mediaRecordEvent{},
 		mediaRecordState:      &mediaRecordState{},
 		mediaStreamingState:   &mediaStreamingState{},
@@ -66,7 +85,12 @@ func NewFeature() Feature {
 		pictureSettingsState:  &pictureSettingsState{},
 		pilotingEvent:         &pilotingEvent{},
 		pilotingSettingsState: &pilotingSettingsState{},
-		pilotingState:         &pilotingState{},
+		pilotingState: &pilotingState{
+			Publisher: subscriptions.NewPublisher(
+				"PilotingState",
+				pilotingStateAttributes...,
+			),
+		},
 		// proState:              &proState{},
 		settingsState: &settingsState{},
 		// soundState:         &soundState{},
@@ -75,7 +99,7 @@ func NewFeature() Feature {
 }
 
 func (f *feature) ID() uint8 {
-	return 1
+	return featureID
 }
 
 func (f *feature) Name() string {
@@ -106,6 +130,14 @@ func (f *feature) D2CClasses() []arcommands.D2CClass {
 	}
 }
 
+func (f *feature) Piloting() Piloting {
+	return f.piloting
+}
+
+func (f *feature) MediaStreaming() MediaStreaming {
+	return f.mediaStreaming
+}
+
 // func (f *feature) AccessoryState() AccessoryState {
 // 	return f.accessoryState
 // }
@@ -0,0 +1,35 @@
+package ardrone3
+
+import (
+	log "github.com/Sirupsen/logrus"
+	"github.com/krancour/go-parrot/protocols/arcommands"
+)
+
+// Control media streaming behavior.
+
+// MediaStreaming exposes commands related to streaming video from the device.
+type MediaStreaming interface {
+	// VideoEnable asks the device to start (true) or stop (false) streaming
+	// video. The device reports the resulting status using the
+	// VideoEnableChanged event.
+	VideoEnable(enable bool) error
+}
+
+type mediaStreaming struct {
+	c2dCommandClient arcommands.C2DCommandClient
+}
+
+func (m *mediaStreaming) ID() uint8 {
+	return 21
+}
+
+func (m *mediaStreaming) Name() string {
+	return "MediaStreaming"
+}
+
+func (m *mediaStreaming) VideoEnable(enable bool) error {
+	log.WithField(
+		"enable", enable,
+	).Debug("sending ardrone3 media streaming VideoEnable command")
+	return sendMediaStreamingVideoEnable(m.c2dCommandClient, boolToUint8(enable))
+}
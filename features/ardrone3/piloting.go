@@ -0,0 +1,186 @@
+package ardrone3
+
+import (
+	"github.com/krancour/go-parrot/protocols/arcommands"
+	"github.com/krancour/go-parrot/protocols/arnetwork"
+)
+
+// All commands related to piloting the drone
+
+// MoveToOrientationMode is a type for constants used to indicate how the
+// device should orient itself while executing a MoveTo command.
+type MoveToOrientationMode = PilotingMoveToOrientationMode
+
+const (
+	// MoveToOrientationModeNone indicates the drone won't change its
+	// orientation.
+	MoveToOrientationModeNone = PilotingMoveToOrientationModeNONE
+	// MoveToOrientationModeToTarget indicates the drone will make a rotation to
+	// look in the direction of the given location.
+	MoveToOrientationModeToTarget = PilotingMoveToOrientationModeToTarget
+	// MoveToOrientationModeHeadingStart indicates the drone will orient itself
+	// to the given heading before moving to the location.
+	MoveToOrientationModeHeadingStart = PilotingMoveToOrientationModeHeadingStart
+	// MoveToOrientationModeHeadingDuring indicates the drone will orient itself
+	// to the given heading while moving to the location.
+	MoveToOrientationModeHeadingDuring = PilotingMoveToOrientationModeHeadingDuring
+)
+
+// Piloting exposes commands related to piloting the device.
+type Piloting interface {
+	// FlatTrim asks the device to do a flat trim of its accelerometer and
+	// gyroscope. This can be useful when the drone is sliding in hover mode.
+	// The device acknowledges that flat trim was processed using the
+	// FlatTrimChanged event.
+	FlatTrim() error
+	// TakeOff asks the device to take off.
+	TakeOff() error
+	// TakeOffWithReceipt is like TakeOff, but also returns an
+	// arnetwork.Receipt that is resolved once the device has acknowledged
+	// receipt of the command or delivery has failed. See
+	// arnetwork.FrameDelivery for the errors that are possible.
+	TakeOffWithReceipt() (*arnetwork.Receipt, error)
+	// PCMD moves the device. When flag is true, roll and pitch values are taken
+	// into consideration; otherwise they are ignored. Roll and pitch are
+	// expressed as signed percentages of the max pitch/roll setting in the range
+	// [-100, 100]. Yaw is expressed as a signed percentage of the max yaw
+	// rotation speed setting in the range [-100, 100]. Gaz (throttle) is
+	// expressed as a signed percentage of the max vertical speed setting in the
+	// range [-100, 100]. timestampAndSeqNum is the command timestamp in
+	// milliseconds (low 24 bits) plus a command sequence number (high 8 bits).
+	// Because this command is meant to be sent periodically, it is sent over the
+	// (overwriting) non-ack buffer.
+	PCMD(
+		flag bool,
+		roll int8,
+		pitch int8,
+		yaw int8,
+		gaz int8,
+		timestampAndSeqNum uint32,
+	) error
+	// Landing asks the device to land.
+	Landing() error
+	// LandingWithReceipt is like Landing, but also returns an arnetwork.Receipt
+	// that is resolved once the device has acknowledged receipt of the command
+	// or delivery has failed. See arnetwork.FrameDelivery for the errors that
+	// are possible.
+	LandingWithReceipt() (*arnetwork.Receipt, error)
+	// Emergency immediately cuts out the device's motors. The drone will fall.
+	// This command is sent over the dedicated emergency buffer, which retries
+	// infinitely until delivery is acknowledged.
+	Emergency() error
+	// NavigateHome asks the device to start (true) or stop (false) flying to its
+	// home position.
+	NavigateHome(start bool) error
+	// MoveBy asks the device to move to a relative position and rotate its
+	// heading by a given angle. Moves are relative to the device's current
+	// orientation. dX, dY, and dZ are the wanted displacements along the front,
+	// right, and down axes, respectively, in meters. dPsi is the wanted rotation
+	// of heading in radians. The device reports the result using the moveByEnd
+	// event.
+	MoveBy(dX, dY, dZ, dPsi float32) error
+	// MoveTo asks the device to move to a given location. latitude and
+	// longitude are in degrees. altitude is above sea level, in meters. heading
+	// is relative to North, in degrees, and is only used if orientationMode is
+	// MoveToOrientationModeHeadingStart or MoveToOrientationModeHeadingDuring.
+	MoveTo(
+		latitude float64,
+		longitude float64,
+		altitude float64,
+		orientationMode MoveToOrientationMode,
+		heading float32,
+	) error
+	// CancelMoveTo cancels the current MoveTo, if any.
+	CancelMoveTo() error
+}
+
+type piloting struct {
+	c2dCommandClient arcommands.C2DCommandClient
+}
+
+func (p *piloting) ID() uint8 {
+	return 0
+}
+
+func (p *piloting) Name() string {
+	return "Piloting"
+}
+
+func (p *piloting) FlatTrim() error {
+	return sendPilotingFlatTrim(p.c2dCommandClient)
+}
+
+func (p *piloting) TakeOff() error {
+	return sendPilotingTakeOff(p.c2dCommandClient)
+}
+
+func (p *piloting) TakeOffWithReceipt() (*arnetwork.Receipt, error) {
+	return sendPilotingTakeOffWithReceipt(p.c2dCommandClient)
+}
+
+func (p *piloting) PCMD(
+	flag bool,
+	roll int8,
+	pitch int8,
+	yaw int8,
+	gaz int8,
+	timestampAndSeqNum uint32,
+) error {
+	return sendPilotingPCMD(
+		p.c2dCommandClient,
+		boolToUint8(flag),
+		roll,
+		pitch,
+		yaw,
+		gaz,
+		timestampAndSeqNum,
+	)
+}
+
+func (p *piloting) Landing() error {
+	return sendPilotingLanding(p.c2dCommandClient)
+}
+
+func (p *piloting) LandingWithReceipt() (*arnetwork.Receipt, error) {
+	return sendPilotingLandingWithReceipt(p.c2dCommandClient)
+}
+
+func (p *piloting) Emergency() error {
+	return sendPilotingEmergency(p.c2dCommandClient)
+}
+
+func (p *piloting) NavigateHome(start bool) error {
+	return sendPilotingNavigateHome(p.c2dCommandClient, boolToUint8(start))
+}
+
+func (p *piloting) MoveBy(dX, dY, dZ, dPsi float32) error {
+	return sendPilotingMoveBy(p.c2dCommandClient, dX, dY, dZ, dPsi)
+}
+
+func (p *piloting) MoveTo(
+	latitude float64,
+	longitude float64,
+	altitude float64,
+	orientationMode MoveToOrientationMode,
+	heading float32,
+) error {
+	return sendPilotingMoveTo(
+		p.c2dCommandClient,
+		latitude,
+		longitude,
+		altitude,
+		orientationMode,
+		heading,
+	)
+}
+
+func (p *piloting) CancelMoveTo() error {
+	return sendPilotingCancelMoveTo(p.c2dCommandClient)
+}
+
+func boolToUint8(b bool) uint8 {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -4,12 +4,151 @@ import (
 	"sync"
 
 	log "github.com/Sirupsen/logrus"
+	"github.com/krancour/go-parrot/features/subscriptions"
 	"github.com/krancour/go-parrot/protocols/arcommands"
 	"github.com/krancour/go-parrot/ptr"
 )
 
 // State from drone
 
+// The following types are aliases for enum types generated from the arsdk-xml
+// project files, given shorter names.
+type (
+	// FlyingState is a type for constants used to indicate the flying state of
+	// the device.
+	FlyingState = PilotingStateFlyingStateChangedState
+	// AlertState is a type for constants used to indicate alerts raised by the
+	// device.
+	AlertState = PilotingStateAlertStateChangedState
+	// NavigateHomeState is a type for constants used to indicate the state of
+	// the device's navigate (return) home function.
+	NavigateHomeState = PilotingStateNavigateHomeStateChangedState
+	// NavigateHomeReason is a type for constants used to indicate the reason
+	// for the most recent change to the state of the device's navigate home
+	// function.
+	NavigateHomeReason = PilotingStateNavigateHomeStateChangedReason
+)
+
+const (
+	// FlyingStateLanded indicates the device is landed.
+	FlyingStateLanded = PilotingStateFlyingStateChangedStateLanded
+	// FlyingStateTakingOff indicates the device is taking off.
+	FlyingStateTakingOff = PilotingStateFlyingStateChangedStateTakingoff
+	// FlyingStateHovering indicates the device is hovering (or circling, for
+	// fixed wings).
+	FlyingStateHovering = PilotingStateFlyingStateChangedStateHovering
+	// FlyingStateFlying indicates the device is flying.
+	FlyingStateFlying = PilotingStateFlyingStateChangedStateFlying
+	// FlyingStateLanding indicates the device is landing.
+	FlyingStateLanding = PilotingStateFlyingStateChangedStateLanding
+	// FlyingStateEmergency indicates the device is in an emergency state.
+	FlyingStateEmergency = PilotingStateFlyingStateChangedStateEmergency
+	// FlyingStateUserTakeOff indicates the device is waiting for user action
+	// to take off.
+	FlyingStateUserTakeOff = PilotingStateFlyingStateChangedStateUsertakeoff
+	// FlyingStateMotorRamping indicates the device's motors are ramping up.
+	FlyingStateMotorRamping = PilotingStateFlyingStateChangedStateMotorRamping
+	// FlyingStateEmergencyLanding indicates the device's autopilot has detected
+	// defective sensor(s) and the device is landing. Only the yaw component of
+	// PCMD commands is taken into account. All other piloting commands are
+	// ignored.
+	FlyingStateEmergencyLanding = PilotingStateFlyingStateChangedStateEmergencyLanding
+)
+
+const (
+	// AlertStateNone indicates there is no alert.
+	AlertStateNone = PilotingStateAlertStateChangedStateNone
+	// AlertStateUser indicates a user emergency alert.
+	AlertStateUser = PilotingStateAlertStateChangedStateUser
+	// AlertStateCutOut indicates the motors were cut out.
+	AlertStateCutOut = PilotingStateAlertStateChangedStateCutOut
+	// AlertStateCriticalBattery indicates the battery level is critical.
+	AlertStateCriticalBattery = PilotingStateAlertStateChangedStateCriticalBattery
+	// AlertStateLowBattery indicates the battery level is low.
+	AlertStateLowBattery = PilotingStateAlertStateChangedStateLowBattery
+	// AlertStateTooMuchAngle indicates the angle of the device is too high.
+	AlertStateTooMuchAngle = PilotingStateAlertStateChangedStateTooMuchAngle
+)
+
+const (
+	// NavigateHomeStateAvailable indicates navigate home is available.
+	NavigateHomeStateAvailable = PilotingStateNavigateHomeStateChangedStateAvailable
+	// NavigateHomeStateInProgress indicates navigate home is in progress.
+	NavigateHomeStateInProgress = PilotingStateNavigateHomeStateChangedStateInProgress
+	// NavigateHomeStateUnavailable indicates navigate home is not available.
+	NavigateHomeStateUnavailable = PilotingStateNavigateHomeStateChangedStateUnavailable
+	// NavigateHomeStatePending indicates navigate home has been requested, but
+	// is pending.
+	NavigateHomeStatePending = PilotingStateNavigateHomeStateChangedStatePending
+)
+
+const (
+	// NavigateHomeReasonUserRequest indicates the user requested navigate home
+	// (available -> inProgress).
+	NavigateHomeReasonUserRequest = PilotingStateNavigateHomeStateChangedReasonUserRequest
+	// NavigateHomeReasonConnectionLost indicates the connection between the
+	// controller and the device was lost (available -> inProgress).
+	NavigateHomeReasonConnectionLost = PilotingStateNavigateHomeStateChangedReasonConnectionLost
+	// NavigateHomeReasonLowBattery indicates the battery level is low
+	// (available -> inProgress).
+	NavigateHomeReasonLowBattery = PilotingStateNavigateHomeStateChangedReasonLowBattery
+	// NavigateHomeReasonFinished indicates navigate home finished
+	// (inProgress -> available).
+	NavigateHomeReasonFinished = PilotingStateNavigateHomeStateChangedReasonFinished
+	// NavigateHomeReasonStopped indicates navigate home was stopped
+	// (inProgress -> available).
+	NavigateHomeReasonStopped = PilotingStateNavigateHomeStateChangedReasonStopped
+	// NavigateHomeReasonDisabled indicates navigate home was disabled by the
+	// device (inProgress -> unavailable or available -> unavailable).
+	NavigateHomeReasonDisabled = PilotingStateNavigateHomeStateChangedReasonDisabled
+	// NavigateHomeReasonEnabled indicates navigate home was enabled by the
+	// device (unavailable -> available).
+	NavigateHomeReasonEnabled = PilotingStateNavigateHomeStateChangedReasonEnabled
+)
+
+// Attributes of PilotingState that can be subscribed to. Each is named for the
+// getter function that returns it. See the subscriptions package.
+const (
+	PilotingStateAttributeFlyingState         = "FlyingState"
+	PilotingStateAttributeAlertState          = "AlertState"
+	PilotingStateAttributeNavigateHomeState   = "NavigateHomeState"
+	PilotingStateAttributeNavigateHomeReason  = "NavigateHomeReason"
+	PilotingStateAttributeSpeedX              = "SpeedX"
+	PilotingStateAttributeSpeedY              = "SpeedY"
+	PilotingStateAttributeSpeedZ              = "SpeedZ"
+	PilotingStateAttributeAltitude            = "Altitude"
+	PilotingStateAttributeRoll                = "Roll"
+	PilotingStateAttributePitch               = "Pitch"
+	PilotingStateAttributeYaw                 = "Yaw"
+	PilotingStateAttributeLatitude            = "Latitude"
+	PilotingStateAttributeLongitude           = "Longitude"
+	PilotingStateAttributeGPSAltitude         = "GPSAltitude"
+	PilotingStateAttributeLatitudeAccuracy    = "LatitudeAccuracy"
+	PilotingStateAttributeLongitudeAccuracy   = "LongitudeAccuracy"
+	PilotingStateAttributeGPSAltitudeAccuracy = "GPSAltitudeAccuracy"
+)
+
+// pilotingStateAttributes are all attributes of PilotingState.
+var pilotingStateAttributes = []string{
+	PilotingStateAttributeFlyingState,
+	PilotingStateAttributeAlertState,
+	PilotingStateAttributeNavigateHomeState,
+	PilotingStateAttributeNavigateHomeReason,
+	PilotingStateAttributeSpeedX,
+	PilotingStateAttributeSpeedY,
+	PilotingStateAttributeSpeedZ,
+	PilotingStateAttributeAltitude,
+	PilotingStateAttributeRoll,
+	PilotingStateAttributePitch,
+	PilotingStateAttributeYaw,
+	PilotingStateAttributeLatitude,
+	PilotingStateAttributeLongitude,
+	PilotingStateAttributeGPSAltitude,
+	PilotingStateAttributeLatitudeAccuracy,
+	PilotingStateAttributeLongitudeAccuracy,
+	PilotingStateAttributeGPSAltitudeAccuracy,
+}
+
 // PilotingState ...
 // TODO: Document this
 type PilotingState interface {
@@ -23,6 +162,30 @@ type PilotingState interface {
 	RLock()
 	// RUnlock releases a read lock on the piloting state. See RLock().
 	RUnlock()
+	// Subscribe returns a subscription to changes to the piloting state. See the
+	// subscriptions package.
+	subscriptions.Subscribable
+	// FlyingState returns the flying state of the device. A boolean value is
+	// also returned, indicating whether the first value was reported by the
+	// device (true) or a default value (false). This permits callers to
+	// distinguish real zero values from default zero values.
+	FlyingState() (FlyingState, bool)
+	// AlertState returns the alert state of the device. A boolean value is also
+	// returned, indicating whether the first value was reported by the device
+	// (true) or a default value (false). This permits callers to distinguish
+	// real zero values from default zero values.
+	AlertState() (AlertState, bool)
+	// NavigateHomeState returns the state of the device's navigate home
+	// function. A boolean value is also returned, indicating whether the first
+	// value was reported by the device (true) or a default value (false). This
+	// permits callers to distinguish real zero values from default zero values.
+	NavigateHomeState() (NavigateHomeState, bool)
+	// NavigateHomeReason returns the reason for the most recent change to the
+	// state of the device's navigate home function. A boolean value is also
+	// returned, indicating whether the first value was reported by the device
+	// (true) or a default value (false). This permits callers to distinguish
+	// real zero values from default zero values.
+	NavigateHomeReason() (NavigateHomeReason, bool)
 	// SpeedX returns the velocity relative to the north in m/s. When the drone
 	// moves to the north, the value is > 0. A boolean value is also returned,
 	// indicating whether the first value was reported by the device (true) or a
@@ -99,6 +262,15 @@ type PilotingState interface {
 }
 
 type pilotingState struct {
+	// flyingState is the flying state of the device
+	flyingState *FlyingState
+	// alertState is the alert state of the device
+	alertState *AlertState
+	// navigateHomeState is the state of the device's navigate home function
+	navigateHomeState *NavigateHomeState
+	// navigateHomeReason is the reason for the most recent change to the state
+	// of the device's navigate home function
+	navigateHomeReason *NavigateHomeReason
 	// speedX is velocity relative to the north in m/s. When the drone moves to
 	// the north, the value is > 0
 	speedX *float32
@@ -133,6 +305,8 @@ type pilotingState struct {
 	// deviation) in meters (-1 if unavailable)
 	gpsAltitudeAccuracy *int8
 	lock                sync.RWMutex
+	*subscriptions.Publisher
+	pilotingStateDefaults
 }
 
 func (p *pilotingState) ID() uint8 {
@@ -144,243 +318,57 @@ func (p *pilotingState) Name() string {
 }
 
 func (p *pilotingState) D2CCommands() []arcommands.D2CCommand {
-	return []arcommands.D2CCommand{
-		arcommands.NewD2CCommand(
-			0,
-			"FlatTrimChanged",
-			[]interface{}{},
-			p.flatTrimChanged,
-		),
-		arcommands.NewD2CCommand(
-			1,
-			"FlyingStateChanged",
-			[]interface{}{
-				int32(0), // state,
-			},
-			p.flyingStateChanged,
-		),
-		arcommands.NewD2CCommand(
-			2,
-			"AlertStateChanged",
-			[]interface{}{
-				int32(0), // state,
-			},
-			p.alertStateChanged,
-		),
-		arcommands.NewD2CCommand(
-			3,
-			"NavigateHomeStateChanged",
-			[]interface{}{
-				int32(0), // state,
-				int32(0), // reason,
-			},
-			p.navigateHomeStateChanged,
-		),
-		// According to Parrot developer documentation, they seem poised to replace
-		// PositionChanged with GpsLocationChanged. Since GpsLocationChanged is
-		// functioning as expected, but we can still see this command being invoked,
-		// we'll implement the command to avoid a warning, but the implementation
-		// will remain a no-op unless / until such time that it becomes clear that
-		// older versions of the firmware might require us to support both commands.
-		arcommands.NewD2CCommand(
-			4,
-			"PositionChanged",
-			[]interface{}{
-				float64(0), // latitude,
-				float64(0), // longitude,
-				float64(0), // altitude,
-			},
-			p.positionChanged,
-		),
-		arcommands.NewD2CCommand(
-			5,
-			"SpeedChanged",
-			[]interface{}{
-				float32(0), // speedX,
-				float32(0), // speedY,
-				float32(0), // speedZ,
-			},
-			p.speedChanged,
-		),
-		arcommands.NewD2CCommand(
-			6,
-			"AttitudeChanged",
-			[]interface{}{
-				float32(0), // roll,
-				float32(0), // pitch,
-				float32(0), // yaw,
-			},
-			p.attitudeChanged,
-		),
-		// arcommands.NewD2CCommand(
-		// 	7,
-		// 	"AutoTakeOffModeChanged",
-		// 	[]interface{}{
-		// 		uint8(0), // state,
-		// 	},
-		// 	p.autoTakeOffModeChanged,
-		// ),
-		arcommands.NewD2CCommand(
-			8,
-			"AltitudeChanged",
-			[]interface{}{
-				float64(0), // altitude,
-			},
-			p.altitudeChanged,
-		),
-		arcommands.NewD2CCommand(
-			9,
-			"GpsLocationChanged",
-			[]interface{}{
-				float64(0), // latitude,
-				float64(0), // longitude,
-				float64(0), // altitude,
-				int8(0),    // latitude_accuracy,
-				int8(0),    // longitude_accuracy,
-				int8(0),    // altitude_accuracy,
-			},
-			p.gpsLocationChanged,
-		),
-		// arcommands.NewD2CCommand(
-		// 	10,
-		// 	"LandingStateChanged",
-		// 	[]interface{}{
-		// 		int32(0), // state,
-		// 	},
-		// 	p.landingStateChanged,
-		// ),
-		// arcommands.NewD2CCommand(
-		// 	11,
-		// 	"AirSpeedChanged",
-		// 	[]interface{}{
-		// 		float32(0), // airSpeed,
-		// 	},
-		// 	p.airSpeedChanged,
-		// ),
-		// arcommands.NewD2CCommand(
-		// 	12,
-		// 	"moveToChanged",
-		// 	[]interface{}{
-		// 		float64(0), // latitude,
-		// 		float64(0), // longitude,
-		// 		float64(0), // altitude,
-		// 		int32(0),   // orientation_mode,
-		// 		float32(0), // heading,
-		// 		int32(0),   // status,
-		// 	},
-		// 	p.moveToChanged,
-		// ),
-		// arcommands.NewD2CCommand(
-		// 	13,
-		// 	"MotionState",
-		// 	[]interface{}{
-		// 		int32(0), // state,
-		// 	},
-		// 	p.motionState,
-		// ),
-		// arcommands.NewD2CCommand(
-		// 	14,
-		// 	"PilotedPOI",
-		// 	[]interface{}{
-		// 		float64(0), // latitude,
-		// 		float64(0), // longitude,
-		// 		float64(0), // altitude,
-		// 		int32(0),   // status,
-		// 	},
-		// 	p.pilotedPOI,
-		// ),
-		// arcommands.NewD2CCommand(
-		// 	15,
-		// 	"ReturnHomeBatteryCapacity",
-		// 	[]interface{}{
-		// 		int32(0), // status,
-		// 	},
-		// 	p.returnHomeBatteryCapacity,
-		// ),
-	}
+	return pilotingStateD2CCommands(p)
 }
 
-// TODO: Implement this
-// Title: Flat trim changed
-// Description: Drone acknowledges that flat trim was correctly processed.
-// Support: 0901;090c;090e
-// Triggered: by [FlatTrim](#1-0-0).
-// Result:
-func (p *pilotingState) flatTrimChanged(args []interface{}) error {
-	log.Info("ardrone3.flatTrimChanged() called")
-	return nil
-}
-
-// TODO: Implement this
-// Title: Flying state
-// Description: Flying state.
-// Support: 0901;090c;090e
-// Triggered: when the flying state changes.
-// Result:
+// flyingStateChanged is invoked when the device reports that its flying
+// state has changed.
 func (p *pilotingState) flyingStateChanged(args []interface{}) error {
-	// state := args[0].(int32)
-	//   Drone flying state
-	//   0: landed: Landed state
-	//   1: takingoff: Taking off state
-	//   2: hovering: Hovering / Circling (for fixed wings) state
-	//   3: flying: Flying state
-	//   4: landing: Landing state
-	//   5: emergency: Emergency state
-	//   6: usertakeoff: User take off state. Waiting for user action to take off.
-	//   7: motor_ramping: Motor ramping state.
-	//   8: emergency_landing: Emergency landing state. Drone autopilot has
-	//      detected defective sensor(s). Only Yaw argument in PCMD is taken into
-	//      account. All others flying commands are ignored.
-	log.Info("ardrone3.flyingStateChanged() called")
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	flyingState := args[0].(FlyingState)
+	p.flyingState = &flyingState
+	p.Publish(PilotingStateAttributeFlyingState)
+	log.WithField(
+		"flyingState", flyingState,
+	).Debug("piloting state flying state updated")
 	return nil
 }
 
-// TODO: Implement this
-// Title: Alert state
-// Description: Alert state.
-// Support: 0901;090c;090e
-// Triggered: when an alert happens on the drone.
-// Result:
+// alertStateChanged is invoked when the device reports that an alert has been
+// raised or cleared.
 func (p *pilotingState) alertStateChanged(args []interface{}) error {
-	// state := args[0].(int32)
-	//   Drone alert state
-	//   0: none: No alert
-	//   1: user: User emergency alert
-	//   2: cut_out: Cut out alert
-	//   3: critical_battery: Critical battery alert
-	//   4: low_battery: Low battery alert
-	//   5: too_much_angle: The angle of the drone is too high
-	log.Info("ardrone3.alertStateChanged() called")
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	alertState := args[0].(AlertState)
+	p.alertState = &alertState
+	p.Publish(PilotingStateAttributeAlertState)
+	log.WithField(
+		"alertState", alertState,
+	).Debug("piloting state alert state updated")
 	return nil
 }
 
-// TODO: Implement this
-// Title: Return home state
-// Description: Return home state.\n Availability is related to gps fix,
-//   magnetometer calibration.
-// Support: 0901;090c;090e
-// Triggered: by [ReturnHome](#1-0-5) or when the state of the return home
-//   changes.
-// Result:
+// navigateHomeStateChanged is invoked when the device reports that the state
+// of its navigate home function has changed, either because navigate home was
+// requested or because its availability changed. Availability is related to
+// GPS fix and magnetometer calibration.
 func (p *pilotingState) navigateHomeStateChanged(args []interface{}) error {
-	// state := args[0].(int32)
-	//   State of navigate home
-	//   0: available: Navigate home is available
-	//   1: inProgress: Navigate home is in progress
-	//   2: unavailable: Navigate home is not available
-	//   3: pending: Navigate home has been received, but its process is pending
-	// reason := args[1].(int32)
-	//   Reason of the state
-	//   0: userRequest: User requested a navigate home (available-&gt;inProgress)
-	//   1: connectionLost: Connection between controller and product lost
-	//      (available-&gt;inProgress)
-	//   2: lowBattery: Low battery occurred (available-&gt;inProgress)
-	//   3: finished: Navigate home is finished (inProgress-&gt;available)
-	//   4: stopped: Navigate home has been stopped (inProgress-&gt;available)
-	//   5: disabled: Navigate home disabled by product
-	//      (inProgress-&gt;unavailable or available-&gt;unavailable)
-	//   6: enabled: Navigate home enabled by product (unavailable-&gt;available)
-	log.Info("ardrone3.navigateHomeStateChanged() called")
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	navigateHomeState := args[0].(NavigateHomeState)
+	navigateHomeReason := args[1].(NavigateHomeReason)
+	p.navigateHomeState = &navigateHomeState
+	p.navigateHomeReason = &navigateHomeReason
+	p.Publish(
+		PilotingStateAttributeNavigateHomeState,
+		PilotingStateAttributeNavigateHomeReason,
+	)
+	log.WithField(
+		"navigateHomeState", navigateHomeState,
+	).WithField(
+		"navigateHomeReason", navigateHomeReason,
+	).Debug("piloting state navigate home state updated")
 	return nil
 }
 
@@ -411,6 +399,11 @@ func (p *pilotingState) speedChanged(args []interface{}) error {
 	p.speedX = ptr.ToFloat32(args[0].(float32))
 	p.speedY = ptr.ToFloat32(args[1].(float32))
 	p.speedZ = ptr.ToFloat32(args[2].(float32))
+	p.Publish(
+		PilotingStateAttributeSpeedX,
+		PilotingStateAttributeSpeedY,
+		PilotingStateAttributeSpeedZ,
+	)
 	log.WithField(
 		"speedX", p.speedX,
 	).WithField(
@@ -429,6 +422,11 @@ func (p *pilotingState) attitudeChanged(args []interface{}) error {
 	p.roll = ptr.ToFloat32(args[0].(float32))
 	p.pitch = ptr.ToFloat32(args[1].(float32))
 	p.yaw = ptr.ToFloat32(args[2].(float32))
+	p.Publish(
+		PilotingStateAttributeRoll,
+		PilotingStateAttributePitch,
+		PilotingStateAttributeYaw,
+	)
 	log.WithField(
 		"roll", p.roll,
 	).WithField(
@@ -439,26 +437,13 @@ func (p *pilotingState) attitudeChanged(args []interface{}) error {
 	return nil
 }
 
-// // TODO: Implement this
-// // Title: Auto takeoff mode
-// // Description: Auto takeoff mode
-// // Support:
-// // Triggered:
-// // Result:
-// // WARNING: Deprecated
-// func (p *pilotingState) autoTakeOffModeChanged(args []interface{}) error {
-// 	// state := args[0].(uint8)
-// 	//   State of automatic take off mode (1 if enabled)
-// 	log.Info("ardrone3.autoTakeOffModeChanged() called")
-// 	return nil
-// }
-
 // altitudeChanged is invoked when the device reports attitude relative to the
 // take off point at regular intervals.
 func (p *pilotingState) altitudeChanged(args []interface{}) error {
 	p.lock.Lock()
 	defer p.lock.Unlock()
 	p.altitude = ptr.ToFloat64(args[0].(float64))
+	p.Publish(PilotingStateAttributeAltitude)
 	log.WithField(
 		"altitude", p.altitude,
 	).Debug("piloting state altitude updated")
@@ -476,6 +461,14 @@ func (p *pilotingState) gpsLocationChanged(args []interface{}) error {
 	p.latitudeAccuracy = ptr.ToInt8(args[3].(int8))
 	p.longitudeAccuracy = ptr.ToInt8(args[4].(int8))
 	p.gpsAltitudeAccuracy = ptr.ToInt8(args[5].(int8))
+	p.Publish(
+		PilotingStateAttributeLatitude,
+		PilotingStateAttributeLongitude,
+		PilotingStateAttributeAltitude,
+		PilotingStateAttributeLatitudeAccuracy,
+		PilotingStateAttributeLongitudeAccuracy,
+		PilotingStateAttributeGPSAltitudeAccuracy,
+	)
 	log.WithField(
 		"latitude", p.latitude,
 	).WithField(
@@ -492,144 +485,6 @@ func (p *pilotingState) gpsLocationChanged(args []interface{}) error {
 	return nil
 }
 
-// // TODO: Implement this
-// // Title: Landing state
-// // Description: Landing state.\n Only available for fixed wings (which have two
-// //   landing modes).
-// // Support: 090e
-// // Triggered: when the landing state changes.
-// // Result:
-// func (p *pilotingState) landingStateChanged(args []interface{}) error {
-// 	// state := args[0].(int32)
-// 	//   Drone landing state
-// 	//   0: linear: Linear landing
-// 	//   1: spiral: Spiral landing
-// 	log.Info("ardrone3.landingStateChanged() called")
-// 	return nil
-// }
-
-// // TODO: Implement this
-// // Title: Drone&#39;s air speed changed
-// // Description: Drone&#39;s air speed changed\n Expressed in the drone&#39;s
-// //   referential.
-// // Support: 090e:1.2.0
-// // Triggered: regularly.
-// // Result:
-// func (p *pilotingState) airSpeedChanged(args []interface{}) error {
-// 	// airSpeed := args[0].(float32)
-// 	//   Speed relative to air on x axis (speed is always &gt; 0) (in m/s)
-// 	log.Info("ardrone3.airSpeedChanged() called")
-// 	return nil
-// }
-
-// // TODO: Implement this
-// // Title: Move to changed
-// // Description: The drone moves or moved to a given location.
-// // Support: 090c:4.3.0
-// // Triggered: by [MoveTo](#1-0-10) or when the drone did reach the given
-// //   position.
-// // Result:
-// func (p *pilotingState) moveToChanged(args []interface{}) error {
-// 	// latitude := args[0].(float64)
-// 	//   Latitude of the location (in degrees) to reach
-// 	// longitude := args[1].(float64)
-// 	//   Longitude of the location (in degrees) to reach
-// 	// altitude := args[2].(float64)
-// 	//   Altitude above sea level (in m) to reach
-// 	// orientation_mode := args[3].(int32)
-// 	//   Orientation mode of the move to
-// 	//   0: NONE: The drone won&#39;t change its orientation
-// 	//   1: TO_TARGET: The drone will make a rotation to look in direction of the
-// 	//      given location
-// 	//   2: HEADING_START: The drone will orientate itself to the given heading
-// 	//      before moving to the location
-// 	//   3: HEADING_DURING: The drone will orientate itself to the given heading
-// 	//      while moving to the location
-// 	// heading := args[4].(float32)
-// 	//   Heading (relative to the North in degrees). This value is only used if
-// 	//   the orientation mode is HEADING_START or HEADING_DURING
-// 	// status := args[5].(int32)
-// 	//   Status of the move to
-// 	//   0: RUNNING: The drone is actually flying to the given position
-// 	//   1: DONE: The drone has reached the target
-// 	//   2: CANCELED: The move to has been canceled, either by a CancelMoveTo
-// 	//      command or when a disconnection appears.
-// 	//   3: ERROR: The move to has not been finished or started because of an
-// 	//      error.
-// 	log.Info("ardrone3.moveToChanged() called")
-// 	return nil
-// }
-
-// // TODO: Implement this
-// // Title: Motion state
-// // Description: Motion state.\n If [MotionDetection](#1-6-16) is disabled,
-// //   motion is steady.\n This information is only valid when the drone is not
-// //   flying.
-// // Support: 090c:4.3.0
-// // Triggered: when the [FlyingState](#1-4-1) is landed and the
-// //   [MotionDetection](#1-6-16) is enabled and the motion state changes.\n This
-// //   event is triggered at a filtered rate.
-// // Result:
-// func (p *pilotingState) motionState(args []interface{}) error {
-// 	// state := args[0].(int32)
-// 	//   Motion state
-// 	//   0: steady: Drone is steady
-// 	//   1: moving: Drone is moving
-// 	log.Info("ardrone3.motionState() called")
-// 	return nil
-// }
-
-// // TODO: Implement this
-// // Title: Piloted POI state
-// // Description: Piloted POI state.
-// // Support: 090c:4.3.0
-// // Triggered: by [StartPilotedPOI](#1-0-12) or [StopPilotedPOI](#1-0-13) or when
-// //   piloted POI becomes unavailable.
-// // Result:
-// func (p *pilotingState) pilotedPOI(args []interface{}) error {
-// 	// latitude := args[0].(float64)
-// 	//   Latitude of the location (in degrees) to look at. This information is
-// 	//   only valid when the state is pending or running.
-// 	// longitude := args[1].(float64)
-// 	//   Longitude of the location (in degrees) to look at. This information is
-// 	//   only valid when the state is pending or running.
-// 	// altitude := args[2].(float64)
-// 	//   Altitude above sea level (in m) to look at. This information is only
-// 	//   valid when the state is pending or running.
-// 	// status := args[3].(int32)
-// 	//   Status of the move to
-// 	//   0: UNAVAILABLE: The piloted POI is not available
-// 	//   1: AVAILABLE: The piloted POI is available
-// 	//   2: PENDING: Piloted POI has been requested. Waiting to be in state that
-// 	//      allow the piloted POI to start
-// 	//   3: RUNNING: Piloted POI is running
-// 	log.Info("ardrone3.pilotedPOI() called")
-// 	return nil
-// }
-
-// // TODO: Implement this
-// // Title: Return home battery capacity
-// // Description: Battery capacity status to return home.
-// // Support: 090c:4.3.0
-// // Triggered: when the status of the battery capacity to do a return home
-// //   changes. This means that it is triggered either when the battery level
-// //   changes, when the distance to the home changes or when the position of the
-// //   home changes.
-// // Result:
-// func (p *pilotingState) returnHomeBatteryCapacity(args []interface{}) error {
-// 	// status := args[0].(int32)
-// 	//   Status of battery to return home
-// 	//   0: OK: The battery is full enough to do a return home
-// 	//   1: WARNING: The battery is about to be too discharged to do a return home
-// 	//   2: CRITICAL: The battery level is too low to return to the home position
-// 	//   3: UNKNOWN: Battery capacity to do a return home is unknown. This can be
-// 	//      either because the home is unknown or the position of the drone is
-// 	//      unknown, or the drone has not enough information to determine how long
-// 	//      it takes to fly home.
-// 	log.Info("ardrone3.returnHomeBatteryCapacity() called")
-// 	return nil
-// }
-
 func (p *pilotingState) RLock() {
 	p.lock.RLock()
 }
@@ -638,6 +493,34 @@ func (p *pilotingState) RUnlock() {
 	p.lock.RUnlock()
 }
 
+func (p *pilotingState) FlyingState() (FlyingState, bool) {
+	if p.flyingState == nil {
+		return 0, false
+	}
+	return *p.flyingState, true
+}
+
+func (p *pilotingState) AlertState() (AlertState, bool) {
+	if p.alertState == nil {
+		return 0, false
+	}
+	return *p.alertState, true
+}
+
+func (p *pilotingState) NavigateHomeState() (NavigateHomeState, bool) {
+	if p.navigateHomeState == nil {
+		return 0, false
+	}
+	return *p.navigateHomeState, true
+}
+
+func (p *pilotingState) NavigateHomeReason() (NavigateHomeReason, bool) {
+	if p.navigateHomeReason == nil {
+		return 0, false
+	}
+	return *p.navigateHomeReason, true
+}
+
 func (p *pilotingState) SpeedX() (float32, bool) {
 	if p.speedX == nil {
 		return 0, false
@@ -0,0 +1,93 @@
+package ardrone3
+
+import (
+	"testing"
+
+	"github.com/krancour/go-parrot/features/subscriptions"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPilotingStateHandlers(t *testing.T) {
+	testCases := []struct {
+		name       string
+		handler    func(*pilotingState, []interface{}) error
+		args       []interface{}
+		attributes []string
+		assertions func(*testing.T, *pilotingState)
+	}{
+
+		{
+			name:       "flying state changed",
+			handler:    (*pilotingState).flyingStateChanged,
+			args:       []interface{}{PilotingStateFlyingStateChangedStateHovering},
+			attributes: []string{PilotingStateAttributeFlyingState},
+			assertions: func(t *testing.T, p *pilotingState) {
+				flyingState, ok := p.FlyingState()
+				require.True(t, ok)
+				require.Equal(t, FlyingStateHovering, flyingState)
+			},
+		},
+
+		{
+			name:       "alert state changed",
+			handler:    (*pilotingState).alertStateChanged,
+			args:       []interface{}{PilotingStateAlertStateChangedStateLowBattery},
+			attributes: []string{PilotingStateAttributeAlertState},
+			assertions: func(t *testing.T, p *pilotingState) {
+				alertState, ok := p.AlertState()
+				require.True(t, ok)
+				require.Equal(t, AlertStateLowBattery, alertState)
+			},
+		},
+
+		{
+			name:    "navigate home state changed",
+			handler: (*pilotingState).navigateHomeStateChanged,
+			args: []interface{}{
+				PilotingStateNavigateHomeStateChangedStateInProgress,
+				PilotingStateNavigateHomeStateChangedReasonConnectionLost,
+			},
+			attributes: []string{
+				PilotingStateAttributeNavigateHomeState,
+				PilotingStateAttributeNavigateHomeReason,
+			},
+			assertions: func(t *testing.T, p *pilotingState) {
+				navigateHomeState, ok := p.NavigateHomeState()
+				require.True(t, ok)
+				require.Equal(t, NavigateHomeStateInProgress, navigateHomeState)
+				navigateHomeReason, ok := p.NavigateHomeReason()
+				require.True(t, ok)
+				require.Equal(
+					t,
+					NavigateHomeReasonConnectionLost,
+					navigateHomeReason,
+				)
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			p := &pilotingState{
+				Publisher: subscriptions.NewPublisher(
+					"PilotingState",
+					pilotingStateAttributes...,
+				),
+			}
+			subscription, err := p.Subscribe()
+			require.NoError(t, err)
+			defer subscription.Unsubscribe()
+			err = testCase.handler(p, testCase.args)
+			require.NoError(t, err)
+			require.Equal(
+				t,
+				subscriptions.Notification{
+					Class:      "PilotingState",
+					Attributes: testCase.attributes,
+				},
+				<-subscription.NotificationsCh(),
+			)
+			testCase.assertions(t, p)
+		})
+	}
+}
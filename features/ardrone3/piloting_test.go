@@ -0,0 +1,67 @@
+package ardrone3
+
+import (
+	"testing"
+
+	"github.com/krancour/go-parrot/protocols/arcommands"
+	"github.com/krancour/go-parrot/protocols/arnetwork"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTakeOffAndLanding(t *testing.T) {
+	testCases := []struct {
+		name         string
+		send         func(Piloting) (*arnetwork.Receipt, error)
+		expectedData []byte
+	}{
+
+		{
+			name: "take off",
+			send: func(p Piloting) (*arnetwork.Receipt, error) {
+				return nil, p.TakeOff()
+			},
+			expectedData: []byte{1, 0, 1, 0},
+		},
+
+		{
+			name:         "take off with receipt",
+			send:         Piloting.TakeOffWithReceipt,
+			expectedData: []byte{1, 0, 1, 0},
+		},
+
+		{
+			name: "landing",
+			send: func(p Piloting) (*arnetwork.Receipt, error) {
+				return nil, p.Landing()
+			},
+			expectedData: []byte{1, 0, 3, 0},
+		},
+
+		{
+			name:         "landing with receipt",
+			send:         Piloting.LandingWithReceipt,
+			expectedData: []byte{1, 0, 3, 0},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			c2dCh := make(chan arnetwork.Frame, 1)
+			c2dCommandClient := arcommands.NewC2DCommandClient(
+				map[uint8]chan<- arnetwork.Frame{
+					arcommands.C2DAckBufferID: c2dCh,
+				},
+			)
+			defer c2dCommandClient.Close()
+			p := &piloting{
+				c2dCommandClient: c2dCommandClient,
+			}
+			// Neither variant waits for the device to acknowledge the command
+			receipt, err := testCase.send(p)
+			require.NoError(t, err)
+			frame := <-c2dCh
+			require.Equal(t, testCase.expectedData, frame.Data)
+			require.Equal(t, receipt, frame.Receipt)
+		})
+	}
+}
@@ -0,0 +1,5409 @@
+// Code generated by arsdkgen from ardrone3.xml. DO NOT EDIT.
+
+package ardrone3
+
+import (
+	"fmt"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/krancour/go-parrot/protocols/arcommands"
+	"github.com/krancour/go-parrot/protocols/arnetwork"
+	"github.com/pkg/errors"
+)
+
+// PilotingMoveToOrientationMode is a type for constants used as the
+// orientation_mode argument of the Piloting moveTo command. Orientation mode of
+// the move to.
+type PilotingMoveToOrientationMode int32
+
+const (
+	// PilotingMoveToOrientationModeNONE is NONE: The drone won't change its
+	// orientation
+	PilotingMoveToOrientationModeNONE PilotingMoveToOrientationMode = iota
+	// PilotingMoveToOrientationModeToTarget is TO_TARGET: The drone will make a
+	// rotation to look in direction of the given location
+	PilotingMoveToOrientationModeToTarget
+	// PilotingMoveToOrientationModeHeadingStart is HEADING_START: The drone will
+	// orientate itself to the given heading before moving to the location
+	PilotingMoveToOrientationModeHeadingStart
+	// PilotingMoveToOrientationModeHeadingDuring is HEADING_DURING: The drone will
+	// orientate itself to the given heading while moving to the location
+	PilotingMoveToOrientationModeHeadingDuring
+)
+
+// pilotingMoveToOrientationModeNames maps each PilotingMoveToOrientationMode
+// value to its name as defined by the device SDK.
+var pilotingMoveToOrientationModeNames = map[PilotingMoveToOrientationMode]string{
+	PilotingMoveToOrientationModeNONE:          "NONE",
+	PilotingMoveToOrientationModeToTarget:      "TO_TARGET",
+	PilotingMoveToOrientationModeHeadingStart:  "HEADING_START",
+	PilotingMoveToOrientationModeHeadingDuring: "HEADING_DURING",
+}
+
+func (e PilotingMoveToOrientationMode) String() string {
+	if name, ok := pilotingMoveToOrientationModeNames[e]; ok {
+		return name
+	}
+	return fmt.Sprintf("PilotingMoveToOrientationMode(%d)", int32(e))
+}
+
+// IsValid returns true if the PilotingMoveToOrientationMode is one defined by
+// the device SDK.
+func (e PilotingMoveToOrientationMode) IsValid() bool {
+	_, ok := pilotingMoveToOrientationModeNames[e]
+	return ok
+}
+
+// sendPilotingFlatTrim sends the Piloting FlatTrim command to the device.
+// Title: Do a flat trim
+// Description: Do a flat trim of the accelerometer/gyro.\n Could be useful when
+// the drone is sliding in hover mode.
+// Support: 0901;090c;090e
+// Result: Accelerometer and gyroscope are calibrated then event
+// [FlatTrimChanged](#1-4-0) is triggered.
+func sendPilotingFlatTrim(
+	c2dCommandClient arcommands.C2DCommandClient,
+) error {
+	if err := c2dCommandClient.SendCommand(
+		arcommands.C2DAckBufferID,
+		1, // ardrone3
+		0, // Piloting
+		0, // FlatTrim
+	); err != nil {
+		return errors.Wrap(err, "error sending FlatTrim command")
+	}
+	return nil
+}
+
+// sendPilotingFlatTrimWithReceipt is like sendPilotingFlatTrim, but also
+// returns an arnetwork.Receipt that is resolved with the outcome of delivering
+// the command.
+func sendPilotingFlatTrimWithReceipt(
+	c2dCommandClient arcommands.C2DCommandClient,
+) (*arnetwork.Receipt, error) {
+	receipt, err := c2dCommandClient.SendCommandWithReceipt(
+		arcommands.C2DAckBufferID,
+		1, // ardrone3
+		0, // Piloting
+		0, // FlatTrim
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "error sending FlatTrim command")
+	}
+	return receipt, nil
+}
+
+// sendPilotingTakeOff sends the Piloting TakeOff command to the device.
+// Title: Take off
+// Description: Ask the drone to take off.\n On the fixed wings (such as Disco):
+// not used except to cancel a land.
+// Support: 0901;090c;090e
+// Result: On the quadcopters: the drone takes off if its [FlyingState](#1-4-1)
+// was landed.\n Then, event [FlyingState](#1-4-1) is triggered.
+func sendPilotingTakeOff(
+	c2dCommandClient arcommands.C2DCommandClient,
+) error {
+	if err := c2dCommandClient.SendCommand(
+		arcommands.C2DAckBufferID,
+		1, // ardrone3
+		0, // Piloting
+		1, // TakeOff
+	); err != nil {
+		return errors.Wrap(err, "error sending TakeOff command")
+	}
+	return nil
+}
+
+// sendPilotingTakeOffWithReceipt is like sendPilotingTakeOff, but also returns
+// an arnetwork.Receipt that is resolved with the outcome of delivering the
+// command.
+func sendPilotingTakeOffWithReceipt(
+	c2dCommandClient arcommands.C2DCommandClient,
+) (*arnetwork.Receipt, error) {
+	receipt, err := c2dCommandClient.SendCommandWithReceipt(
+		arcommands.C2DAckBufferID,
+		1, // ardrone3
+		0, // Piloting
+		1, // TakeOff
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "error sending TakeOff command")
+	}
+	return receipt, nil
+}
+
+// sendPilotingPCMD sends the Piloting PCMD command to the device.
+// Title: Move the drone
+// Description: Move the drone.\n The libARController is sending the command
+// each 50ms.\n\n **Please note that you should call setPilotingPCMD and not
+// sendPilotingPCMD because the libARController is handling the periodicity and
+// the buffer on which it is sent.**
+// Support: 0901;090c;090e
+// Result: The drone moves! Yayyy!\n Event [SpeedChanged](#1-4-5),
+// [AttitudeChanged](#1-4-6) and [PositionChanged](#1-4-4) (only if gps of the
+// drone has fixed) are triggered.
+func sendPilotingPCMD(
+	c2dCommandClient arcommands.C2DCommandClient,
+	flag uint8,
+	roll int8,
+	pitch int8,
+	yaw int8,
+	gaz int8,
+	timestampAndSeqNum uint32,
+) error {
+	// flag:
+	//   Boolean flag: 1 if the roll and pitch values should be taken in
+	//   consideration. 0 otherwise
+	// roll:
+	//   Roll angle as signed percentage.
+	// pitch:
+	//   Pitch angle as signed percentage.
+	// yaw:
+	//   Yaw rotation speed as signed percentage.
+	// gaz:
+	//   Throttle as signed percentage.
+	// timestampAndSeqNum:
+	//   Command timestamp in milliseconds (low 24 bits) + command sequence number
+	//   (high 8 bits) [0;255].
+	if err := c2dCommandClient.SendCommand(
+		arcommands.C2DNonAckBufferID,
+		1, // ardrone3
+		0, // Piloting
+		2, // PCMD
+		flag,
+		roll,
+		pitch,
+		yaw,
+		gaz,
+		timestampAndSeqNum,
+	); err != nil {
+		return errors.Wrap(err, "error sending PCMD command")
+	}
+	return nil
+}
+
+// sendPilotingLanding sends the Piloting Landing command to the device.
+// Title: Land
+// Description: Land.\n Please note that on copters, if you put some positive
+// gaz (in the [PilotingCommand](#1-0-2)) during the landing, it will cancel it.
+// Support: 0901;090c;090e
+// Result: On the copters, the drone lands if its [FlyingState](#1-4-1) was
+// taking off, hovering or flying.\n On the fixed wings, the drone lands if its
+// [FlyingState](#1-4-1) was hovering or flying.\n Then, event
+// [FlyingState](#1-4-1) is triggered.
+func sendPilotingLanding(
+	c2dCommandClient arcommands.C2DCommandClient,
+) error {
+	if err := c2dCommandClient.SendCommand(
+		arcommands.C2DAckBufferID,
+		1, // ardrone3
+		0, // Piloting
+		3, // Landing
+	); err != nil {
+		return errors.Wrap(err, "error sending Landing command")
+	}
+	return nil
+}
+
+// sendPilotingLandingWithReceipt is like sendPilotingLanding, but also returns
+// an arnetwork.Receipt that is resolved with the outcome of delivering the
+// command.
+func sendPilotingLandingWithReceipt(
+	c2dCommandClient arcommands.C2DCommandClient,
+) (*arnetwork.Receipt, error) {
+	receipt, err := c2dCommandClient.SendCommandWithReceipt(
+		arcommands.C2DAckBufferID,
+		1, // ardrone3
+		0, // Piloting
+		3, // Landing
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "error sending Landing command")
+	}
+	return receipt, nil
+}
+
+// sendPilotingEmergency sends the Piloting Emergency command to the device.
+// Title: Cut out the motors
+// Description: Cut out the motors.\n This cuts immediatly the motors. The drone
+// will fall.\n This command is sent on a dedicated high priority buffer which
+// will infinitely retry to send it if the command is not delivered.
+// Support: 0901;090c;090e
+// Result: The drone immediatly cuts off its motors.\n Then, event
+// [FlyingState](#1-4-1) is triggered.
+func sendPilotingEmergency(
+	c2dCommandClient arcommands.C2DCommandClient,
+) error {
+	if err := c2dCommandClient.SendCommand(
+		arcommands.C2DEmergencyBufferID,
+		1, // ardrone3
+		0, // Piloting
+		4, // Emergency
+	); err != nil {
+		return errors.Wrap(err, "error sending Emergency command")
+	}
+	return nil
+}
+
+// sendPilotingNavigateHome sends the Piloting NavigateHome command to the
+// device.
+// Title: Return home
+// Description: Return home.\n Ask the drone to fly to its
+// [HomePosition](#1-24-0).\n The availability of the return home can be get
+// from [ReturnHomeState](#1-4-3).\n Please note that the drone will wait to be
+// hovering to start its return home. This means that it will wait to have a
+// [flag](#1-0-2) set at 0.
+// Support: 0901;090c;090e
+// Result: The drone will fly back to its home position.\n Then, event
+// [ReturnHomeState](#1-4-3) is triggered.\n You can get a state pending if the
+// drone is not ready to start its return home process but will do it as soon as
+// it is possible.
+func sendPilotingNavigateHome(
+	c2dCommandClient arcommands.C2DCommandClient,
+	start uint8,
+) error {
+	// start:
+	//   1 to start the navigate home, 0 to stop it
+	if err := c2dCommandClient.SendCommand(
+		arcommands.C2DAckBufferID,
+		1, // ardrone3
+		0, // Piloting
+		5, // NavigateHome
+		start,
+	); err != nil {
+		return errors.Wrap(err, "error sending NavigateHome command")
+	}
+	return nil
+}
+
+// sendPilotingNavigateHomeWithReceipt is like sendPilotingNavigateHome, but
+// also returns an arnetwork.Receipt that is resolved with the outcome of
+// delivering the command.
+func sendPilotingNavigateHomeWithReceipt(
+	c2dCommandClient arcommands.C2DCommandClient,
+	start uint8,
+) (*arnetwork.Receipt, error) {
+	receipt, err := c2dCommandClient.SendCommandWithReceipt(
+		arcommands.C2DAckBufferID,
+		1, // ardrone3
+		0, // Piloting
+		5, // NavigateHome
+		start,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "error sending NavigateHome command")
+	}
+	return receipt, nil
+}
+
+// sendPilotingMoveBy sends the Piloting moveBy command to the device.
+// Title: Move the drone to a relative position
+// Description: Move the drone to a relative position and rotate heading by a
+// given angle.\n Moves are relative to the current drone orientation, (drone's
+// reference).\n Also note that the given rotation will not modify the move
+// (i.e. moves are always rectilinear).
+// Support: 0901:3.3.0;090c:3.3.0
+// Result: The drone will move of the given offsets.\n Then, event
+// [RelativeMoveEnded](#1-34-0) is triggered.\n If you send a second relative
+// move command, the drone will trigger a [RelativeMoveEnded](#1-34-0) with the
+// offsets it managed to do before this new command and the value of error set
+// to interrupted.
+func sendPilotingMoveBy(
+	c2dCommandClient arcommands.C2DCommandClient,
+	dx float32,
+	dy float32,
+	dz float32,
+	dPsi float32,
+) error {
+	// dx:
+	//   Wanted displacement along the front axis [m]
+	// dy:
+	//   Wanted displacement along the right axis [m]
+	// dz:
+	//   Wanted displacement along the down axis [m]
+	// dPsi:
+	//   Wanted rotation of heading [rad]
+	if err := c2dCommandClient.SendCommand(
+		arcommands.C2DAckBufferID,
+		1, // ardrone3
+		0, // Piloting
+		7, // moveBy
+		dx,
+		dy,
+		dz,
+		dPsi,
+	); err != nil {
+		return errors.Wrap(err, "error sending moveBy command")
+	}
+	return nil
+}
+
+// sendPilotingMoveByWithReceipt is like sendPilotingMoveBy, but also returns an
+// arnetwork.Receipt that is resolved with the outcome of delivering the
+// command.
+func sendPilotingMoveByWithReceipt(
+	c2dCommandClient arcommands.C2DCommandClient,
+	dx float32,
+	dy float32,
+	dz float32,
+	dPsi float32,
+) (*arnetwork.Receipt, error) {
+	receipt, err := c2dCommandClient.SendCommandWithReceipt(
+		arcommands.C2DAckBufferID,
+		1, // ardrone3
+		0, // Piloting
+		7, // moveBy
+		dx,
+		dy,
+		dz,
+		dPsi,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "error sending moveBy command")
+	}
+	return receipt, nil
+}
+
+// sendPilotingMoveTo sends the Piloting moveTo command to the device.
+// Title: Move to a location
+// Description: Move the drone to a specified location.\n If a new value is
+// sent, the drone will immediatly run the new moveTo, canceling the previous
+// one.\n If a [MoveBy](#1-0-7) is running, it will be replaced by this moveTo.
+// Support: 0901:4.3.0;090c:4.3.0
+// Result: Event [MovingTo](#1-4-12) is triggered with state running. Then, the
+// drone will move to the given location.\n Then, event [MoveToChanged](#1-4-12)
+// is triggered with state succeed.
+func sendPilotingMoveTo(
+	c2dCommandClient arcommands.C2DCommandClient,
+	latitude float64,
+	longitude float64,
+	altitude float64,
+	orientationMode PilotingMoveToOrientationMode,
+	heading float32,
+) error {
+	// latitude:
+	//   Latitude of the location (in degrees) to reach
+	// longitude:
+	//   Longitude of the location (in degrees) to reach
+	// altitude:
+	//   Altitude above sea level (in m) to reach
+	// orientationMode:
+	//   Orientation mode of the move to
+	//   0: NONE: The drone won't change its orientation
+	//   1: TO_TARGET: The drone will make a rotation to look in direction of the
+	//      given location
+	//   2: HEADING_START: The drone will orientate itself to the given heading
+	//      before moving to the location
+	//   3: HEADING_DURING: The drone will orientate itself to the given heading
+	//      while moving to the location
+	// heading:
+	//   Heading (relative to the North in degrees).\n This value is only used if
+	//   the orientation mode is HEADING_START or HEADING_DURING
+	if err := c2dCommandClient.SendCommand(
+		arcommands.C2DAckBufferID,
+		1,  // ardrone3
+		0,  // Piloting
+		10, // moveTo
+		latitude,
+		longitude,
+		altitude,
+		orientationMode,
+		heading,
+	); err != nil {
+		return errors.Wrap(err, "error sending moveTo command")
+	}
+	return nil
+}
+
+// sendPilotingMoveToWithReceipt is like sendPilotingMoveTo, but also returns an
+// arnetwork.Receipt that is resolved with the outcome of delivering the
+// command.
+func sendPilotingMoveToWithReceipt(
+	c2dCommandClient arcommands.C2DCommandClient,
+	latitude float64,
+	longitude float64,
+	altitude float64,
+	orientationMode PilotingMoveToOrientationMode,
+	heading float32,
+) (*arnetwork.Receipt, error) {
+	receipt, err := c2dCommandClient.SendCommandWithReceipt(
+		arcommands.C2DAckBufferID,
+		1,  // ardrone3
+		0,  // Piloting
+		10, // moveTo
+		latitude,
+		longitude,
+		altitude,
+		orientationMode,
+		heading,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "error sending moveTo command")
+	}
+	return receipt, nil
+}
+
+// sendPilotingCancelMoveTo sends the Piloting CancelMoveTo command to the
+// device.
+// Title: Cancel the moveTo
+// Description: Cancel the current moveTo.\n If there is no current moveTo, this
+// command has no effect.
+// Support: 0901:4.3.0;090c:4.3.0
+// Result: Event [MoveToChanged](#1-4-12) is triggered with state canceled.
+func sendPilotingCancelMoveTo(
+	c2dCommandClient arcommands.C2DCommandClient,
+) error {
+	if err := c2dCommandClient.SendCommand(
+		arcommands.C2DAckBufferID,
+		1,  // ardrone3
+		0,  // Piloting
+		11, // CancelMoveTo
+	); err != nil {
+		return errors.Wrap(err, "error sending CancelMoveTo command")
+	}
+	return nil
+}
+
+// sendPilotingCancelMoveToWithReceipt is like sendPilotingCancelMoveTo, but
+// also returns an arnetwork.Receipt that is resolved with the outcome of
+// delivering the command.
+func sendPilotingCancelMoveToWithReceipt(
+	c2dCommandClient arcommands.C2DCommandClient,
+) (*arnetwork.Receipt, error) {
+	receipt, err := c2dCommandClient.SendCommandWithReceipt(
+		arcommands.C2DAckBufferID,
+		1,  // ardrone3
+		0,  // Piloting
+		11, // CancelMoveTo
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "error sending CancelMoveTo command")
+	}
+	return receipt, nil
+}
+
+// MediaRecordEventPictureEventChangedEvent is a type for constants used as the
+// event argument of the MediaRecordEvent PictureEventChanged command. Last
+// event of picture recording.
+type MediaRecordEventPictureEventChangedEvent int32
+
+const (
+	// MediaRecordEventPictureEventChangedEventTaken is taken: Picture taken and
+	// saved
+	MediaRecordEventPictureEventChangedEventTaken MediaRecordEventPictureEventChangedEvent = iota
+	// MediaRecordEventPictureEventChangedEventFailed is failed: Picture failed
+	MediaRecordEventPictureEventChangedEventFailed
+)
+
+// mediaRecordEventPictureEventChangedEventNames maps each
+// MediaRecordEventPictureEventChangedEvent value to its name as defined by the
+// device SDK.
+var mediaRecordEventPictureEventChangedEventNames = map[MediaRecordEventPictureEventChangedEvent]string{
+	MediaRecordEventPictureEventChangedEventTaken:  "taken",
+	MediaRecordEventPictureEventChangedEventFailed: "failed",
+}
+
+func (e MediaRecordEventPictureEventChangedEvent) String() string {
+	if name, ok := mediaRecordEventPictureEventChangedEventNames[e]; ok {
+		return name
+	}
+	return fmt.Sprintf("MediaRecordEventPictureEventChangedEvent(%d)", int32(e))
+}
+
+// IsValid returns true if the MediaRecordEventPictureEventChangedEvent is one
+// defined by the device SDK.
+func (e MediaRecordEventPictureEventChangedEvent) IsValid() bool {
+	_, ok := mediaRecordEventPictureEventChangedEventNames[e]
+	return ok
+}
+
+// MediaRecordEventPictureEventChangedError is a type for constants used as the
+// error argument of the MediaRecordEvent PictureEventChanged command. Error to
+// explain the event.
+type MediaRecordEventPictureEventChangedError int32
+
+const (
+	// MediaRecordEventPictureEventChangedErrorOk is ok: No Error
+	MediaRecordEventPictureEventChangedErrorOk MediaRecordEventPictureEventChangedError = iota
+	// MediaRecordEventPictureEventChangedErrorUnknown is unknown: Unknown generic
+	// error ; only when state is failed
+	MediaRecordEventPictureEventChangedErrorUnknown
+	// MediaRecordEventPictureEventChangedErrorBusy is busy: Picture recording is
+	// busy ; only when state is failed
+	MediaRecordEventPictureEventChangedErrorBusy
+	// MediaRecordEventPictureEventChangedErrorNotAvailable is notAvailable:
+	// Picture recording not available ; only when state is failed
+	MediaRecordEventPictureEventChangedErrorNotAvailable
+	// MediaRecordEventPictureEventChangedErrorMemoryFull is memoryFull: Memory
+	// full ; only when state is failed
+	MediaRecordEventPictureEventChangedErrorMemoryFull
+	// MediaRecordEventPictureEventChangedErrorLowBattery is lowBattery: Battery is
+	// too low to record.
+	MediaRecordEventPictureEventChangedErrorLowBattery
+)
+
+// mediaRecordEventPictureEventChangedErrorNames maps each
+// MediaRecordEventPictureEventChangedError value to its name as defined by the
+// device SDK.
+var mediaRecordEventPictureEventChangedErrorNames = map[MediaRecordEventPictureEventChangedError]string{
+	MediaRecordEventPictureEventChangedErrorOk:           "ok",
+	MediaRecordEventPictureEventChangedErrorUnknown:      "unknown",
+	MediaRecordEventPictureEventChangedErrorBusy:         "busy",
+	MediaRecordEventPictureEventChangedErrorNotAvailable: "notAvailable",
+	MediaRecordEventPictureEventChangedErrorMemoryFull:   "memoryFull",
+	MediaRecordEventPictureEventChangedErrorLowBattery:   "lowBattery",
+}
+
+func (e MediaRecordEventPictureEventChangedError) String() string {
+	if name, ok := mediaRecordEventPictureEventChangedErrorNames[e]; ok {
+		return name
+	}
+	return fmt.Sprintf("MediaRecordEventPictureEventChangedError(%d)", int32(e))
+}
+
+// IsValid returns true if the MediaRecordEventPictureEventChangedError is one
+// defined by the device SDK.
+func (e MediaRecordEventPictureEventChangedError) IsValid() bool {
+	_, ok := mediaRecordEventPictureEventChangedErrorNames[e]
+	return ok
+}
+
+// MediaRecordEventVideoEventChangedEvent is a type for constants used as the
+// event argument of the MediaRecordEvent VideoEventChanged command. Event of
+// video recording.
+type MediaRecordEventVideoEventChangedEvent int32
+
+const (
+	// MediaRecordEventVideoEventChangedEventStart is start: Video start
+	MediaRecordEventVideoEventChangedEventStart MediaRecordEventVideoEventChangedEvent = iota
+	// MediaRecordEventVideoEventChangedEventStop is stop: Video stop and saved
+	MediaRecordEventVideoEventChangedEventStop
+	// MediaRecordEventVideoEventChangedEventFailed is failed: Video failed
+	MediaRecordEventVideoEventChangedEventFailed
+)
+
+// mediaRecordEventVideoEventChangedEventNames maps each
+// MediaRecordEventVideoEventChangedEvent value to its name as defined by the
+// device SDK.
+var mediaRecordEventVideoEventChangedEventNames = map[MediaRecordEventVideoEventChangedEvent]string{
+	MediaRecordEventVideoEventChangedEventStart:  "start",
+	MediaRecordEventVideoEventChangedEventStop:   "stop",
+	MediaRecordEventVideoEventChangedEventFailed: "failed",
+}
+
+func (e MediaRecordEventVideoEventChangedEvent) String() string {
+	if name, ok := mediaRecordEventVideoEventChangedEventNames[e]; ok {
+		return name
+	}
+	return fmt.Sprintf("MediaRecordEventVideoEventChangedEvent(%d)", int32(e))
+}
+
+// IsValid returns true if the MediaRecordEventVideoEventChangedEvent is one
+// defined by the device SDK.
+func (e MediaRecordEventVideoEventChangedEvent) IsValid() bool {
+	_, ok := mediaRecordEventVideoEventChangedEventNames[e]
+	return ok
+}
+
+// MediaRecordEventVideoEventChangedError is a type for constants used as the
+// error argument of the MediaRecordEvent VideoEventChanged command. Error to
+// explain the event.
+type MediaRecordEventVideoEventChangedError int32
+
+const (
+	// MediaRecordEventVideoEventChangedErrorOk is ok: No Error
+	MediaRecordEventVideoEventChangedErrorOk MediaRecordEventVideoEventChangedError = iota
+	// MediaRecordEventVideoEventChangedErrorUnknown is unknown: Unknown generic
+	// error ; only when state is failed
+	MediaRecordEventVideoEventChangedErrorUnknown
+	// MediaRecordEventVideoEventChangedErrorBusy is busy: Video recording is busy
+	// ; only when state is failed
+	MediaRecordEventVideoEventChangedErrorBusy
+	// MediaRecordEventVideoEventChangedErrorNotAvailable is notAvailable: Video
+	// recording not available ; only when state is failed
+	MediaRecordEventVideoEventChangedErrorNotAvailable
+	// MediaRecordEventVideoEventChangedErrorMemoryFull is memoryFull: Memory full
+	MediaRecordEventVideoEventChangedErrorMemoryFull
+	// MediaRecordEventVideoEventChangedErrorLowBattery is lowBattery: Battery is
+	// too low to record.
+	MediaRecordEventVideoEventChangedErrorLowBattery
+	// MediaRecordEventVideoEventChangedErrorAutoStopped is autoStopped: Video was
+	// auto stopped
+	MediaRecordEventVideoEventChangedErrorAutoStopped
+)
+
+// mediaRecordEventVideoEventChangedErrorNames maps each
+// MediaRecordEventVideoEventChangedError value to its name as defined by the
+// device SDK.
+var mediaRecordEventVideoEventChangedErrorNames = map[MediaRecordEventVideoEventChangedError]string{
+	MediaRecordEventVideoEventChangedErrorOk:           "ok",
+	MediaRecordEventVideoEventChangedErrorUnknown:      "unknown",
+	MediaRecordEventVideoEventChangedErrorBusy:         "busy",
+	MediaRecordEventVideoEventChangedErrorNotAvailable: "notAvailable",
+	MediaRecordEventVideoEventChangedErrorMemoryFull:   "memoryFull",
+	MediaRecordEventVideoEventChangedErrorLowBattery:   "lowBattery",
+	MediaRecordEventVideoEventChangedErrorAutoStopped:  "autoStopped",
+}
+
+func (e MediaRecordEventVideoEventChangedError) String() string {
+	if name, ok := mediaRecordEventVideoEventChangedErrorNames[e]; ok {
+		return name
+	}
+	return fmt.Sprintf("MediaRecordEventVideoEventChangedError(%d)", int32(e))
+}
+
+// IsValid returns true if the MediaRecordEventVideoEventChangedError is one
+// defined by the device SDK.
+func (e MediaRecordEventVideoEventChangedError) IsValid() bool {
+	_, ok := mediaRecordEventVideoEventChangedErrorNames[e]
+	return ok
+}
+
+// mediaRecordEventHandlers is implemented by the MediaRecordEvent class, which
+// handles each of its commands. Events of media recording.
+type mediaRecordEventHandlers interface {
+	pictureEventChanged(args []interface{}) error
+	videoEventChanged(args []interface{}) error
+}
+
+// mediaRecordEventDefaults implements every handler of the MediaRecordEvent
+// class by logging that the command was received. The MediaRecordEvent class
+// can embed it and implement only the handlers for commands it acts upon.
+type mediaRecordEventDefaults struct{}
+
+// mediaRecordEventD2CCommands returns the commands of the MediaRecordEvent
+// class, each dispatched to the corresponding handler.
+func mediaRecordEventD2CCommands(
+	handlers mediaRecordEventHandlers,
+) []arcommands.D2CCommand {
+	return []arcommands.D2CCommand{
+		arcommands.NewD2CCommand(
+			0,
+			"PictureEventChanged",
+			[]interface{}{
+				MediaRecordEventPictureEventChangedEvent(0), // event,
+				MediaRecordEventPictureEventChangedError(0), // error,
+			},
+			handlers.pictureEventChanged,
+		),
+		arcommands.NewD2CCommand(
+			1,
+			"VideoEventChanged",
+			[]interface{}{
+				MediaRecordEventVideoEventChangedEvent(0), // event,
+				MediaRecordEventVideoEventChangedError(0), // error,
+			},
+			handlers.videoEventChanged,
+		),
+	}
+}
+
+// Title: Picture taken
+// Description: Picture taken.\n\n **This event is a notification, you can't
+// retrieve it in the cache of the device controller.**
+// Support: 0901:2.0.1;090c;090e
+// Triggered: after a [TakePicture](#1-7-2), when the picture has been taken (or
+// it has failed).
+// Result:
+func (mediaRecordEventDefaults) pictureEventChanged(args []interface{}) error {
+	// event := args[0].(MediaRecordEventPictureEventChangedEvent)
+	//   Last event of picture recording
+	//   0: taken: Picture taken and saved
+	//   1: failed: Picture failed
+	// error := args[1].(MediaRecordEventPictureEventChangedError)
+	//   Error to explain the event
+	//   0: ok: No Error
+	//   1: unknown: Unknown generic error ; only when state is failed
+	//   2: busy: Picture recording is busy ; only when state is failed
+	//   3: notAvailable: Picture recording not available ; only when state is
+	//      failed
+	//   4: memoryFull: Memory full ; only when state is failed
+	//   5: lowBattery: Battery is too low to record.
+	log.Info("ardrone3.pictureEventChanged() called")
+	return nil
+}
+
+// Title: Video record notification
+// Description: Video record notification.\n\n **This event is a notification,
+// you can't retrieve it in the cache of the device controller.**
+// Support: 0901:2.0.1;090c;090e
+// Triggered: by [RecordVideo](#1-7-3) or a change in the video state.
+// Result:
+func (mediaRecordEventDefaults) videoEventChanged(args []interface{}) error {
+	// event := args[0].(MediaRecordEventVideoEventChangedEvent)
+	//   Event of video recording
+	//   0: start: Video start
+	//   1: stop: Video stop and saved
+	//   2: failed: Video failed
+	// error := args[1].(MediaRecordEventVideoEventChangedError)
+	//   Error to explain the event
+	//   0: ok: No Error
+	//   1: unknown: Unknown generic error ; only when state is failed
+	//   2: busy: Video recording is busy ; only when state is failed
+	//   3: notAvailable: Video recording not available ; only when state is failed
+	//   4: memoryFull: Memory full
+	//   5: lowBattery: Battery is too low to record.
+	//   6: autoStopped: Video was auto stopped
+	log.Info("ardrone3.videoEventChanged() called")
+	return nil
+}
+
+// PilotingStateFlyingStateChangedState is a type for constants used as the
+// state argument of the PilotingState FlyingStateChanged command. Drone flying
+// state.
+type PilotingStateFlyingStateChangedState int32
+
+const (
+	// PilotingStateFlyingStateChangedStateLanded is landed: Landed state
+	PilotingStateFlyingStateChangedStateLanded PilotingStateFlyingStateChangedState = iota
+	// PilotingStateFlyingStateChangedStateTakingoff is takingoff: Taking off state
+	PilotingStateFlyingStateChangedStateTakingoff
+	// PilotingStateFlyingStateChangedStateHovering is hovering: Hovering /
+	// Circling (for fixed wings) state
+	PilotingStateFlyingStateChangedStateHovering
+	// PilotingStateFlyingStateChangedStateFlying is flying: Flying state
+	PilotingStateFlyingStateChangedStateFlying
+	// PilotingStateFlyingStateChangedStateLanding is landing: Landing state
+	PilotingStateFlyingStateChangedStateLanding
+	// PilotingStateFlyingStateChangedStateEmergency is emergency: Emergency state
+	PilotingStateFlyingStateChangedStateEmergency
+	// PilotingStateFlyingStateChangedStateUsertakeoff is usertakeoff: User take
+	// off state. Waiting for user action to take off.
+	PilotingStateFlyingStateChangedStateUsertakeoff
+	// PilotingStateFlyingStateChangedStateMotorRamping is motor_ramping: Motor
+	// ramping state.
+	PilotingStateFlyingStateChangedStateMotorRamping
+	// PilotingStateFlyingStateChangedStateEmergencyLanding is emergency_landing:
+	// Emergency landing state. Drone autopilot has detected defective sensor(s).
+	// Only Yaw argument in PCMD is taken into account. All others flying commands
+	// are ignored.
+	PilotingStateFlyingStateChangedStateEmergencyLanding
+)
+
+// pilotingStateFlyingStateChangedStateNames maps each
+// PilotingStateFlyingStateChangedState value to its name as defined by the
+// device SDK.
+var pilotingStateFlyingStateChangedStateNames = map[PilotingStateFlyingStateChangedState]string{
+	PilotingStateFlyingStateChangedStateLanded:           "landed",
+	PilotingStateFlyingStateChangedStateTakingoff:        "takingoff",
+	PilotingStateFlyingStateChangedStateHovering:         "hovering",
+	PilotingStateFlyingStateChangedStateFlying:           "flying",
+	PilotingStateFlyingStateChangedStateLanding:          "landing",
+	PilotingStateFlyingStateChangedStateEmergency:        "emergency",
+	PilotingStateFlyingStateChangedStateUsertakeoff:      "usertakeoff",
+	PilotingStateFlyingStateChangedStateMotorRamping:     "motor_ramping",
+	PilotingStateFlyingStateChangedStateEmergencyLanding: "emergency_landing",
+}
+
+func (e PilotingStateFlyingStateChangedState) String() string {
+	if name, ok := pilotingStateFlyingStateChangedStateNames[e]; ok {
+		return name
+	}
+	return fmt.Sprintf("PilotingStateFlyingStateChangedState(%d)", int32(e))
+}
+
+// IsValid returns true if the PilotingStateFlyingStateChangedState is one
+// defined by the device SDK.
+func (e PilotingStateFlyingStateChangedState) IsValid() bool {
+	_, ok := pilotingStateFlyingStateChangedStateNames[e]
+	return ok
+}
+
+// PilotingStateAlertStateChangedState is a type for constants used as the state
+// argument of the PilotingState AlertStateChanged command. Drone alert state.
+type PilotingStateAlertStateChangedState int32
+
+const (
+	// PilotingStateAlertStateChangedStateNone is none: No alert
+	PilotingStateAlertStateChangedStateNone PilotingStateAlertStateChangedState = iota
+	// PilotingStateAlertStateChangedStateUser is user: User emergency alert
+	PilotingStateAlertStateChangedStateUser
+	// PilotingStateAlertStateChangedStateCutOut is cut_out: Cut out alert
+	PilotingStateAlertStateChangedStateCutOut
+	// PilotingStateAlertStateChangedStateCriticalBattery is critical_battery:
+	// Critical battery alert
+	PilotingStateAlertStateChangedStateCriticalBattery
+	// PilotingStateAlertStateChangedStateLowBattery is low_battery: Low battery
+	// alert
+	PilotingStateAlertStateChangedStateLowBattery
+	// PilotingStateAlertStateChangedStateTooMuchAngle is too_much_angle: The angle
+	// of the drone is too high
+	PilotingStateAlertStateChangedStateTooMuchAngle
+)
+
+// pilotingStateAlertStateChangedStateNames maps each
+// PilotingStateAlertStateChangedState value to its name as defined by the
+// device SDK.
+var pilotingStateAlertStateChangedStateNames = map[PilotingStateAlertStateChangedState]string{
+	PilotingStateAlertStateChangedStateNone:            "none",
+	PilotingStateAlertStateChangedStateUser:            "user",
+	PilotingStateAlertStateChangedStateCutOut:          "cut_out",
+	PilotingStateAlertStateChangedStateCriticalBattery: "critical_battery",
+	PilotingStateAlertStateChangedStateLowBattery:      "low_battery",
+	PilotingStateAlertStateChangedStateTooMuchAngle:    "too_much_angle",
+}
+
+func (e PilotingStateAlertStateChangedState) String() string {
+	if name, ok := pilotingStateAlertStateChangedStateNames[e]; ok {
+		return name
+	}
+	return fmt.Sprintf("PilotingStateAlertStateChangedState(%d)", int32(e))
+}
+
+// IsValid returns true if the PilotingStateAlertStateChangedState is one
+// defined by the device SDK.
+func (e PilotingStateAlertStateChangedState) IsValid() bool {
+	_, ok := pilotingStateAlertStateChangedStateNames[e]
+	return ok
+}
+
+// PilotingStateNavigateHomeStateChangedState is a type for constants used as
+// the state argument of the PilotingState NavigateHomeStateChanged command.
+// State of navigate home.
+type PilotingStateNavigateHomeStateChangedState int32
+
+const (
+	// PilotingStateNavigateHomeStateChangedStateAvailable is available: Navigate
+	// home is available
+	PilotingStateNavigateHomeStateChangedStateAvailable PilotingStateNavigateHomeStateChangedState = iota
+	// PilotingStateNavigateHomeStateChangedStateInProgress is inProgress: Navigate
+	// home is in progress
+	PilotingStateNavigateHomeStateChangedStateInProgress
+	// PilotingStateNavigateHomeStateChangedStateUnavailable is unavailable:
+	// Navigate home is not available
+	PilotingStateNavigateHomeStateChangedStateUnavailable
+	// PilotingStateNavigateHomeStateChangedStatePending is pending: Navigate home
+	// has been received, but its process is pending
+	PilotingStateNavigateHomeStateChangedStatePending
+)
+
+// pilotingStateNavigateHomeStateChangedStateNames maps each
+// PilotingStateNavigateHomeStateChangedState value to its name as defined by
+// the device SDK.
+var pilotingStateNavigateHomeStateChangedStateNames = map[PilotingStateNavigateHomeStateChangedState]string{
+	PilotingStateNavigateHomeStateChangedStateAvailable:   "available",
+	PilotingStateNavigateHomeStateChangedStateInProgress:  "inProgress",
+	PilotingStateNavigateHomeStateChangedStateUnavailable: "unavailable",
+	PilotingStateNavigateHomeStateChangedStatePending:     "pending",
+}
+
+func (e PilotingStateNavigateHomeStateChangedState) String() string {
+	if name, ok := pilotingStateNavigateHomeStateChangedStateNames[e]; ok {
+		return name
+	}
+	return fmt.Sprintf("PilotingStateNavigateHomeStateChangedState(%d)", int32(e))
+}
+
+// IsValid returns true if the PilotingStateNavigateHomeStateChangedState is one
+// defined by the device SDK.
+func (e PilotingStateNavigateHomeStateChangedState) IsValid() bool {
+	_, ok := pilotingStateNavigateHomeStateChangedStateNames[e]
+	return ok
+}
+
+// PilotingStateNavigateHomeStateChangedReason is a type for constants used as
+// the reason argument of the PilotingState NavigateHomeStateChanged command.
+// Reason of the state.
+type PilotingStateNavigateHomeStateChangedReason int32
+
+const (
+	// PilotingStateNavigateHomeStateChangedReasonUserRequest is userRequest: User
+	// requested a navigate home (available->inProgress)
+	PilotingStateNavigateHomeStateChangedReasonUserRequest PilotingStateNavigateHomeStateChangedReason = iota
+	// PilotingStateNavigateHomeStateChangedReasonConnectionLost is connectionLost:
+	// Connection between controller and product lost (available->inProgress)
+	PilotingStateNavigateHomeStateChangedReasonConnectionLost
+	// PilotingStateNavigateHomeStateChangedReasonLowBattery is lowBattery: Low
+	// battery occurred (available->inProgress)
+	PilotingStateNavigateHomeStateChangedReasonLowBattery
+	// PilotingStateNavigateHomeStateChangedReasonFinished is finished: Navigate
+	// home is finished (inProgress->available)
+	PilotingStateNavigateHomeStateChangedReasonFinished
+	// PilotingStateNavigateHomeStateChangedReasonStopped is stopped: Navigate home
+	// has been stopped (inProgress->available)
+	PilotingStateNavigateHomeStateChangedReasonStopped
+	// PilotingStateNavigateHomeStateChangedReasonDisabled is disabled: Navigate
+	// home disabled by product (inProgress->unavailable or available->unavailable)
+	PilotingStateNavigateHomeStateChangedReasonDisabled
+	// PilotingStateNavigateHomeStateChangedReasonEnabled is enabled: Navigate home
+	// enabled by product (unavailable->available)
+	PilotingStateNavigateHomeStateChangedReasonEnabled
+)
+
+// pilotingStateNavigateHomeStateChangedReasonNames maps each
+// PilotingStateNavigateHomeStateChangedReason value to its name as defined by
+// the device SDK.
+var pilotingStateNavigateHomeStateChangedReasonNames = map[PilotingStateNavigateHomeStateChangedReason]string{
+	PilotingStateNavigateHomeStateChangedReasonUserRequest:    "userRequest",
+	PilotingStateNavigateHomeStateChangedReasonConnectionLost: "connectionLost",
+	PilotingStateNavigateHomeStateChangedReasonLowBattery:     "lowBattery",
+	PilotingStateNavigateHomeStateChangedReasonFinished:       "finished",
+	PilotingStateNavigateHomeStateChangedReasonStopped:        "stopped",
+	PilotingStateNavigateHomeStateChangedReasonDisabled:       "disabled",
+	PilotingStateNavigateHomeStateChangedReasonEnabled:        "enabled",
+}
+
+func (e PilotingStateNavigateHomeStateChangedReason) String() string {
+	if name, ok := pilotingStateNavigateHomeStateChangedReasonNames[e]; ok {
+		return name
+	}
+	return fmt.Sprintf("PilotingStateNavigateHomeStateChangedReason(%d)", int32(e))
+}
+
+// IsValid returns true if the PilotingStateNavigateHomeStateChangedReason is
+// one defined by the device SDK.
+func (e PilotingStateNavigateHomeStateChangedReason) IsValid() bool {
+	_, ok := pilotingStateNavigateHomeStateChangedReasonNames[e]
+	return ok
+}
+
+// PilotingStateLandingStateChangedState is a type for constants used as the
+// state argument of the PilotingState LandingStateChanged command. Drone
+// landing state.
+type PilotingStateLandingStateChangedState int32
+
+const (
+	// PilotingStateLandingStateChangedStateLinear is linear: Linear landing
+	PilotingStateLandingStateChangedStateLinear PilotingStateLandingStateChangedState = iota
+	// PilotingStateLandingStateChangedStateSpiral is spiral: Spiral landing
+	PilotingStateLandingStateChangedStateSpiral
+)
+
+// pilotingStateLandingStateChangedStateNames maps each
+// PilotingStateLandingStateChangedState value to its name as defined by the
+// device SDK.
+var pilotingStateLandingStateChangedStateNames = map[PilotingStateLandingStateChangedState]string{
+	PilotingStateLandingStateChangedStateLinear: "linear",
+	PilotingStateLandingStateChangedStateSpiral: "spiral",
+}
+
+func (e PilotingStateLandingStateChangedState) String() string {
+	if name, ok := pilotingStateLandingStateChangedStateNames[e]; ok {
+		return name
+	}
+	return fmt.Sprintf("PilotingStateLandingStateChangedState(%d)", int32(e))
+}
+
+// IsValid returns true if the PilotingStateLandingStateChangedState is one
+// defined by the device SDK.
+func (e PilotingStateLandingStateChangedState) IsValid() bool {
+	_, ok := pilotingStateLandingStateChangedStateNames[e]
+	return ok
+}
+
+// PilotingStateMoveToChangedOrientationMode is a type for constants used as the
+// orientation_mode argument of the PilotingState moveToChanged command.
+// Orientation mode of the move to.
+type PilotingStateMoveToChangedOrientationMode int32
+
+const (
+	// PilotingStateMoveToChangedOrientationModeNONE is NONE: The drone won't
+	// change its orientation
+	PilotingStateMoveToChangedOrientationModeNONE PilotingStateMoveToChangedOrientationMode = iota
+	// PilotingStateMoveToChangedOrientationModeToTarget is TO_TARGET: The drone
+	// will make a rotation to look in direction of the given location
+	PilotingStateMoveToChangedOrientationModeToTarget
+	// PilotingStateMoveToChangedOrientationModeHeadingStart is HEADING_START: The
+	// drone will orientate itself to the given heading before moving to the
+	// location
+	PilotingStateMoveToChangedOrientationModeHeadingStart
+	// PilotingStateMoveToChangedOrientationModeHeadingDuring is HEADING_DURING:
+	// The drone will orientate itself to the given heading while moving to the
+	// location
+	PilotingStateMoveToChangedOrientationModeHeadingDuring
+)
+
+// pilotingStateMoveToChangedOrientationModeNames maps each
+// PilotingStateMoveToChangedOrientationMode value to its name as defined by the
+// device SDK.
+var pilotingStateMoveToChangedOrientationModeNames = map[PilotingStateMoveToChangedOrientationMode]string{
+	PilotingStateMoveToChangedOrientationModeNONE:          "NONE",
+	PilotingStateMoveToChangedOrientationModeToTarget:      "TO_TARGET",
+	PilotingStateMoveToChangedOrientationModeHeadingStart:  "HEADING_START",
+	PilotingStateMoveToChangedOrientationModeHeadingDuring: "HEADING_DURING",
+}
+
+func (e PilotingStateMoveToChangedOrientationMode) String() string {
+	if name, ok := pilotingStateMoveToChangedOrientationModeNames[e]; ok {
+		return name
+	}
+	return fmt.Sprintf("PilotingStateMoveToChangedOrientationMode(%d)", int32(e))
+}
+
+// IsValid returns true if the PilotingStateMoveToChangedOrientationMode is one
+// defined by the device SDK.
+func (e PilotingStateMoveToChangedOrientationMode) IsValid() bool {
+	_, ok := pilotingStateMoveToChangedOrientationModeNames[e]
+	return ok
+}
+
+// PilotingStateMoveToChangedStatus is a type for constants used as the status
+// argument of the PilotingState moveToChanged command. Status of the move to.
+type PilotingStateMoveToChangedStatus int32
+
+const (
+	// PilotingStateMoveToChangedStatusRUNNING is RUNNING: The drone is actually
+	// flying to the given position
+	PilotingStateMoveToChangedStatusRUNNING PilotingStateMoveToChangedStatus = iota
+	// PilotingStateMoveToChangedStatusDONE is DONE: The drone has reached the
+	// target
+	PilotingStateMoveToChangedStatusDONE
+	// PilotingStateMoveToChangedStatusCANCELED is CANCELED: The move to has been
+	// canceled, either by a CancelMoveTo command or when a disconnection appears.
+	PilotingStateMoveToChangedStatusCANCELED
+	// PilotingStateMoveToChangedStatusERROR is ERROR: The move to has not been
+	// finished or started because of an error.
+	PilotingStateMoveToChangedStatusERROR
+)
+
+// pilotingStateMoveToChangedStatusNames maps each
+// PilotingStateMoveToChangedStatus value to its name as defined by the device
+// SDK.
+var pilotingStateMoveToChangedStatusNames = map[PilotingStateMoveToChangedStatus]string{
+	PilotingStateMoveToChangedStatusRUNNING:  "RUNNING",
+	PilotingStateMoveToChangedStatusDONE:     "DONE",
+	PilotingStateMoveToChangedStatusCANCELED: "CANCELED",
+	PilotingStateMoveToChangedStatusERROR:    "ERROR",
+}
+
+func (e PilotingStateMoveToChangedStatus) String() string {
+	if name, ok := pilotingStateMoveToChangedStatusNames[e]; ok {
+		return name
+	}
+	return fmt.Sprintf("PilotingStateMoveToChangedStatus(%d)", int32(e))
+}
+
+// IsValid returns true if the PilotingStateMoveToChangedStatus is one defined
+// by the device SDK.
+func (e PilotingStateMoveToChangedStatus) IsValid() bool {
+	_, ok := pilotingStateMoveToChangedStatusNames[e]
+	return ok
+}
+
+// PilotingStateMotionStateState is a type for constants used as the state
+// argument of the PilotingState MotionState command. Motion state.
+type PilotingStateMotionStateState int32
+
+const (
+	// PilotingStateMotionStateStateSteady is steady: Drone is steady
+	PilotingStateMotionStateStateSteady PilotingStateMotionStateState = iota
+	// PilotingStateMotionStateStateMoving is moving: Drone is moving
+	PilotingStateMotionStateStateMoving
+)
+
+// pilotingStateMotionStateStateNames maps each PilotingStateMotionStateState
+// value to its name as defined by the device SDK.
+var pilotingStateMotionStateStateNames = map[PilotingStateMotionStateState]string{
+	PilotingStateMotionStateStateSteady: "steady",
+	PilotingStateMotionStateStateMoving: "moving",
+}
+
+func (e PilotingStateMotionStateState) String() string {
+	if name, ok := pilotingStateMotionStateStateNames[e]; ok {
+		return name
+	}
+	return fmt.Sprintf("PilotingStateMotionStateState(%d)", int32(e))
+}
+
+// IsValid returns true if the PilotingStateMotionStateState is one defined by
+// the device SDK.
+func (e PilotingStateMotionStateState) IsValid() bool {
+	_, ok := pilotingStateMotionStateStateNames[e]
+	return ok
+}
+
+// PilotingStatePilotedPOIStatus is a type for constants used as the status
+// argument of the PilotingState PilotedPOI command. Status of the move to.
+type PilotingStatePilotedPOIStatus int32
+
+const (
+	// PilotingStatePilotedPOIStatusUNAVAILABLE is UNAVAILABLE: The piloted POI is
+	// not available
+	PilotingStatePilotedPOIStatusUNAVAILABLE PilotingStatePilotedPOIStatus = iota
+	// PilotingStatePilotedPOIStatusAVAILABLE is AVAILABLE: The piloted POI is
+	// available
+	PilotingStatePilotedPOIStatusAVAILABLE
+	// PilotingStatePilotedPOIStatusPENDING is PENDING: Piloted POI has been
+	// requested. Waiting to be in state that allow the piloted POI to start
+	PilotingStatePilotedPOIStatusPENDING
+	// PilotingStatePilotedPOIStatusRUNNING is RUNNING: Piloted POI is running
+	PilotingStatePilotedPOIStatusRUNNING
+)
+
+// pilotingStatePilotedPOIStatusNames maps each PilotingStatePilotedPOIStatus
+// value to its name as defined by the device SDK.
+var pilotingStatePilotedPOIStatusNames = map[PilotingStatePilotedPOIStatus]string{
+	PilotingStatePilotedPOIStatusUNAVAILABLE: "UNAVAILABLE",
+	PilotingStatePilotedPOIStatusAVAILABLE:   "AVAILABLE",
+	PilotingStatePilotedPOIStatusPENDING:     "PENDING",
+	PilotingStatePilotedPOIStatusRUNNING:     "RUNNING",
+}
+
+func (e PilotingStatePilotedPOIStatus) String() string {
+	if name, ok := pilotingStatePilotedPOIStatusNames[e]; ok {
+		return name
+	}
+	return fmt.Sprintf("PilotingStatePilotedPOIStatus(%d)", int32(e))
+}
+
+// IsValid returns true if the PilotingStatePilotedPOIStatus is one defined by
+// the device SDK.
+func (e PilotingStatePilotedPOIStatus) IsValid() bool {
+	_, ok := pilotingStatePilotedPOIStatusNames[e]
+	return ok
+}
+
+// PilotingStateReturnHomeBatteryCapacityStatus is a type for constants used as
+// the status argument of the PilotingState ReturnHomeBatteryCapacity command.
+// Status of battery to return home.
+type PilotingStateReturnHomeBatteryCapacityStatus int32
+
+const (
+	// PilotingStateReturnHomeBatteryCapacityStatusOK is OK: The battery is full
+	// enough to do a return home
+	PilotingStateReturnHomeBatteryCapacityStatusOK PilotingStateReturnHomeBatteryCapacityStatus = iota
+	// PilotingStateReturnHomeBatteryCapacityStatusWARNING is WARNING: The battery
+	// is about to be too discharged to do a return home
+	PilotingStateReturnHomeBatteryCapacityStatusWARNING
+	// PilotingStateReturnHomeBatteryCapacityStatusCRITICAL is CRITICAL: The
+	// battery level is too low to return to the home position
+	PilotingStateReturnHomeBatteryCapacityStatusCRITICAL
+	// PilotingStateReturnHomeBatteryCapacityStatusUNKNOWN is UNKNOWN: Battery
+	// capacity to do a return home is unknown. This can be either because the home
+	// is unknown or the position of the drone is unknown, or the drone has not
+	// enough information to determine how long it takes to fly home.
+	PilotingStateReturnHomeBatteryCapacityStatusUNKNOWN
+)
+
+// pilotingStateReturnHomeBatteryCapacityStatusNames maps each
+// PilotingStateReturnHomeBatteryCapacityStatus value to its name as defined by
+// the device SDK.
+var pilotingStateReturnHomeBatteryCapacityStatusNames = map[PilotingStateReturnHomeBatteryCapacityStatus]string{
+	PilotingStateReturnHomeBatteryCapacityStatusOK:       "OK",
+	PilotingStateReturnHomeBatteryCapacityStatusWARNING:  "WARNING",
+	PilotingStateReturnHomeBatteryCapacityStatusCRITICAL: "CRITICAL",
+	PilotingStateReturnHomeBatteryCapacityStatusUNKNOWN:  "UNKNOWN",
+}
+
+func (e PilotingStateReturnHomeBatteryCapacityStatus) String() string {
+	if name, ok := pilotingStateReturnHomeBatteryCapacityStatusNames[e]; ok {
+		return name
+	}
+	return fmt.Sprintf("PilotingStateReturnHomeBatteryCapacityStatus(%d)", int32(e))
+}
+
+// IsValid returns true if the PilotingStateReturnHomeBatteryCapacityStatus is
+// one defined by the device SDK.
+func (e PilotingStateReturnHomeBatteryCapacityStatus) IsValid() bool {
+	_, ok := pilotingStateReturnHomeBatteryCapacityStatusNames[e]
+	return ok
+}
+
+// pilotingStateHandlers is implemented by the PilotingState class, which
+// handles each of its commands. State from drone.
+type pilotingStateHandlers interface {
+	flatTrimChanged(args []interface{}) error
+	flyingStateChanged(args []interface{}) error
+	alertStateChanged(args []interface{}) error
+	navigateHomeStateChanged(args []interface{}) error
+	positionChanged(args []interface{}) error
+	speedChanged(args []interface{}) error
+	attitudeChanged(args []interface{}) error
+	autoTakeOffModeChanged(args []interface{}) error
+	altitudeChanged(args []interface{}) error
+	gpsLocationChanged(args []interface{}) error
+	landingStateChanged(args []interface{}) error
+	airSpeedChanged(args []interface{}) error
+	moveToChanged(args []interface{}) error
+	motionState(args []interface{}) error
+	pilotedPOI(args []interface{}) error
+	returnHomeBatteryCapacity(args []interface{}) error
+}
+
+// pilotingStateDefaults implements every handler of the PilotingState class by
+// logging that the command was received. The PilotingState class can embed it
+// and implement only the handlers for commands it acts upon.
+type pilotingStateDefaults struct{}
+
+// pilotingStateD2CCommands returns the commands of the PilotingState class,
+// each dispatched to the corresponding handler.
+func pilotingStateD2CCommands(
+	handlers pilotingStateHandlers,
+) []arcommands.D2CCommand {
+	return []arcommands.D2CCommand{
+		arcommands.NewD2CCommand(
+			0,
+			"FlatTrimChanged",
+			[]interface{}{},
+			handlers.flatTrimChanged,
+		),
+		arcommands.NewD2CCommand(
+			1,
+			"FlyingStateChanged",
+			[]interface{}{
+				PilotingStateFlyingStateChangedState(0), // state,
+			},
+			handlers.flyingStateChanged,
+		),
+		arcommands.NewD2CCommand(
+			2,
+			"AlertStateChanged",
+			[]interface{}{
+				PilotingStateAlertStateChangedState(0), // state,
+			},
+			handlers.alertStateChanged,
+		),
+		arcommands.NewD2CCommand(
+			3,
+			"NavigateHomeStateChanged",
+			[]interface{}{
+				PilotingStateNavigateHomeStateChangedState(0),  // state,
+				PilotingStateNavigateHomeStateChangedReason(0), // reason,
+			},
+			handlers.navigateHomeStateChanged,
+		),
+		arcommands.NewD2CCommand(
+			4,
+			"PositionChanged",
+			[]interface{}{
+				float64(0), // latitude,
+				float64(0), // longitude,
+				float64(0), // altitude,
+			},
+			handlers.positionChanged,
+		),
+		arcommands.NewD2CCommand(
+			5,
+			"SpeedChanged",
+			[]interface{}{
+				float32(0), // speedX,
+				float32(0), // speedY,
+				float32(0), // speedZ,
+			},
+			handlers.speedChanged,
+		),
+		arcommands.NewD2CCommand(
+			6,
+			"AttitudeChanged",
+			[]interface{}{
+				float32(0), // roll,
+				float32(0), // pitch,
+				float32(0), // yaw,
+			},
+			handlers.attitudeChanged,
+		),
+		arcommands.NewD2CCommand(
+			7,
+			"AutoTakeOffModeChanged",
+			[]interface{}{
+				uint8(0), // state,
+			},
+			handlers.autoTakeOffModeChanged,
+		),
+		arcommands.NewD2CCommand(
+			8,
+			"AltitudeChanged",
+			[]interface{}{
+				float64(0), // altitude,
+			},
+			handlers.altitudeChanged,
+		),
+		arcommands.NewD2CCommand(
+			9,
+			"GpsLocationChanged",
+			[]interface{}{
+				float64(0), // latitude,
+				float64(0), // longitude,
+				float64(0), // altitude,
+				int8(0),    // latitude_accuracy,
+				int8(0),    // longitude_accuracy,
+				int8(0),    // altitude_accuracy,
+			},
+			handlers.gpsLocationChanged,
+		),
+		arcommands.NewD2CCommand(
+			10,
+			"LandingStateChanged",
+			[]interface{}{
+				PilotingStateLandingStateChangedState(0), // state,
+			},
+			handlers.landingStateChanged,
+		),
+		arcommands.NewD2CCommand(
+			11,
+			"AirSpeedChanged",
+			[]interface{}{
+				float32(0), // airSpeed,
+			},
+			handlers.airSpeedChanged,
+		),
+		arcommands.NewD2CCommand(
+			12,
+			"moveToChanged",
+			[]interface{}{
+				float64(0), // latitude,
+				float64(0), // longitude,
+				float64(0), // altitude,
+				PilotingStateMoveToChangedOrientationMode(0), // orientation_mode,
+				float32(0),                          // heading,
+				PilotingStateMoveToChangedStatus(0), // status,
+			},
+			handlers.moveToChanged,
+		),
+		arcommands.NewD2CCommand(
+			13,
+			"MotionState",
+			[]interface{}{
+				PilotingStateMotionStateState(0), // state,
+			},
+			handlers.motionState,
+		),
+		arcommands.NewD2CCommand(
+			14,
+			"PilotedPOI",
+			[]interface{}{
+				float64(0),                       // latitude,
+				float64(0),                       // longitude,
+				float64(0),                       // altitude,
+				PilotingStatePilotedPOIStatus(0), // status,
+			},
+			handlers.pilotedPOI,
+		),
+		arcommands.NewD2CCommand(
+			15,
+			"ReturnHomeBatteryCapacity",
+			[]interface{}{
+				PilotingStateReturnHomeBatteryCapacityStatus(0), // status,
+			},
+			handlers.returnHomeBatteryCapacity,
+		),
+	}
+}
+
+// Title: Flat trim changed
+// Description: Drone acknowledges that flat trim was correctly processed.
+// Support: 0901;090c;090e
+// Triggered: by [FlatTrim](#1-0-0).
+// Result:
+func (pilotingStateDefaults) flatTrimChanged(args []interface{}) error {
+	log.Info("ardrone3.flatTrimChanged() called")
+	return nil
+}
+
+// Title: Flying state
+// Description: Flying state.
+// Support: 0901;090c;090e
+// Triggered: when the flying state changes.
+// Result:
+func (pilotingStateDefaults) flyingStateChanged(args []interface{}) error {
+	// state := args[0].(PilotingStateFlyingStateChangedState)
+	//   Drone flying state
+	//   0: landed: Landed state
+	//   1: takingoff: Taking off state
+	//   2: hovering: Hovering / Circling (for fixed wings) state
+	//   3: flying: Flying state
+	//   4: landing: Landing state
+	//   5: emergency: Emergency state
+	//   6: usertakeoff: User take off state. Waiting for user action to take off.
+	//   7: motor_ramping: Motor ramping state.
+	//   8: emergency_landing: Emergency landing state. Drone autopilot has
+	//      detected defective sensor(s). Only Yaw argument in PCMD is taken into
+	//      account. All others flying commands are ignored.
+	log.Info("ardrone3.flyingStateChanged() called")
+	return nil
+}
+
+// Title: Alert state
+// Description: Alert state.
+// Support: 0901;090c;090e
+// Triggered: when an alert happens on the drone.
+// Result:
+func (pilotingStateDefaults) alertStateChanged(args []interface{}) error {
+	// state := args[0].(PilotingStateAlertStateChangedState)
+	//   Drone alert state
+	//   0: none: No alert
+	//   1: user: User emergency alert
+	//   2: cut_out: Cut out alert
+	//   3: critical_battery: Critical battery alert
+	//   4: low_battery: Low battery alert
+	//   5: too_much_angle: The angle of the drone is too high
+	log.Info("ardrone3.alertStateChanged() called")
+	return nil
+}
+
+// Title: Return home state
+// Description: Return home state.\n Availability is related to gps fix,
+// magnetometer calibration.
+// Support: 0901;090c;090e
+// Triggered: by [ReturnHome](#1-0-5) or when the state of the return home
+// changes.
+// Result:
+func (pilotingStateDefaults) navigateHomeStateChanged(
+	args []interface{},
+) error {
+	// state := args[0].(PilotingStateNavigateHomeStateChangedState)
+	//   State of navigate home
+	//   0: available: Navigate home is available
+	//   1: inProgress: Navigate home is in progress
+	//   2: unavailable: Navigate home is not available
+	//   3: pending: Navigate home has been received, but its process is pending
+	// reason := args[1].(PilotingStateNavigateHomeStateChangedReason)
+	//   Reason of the state
+	//   0: userRequest: User requested a navigate home (available->inProgress)
+	//   1: connectionLost: Connection between controller and product lost
+	//      (available->inProgress)
+	//   2: lowBattery: Low battery occurred (available->inProgress)
+	//   3: finished: Navigate home is finished (inProgress->available)
+	//   4: stopped: Navigate home has been stopped (inProgress->available)
+	//   5: disabled: Navigate home disabled by product (inProgress->unavailable or
+	//      available->unavailable)
+	//   6: enabled: Navigate home enabled by product (unavailable->available)
+	log.Info("ardrone3.navigateHomeStateChanged() called")
+	return nil
+}
+
+// Title: Drone's position changed
+// Description: Drone's position changed.
+// Support: 0901;090c;090e
+// Triggered: regularly.
+// Result:
+func (pilotingStateDefaults) positionChanged(args []interface{}) error {
+	// latitude := args[0].(float64)
+	//   Latitude position in decimal degrees (500.0 if not available)
+	// longitude := args[1].(float64)
+	//   Longitude position in decimal degrees (500.0 if not available)
+	// altitude := args[2].(float64)
+	//   Altitude in meters (from GPS)
+	log.Info("ardrone3.positionChanged() called")
+	return nil
+}
+
+// Title: Drone's speed changed
+// Description: Drone's speed changed.\n Expressed in the NED referential
+// (North-East-Down).
+// Support: 0901;090c;090e
+// Triggered: regularly.
+// Result:
+func (pilotingStateDefaults) speedChanged(args []interface{}) error {
+	// speedX := args[0].(float32)
+	//   Speed relative to the North (when drone moves to the north, speed is > 0)
+	//   (in m/s)
+	// speedY := args[1].(float32)
+	//   Speed relative to the East (when drone moves to the east, speed is > 0)
+	//   (in m/s)
+	// speedZ := args[2].(float32)
+	//   Speed on the z axis (when drone moves down, speed is > 0) (in m/s)
+	log.Info("ardrone3.speedChanged() called")
+	return nil
+}
+
+// Title: Drone's attitude changed
+// Description: Drone's attitude changed.
+// Support: 0901;090c;090e
+// Triggered: regularly.
+// Result:
+func (pilotingStateDefaults) attitudeChanged(args []interface{}) error {
+	// roll := args[0].(float32)
+	//   roll value (in radian)
+	// pitch := args[1].(float32)
+	//   Pitch value (in radian)
+	// yaw := args[2].(float32)
+	//   Yaw value (in radian)
+	log.Info("ardrone3.attitudeChanged() called")
+	return nil
+}
+
+// Title: Auto takeoff mode
+// Description: Auto takeoff mode
+// Support:
+// Result:
+//
+// Deprecated: This command is deprecated by the device SDK.
+func (pilotingStateDefaults) autoTakeOffModeChanged(args []interface{}) error {
+	// state := args[0].(uint8)
+	//   State of automatic take off mode (1 if enabled)
+	log.Info("ardrone3.autoTakeOffModeChanged() called")
+	return nil
+}
+
+// Title: Drone's altitude changed
+// Description: Drone's altitude changed.\n The altitude reported is the
+// altitude above the take off point.\n To get the altitude above sea level, see
+// [PositionChanged](#1-4-4).
+// Support: 0901;090c;090e
+// Triggered: regularly.
+// Result:
+func (pilotingStateDefaults) altitudeChanged(args []interface{}) error {
+	// altitude := args[0].(float64)
+	//   Altitude in meters
+	log.Info("ardrone3.altitudeChanged() called")
+	return nil
+}
+
+// Title: Drone's location changed
+// Description: Drone's location changed.\n This event is meant to replace
+// [PositionChanged](#1-4-4).
+// Support: 0901:4.0.0;090c:4.0.0
+// Triggered: regularly.
+// Result:
+func (pilotingStateDefaults) gpsLocationChanged(args []interface{}) error {
+	// latitude := args[0].(float64)
+	//   Latitude location in decimal degrees (500.0 if not available)
+	// longitude := args[1].(float64)
+	//   Longitude location in decimal degrees (500.0 if not available)
+	// altitude := args[2].(float64)
+	//   Altitude location in meters.
+	// latitude_accuracy := args[3].(int8)
+	//   Latitude location error in meters (1 sigma/standard deviation) -1 if not
+	//   available.
+	// longitude_accuracy := args[4].(int8)
+	//   Longitude location error in meters (1 sigma/standard deviation) -1 if not
+	//   available.
+	// altitude_accuracy := args[5].(int8)
+	//   Altitude location error in meters (1 sigma/standard deviation) -1 if not
+	//   available.
+	log.Info("ardrone3.gpsLocationChanged() called")
+	return nil
+}
+
+// Title: Landing state
+// Description: Landing state.\n Only available for fixed wings (which have two
+// landing modes).
+// Support: 090e
+// Triggered: when the landing state changes.
+// Result:
+func (pilotingStateDefaults) landingStateChanged(args []interface{}) error {
+	// state := args[0].(PilotingStateLandingStateChangedState)
+	//   Drone landing state
+	//   0: linear: Linear landing
+	//   1: spiral: Spiral landing
+	log.Info("ardrone3.landingStateChanged() called")
+	return nil
+}
+
+// Title: Drone's air speed changed
+// Description: Drone's air speed changed\n Expressed in the drone's
+// referential.
+// Support: 090e:1.2.0
+// Triggered: regularly.
+// Result:
+func (pilotingStateDefaults) airSpeedChanged(args []interface{}) error {
+	// airSpeed := args[0].(float32)
+	//   Speed relative to air on x axis (speed is always > 0) (in m/s)
+	log.Info("ardrone3.airSpeedChanged() called")
+	return nil
+}
+
+// Title: Move to changed
+// Description: The drone moves or moved to a given location.
+// Support: 090c:4.3.0
+// Triggered: by [MoveTo](#1-0-10) or when the drone did reach the given
+// position.
+// Result:
+func (pilotingStateDefaults) moveToChanged(args []interface{}) error {
+	// latitude := args[0].(float64)
+	//   Latitude of the location (in degrees) to reach
+	// longitude := args[1].(float64)
+	//   Longitude of the location (in degrees) to reach
+	// altitude := args[2].(float64)
+	//   Altitude above sea level (in m) to reach
+	// orientation_mode := args[3].(PilotingStateMoveToChangedOrientationMode)
+	//   Orientation mode of the move to
+	//   0: NONE: The drone won't change its orientation
+	//   1: TO_TARGET: The drone will make a rotation to look in direction of the
+	//      given location
+	//   2: HEADING_START: The drone will orientate itself to the given heading
+	//      before moving to the location
+	//   3: HEADING_DURING: The drone will orientate itself to the given heading
+	//      while moving to the location
+	// heading := args[4].(float32)
+	//   Heading (relative to the North in degrees). This value is only used if the
+	//   orientation mode is HEADING_START or HEADING_DURING
+	// status := args[5].(PilotingStateMoveToChangedStatus)
+	//   Status of the move to
+	//   0: RUNNING: The drone is actually flying to the given position
+	//   1: DONE: The drone has reached the target
+	//   2: CANCELED: The move to has been canceled, either by a CancelMoveTo
+	//      command or when a disconnection appears.
+	//   3: ERROR: The move to has not been finished or started because of an
+	//      error.
+	log.Info("ardrone3.moveToChanged() called")
+	return nil
+}
+
+// Title: Motion state
+// Description: Motion state.\n If [MotionDetection](#1-6-16) is disabled,
+// motion is steady.\n This information is only valid when the drone is not
+// flying.
+// Support: 090c:4.3.0
+// Triggered: when the [FlyingState](#1-4-1) is landed and the
+// [MotionDetection](#1-6-16) is enabled and the motion state changes.\n This
+// event is triggered at a filtered rate.
+// Result:
+func (pilotingStateDefaults) motionState(args []interface{}) error {
+	// state := args[0].(PilotingStateMotionStateState)
+	//   Motion state
+	//   0: steady: Drone is steady
+	//   1: moving: Drone is moving
+	log.Info("ardrone3.motionState() called")
+	return nil
+}
+
+// Title: Piloted POI state
+// Description: Piloted POI state.
+// Support: 090c:4.3.0
+// Triggered: by [StartPilotedPOI](#1-0-12) or [StopPilotedPOI](#1-0-13) or when
+// piloted POI becomes unavailable.
+// Result:
+func (pilotingStateDefaults) pilotedPOI(args []interface{}) error {
+	// latitude := args[0].(float64)
+	//   Latitude of the location (in degrees) to look at. This information is only
+	//   valid when the state is pending or running.
+	// longitude := args[1].(float64)
+	//   Longitude of the location (in degrees) to look at. This information is
+	//   only valid when the state is pending or running.
+	// altitude := args[2].(float64)
+	//   Altitude above sea level (in m) to look at. This information is only valid
+	//   when the state is pending or running.
+	// status := args[3].(PilotingStatePilotedPOIStatus)
+	//   Status of the move to
+	//   0: UNAVAILABLE: The piloted POI is not available
+	//   1: AVAILABLE: The piloted POI is available
+	//   2: PENDING: Piloted POI has been requested. Waiting to be in state that
+	//      allow the piloted POI to start
+	//   3: RUNNING: Piloted POI is running
+	log.Info("ardrone3.pilotedPOI() called")
+	return nil
+}
+
+// Title: Return home battery capacity
+// Description: Battery capacity status to return home.
+// Support: 090c:4.3.0
+// Triggered: when the status of the battery capacity to do a return home
+// changes. This means that it is triggered either when the battery level
+// changes, when the distance to the home changes or when the position of the
+// home changes.
+// Result:
+func (pilotingStateDefaults) returnHomeBatteryCapacity(
+	args []interface{},
+) error {
+	// status := args[0].(PilotingStateReturnHomeBatteryCapacityStatus)
+	//   Status of battery to return home
+	//   0: OK: The battery is full enough to do a return home
+	//   1: WARNING: The battery is about to be too discharged to do a return home
+	//   2: CRITICAL: The battery level is too low to return to the home position
+	//   3: UNKNOWN: Battery capacity to do a return home is unknown. This can be
+	//      either because the home is unknown or the position of the drone is
+	//      unknown, or the drone has not enough information to determine how long
+	//      it takes to fly home.
+	log.Info("ardrone3.returnHomeBatteryCapacity() called")
+	return nil
+}
+
+// PilotingSettingsStateCirclingDirectionChangedValue is a type for constants
+// used as the value argument of the PilotingSettingsState
+// CirclingDirectionChanged command. The circling direction.
+type PilotingSettingsStateCirclingDirectionChangedValue int32
+
+const (
+	// PilotingSettingsStateCirclingDirectionChangedValueCW is CW: Circling
+	// ClockWise
+	PilotingSettingsStateCirclingDirectionChangedValueCW PilotingSettingsStateCirclingDirectionChangedValue = iota
+	// PilotingSettingsStateCirclingDirectionChangedValueCCW is CCW: Circling
+	// Counter ClockWise
+	PilotingSettingsStateCirclingDirectionChangedValueCCW
+)
+
+// pilotingSettingsStateCirclingDirectionChangedValueNames maps each
+// PilotingSettingsStateCirclingDirectionChangedValue value to its name as
+// defined by the device SDK.
+var pilotingSettingsStateCirclingDirectionChangedValueNames = map[PilotingSettingsStateCirclingDirectionChangedValue]string{
+	PilotingSettingsStateCirclingDirectionChangedValueCW:  "CW",
+	PilotingSettingsStateCirclingDirectionChangedValueCCW: "CCW",
+}
+
+func (e PilotingSettingsStateCirclingDirectionChangedValue) String() string {
+	if name, ok := pilotingSettingsStateCirclingDirectionChangedValueNames[e]; ok {
+		return name
+	}
+	return fmt.Sprintf("PilotingSettingsStateCirclingDirectionChangedValue(%d)", int32(e))
+}
+
+// IsValid returns true if the
+// PilotingSettingsStateCirclingDirectionChangedValue is one defined by the
+// device SDK.
+func (e PilotingSettingsStateCirclingDirectionChangedValue) IsValid() bool {
+	_, ok := pilotingSettingsStateCirclingDirectionChangedValueNames[e]
+	return ok
+}
+
+// PilotingSettingsStatePitchModeChangedValue is a type for constants used as
+// the value argument of the PilotingSettingsState PitchModeChanged command. The
+// Pitch mode.
+type PilotingSettingsStatePitchModeChangedValue int32
+
+const (
+	// PilotingSettingsStatePitchModeChangedValueNORMAL is NORMAL: Positive pitch
+	// values will make the drone lower its nose. Negative pitch values will make
+	// the drone raise its nose.
+	PilotingSettingsStatePitchModeChangedValueNORMAL PilotingSettingsStatePitchModeChangedValue = iota
+	// PilotingSettingsStatePitchModeChangedValueINVERTED is INVERTED: Pitch
+	// commands are inverted. Positive pitch values will make the drone raise its
+	// nose. Negative pitch values will make the drone lower its nose.
+	PilotingSettingsStatePitchModeChangedValueINVERTED
+)
+
+// pilotingSettingsStatePitchModeChangedValueNames maps each
+// PilotingSettingsStatePitchModeChangedValue value to its name as defined by
+// the device SDK.
+var pilotingSettingsStatePitchModeChangedValueNames = map[PilotingSettingsStatePitchModeChangedValue]string{
+	PilotingSettingsStatePitchModeChangedValueNORMAL:   "NORMAL",
+	PilotingSettingsStatePitchModeChangedValueINVERTED: "INVERTED",
+}
+
+func (e PilotingSettingsStatePitchModeChangedValue) String() string {
+	if name, ok := pilotingSettingsStatePitchModeChangedValueNames[e]; ok {
+		return name
+	}
+	return fmt.Sprintf("PilotingSettingsStatePitchModeChangedValue(%d)", int32(e))
+}
+
+// IsValid returns true if the PilotingSettingsStatePitchModeChangedValue is one
+// defined by the device SDK.
+func (e PilotingSettingsStatePitchModeChangedValue) IsValid() bool {
+	_, ok := pilotingSettingsStatePitchModeChangedValueNames[e]
+	return ok
+}
+
+// pilotingSettingsStateHandlers is implemented by the PilotingSettingsState
+// class, which handles each of its commands. Piloting Settings state from
+// product.
+type pilotingSettingsStateHandlers interface {
+	maxAltitudeChanged(args []interface{}) error
+	maxTiltChanged(args []interface{}) error
+	absolutControlChanged(args []interface{}) error
+	maxDistanceChanged(args []interface{}) error
+	noFlyOverMaxDistanceChanged(args []interface{}) error
+	autonomousFlightMaxHorizontalSpeed(args []interface{}) error
+	autonomousFlightMaxVerticalSpeed(args []interface{}) error
+	autonomousFlightMaxHorizontalAcceleration(args []interface{}) error
+	autonomousFlightMaxVerticalAcceleration(args []interface{}) error
+	autonomousFlightMaxRotationSpeed(args []interface{}) error
+	bankedTurnChanged(args []interface{}) error
+	minAltitudeChanged(args []interface{}) error
+	circlingDirectionChanged(args []interface{}) error
+	circlingRadiusChanged(args []interface{}) error
+	circlingAltitudeChanged(args []interface{}) error
+	pitchModeChanged(args []interface{}) error
+	motionDetection(args []interface{}) error
+}
+
+// pilotingSettingsStateDefaults implements every handler of the
+// PilotingSettingsState class by logging that the command was received. The
+// PilotingSettingsState class can embed it and implement only the handlers for
+// commands it acts upon.
+type pilotingSettingsStateDefaults struct{}
+
+// pilotingSettingsStateD2CCommands returns the commands of the
+// PilotingSettingsState class, each dispatched to the corresponding handler.
+func pilotingSettingsStateD2CCommands(
+	handlers pilotingSettingsStateHandlers,
+) []arcommands.D2CCommand {
+	return []arcommands.D2CCommand{
+		arcommands.NewD2CCommand(
+			0,
+			"MaxAltitudeChanged",
+			[]interface{}{
+				float32(0), // current,
+				float32(0), // min,
+				float32(0), // max,
+			},
+			handlers.maxAltitudeChanged,
+		),
+		arcommands.NewD2CCommand(
+			1,
+			"MaxTiltChanged",
+			[]interface{}{
+				float32(0), // current,
+				float32(0), // min,
+				float32(0), // max,
+			},
+			handlers.maxTiltChanged,
+		),
+		arcommands.NewD2CCommand(
+			2,
+			"AbsolutControlChanged",
+			[]interface{}{
+				uint8(0), // on,
+			},
+			handlers.absolutControlChanged,
+		),
+		arcommands.NewD2CCommand(
+			3,
+			"MaxDistanceChanged",
+			[]interface{}{
+				float32(0), // current,
+				float32(0), // min,
+				float32(0), // max,
+			},
+			handlers.maxDistanceChanged,
+		),
+		arcommands.NewD2CCommand(
+			4,
+			"NoFlyOverMaxDistanceChanged",
+			[]interface{}{
+				uint8(0), // shouldNotFlyOver,
+			},
+			handlers.noFlyOverMaxDistanceChanged,
+		),
+		arcommands.NewD2CCommand(
+			5,
+			"AutonomousFlightMaxHorizontalSpeed",
+			[]interface{}{
+				float32(0), // value,
+			},
+			handlers.autonomousFlightMaxHorizontalSpeed,
+		),
+		arcommands.NewD2CCommand(
+			6,
+			"AutonomousFlightMaxVerticalSpeed",
+			[]interface{}{
+				float32(0), // value,
+			},
+			handlers.autonomousFlightMaxVerticalSpeed,
+		),
+		arcommands.NewD2CCommand(
+			7,
+			"AutonomousFlightMaxHorizontalAcceleration",
+			[]interface{}{
+				float32(0), // value,
+			},
+			handlers.autonomousFlightMaxHorizontalAcceleration,
+		),
+		arcommands.NewD2CCommand(
+			8,
+			"AutonomousFlightMaxVerticalAcceleration",
+			[]interface{}{
+				float32(0), // value,
+			},
+			handlers.autonomousFlightMaxVerticalAcceleration,
+		),
+		arcommands.NewD2CCommand(
+			9,
+			"AutonomousFlightMaxRotationSpeed",
+			[]interface{}{
+				float32(0), // value,
+			},
+			handlers.autonomousFlightMaxRotationSpeed,
+		),
+		arcommands.NewD2CCommand(
+			10,
+			"BankedTurnChanged",
+			[]interface{}{
+				uint8(0), // state,
+			},
+			handlers.bankedTurnChanged,
+		),
+		arcommands.NewD2CCommand(
+			11,
+			"MinAltitudeChanged",
+			[]interface{}{
+				float32(0), // current,
+				float32(0), // min,
+				float32(0), // max,
+			},
+			handlers.minAltitudeChanged,
+		),
+		arcommands.NewD2CCommand(
+			12,
+			"CirclingDirectionChanged",
+			[]interface{}{
+				PilotingSettingsStateCirclingDirectionChangedValue(0), // value,
+			},
+			handlers.circlingDirectionChanged,
+		),
+		arcommands.NewD2CCommand(
+			13,
+			"CirclingRadiusChanged",
+			[]interface{}{
+				uint16(0), // current,
+				uint16(0), // min,
+				uint16(0), // max,
+			},
+			handlers.circlingRadiusChanged,
+		),
+		arcommands.NewD2CCommand(
+			14,
+			"CirclingAltitudeChanged",
+			[]interface{}{
+				uint16(0), // current,
+				uint16(0), // min,
+				uint16(0), // max,
+			},
+			handlers.circlingAltitudeChanged,
+		),
+		arcommands.NewD2CCommand(
+			15,
+			"PitchModeChanged",
+			[]interface{}{
+				PilotingSettingsStatePitchModeChangedValue(0), // value,
+			},
+			handlers.pitchModeChanged,
+		),
+		arcommands.NewD2CCommand(
+			16,
+			"MotionDetection",
+			[]interface{}{
+				uint8(0), // enabled,
+			},
+			handlers.motionDetection,
+		),
+	}
+}
+
+// Title: Max altitude
+// Description: Max altitude.\n The drone will not fly higher than this altitude
+// (above take off point).
+// Support: 0901;090c;090e
+// Triggered: by [SetMaxAltitude](#1-2-0).
+// Result:
+func (pilotingSettingsStateDefaults) maxAltitudeChanged(
+	args []interface{},
+) error {
+	// current := args[0].(float32)
+	//   Current altitude max
+	// min := args[1].(float32)
+	//   Range min of altitude
+	// max := args[2].(float32)
+	//   Range max of altitude
+	log.Info("ardrone3.maxAltitudeChanged() called")
+	return nil
+}
+
+// Title: Max pitch/roll
+// Description: Max pitch/roll.\n The drone will not fly higher than this
+// altitude (above take off point).
+// Support: 0901;090c
+// Triggered: by [SetMaxAltitude](#1-2-0).
+// Result:
+func (pilotingSettingsStateDefaults) maxTiltChanged(args []interface{}) error {
+	// current := args[0].(float32)
+	//   Current max tilt
+	// min := args[1].(float32)
+	//   Range min of tilt
+	// max := args[2].(float32)
+	//   Range max of tilt
+	log.Info("ardrone3.maxTiltChanged() called")
+	return nil
+}
+
+// Title: Absolut control
+// Description: Absolut control.
+// Support:
+// Result:
+//
+// Deprecated: This command is deprecated by the device SDK.
+func (pilotingSettingsStateDefaults) absolutControlChanged(
+	args []interface{},
+) error {
+	// on := args[0].(uint8)
+	//   1 if enabled, 0 if disabled
+	log.Info("ardrone3.absolutControlChanged() called")
+	return nil
+}
+
+// Title: Max distance
+// Description: Max distance.
+// Support: 0901;090c;090e
+// Triggered: by [SetMaxDistance](#1-2-3).
+// Result:
+func (pilotingSettingsStateDefaults) maxDistanceChanged(
+	args []interface{},
+) error {
+	// current := args[0].(float32)
+	//   Current max distance in meter
+	// min := args[1].(float32)
+	//   Minimal possible max distance
+	// max := args[2].(float32)
+	//   Maximal possible max distance
+	log.Info("ardrone3.maxDistanceChanged() called")
+	return nil
+}
+
+// Title: Geofencing
+// Description: Geofencing.\n If set, the drone won't fly over the
+// [MaxDistance](#1-6-3).
+// Support: 0901;090c;090e
+// Triggered: by [EnableGeofence](#1-2-4).
+// Result:
+func (pilotingSettingsStateDefaults) noFlyOverMaxDistanceChanged(
+	args []interface{},
+) error {
+	// shouldNotFlyOver := args[0].(uint8)
+	//   1 if the drone won't fly further than max distance, 0 if no limitation on
+	//   the drone will be done
+	log.Info("ardrone3.noFlyOverMaxDistanceChanged() called")
+	return nil
+}
+
+// Title: Autonomous flight max horizontal speed
+// Description: Autonomous flight max horizontal speed.
+// Support: 0901:3.3.0;090c:3.3.0
+// Triggered: by [SetAutonomousFlightMaxHorizontalSpeed](#1-2-5).
+// Result:
+func (pilotingSettingsStateDefaults) autonomousFlightMaxHorizontalSpeed(
+	args []interface{},
+) error {
+	// value := args[0].(float32)
+	//   maximum horizontal speed [m/s]
+	log.Info("ardrone3.autonomousFlightMaxHorizontalSpeed() called")
+	return nil
+}
+
+// Title: Autonomous flight max vertical speed
+// Description: Autonomous flight max vertical speed.
+// Support: 0901:3.3.0;090c:3.3.0
+// Triggered: by [SetAutonomousFlightMaxVerticalSpeed](#1-2-6).
+// Result:
+func (pilotingSettingsStateDefaults) autonomousFlightMaxVerticalSpeed(
+	args []interface{},
+) error {
+	// value := args[0].(float32)
+	//   maximum vertical speed [m/s]
+	log.Info("ardrone3.autonomousFlightMaxVerticalSpeed() called")
+	return nil
+}
+
+// Title: Autonomous flight max horizontal acceleration
+// Description: Autonomous flight max horizontal acceleration.
+// Support: 0901:3.3.0;090c:3.3.0
+// Triggered: by [SetAutonomousFlightMaxHorizontalAcceleration](#1-2-7).
+// Result:
+func (pilotingSettingsStateDefaults) autonomousFlightMaxHorizontalAcceleration(
+	args []interface{},
+) error {
+	// value := args[0].(float32)
+	//   maximum horizontal acceleration [m/s2]
+	log.Info("ardrone3.autonomousFlightMaxHorizontalAcceleration() called")
+	return nil
+}
+
+// Title: Autonomous flight max vertical acceleration
+// Description: Autonomous flight max vertical acceleration.
+// Support: 0901:3.3.0;090c:3.3.0
+// Triggered: by [SetAutonomousFlightMaxVerticalAcceleration](#1-2-8).
+// Result:
+func (pilotingSettingsStateDefaults) autonomousFlightMaxVerticalAcceleration(
+	args []interface{},
+) error {
+	// value := args[0].(float32)
+	//   maximum vertical acceleration [m/s2]
+	log.Info("ardrone3.autonomousFlightMaxVerticalAcceleration() called")
+	return nil
+}
+
+// Title: Autonomous flight max rotation speed
+// Description: Autonomous flight max rotation speed.
+// Support: 0901:3.3.0;090c:3.3.0
+// Triggered: by [SetAutonomousFlightMaxRotationSpeed](#1-2-9).
+// Result:
+func (pilotingSettingsStateDefaults) autonomousFlightMaxRotationSpeed(
+	args []interface{},
+) error {
+	// value := args[0].(float32)
+	//   maximum yaw rotation speed [deg/s]
+	log.Info("ardrone3.autonomousFlightMaxRotationSpeed() called")
+	return nil
+}
+
+// Title: Banked Turn mode
+// Description: Banked Turn mode.\n If banked turn mode is enabled, the drone
+// will use yaw values from the piloting command to infer with roll and pitch on
+// the drone when its horizontal speed is not null.
+// Support: 0901:3.2.0;090c:3.2.0
+// Triggered: by [SetBankedTurnMode](#1-2-10).
+// Result:
+func (pilotingSettingsStateDefaults) bankedTurnChanged(
+	args []interface{},
+) error {
+	// state := args[0].(uint8)
+	//   1 if enabled, 0 if disabled
+	log.Info("ardrone3.bankedTurnChanged() called")
+	return nil
+}
+
+// Title: Min altitude
+// Description: Min altitude.\n Only sent by fixed wings.
+// Support: 090e
+// Triggered: by [SetMinAltitude](#1-2-11).
+// Result:
+func (pilotingSettingsStateDefaults) minAltitudeChanged(
+	args []interface{},
+) error {
+	// current := args[0].(float32)
+	//   Current altitude min
+	// min := args[1].(float32)
+	//   Range min of altitude min
+	// max := args[2].(float32)
+	//   Range max of altitude min
+	log.Info("ardrone3.minAltitudeChanged() called")
+	return nil
+}
+
+// Title: Circling direction
+// Description: Circling direction.\n Only sent by fixed wings.
+// Support: 090e
+// Triggered: by [SetCirclingDirection](#1-2-12).
+// Result:
+func (pilotingSettingsStateDefaults) circlingDirectionChanged(
+	args []interface{},
+) error {
+	// value := args[0].(PilotingSettingsStateCirclingDirectionChangedValue)
+	//   The circling direction
+	//   0: CW: Circling ClockWise
+	//   1: CCW: Circling Counter ClockWise
+	log.Info("ardrone3.circlingDirectionChanged() called")
+	return nil
+}
+
+// Title: Circling radius
+// Description: Circling radius.\n Only sent by fixed wings.
+// Support: none
+// Triggered: by [SetCirclingRadius](#1-2-13).
+// Result:
+//
+// Deprecated: This command is deprecated by the device SDK.
+func (pilotingSettingsStateDefaults) circlingRadiusChanged(
+	args []interface{},
+) error {
+	// current := args[0].(uint16)
+	//   The current circling radius in meter
+	// min := args[1].(uint16)
+	//   Range min of circling radius in meter
+	// max := args[2].(uint16)
+	//   Range max of circling radius in meter
+	log.Info("ardrone3.circlingRadiusChanged() called")
+	return nil
+}
+
+// Title: Circling altitude
+// Description: Circling altitude.\n Bounds will be automatically adjusted
+// according to the [MaxAltitude](#1-6-0).\n Only sent by fixed wings.
+// Support: 090e
+// Triggered: by [SetCirclingRadius](#1-2-14) or when bounds change due to
+// [SetMaxAltitude](#1-2-0).
+// Result:
+func (pilotingSettingsStateDefaults) circlingAltitudeChanged(
+	args []interface{},
+) error {
+	// current := args[0].(uint16)
+	//   The current circling altitude in meter
+	// min := args[1].(uint16)
+	//   Range min of circling altitude in meter
+	// max := args[2].(uint16)
+	//   Range max of circling altitude in meter
+	log.Info("ardrone3.circlingAltitudeChanged() called")
+	return nil
+}
+
+// Title: Pitch mode
+// Description: Pitch mode.
+// Support: 090e
+// Triggered: by [SetPitchMode](#1-2-15).
+// Result:
+func (pilotingSettingsStateDefaults) pitchModeChanged(
+	args []interface{},
+) error {
+	// value := args[0].(PilotingSettingsStatePitchModeChangedValue)
+	//   The Pitch mode
+	//   0: NORMAL: Positive pitch values will make the drone lower its nose.
+	//      Negative pitch values will make the drone raise its nose.
+	//   1: INVERTED: Pitch commands are inverted. Positive pitch values will make
+	//      the drone raise its nose. Negative pitch values will make the drone
+	//      lower its nose.
+	log.Info("ardrone3.pitchModeChanged() called")
+	return nil
+}
+
+// Title: State of the motion detection
+// Description: State of the motion detection.
+// Support: 090c:4.3.0
+// Triggered: by [SetMotionDetectionMode](#1-2-16)
+// Result:
+func (pilotingSettingsStateDefaults) motionDetection(args []interface{}) error {
+	// enabled := args[0].(uint8)
+	//   1 if motion detection is enabled, 0 otherwise.
+	log.Info("ardrone3.motionDetection() called")
+	return nil
+}
+
+// MediaRecordStateVideoStateChangedState is a type for constants used as the
+// state argument of the MediaRecordState VideoStateChanged command. State of
+// video.
+type MediaRecordStateVideoStateChangedState int32
+
+const (
+	// MediaRecordStateVideoStateChangedStateStopped is stopped: Video was stopped
+	MediaRecordStateVideoStateChangedStateStopped MediaRecordStateVideoStateChangedState = iota
+	// MediaRecordStateVideoStateChangedStateStarted is started: Video was started
+	MediaRecordStateVideoStateChangedStateStarted
+	// MediaRecordStateVideoStateChangedStateFailed is failed: Video was failed
+	MediaRecordStateVideoStateChangedStateFailed
+	// MediaRecordStateVideoStateChangedStateAutostopped is autostopped: Video was
+	// auto stopped
+	MediaRecordStateVideoStateChangedStateAutostopped
+)
+
+// mediaRecordStateVideoStateChangedStateNames maps each
+// MediaRecordStateVideoStateChangedState value to its name as defined by the
+// device SDK.
+var mediaRecordStateVideoStateChangedStateNames = map[MediaRecordStateVideoStateChangedState]string{
+	MediaRecordStateVideoStateChangedStateStopped:     "stopped",
+	MediaRecordStateVideoStateChangedStateStarted:     "started",
+	MediaRecordStateVideoStateChangedStateFailed:      "failed",
+	MediaRecordStateVideoStateChangedStateAutostopped: "autostopped",
+}
+
+func (e MediaRecordStateVideoStateChangedState) String() string {
+	if name, ok := mediaRecordStateVideoStateChangedStateNames[e]; ok {
+		return name
+	}
+	return fmt.Sprintf("MediaRecordStateVideoStateChangedState(%d)", int32(e))
+}
+
+// IsValid returns true if the MediaRecordStateVideoStateChangedState is one
+// defined by the device SDK.
+func (e MediaRecordStateVideoStateChangedState) IsValid() bool {
+	_, ok := mediaRecordStateVideoStateChangedStateNames[e]
+	return ok
+}
+
+// MediaRecordStatePictureStateChangedV2State is a type for constants used as
+// the state argument of the MediaRecordState PictureStateChangedV2 command.
+// State of device picture recording.
+type MediaRecordStatePictureStateChangedV2State int32
+
+const (
+	// MediaRecordStatePictureStateChangedV2StateReady is ready: The picture
+	// recording is ready
+	MediaRecordStatePictureStateChangedV2StateReady MediaRecordStatePictureStateChangedV2State = iota
+	// MediaRecordStatePictureStateChangedV2StateBusy is busy: The picture
+	// recording is busy
+	MediaRecordStatePictureStateChangedV2StateBusy
+	// MediaRecordStatePictureStateChangedV2StateNotAvailable is notAvailable: The
+	// picture recording is not available
+	MediaRecordStatePictureStateChangedV2StateNotAvailable
+)
+
+// mediaRecordStatePictureStateChangedV2StateNames maps each
+// MediaRecordStatePictureStateChangedV2State value to its name as defined by
+// the device SDK.
+var mediaRecordStatePictureStateChangedV2StateNames = map[MediaRecordStatePictureStateChangedV2State]string{
+	MediaRecordStatePictureStateChangedV2StateReady:        "ready",
+	MediaRecordStatePictureStateChangedV2StateBusy:         "busy",
+	MediaRecordStatePictureStateChangedV2StateNotAvailable: "notAvailable",
+}
+
+func (e MediaRecordStatePictureStateChangedV2State) String() string {
+	if name, ok := mediaRecordStatePictureStateChangedV2StateNames[e]; ok {
+		return name
+	}
+	return fmt.Sprintf("MediaRecordStatePictureStateChangedV2State(%d)", int32(e))
+}
+
+// IsValid returns true if the MediaRecordStatePictureStateChangedV2State is one
+// defined by the device SDK.
+func (e MediaRecordStatePictureStateChangedV2State) IsValid() bool {
+	_, ok := mediaRecordStatePictureStateChangedV2StateNames[e]
+	return ok
+}
+
+// MediaRecordStatePictureStateChangedV2Error is a type for constants used as
+// the error argument of the MediaRecordState PictureStateChangedV2 command.
+// Error to explain the state.
+type MediaRecordStatePictureStateChangedV2Error int32
+
+const (
+	// MediaRecordStatePictureStateChangedV2ErrorOk is ok: No Error
+	MediaRecordStatePictureStateChangedV2ErrorOk MediaRecordStatePictureStateChangedV2Error = iota
+	// MediaRecordStatePictureStateChangedV2ErrorUnknown is unknown: Unknown
+	// generic error
+	MediaRecordStatePictureStateChangedV2ErrorUnknown
+	// MediaRecordStatePictureStateChangedV2ErrorCameraKo is camera_ko: Picture
+	// camera is out of order
+	MediaRecordStatePictureStateChangedV2ErrorCameraKo
+	// MediaRecordStatePictureStateChangedV2ErrorMemoryFull is memoryFull: Memory
+	// full ; cannot save one additional picture
+	MediaRecordStatePictureStateChangedV2ErrorMemoryFull
+	// MediaRecordStatePictureStateChangedV2ErrorLowBattery is lowBattery: Battery
+	// is too low to start/keep recording.
+	MediaRecordStatePictureStateChangedV2ErrorLowBattery
+)
+
+// mediaRecordStatePictureStateChangedV2ErrorNames maps each
+// MediaRecordStatePictureStateChangedV2Error value to its name as defined by
+// the device SDK.
+var mediaRecordStatePictureStateChangedV2ErrorNames = map[MediaRecordStatePictureStateChangedV2Error]string{
+	MediaRecordStatePictureStateChangedV2ErrorOk:         "ok",
+	MediaRecordStatePictureStateChangedV2ErrorUnknown:    "unknown",
+	MediaRecordStatePictureStateChangedV2ErrorCameraKo:   "camera_ko",
+	MediaRecordStatePictureStateChangedV2ErrorMemoryFull: "memoryFull",
+	MediaRecordStatePictureStateChangedV2ErrorLowBattery: "lowBattery",
+}
+
+func (e MediaRecordStatePictureStateChangedV2Error) String() string {
+	if name, ok := mediaRecordStatePictureStateChangedV2ErrorNames[e]; ok {
+		return name
+	}
+	return fmt.Sprintf("MediaRecordStatePictureStateChangedV2Error(%d)", int32(e))
+}
+
+// IsValid returns true if the MediaRecordStatePictureStateChangedV2Error is one
+// defined by the device SDK.
+func (e MediaRecordStatePictureStateChangedV2Error) IsValid() bool {
+	_, ok := mediaRecordStatePictureStateChangedV2ErrorNames[e]
+	return ok
+}
+
+// MediaRecordStateVideoStateChangedV2State is a type for constants used as the
+// state argument of the MediaRecordState VideoStateChangedV2 command. State of
+// device video recording.
+type MediaRecordStateVideoStateChangedV2State int32
+
+const (
+	// MediaRecordStateVideoStateChangedV2StateStopped is stopped: Video is stopped
+	MediaRecordStateVideoStateChangedV2StateStopped MediaRecordStateVideoStateChangedV2State = iota
+	// MediaRecordStateVideoStateChangedV2StateStarted is started: Video is started
+	MediaRecordStateVideoStateChangedV2StateStarted
+	// MediaRecordStateVideoStateChangedV2StateNotAvailable is notAvailable: The
+	// video recording is not available
+	MediaRecordStateVideoStateChangedV2StateNotAvailable
+)
+
+// mediaRecordStateVideoStateChangedV2StateNames maps each
+// MediaRecordStateVideoStateChangedV2State value to its name as defined by the
+// device SDK.
+var mediaRecordStateVideoStateChangedV2StateNames = map[MediaRecordStateVideoStateChangedV2State]string{
+	MediaRecordStateVideoStateChangedV2StateStopped:      "stopped",
+	MediaRecordStateVideoStateChangedV2StateStarted:      "started",
+	MediaRecordStateVideoStateChangedV2StateNotAvailable: "notAvailable",
+}
+
+func (e MediaRecordStateVideoStateChangedV2State) String() string {
+	if name, ok := mediaRecordStateVideoStateChangedV2StateNames[e]; ok {
+		return name
+	}
+	return fmt.Sprintf("MediaRecordStateVideoStateChangedV2State(%d)", int32(e))
+}
+
+// IsValid returns true if the MediaRecordStateVideoStateChangedV2State is one
+// defined by the device SDK.
+func (e MediaRecordStateVideoStateChangedV2State) IsValid() bool {
+	_, ok := mediaRecordStateVideoStateChangedV2StateNames[e]
+	return ok
+}
+
+// MediaRecordStateVideoStateChangedV2Error is a type for constants used as the
+// error argument of the MediaRecordState VideoStateChangedV2 command. Error to
+// explain the state.
+type MediaRecordStateVideoStateChangedV2Error int32
+
+const (
+	// MediaRecordStateVideoStateChangedV2ErrorOk is ok: No Error
+	MediaRecordStateVideoStateChangedV2ErrorOk MediaRecordStateVideoStateChangedV2Error = iota
+	// MediaRecordStateVideoStateChangedV2ErrorUnknown is unknown: Unknown generic
+	// error
+	MediaRecordStateVideoStateChangedV2ErrorUnknown
+	// MediaRecordStateVideoStateChangedV2ErrorCameraKo is camera_ko: Video camera
+	// is out of order
+	MediaRecordStateVideoStateChangedV2ErrorCameraKo
+	// MediaRecordStateVideoStateChangedV2ErrorMemoryFull is memoryFull: Memory
+	// full ; cannot save one additional video
+	MediaRecordStateVideoStateChangedV2ErrorMemoryFull
+	// MediaRecordStateVideoStateChangedV2ErrorLowBattery is lowBattery: Battery is
+	// too low to start/keep recording.
+	MediaRecordStateVideoStateChangedV2ErrorLowBattery
+)
+
+// mediaRecordStateVideoStateChangedV2ErrorNames maps each
+// MediaRecordStateVideoStateChangedV2Error value to its name as defined by the
+// device SDK.
+var mediaRecordStateVideoStateChangedV2ErrorNames = map[MediaRecordStateVideoStateChangedV2Error]string{
+	MediaRecordStateVideoStateChangedV2ErrorOk:         "ok",
+	MediaRecordStateVideoStateChangedV2ErrorUnknown:    "unknown",
+	MediaRecordStateVideoStateChangedV2ErrorCameraKo:   "camera_ko",
+	MediaRecordStateVideoStateChangedV2ErrorMemoryFull: "memoryFull",
+	MediaRecordStateVideoStateChangedV2ErrorLowBattery: "lowBattery",
+}
+
+func (e MediaRecordStateVideoStateChangedV2Error) String() string {
+	if name, ok := mediaRecordStateVideoStateChangedV2ErrorNames[e]; ok {
+		return name
+	}
+	return fmt.Sprintf("MediaRecordStateVideoStateChangedV2Error(%d)", int32(e))
+}
+
+// IsValid returns true if the MediaRecordStateVideoStateChangedV2Error is one
+// defined by the device SDK.
+func (e MediaRecordStateVideoStateChangedV2Error) IsValid() bool {
+	_, ok := mediaRecordStateVideoStateChangedV2ErrorNames[e]
+	return ok
+}
+
+// MediaRecordStateVideoResolutionStateStreaming is a type for constants used as
+// the streaming argument of the MediaRecordState VideoResolutionState command.
+// Streaming resolution.
+type MediaRecordStateVideoResolutionStateStreaming int32
+
+const (
+	// MediaRecordStateVideoResolutionStateStreamingRes360p is res360p: 360p
+	// resolution.
+	MediaRecordStateVideoResolutionStateStreamingRes360p MediaRecordStateVideoResolutionStateStreaming = iota
+	// MediaRecordStateVideoResolutionStateStreamingRes480p is res480p: 480p
+	// resolution.
+	MediaRecordStateVideoResolutionStateStreamingRes480p
+	// MediaRecordStateVideoResolutionStateStreamingRes720p is res720p: 720p
+	// resolution.
+	MediaRecordStateVideoResolutionStateStreamingRes720p
+	// MediaRecordStateVideoResolutionStateStreamingRes1080p is res1080p: 1080p
+	// resolution.
+	MediaRecordStateVideoResolutionStateStreamingRes1080p
+)
+
+// mediaRecordStateVideoResolutionStateStreamingNames maps each
+// MediaRecordStateVideoResolutionStateStreaming value to its name as defined by
+// the device SDK.
+var mediaRecordStateVideoResolutionStateStreamingNames = map[MediaRecordStateVideoResolutionStateStreaming]string{
+	MediaRecordStateVideoResolutionStateStreamingRes360p:  "res360p",
+	MediaRecordStateVideoResolutionStateStreamingRes480p:  "res480p",
+	MediaRecordStateVideoResolutionStateStreamingRes720p:  "res720p",
+	MediaRecordStateVideoResolutionStateStreamingRes1080p: "res1080p",
+}
+
+func (e MediaRecordStateVideoResolutionStateStreaming) String() string {
+	if name, ok := mediaRecordStateVideoResolutionStateStreamingNames[e]; ok {
+		return name
+	}
+	return fmt.Sprintf("MediaRecordStateVideoResolutionStateStreaming(%d)", int32(e))
+}
+
+// IsValid returns true if the MediaRecordStateVideoResolutionStateStreaming is
+// one defined by the device SDK.
+func (e MediaRecordStateVideoResolutionStateStreaming) IsValid() bool {
+	_, ok := mediaRecordStateVideoResolutionStateStreamingNames[e]
+	return ok
+}
+
+// MediaRecordStateVideoResolutionStateRecording is a type for constants used as
+// the recording argument of the MediaRecordState VideoResolutionState command.
+// Recording resolution.
+type MediaRecordStateVideoResolutionStateRecording int32
+
+const (
+	// MediaRecordStateVideoResolutionStateRecordingRes360p is res360p: 360p
+	// resolution.
+	MediaRecordStateVideoResolutionStateRecordingRes360p MediaRecordStateVideoResolutionStateRecording = iota
+	// MediaRecordStateVideoResolutionStateRecordingRes480p is res480p: 480p
+	// resolution.
+	MediaRecordStateVideoResolutionStateRecordingRes480p
+	// MediaRecordStateVideoResolutionStateRecordingRes720p is res720p: 720p
+	// resolution.
+	MediaRecordStateVideoResolutionStateRecordingRes720p
+	// MediaRecordStateVideoResolutionStateRecordingRes1080p is res1080p: 1080p
+	// resolution.
+	MediaRecordStateVideoResolutionStateRecordingRes1080p
+)
+
+// mediaRecordStateVideoResolutionStateRecordingNames maps each
+// MediaRecordStateVideoResolutionStateRecording value to its name as defined by
+// the device SDK.
+var mediaRecordStateVideoResolutionStateRecordingNames = map[MediaRecordStateVideoResolutionStateRecording]string{
+	MediaRecordStateVideoResolutionStateRecordingRes360p:  "res360p",
+	MediaRecordStateVideoResolutionStateRecordingRes480p:  "res480p",
+	MediaRecordStateVideoResolutionStateRecordingRes720p:  "res720p",
+	MediaRecordStateVideoResolutionStateRecordingRes1080p: "res1080p",
+}
+
+func (e MediaRecordStateVideoResolutionStateRecording) String() string {
+	if name, ok := mediaRecordStateVideoResolutionStateRecordingNames[e]; ok {
+		return name
+	}
+	return fmt.Sprintf("MediaRecordStateVideoResolutionStateRecording(%d)", int32(e))
+}
+
+// IsValid returns true if the MediaRecordStateVideoResolutionStateRecording is
+// one defined by the device SDK.
+func (e MediaRecordStateVideoResolutionStateRecording) IsValid() bool {
+	_, ok := mediaRecordStateVideoResolutionStateRecordingNames[e]
+	return ok
+}
+
+// mediaRecordStateHandlers is implemented by the MediaRecordState class, which
+// handles each of its commands. State of media recording.
+type mediaRecordStateHandlers interface {
+	pictureStateChanged(args []interface{}) error
+	videoStateChanged(args []interface{}) error
+	pictureStateChangedV2(args []interface{}) error
+	videoStateChangedV2(args []interface{}) error
+	videoResolutionState(args []interface{}) error
+}
+
+// mediaRecordStateDefaults implements every handler of the MediaRecordState
+// class by logging that the command was received. The MediaRecordState class
+// can embed it and implement only the handlers for commands it acts upon.
+type mediaRecordStateDefaults struct{}
+
+// mediaRecordStateD2CCommands returns the commands of the MediaRecordState
+// class, each dispatched to the corresponding handler.
+func mediaRecordStateD2CCommands(
+	handlers mediaRecordStateHandlers,
+) []arcommands.D2CCommand {
+	return []arcommands.D2CCommand{
+		arcommands.NewD2CCommand(
+			0,
+			"PictureStateChanged",
+			[]interface{}{
+				uint8(0), // state,
+				uint8(0), // mass_storage_id,
+			},
+			handlers.pictureStateChanged,
+		),
+		arcommands.NewD2CCommand(
+			1,
+			"VideoStateChanged",
+			[]interface{}{
+				MediaRecordStateVideoStateChangedState(0), // state,
+				uint8(0), // mass_storage_id,
+			},
+			handlers.videoStateChanged,
+		),
+		arcommands.NewD2CCommand(
+			2,
+			"PictureStateChangedV2",
+			[]interface{}{
+				MediaRecordStatePictureStateChangedV2State(0), // state,
+				MediaRecordStatePictureStateChangedV2Error(0), // error,
+			},
+			handlers.pictureStateChangedV2,
+		),
+		arcommands.NewD2CCommand(
+			3,
+			"VideoStateChangedV2",
+			[]interface{}{
+				MediaRecordStateVideoStateChangedV2State(0), // state,
+				MediaRecordStateVideoStateChangedV2Error(0), // error,
+			},
+			handlers.videoStateChangedV2,
+		),
+		arcommands.NewD2CCommand(
+			4,
+			"VideoResolutionState",
+			[]interface{}{
+				MediaRecordStateVideoResolutionStateStreaming(0), // streaming,
+				MediaRecordStateVideoResolutionStateRecording(0), // recording,
+			},
+			handlers.videoResolutionState,
+		),
+	}
+}
+
+// Title: Picture state
+// Description: Picture state.
+// Support:
+// Result:
+//
+// Deprecated: This command is deprecated by the device SDK.
+func (mediaRecordStateDefaults) pictureStateChanged(args []interface{}) error {
+	// state := args[0].(uint8)
+	//   1 if picture has been taken, 0 otherwise
+	// mass_storage_id := args[1].(uint8)
+	//   Mass storage id where the picture was recorded
+	log.Info("ardrone3.pictureStateChanged() called")
+	return nil
+}
+
+// Title: Video record state
+// Description: Picture record state.
+// Support:
+// Result:
+//
+// Deprecated: This command is deprecated by the device SDK.
+func (mediaRecordStateDefaults) videoStateChanged(args []interface{}) error {
+	// state := args[0].(MediaRecordStateVideoStateChangedState)
+	//   State of video
+	//   0: stopped: Video was stopped
+	//   1: started: Video was started
+	//   2: failed: Video was failed
+	//   3: autostopped: Video was auto stopped
+	// mass_storage_id := args[1].(uint8)
+	//   Mass storage id where the video was recorded
+	log.Info("ardrone3.videoStateChanged() called")
+	return nil
+}
+
+// Title: Picture state
+// Description: Picture state.
+// Support: 0901:2.0.1;090c;090e
+// Triggered: by [TakePicture](#1-7-2) or by a change in the picture state
+// Result:
+func (mediaRecordStateDefaults) pictureStateChangedV2(
+	args []interface{},
+) error {
+	// state := args[0].(MediaRecordStatePictureStateChangedV2State)
+	//   State of device picture recording
+	//   0: ready: The picture recording is ready
+	//   1: busy: The picture recording is busy
+	//   2: notAvailable: The picture recording is not available
+	// error := args[1].(MediaRecordStatePictureStateChangedV2Error)
+	//   Error to explain the state
+	//   0: ok: No Error
+	//   1: unknown: Unknown generic error
+	//   2: camera_ko: Picture camera is out of order
+	//   3: memoryFull: Memory full ; cannot save one additional picture
+	//   4: lowBattery: Battery is too low to start/keep recording.
+	log.Info("ardrone3.pictureStateChangedV2() called")
+	return nil
+}
+
+// Title: Video record state
+// Description: Video record state.
+// Support: 0901:2.0.1;090c;090e
+// Triggered: by [RecordVideo](#1-7-3) or by a change in the video state
+// Result:
+func (mediaRecordStateDefaults) videoStateChangedV2(args []interface{}) error {
+	// state := args[0].(MediaRecordStateVideoStateChangedV2State)
+	//   State of device video recording
+	//   0: stopped: Video is stopped
+	//   1: started: Video is started
+	//   2: notAvailable: The video recording is not available
+	// error := args[1].(MediaRecordStateVideoStateChangedV2Error)
+	//   Error to explain the state
+	//   0: ok: No Error
+	//   1: unknown: Unknown generic error
+	//   2: camera_ko: Video camera is out of order
+	//   3: memoryFull: Memory full ; cannot save one additional video
+	//   4: lowBattery: Battery is too low to start/keep recording.
+	log.Info("ardrone3.videoStateChangedV2() called")
+	return nil
+}
+
+// Title: Video resolution
+// Description: Video resolution.\n Informs about streaming and recording video
+// resolutions.\n Note that this is only an indication about what the resolution
+// should be. To know the real resolution, you should get it from the frame.
+// Support: none
+// Triggered: when the resolution changes.
+// Result:
+//
+// Deprecated: This command is deprecated by the device SDK.
+func (mediaRecordStateDefaults) videoResolutionState(args []interface{}) error {
+	// streaming := args[0].(MediaRecordStateVideoResolutionStateStreaming)
+	//   Streaming resolution
+	//   0: res360p: 360p resolution.
+	//   1: res480p: 480p resolution.
+	//   2: res720p: 720p resolution.
+	//   3: res1080p: 1080p resolution.
+	// recording := args[1].(MediaRecordStateVideoResolutionStateRecording)
+	//   Recording resolution
+	//   0: res360p: 360p resolution.
+	//   1: res480p: 480p resolution.
+	//   2: res720p: 720p resolution.
+	//   3: res1080p: 1080p resolution.
+	log.Info("ardrone3.videoResolutionState() called")
+	return nil
+}
+
+// NetworkSettingsStateWifiSelectionChangedType is a type for constants used as
+// the type argument of the NetworkSettingsState WifiSelectionChanged command.
+// The type of wifi selection settings.
+type NetworkSettingsStateWifiSelectionChangedType int32
+
+const (
+	// NetworkSettingsStateWifiSelectionChangedTypeAutoAll is auto_all: Auto
+	// selection
+	NetworkSettingsStateWifiSelectionChangedTypeAutoAll NetworkSettingsStateWifiSelectionChangedType = iota
+	// NetworkSettingsStateWifiSelectionChangedTypeAuto24ghz is auto_2_4ghz: Auto
+	// selection 2.4ghz
+	NetworkSettingsStateWifiSelectionChangedTypeAuto24ghz
+	// NetworkSettingsStateWifiSelectionChangedTypeAuto5ghz is auto_5ghz: Auto
+	// selection 5 ghz
+	NetworkSettingsStateWifiSelectionChangedTypeAuto5ghz
+	// NetworkSettingsStateWifiSelectionChangedTypeManual is manual: Manual
+	// selection
+	NetworkSettingsStateWifiSelectionChangedTypeManual
+)
+
+// networkSettingsStateWifiSelectionChangedTypeNames maps each
+// NetworkSettingsStateWifiSelectionChangedType value to its name as defined by
+// the device SDK.
+var networkSettingsStateWifiSelectionChangedTypeNames = map[NetworkSettingsStateWifiSelectionChangedType]string{
+	NetworkSettingsStateWifiSelectionChangedTypeAutoAll:   "auto_all",
+	NetworkSettingsStateWifiSelectionChangedTypeAuto24ghz: "auto_2_4ghz",
+	NetworkSettingsStateWifiSelectionChangedTypeAuto5ghz:  "auto_5ghz",
+	NetworkSettingsStateWifiSelectionChangedTypeManual:    "manual",
+}
+
+func (e NetworkSettingsStateWifiSelectionChangedType) String() string {
+	if name, ok := networkSettingsStateWifiSelectionChangedTypeNames[e]; ok {
+		return name
+	}
+	return fmt.Sprintf("NetworkSettingsStateWifiSelectionChangedType(%d)", int32(e))
+}
+
+// IsValid returns true if the NetworkSettingsStateWifiSelectionChangedType is
+// one defined by the device SDK.
+func (e NetworkSettingsStateWifiSelectionChangedType) IsValid() bool {
+	_, ok := networkSettingsStateWifiSelectionChangedTypeNames[e]
+	return ok
+}
+
+// NetworkSettingsStateWifiSelectionChangedBand is a type for constants used as
+// the band argument of the NetworkSettingsState WifiSelectionChanged command.
+// The actual wifi band state.
+type NetworkSettingsStateWifiSelectionChangedBand int32
+
+const (
+	// NetworkSettingsStateWifiSelectionChangedBand24ghz is 2_4ghz: 2.4 GHz band
+	NetworkSettingsStateWifiSelectionChangedBand24ghz NetworkSettingsStateWifiSelectionChangedBand = iota
+	// NetworkSettingsStateWifiSelectionChangedBand5ghz is 5ghz: 5 GHz band
+	NetworkSettingsStateWifiSelectionChangedBand5ghz
+	// NetworkSettingsStateWifiSelectionChangedBandAll is all: Both 2.4 and 5 GHz
+	// bands
+	NetworkSettingsStateWifiSelectionChangedBandAll
+)
+
+// networkSettingsStateWifiSelectionChangedBandNames maps each
+// NetworkSettingsStateWifiSelectionChangedBand value to its name as defined by
+// the device SDK.
+var networkSettingsStateWifiSelectionChangedBandNames = map[NetworkSettingsStateWifiSelectionChangedBand]string{
+	NetworkSettingsStateWifiSelectionChangedBand24ghz: "2_4ghz",
+	NetworkSettingsStateWifiSelectionChangedBand5ghz:  "5ghz",
+	NetworkSettingsStateWifiSelectionChangedBandAll:   "all",
+}
+
+func (e NetworkSettingsStateWifiSelectionChangedBand) String() string {
+	if name, ok := networkSettingsStateWifiSelectionChangedBandNames[e]; ok {
+		return name
+	}
+	return fmt.Sprintf("NetworkSettingsStateWifiSelectionChangedBand(%d)", int32(e))
+}
+
+// IsValid returns true if the NetworkSettingsStateWifiSelectionChangedBand is
+// one defined by the device SDK.
+func (e NetworkSettingsStateWifiSelectionChangedBand) IsValid() bool {
+	_, ok := networkSettingsStateWifiSelectionChangedBandNames[e]
+	return ok
+}
+
+// NetworkSettingsStateWifiSecurityChangedType is a type for constants used as
+// the type argument of the NetworkSettingsState wifiSecurityChanged command.
+// The type of wifi security (open, wpa2).
+type NetworkSettingsStateWifiSecurityChangedType int32
+
+const (
+	// NetworkSettingsStateWifiSecurityChangedTypeOpen is open: Wifi is not
+	// protected by any security (default)
+	NetworkSettingsStateWifiSecurityChangedTypeOpen NetworkSettingsStateWifiSecurityChangedType = iota
+	// NetworkSettingsStateWifiSecurityChangedTypeWpa2 is wpa2: Wifi is protected
+	// by wpa2
+	NetworkSettingsStateWifiSecurityChangedTypeWpa2
+)
+
+// networkSettingsStateWifiSecurityChangedTypeNames maps each
+// NetworkSettingsStateWifiSecurityChangedType value to its name as defined by
+// the device SDK.
+var networkSettingsStateWifiSecurityChangedTypeNames = map[NetworkSettingsStateWifiSecurityChangedType]string{
+	NetworkSettingsStateWifiSecurityChangedTypeOpen: "open",
+	NetworkSettingsStateWifiSecurityChangedTypeWpa2: "wpa2",
+}
+
+func (e NetworkSettingsStateWifiSecurityChangedType) String() string {
+	if name, ok := networkSettingsStateWifiSecurityChangedTypeNames[e]; ok {
+		return name
+	}
+	return fmt.Sprintf("NetworkSettingsStateWifiSecurityChangedType(%d)", int32(e))
+}
+
+// IsValid returns true if the NetworkSettingsStateWifiSecurityChangedType is
+// one defined by the device SDK.
+func (e NetworkSettingsStateWifiSecurityChangedType) IsValid() bool {
+	_, ok := networkSettingsStateWifiSecurityChangedTypeNames[e]
+	return ok
+}
+
+// NetworkSettingsStateWifiSecurityType is a type for constants used as the type
+// argument of the NetworkSettingsState wifiSecurity command. The type of wifi
+// security (open, wpa2).
+type NetworkSettingsStateWifiSecurityType int32
+
+const (
+	// NetworkSettingsStateWifiSecurityTypeOpen is open: Wifi is not protected by
+	// any security (default)
+	NetworkSettingsStateWifiSecurityTypeOpen NetworkSettingsStateWifiSecurityType = iota
+	// NetworkSettingsStateWifiSecurityTypeWpa2 is wpa2: Wifi is protected by wpa2
+	NetworkSettingsStateWifiSecurityTypeWpa2
+)
+
+// networkSettingsStateWifiSecurityTypeNames maps each
+// NetworkSettingsStateWifiSecurityType value to its name as defined by the
+// device SDK.
+var networkSettingsStateWifiSecurityTypeNames = map[NetworkSettingsStateWifiSecurityType]string{
+	NetworkSettingsStateWifiSecurityTypeOpen: "open",
+	NetworkSettingsStateWifiSecurityTypeWpa2: "wpa2",
+}
+
+func (e NetworkSettingsStateWifiSecurityType) String() string {
+	if name, ok := networkSettingsStateWifiSecurityTypeNames[e]; ok {
+		return name
+	}
+	return fmt.Sprintf("NetworkSettingsStateWifiSecurityType(%d)", int32(e))
+}
+
+// IsValid returns true if the NetworkSettingsStateWifiSecurityType is one
+// defined by the device SDK.
+func (e NetworkSettingsStateWifiSecurityType) IsValid() bool {
+	_, ok := networkSettingsStateWifiSecurityTypeNames[e]
+	return ok
+}
+
+// NetworkSettingsStateWifiSecurityKeyType is a type for constants used as the
+// keyType argument of the NetworkSettingsState wifiSecurity command. Type of
+// the key.
+type NetworkSettingsStateWifiSecurityKeyType int32
+
+const (
+	// NetworkSettingsStateWifiSecurityKeyTypePlain is plain: Key is plain text,
+	// not encrypted
+	NetworkSettingsStateWifiSecurityKeyTypePlain NetworkSettingsStateWifiSecurityKeyType = iota
+)
+
+// networkSettingsStateWifiSecurityKeyTypeNames maps each
+// NetworkSettingsStateWifiSecurityKeyType value to its name as defined by the
+// device SDK.
+var networkSettingsStateWifiSecurityKeyTypeNames = map[NetworkSettingsStateWifiSecurityKeyType]string{
+	NetworkSettingsStateWifiSecurityKeyTypePlain: "plain",
+}
+
+func (e NetworkSettingsStateWifiSecurityKeyType) String() string {
+	if name, ok := networkSettingsStateWifiSecurityKeyTypeNames[e]; ok {
+		return name
+	}
+	return fmt.Sprintf("NetworkSettingsStateWifiSecurityKeyType(%d)", int32(e))
+}
+
+// IsValid returns true if the NetworkSettingsStateWifiSecurityKeyType is one
+// defined by the device SDK.
+func (e NetworkSettingsStateWifiSecurityKeyType) IsValid() bool {
+	_, ok := networkSettingsStateWifiSecurityKeyTypeNames[e]
+	return ok
+}
+
+// networkSettingsStateHandlers is implemented by the NetworkSettingsState
+// class, which handles each of its commands. Network settings state from
+// product.
+type networkSettingsStateHandlers interface {
+	wifiSelectionChanged(args []interface{}) error
+	wifiSecurityChanged(args []interface{}) error
+	wifiSecurity(args []interface{}) error
+}
+
+// networkSettingsStateDefaults implements every handler of the
+// NetworkSettingsState class by logging that the command was received. The
+// NetworkSettingsState class can embed it and implement only the handlers for
+// commands it acts upon.
+type networkSettingsStateDefaults struct{}
+
+// networkSettingsStateD2CCommands returns the commands of the
+// NetworkSettingsState class, each dispatched to the corresponding handler.
+func networkSettingsStateD2CCommands(
+	handlers networkSettingsStateHandlers,
+) []arcommands.D2CCommand {
+	return []arcommands.D2CCommand{
+		arcommands.NewD2CCommand(
+			0,
+			"WifiSelectionChanged",
+			[]interface{}{
+				NetworkSettingsStateWifiSelectionChangedType(0), // type,
+				NetworkSettingsStateWifiSelectionChangedBand(0), // band,
+				uint8(0), // channel,
+			},
+			handlers.wifiSelectionChanged,
+		),
+		arcommands.NewD2CCommand(
+			1,
+			"wifiSecurityChanged",
+			[]interface{}{
+				NetworkSettingsStateWifiSecurityChangedType(0), // type,
+			},
+			handlers.wifiSecurityChanged,
+		),
+		arcommands.NewD2CCommand(
+			2,
+			"wifiSecurity",
+			[]interface{}{
+				NetworkSettingsStateWifiSecurityType(0), // type,
+				"",                                      // key,
+				NetworkSettingsStateWifiSecurityKeyType(0), // keyType,
+			},
+			handlers.wifiSecurity,
+		),
+	}
+}
+
+// Title: Wifi selection
+// Description: Wifi selection.
+// Support: 0901;090c;090e
+// Triggered: by [SelectWifi](#1-9-0).
+// Result:
+func (networkSettingsStateDefaults) wifiSelectionChanged(
+	args []interface{},
+) error {
+	// type := args[0].(NetworkSettingsStateWifiSelectionChangedType)
+	//   The type of wifi selection settings
+	//   0: auto_all: Auto selection
+	//   1: auto_2_4ghz: Auto selection 2.4ghz
+	//   2: auto_5ghz: Auto selection 5 ghz
+	//   3: manual: Manual selection
+	// band := args[1].(NetworkSettingsStateWifiSelectionChangedBand)
+	//   The actual wifi band state
+	//   0: 2_4ghz: 2.4 GHz band
+	//   1: 5ghz: 5 GHz band
+	//   2: all: Both 2.4 and 5 GHz bands
+	// channel := args[2].(uint8)
+	//   The channel (depends of the band)
+	log.Info("ardrone3.wifiSelectionChanged() called")
+	return nil
+}
+
+// Title: Wifi security type
+// Description: Wifi security type.
+// Support:
+// Result:
+//
+// Deprecated: This command is deprecated by the device SDK.
+func (networkSettingsStateDefaults) wifiSecurityChanged(
+	args []interface{},
+) error {
+	// type := args[0].(NetworkSettingsStateWifiSecurityChangedType)
+	//   The type of wifi security (open, wpa2)
+	//   0: open: Wifi is not protected by any security (default)
+	//   1: wpa2: Wifi is protected by wpa2
+	log.Info("ardrone3.wifiSecurityChanged() called")
+	return nil
+}
+
+// Title: Wifi security type
+// Description: Wifi security type.
+// Support: 0901;090c;090e
+// Triggered: by [SetWifiSecurityType](#1-9-1).
+// Result:
+func (networkSettingsStateDefaults) wifiSecurity(args []interface{}) error {
+	// type := args[0].(NetworkSettingsStateWifiSecurityType)
+	//   The type of wifi security (open, wpa2)
+	//   0: open: Wifi is not protected by any security (default)
+	//   1: wpa2: Wifi is protected by wpa2
+	// key := args[1].(string)
+	//   The key used to secure the network (empty if type is open)
+	// keyType := args[2].(NetworkSettingsStateWifiSecurityKeyType)
+	//   Type of the key
+	//   0: plain: Key is plain text, not encrypted
+	log.Info("ardrone3.wifiSecurity() called")
+	return nil
+}
+
+// speedSettingsStateHandlers is implemented by the SpeedSettingsState class,
+// which handles each of its commands. Speed Settings state from product.
+type speedSettingsStateHandlers interface {
+	maxVerticalSpeedChanged(args []interface{}) error
+	maxRotationSpeedChanged(args []interface{}) error
+	hullProtectionChanged(args []interface{}) error
+	outdoorChanged(args []interface{}) error
+	maxPitchRollRotationSpeedChanged(args []interface{}) error
+}
+
+// speedSettingsStateDefaults implements every handler of the SpeedSettingsState
+// class by logging that the command was received. The SpeedSettingsState class
+// can embed it and implement only the handlers for commands it acts upon.
+type speedSettingsStateDefaults struct{}
+
+// speedSettingsStateD2CCommands returns the commands of the SpeedSettingsState
+// class, each dispatched to the corresponding handler.
+func speedSettingsStateD2CCommands(
+	handlers speedSettingsStateHandlers,
+) []arcommands.D2CCommand {
+	return []arcommands.D2CCommand{
+		arcommands.NewD2CCommand(
+			0,
+			"MaxVerticalSpeedChanged",
+			[]interface{}{
+				float32(0), // current,
+				float32(0), // min,
+				float32(0), // max,
+			},
+			handlers.maxVerticalSpeedChanged,
+		),
+		arcommands.NewD2CCommand(
+			1,
+			"MaxRotationSpeedChanged",
+			[]interface{}{
+				float32(0), // current,
+				float32(0), // min,
+				float32(0), // max,
+			},
+			handlers.maxRotationSpeedChanged,
+		),
+		arcommands.NewD2CCommand(
+			2,
+			"HullProtectionChanged",
+			[]interface{}{
+				uint8(0), // present,
+			},
+			handlers.hullProtectionChanged,
+		),
+		arcommands.NewD2CCommand(
+			3,
+			"OutdoorChanged",
+			[]interface{}{
+				uint8(0), // outdoor,
+			},
+			handlers.outdoorChanged,
+		),
+		arcommands.NewD2CCommand(
+			4,
+			"MaxPitchRollRotationSpeedChanged",
+			[]interface{}{
+				float32(0), // current,
+				float32(0), // min,
+				float32(0), // max,
+			},
+			handlers.maxPitchRollRotationSpeedChanged,
+		),
+	}
+}
+
+// Title: Max vertical speed
+// Description: Max vertical speed.
+// Support: 0901;090c
+// Triggered: by [SetMaxVerticalSpeed](#1-11-0).
+// Result:
+func (speedSettingsStateDefaults) maxVerticalSpeedChanged(
+	args []interface{},
+) error {
+	// current := args[0].(float32)
+	//   Current max vertical speed in m/s
+	// min := args[1].(float32)
+	//   Range min of vertical speed
+	// max := args[2].(float32)
+	//   Range max of vertical speed
+	log.Info("ardrone3.maxVerticalSpeedChanged() called")
+	return nil
+}
+
+// Title: Max rotation speed
+// Description: Max rotation speed.
+// Support: 0901;090c
+// Triggered: by [SetMaxRotationSpeed](#1-11-1).
+// Result:
+func (speedSettingsStateDefaults) maxRotationSpeedChanged(
+	args []interface{},
+) error {
+	// current := args[0].(float32)
+	//   Current max yaw rotation speed in degree/s
+	// min := args[1].(float32)
+	//   Range min of yaw rotation speed
+	// max := args[2].(float32)
+	//   Range max of yaw rotation speed
+	log.Info("ardrone3.maxRotationSpeedChanged() called")
+	return nil
+}
+
+// Title: Presence of hull protection
+// Description: Presence of hull protection.
+// Support: 0901;090c
+// Triggered: by [SetHullProtectionPresence](#1-11-2).
+// Result:
+func (speedSettingsStateDefaults) hullProtectionChanged(
+	args []interface{},
+) error {
+	// present := args[0].(uint8)
+	//   1 if present, 0 if not present
+	log.Info("ardrone3.hullProtectionChanged() called")
+	return nil
+}
+
+// Title: Outdoor mode
+// Description: Outdoor mode.
+// Support:
+// Result:
+//
+// Deprecated: This command is deprecated by the device SDK.
+func (speedSettingsStateDefaults) outdoorChanged(args []interface{}) error {
+	// outdoor := args[0].(uint8)
+	//   1 if outdoor flight, 0 if indoor flight
+	log.Info("ardrone3.outdoorChanged() called")
+	return nil
+}
+
+// Title: Max pitch/roll rotation speed
+// Description: Max pitch/roll rotation speed.
+// Support: 0901;090c
+// Triggered: by [SetMaxPitchRollRotationSpeed](#1-11-4).
+// Result:
+func (speedSettingsStateDefaults) maxPitchRollRotationSpeedChanged(
+	args []interface{},
+) error {
+	// current := args[0].(float32)
+	//   Current max pitch/roll rotation speed in degree/s
+	// min := args[1].(float32)
+	//   Range min of pitch/roll rotation speed
+	// max := args[2].(float32)
+	//   Range max of pitch/roll rotation speed
+	log.Info("ardrone3.maxPitchRollRotationSpeedChanged() called")
+	return nil
+}
+
+// NetworkStateWifiScanListChangedBand is a type for constants used as the band
+// argument of the NetworkState WifiScanListChanged command. The band : 2.4 GHz
+// or 5 GHz.
+type NetworkStateWifiScanListChangedBand int32
+
+const (
+	// NetworkStateWifiScanListChangedBand24ghz is 2_4ghz: 2.4 GHz band
+	NetworkStateWifiScanListChangedBand24ghz NetworkStateWifiScanListChangedBand = iota
+	// NetworkStateWifiScanListChangedBand5ghz is 5ghz: 5 GHz band
+	NetworkStateWifiScanListChangedBand5ghz
+)
+
+// networkStateWifiScanListChangedBandNames maps each
+// NetworkStateWifiScanListChangedBand value to its name as defined by the
+// device SDK.
+var networkStateWifiScanListChangedBandNames = map[NetworkStateWifiScanListChangedBand]string{
+	NetworkStateWifiScanListChangedBand24ghz: "2_4ghz",
+	NetworkStateWifiScanListChangedBand5ghz:  "5ghz",
+}
+
+func (e NetworkStateWifiScanListChangedBand) String() string {
+	if name, ok := networkStateWifiScanListChangedBandNames[e]; ok {
+		return name
+	}
+	return fmt.Sprintf("NetworkStateWifiScanListChangedBand(%d)", int32(e))
+}
+
+// IsValid returns true if the NetworkStateWifiScanListChangedBand is one
+// defined by the device SDK.
+func (e NetworkStateWifiScanListChangedBand) IsValid() bool {
+	_, ok := networkStateWifiScanListChangedBandNames[e]
+	return ok
+}
+
+// NetworkStateWifiAuthChannelListChangedBand is a type for constants used as
+// the band argument of the NetworkState WifiAuthChannelListChanged command. The
+// band of this channel : 2.4 GHz or 5 GHz.
+type NetworkStateWifiAuthChannelListChangedBand int32
+
+const (
+	// NetworkStateWifiAuthChannelListChangedBand24ghz is 2_4ghz: 2.4 GHz band
+	NetworkStateWifiAuthChannelListChangedBand24ghz NetworkStateWifiAuthChannelListChangedBand = iota
+	// NetworkStateWifiAuthChannelListChangedBand5ghz is 5ghz: 5 GHz band
+	NetworkStateWifiAuthChannelListChangedBand5ghz
+)
+
+// networkStateWifiAuthChannelListChangedBandNames maps each
+// NetworkStateWifiAuthChannelListChangedBand value to its name as defined by
+// the device SDK.
+var networkStateWifiAuthChannelListChangedBandNames = map[NetworkStateWifiAuthChannelListChangedBand]string{
+	NetworkStateWifiAuthChannelListChangedBand24ghz: "2_4ghz",
+	NetworkStateWifiAuthChannelListChangedBand5ghz:  "5ghz",
+}
+
+func (e NetworkStateWifiAuthChannelListChangedBand) String() string {
+	if name, ok := networkStateWifiAuthChannelListChangedBandNames[e]; ok {
+		return name
+	}
+	return fmt.Sprintf("NetworkStateWifiAuthChannelListChangedBand(%d)", int32(e))
+}
+
+// IsValid returns true if the NetworkStateWifiAuthChannelListChangedBand is one
+// defined by the device SDK.
+func (e NetworkStateWifiAuthChannelListChangedBand) IsValid() bool {
+	_, ok := networkStateWifiAuthChannelListChangedBandNames[e]
+	return ok
+}
+
+// networkStateHandlers is implemented by the NetworkState class, which handles
+// each of its commands. Network state from Product.
+type networkStateHandlers interface {
+	wifiScanListChanged(args []interface{}) error
+	allWifiScanChanged(args []interface{}) error
+	wifiAuthChannelListChanged(args []interface{}) error
+	allWifiAuthChannelChanged(args []interface{}) error
+}
+
+// networkStateDefaults implements every handler of the NetworkState class by
+// logging that the command was received. The NetworkState class can embed it
+// and implement only the handlers for commands it acts upon.
+type networkStateDefaults struct{}
+
+// networkStateD2CCommands returns the commands of the NetworkState class, each
+// dispatched to the corresponding handler.
+func networkStateD2CCommands(
+	handlers networkStateHandlers,
+) []arcommands.D2CCommand {
+	return []arcommands.D2CCommand{
+		arcommands.NewD2CCommand(
+			0,
+			"WifiScanListChanged",
+			[]interface{}{
+				"",                                     // ssid,
+				int16(0),                               // rssi,
+				NetworkStateWifiScanListChangedBand(0), // band,
+				uint8(0),                               // channel,
+			},
+			handlers.wifiScanListChanged,
+		),
+		arcommands.NewD2CCommand(
+			1,
+			"AllWifiScanChanged",
+			[]interface{}{},
+			handlers.allWifiScanChanged,
+		),
+		arcommands.NewD2CCommand(
+			2,
+			"WifiAuthChannelListChanged",
+			[]interface{}{
+				NetworkStateWifiAuthChannelListChangedBand(0), // band,
+				uint8(0), // channel,
+				uint8(0), // in_or_out,
+			},
+			handlers.wifiAuthChannelListChanged,
+		),
+		arcommands.NewD2CCommand(
+			3,
+			"AllWifiAuthChannelChanged",
+			[]interface{}{},
+			handlers.allWifiAuthChannelChanged,
+		),
+	}
+}
+
+// Title: Wifi scan results
+// Description: Wifi scan results.\n Please note that the list is not complete
+// until you receive the event [WifiScanEnded](#1-14-1).
+// Support: 0901;090c;090e
+// Triggered: for each wifi network scanned after a [ScanWifi](#1-13-0)
+// Result:
+func (networkStateDefaults) wifiScanListChanged(args []interface{}) error {
+	// ssid := args[0].(string)
+	//   SSID of the AP
+	// rssi := args[1].(int16)
+	//   RSSI of the AP in dbm (negative value)
+	// band := args[2].(NetworkStateWifiScanListChangedBand)
+	//   The band : 2.4 GHz or 5 GHz
+	//   0: 2_4ghz: 2.4 GHz band
+	//   1: 5ghz: 5 GHz band
+	// channel := args[3].(uint8)
+	//   Channel of the AP
+	log.Info("ardrone3.wifiScanListChanged() called")
+	return nil
+}
+
+// Title: Wifi scan ended
+// Description: Wifi scan ended.\n When receiving this event, the list of
+// [WifiScanResults](#1-14-0) is complete.
+// Support: 0901;090c;090e
+// Triggered: after the last [WifiScanResult](#1-14-0) has been sent.
+// Result:
+func (networkStateDefaults) allWifiScanChanged(args []interface{}) error {
+	log.Info("ardrone3.allWifiScanChanged() called")
+	return nil
+}
+
+// Title: Available wifi channels
+// Description: Available wifi channels.\n Please note that the list is not
+// complete until you receive the event
+// [AvailableWifiChannelsCompleted](#1-14-3).
+// Support: 0901;090c;090e
+// Triggered: for each available channel after a
+// [GetAvailableWifiChannels](#1-13-1).
+// Result:
+func (networkStateDefaults) wifiAuthChannelListChanged(
+	args []interface{},
+) error {
+	// band := args[0].(NetworkStateWifiAuthChannelListChangedBand)
+	//   The band of this channel : 2.4 GHz or 5 GHz
+	//   0: 2_4ghz: 2.4 GHz band
+	//   1: 5ghz: 5 GHz band
+	// channel := args[1].(uint8)
+	//   The authorized channel.
+	// in_or_out := args[2].(uint8)
+	//   Bit 0 is 1 if channel is authorized outside (0 otherwise) ; Bit 1 is 1 if
+	//   channel is authorized inside (0 otherwise)
+	log.Info("ardrone3.wifiAuthChannelListChanged() called")
+	return nil
+}
+
+// Title: Available wifi channels completed
+// Description: Available wifi channels completed.\n When receiving this event,
+// the list of [AvailableWifiChannels](#1-14-2) is complete.
+// Support: 0901;090c;090e
+// Triggered: after the last [AvailableWifiChannel](#1-14-2) has been sent.
+// Result:
+func (networkStateDefaults) allWifiAuthChannelChanged(
+	args []interface{},
+) error {
+	log.Info("ardrone3.allWifiAuthChannelChanged() called")
+	return nil
+}
+
+// SettingsStateMotorErrorStateChangedMotorError is a type for constants used as
+// the motorError argument of the SettingsState MotorErrorStateChanged command.
+// Enumeration of the motor error.
+type SettingsStateMotorErrorStateChangedMotorError int32
+
+const (
+	// SettingsStateMotorErrorStateChangedMotorErrorNoError is noError: No error
+	// detected
+	SettingsStateMotorErrorStateChangedMotorErrorNoError SettingsStateMotorErrorStateChangedMotorError = iota
+	// SettingsStateMotorErrorStateChangedMotorErrorErrorEEPRom is errorEEPRom:
+	// EEPROM access failure
+	SettingsStateMotorErrorStateChangedMotorErrorErrorEEPRom
+	// SettingsStateMotorErrorStateChangedMotorErrorErrorMotorStalled is
+	// errorMotorStalled: Motor stalled
+	SettingsStateMotorErrorStateChangedMotorErrorErrorMotorStalled
+	// SettingsStateMotorErrorStateChangedMotorErrorErrorPropellerSecurity is
+	// errorPropellerSecurity: Propeller cutout security triggered
+	SettingsStateMotorErrorStateChangedMotorErrorErrorPropellerSecurity
+	// SettingsStateMotorErrorStateChangedMotorErrorErrorCommLost is errorCommLost:
+	// Communication with motor failed by timeout
+	SettingsStateMotorErrorStateChangedMotorErrorErrorCommLost
+	// SettingsStateMotorErrorStateChangedMotorErrorErrorRCEmergencyStop is
+	// errorRCEmergencyStop: RC emergency stop
+	SettingsStateMotorErrorStateChangedMotorErrorErrorRCEmergencyStop
+	// SettingsStateMotorErrorStateChangedMotorErrorErrorRealTime is errorRealTime:
+	// Motor controler scheduler real-time out of bounds
+	SettingsStateMotorErrorStateChangedMotorErrorErrorRealTime
+	// SettingsStateMotorErrorStateChangedMotorErrorErrorMotorSetting is
+	// errorMotorSetting: One or several incorrect values in motor settings
+	SettingsStateMotorErrorStateChangedMotorErrorErrorMotorSetting
+	// SettingsStateMotorErrorStateChangedMotorErrorErrorTemperature is
+	// errorTemperature: Too hot or too cold Cypress temperature
+	SettingsStateMotorErrorStateChangedMotorErrorErrorTemperature
+	// SettingsStateMotorErrorStateChangedMotorErrorErrorBatteryVoltage is
+	// errorBatteryVoltage: Battery voltage out of bounds
+	SettingsStateMotorErrorStateChangedMotorErrorErrorBatteryVoltage
+	// SettingsStateMotorErrorStateChangedMotorErrorErrorLipoCells is
+	// errorLipoCells: Incorrect number of LIPO cells
+	SettingsStateMotorErrorStateChangedMotorErrorErrorLipoCells
+	// SettingsStateMotorErrorStateChangedMotorErrorErrorMOSFET is errorMOSFET:
+	// Defectuous MOSFET or broken motor phases
+	SettingsStateMotorErrorStateChangedMotorErrorErrorMOSFET
+	// SettingsStateMotorErrorStateChangedMotorErrorErrorBootloader is
+	// errorBootloader: Not use for BLDC but useful for HAL
+	SettingsStateMotorErrorStateChangedMotorErrorErrorBootloader
+	// SettingsStateMotorErrorStateChangedMotorErrorErrorAssert is errorAssert:
+	// Error Made by BLDC_ASSERT()
+	SettingsStateMotorErrorStateChangedMotorErrorErrorAssert
+)
+
+// settingsStateMotorErrorStateChangedMotorErrorNames maps each
+// SettingsStateMotorErrorStateChangedMotorError value to its name as defined by
+// the device SDK.
+var settingsStateMotorErrorStateChangedMotorErrorNames = map[SettingsStateMotorErrorStateChangedMotorError]string{
+	SettingsStateMotorErrorStateChangedMotorErrorNoError:                "noError",
+	SettingsStateMotorErrorStateChangedMotorErrorErrorEEPRom:            "errorEEPRom",
+	SettingsStateMotorErrorStateChangedMotorErrorErrorMotorStalled:      "errorMotorStalled",
+	SettingsStateMotorErrorStateChangedMotorErrorErrorPropellerSecurity: "errorPropellerSecurity",
+	SettingsStateMotorErrorStateChangedMotorErrorErrorCommLost:          "errorCommLost",
+	SettingsStateMotorErrorStateChangedMotorErrorErrorRCEmergencyStop:   "errorRCEmergencyStop",
+	SettingsStateMotorErrorStateChangedMotorErrorErrorRealTime:          "errorRealTime",
+	SettingsStateMotorErrorStateChangedMotorErrorErrorMotorSetting:      "errorMotorSetting",
+	SettingsStateMotorErrorStateChangedMotorErrorErrorTemperature:       "errorTemperature",
+	SettingsStateMotorErrorStateChangedMotorErrorErrorBatteryVoltage:    "errorBatteryVoltage",
+	SettingsStateMotorErrorStateChangedMotorErrorErrorLipoCells:         "errorLipoCells",
+	SettingsStateMotorErrorStateChangedMotorErrorErrorMOSFET:            "errorMOSFET",
+	SettingsStateMotorErrorStateChangedMotorErrorErrorBootloader:        "errorBootloader",
+	SettingsStateMotorErrorStateChangedMotorErrorErrorAssert:            "errorAssert",
+}
+
+func (e SettingsStateMotorErrorStateChangedMotorError) String() string {
+	if name, ok := settingsStateMotorErrorStateChangedMotorErrorNames[e]; ok {
+		return name
+	}
+	return fmt.Sprintf("SettingsStateMotorErrorStateChangedMotorError(%d)", int32(e))
+}
+
+// IsValid returns true if the SettingsStateMotorErrorStateChangedMotorError is
+// one defined by the device SDK.
+func (e SettingsStateMotorErrorStateChangedMotorError) IsValid() bool {
+	_, ok := settingsStateMotorErrorStateChangedMotorErrorNames[e]
+	return ok
+}
+
+// SettingsStateMotorErrorLastErrorChangedMotorError is a type for constants
+// used as the motorError argument of the SettingsState
+// MotorErrorLastErrorChanged command. Enumeration of the motor error.
+type SettingsStateMotorErrorLastErrorChangedMotorError int32
+
+const (
+	// SettingsStateMotorErrorLastErrorChangedMotorErrorNoError is noError: No
+	// error detected
+	SettingsStateMotorErrorLastErrorChangedMotorErrorNoError SettingsStateMotorErrorLastErrorChangedMotorError = iota
+	// SettingsStateMotorErrorLastErrorChangedMotorErrorErrorEEPRom is errorEEPRom:
+	// EEPROM access failure
+	SettingsStateMotorErrorLastErrorChangedMotorErrorErrorEEPRom
+	// SettingsStateMotorErrorLastErrorChangedMotorErrorErrorMotorStalled is
+	// errorMotorStalled: Motor stalled
+	SettingsStateMotorErrorLastErrorChangedMotorErrorErrorMotorStalled
+	// SettingsStateMotorErrorLastErrorChangedMotorErrorErrorPropellerSecurity is
+	// errorPropellerSecurity: Propeller cutout security triggered
+	SettingsStateMotorErrorLastErrorChangedMotorErrorErrorPropellerSecurity
+	// SettingsStateMotorErrorLastErrorChangedMotorErrorErrorCommLost is
+	// errorCommLost: Communication with motor failed by timeout
+	SettingsStateMotorErrorLastErrorChangedMotorErrorErrorCommLost
+	// SettingsStateMotorErrorLastErrorChangedMotorErrorErrorRCEmergencyStop is
+	// errorRCEmergencyStop: RC emergency stop
+	SettingsStateMotorErrorLastErrorChangedMotorErrorErrorRCEmergencyStop
+	// SettingsStateMotorErrorLastErrorChangedMotorErrorErrorRealTime is
+	// errorRealTime: Motor controler scheduler real-time out of bounds
+	SettingsStateMotorErrorLastErrorChangedMotorErrorErrorRealTime
+	// SettingsStateMotorErrorLastErrorChangedMotorErrorErrorMotorSetting is
+	// errorMotorSetting: One or several incorrect values in motor settings
+	SettingsStateMotorErrorLastErrorChangedMotorErrorErrorMotorSetting
+	// SettingsStateMotorErrorLastErrorChangedMotorErrorErrorBatteryVoltage is
+	// errorBatteryVoltage: Battery voltage out of bounds
+	SettingsStateMotorErrorLastErrorChangedMotorErrorErrorBatteryVoltage
+	// SettingsStateMotorErrorLastErrorChangedMotorErrorErrorLipoCells is
+	// errorLipoCells: Incorrect number of LIPO cells
+	SettingsStateMotorErrorLastErrorChangedMotorErrorErrorLipoCells
+	// SettingsStateMotorErrorLastErrorChangedMotorErrorErrorMOSFET is errorMOSFET:
+	// Defectuous MOSFET or broken motor phases
+	SettingsStateMotorErrorLastErrorChangedMotorErrorErrorMOSFET
+	// SettingsStateMotorErrorLastErrorChangedMotorErrorErrorTemperature is
+	// errorTemperature: Too hot or too cold Cypress temperature
+	SettingsStateMotorErrorLastErrorChangedMotorErrorErrorTemperature
+	// SettingsStateMotorErrorLastErrorChangedMotorErrorErrorBootloader is
+	// errorBootloader: Not use for BLDC but useful for HAL
+	SettingsStateMotorErrorLastErrorChangedMotorErrorErrorBootloader
+	// SettingsStateMotorErrorLastErrorChangedMotorErrorErrorAssert is errorAssert:
+	// Error Made by BLDC_ASSERT()
+	SettingsStateMotorErrorLastErrorChangedMotorErrorErrorAssert
+)
+
+// settingsStateMotorErrorLastErrorChangedMotorErrorNames maps each
+// SettingsStateMotorErrorLastErrorChangedMotorError value to its name as
+// defined by the device SDK.
+var settingsStateMotorErrorLastErrorChangedMotorErrorNames = map[SettingsStateMotorErrorLastErrorChangedMotorError]string{
+	SettingsStateMotorErrorLastErrorChangedMotorErrorNoError:                "noError",
+	SettingsStateMotorErrorLastErrorChangedMotorErrorErrorEEPRom:            "errorEEPRom",
+	SettingsStateMotorErrorLastErrorChangedMotorErrorErrorMotorStalled:      "errorMotorStalled",
+	SettingsStateMotorErrorLastErrorChangedMotorErrorErrorPropellerSecurity: "errorPropellerSecurity",
+	SettingsStateMotorErrorLastErrorChangedMotorErrorErrorCommLost:          "errorCommLost",
+	SettingsStateMotorErrorLastErrorChangedMotorErrorErrorRCEmergencyStop:   "errorRCEmergencyStop",
+	SettingsStateMotorErrorLastErrorChangedMotorErrorErrorRealTime:          "errorRealTime",
+	SettingsStateMotorErrorLastErrorChangedMotorErrorErrorMotorSetting:      "errorMotorSetting",
+	SettingsStateMotorErrorLastErrorChangedMotorErrorErrorBatteryVoltage:    "errorBatteryVoltage",
+	SettingsStateMotorErrorLastErrorChangedMotorErrorErrorLipoCells:         "errorLipoCells",
+	SettingsStateMotorErrorLastErrorChangedMotorErrorErrorMOSFET:            "errorMOSFET",
+	SettingsStateMotorErrorLastErrorChangedMotorErrorErrorTemperature:       "errorTemperature",
+	SettingsStateMotorErrorLastErrorChangedMotorErrorErrorBootloader:        "errorBootloader",
+	SettingsStateMotorErrorLastErrorChangedMotorErrorErrorAssert:            "errorAssert",
+}
+
+func (e SettingsStateMotorErrorLastErrorChangedMotorError) String() string {
+	if name, ok := settingsStateMotorErrorLastErrorChangedMotorErrorNames[e]; ok {
+		return name
+	}
+	return fmt.Sprintf("SettingsStateMotorErrorLastErrorChangedMotorError(%d)", int32(e))
+}
+
+// IsValid returns true if the SettingsStateMotorErrorLastErrorChangedMotorError
+// is one defined by the device SDK.
+func (e SettingsStateMotorErrorLastErrorChangedMotorError) IsValid() bool {
+	_, ok := settingsStateMotorErrorLastErrorChangedMotorErrorNames[e]
+	return ok
+}
+
+// settingsStateHandlers is implemented by the SettingsState class, which
+// handles each of its commands. Settings state from product.
+type settingsStateHandlers interface {
+	productMotorVersionListChanged(args []interface{}) error
+	productGPSVersionChanged(args []interface{}) error
+	motorErrorStateChanged(args []interface{}) error
+	motorSoftwareVersionChanged(args []interface{}) error
+	motorFlightsStatusChanged(args []interface{}) error
+	motorErrorLastErrorChanged(args []interface{}) error
+	p7ID(args []interface{}) error
+	cPUID(args []interface{}) error
+}
+
+// settingsStateDefaults implements every handler of the SettingsState class by
+// logging that the command was received. The SettingsState class can embed it
+// and implement only the handlers for commands it acts upon.
+type settingsStateDefaults struct{}
+
+// settingsStateD2CCommands returns the commands of the SettingsState class,
+// each dispatched to the corresponding handler.
+func settingsStateD2CCommands(
+	handlers settingsStateHandlers,
+) []arcommands.D2CCommand {
+	return []arcommands.D2CCommand{
+		arcommands.NewD2CCommand(
+			0,
+			"ProductMotorVersionListChanged",
+			[]interface{}{
+				uint8(0), // motor_number,
+				"",       // type,
+				"",       // software,
+				"",       // hardware,
+			},
+			handlers.productMotorVersionListChanged,
+		),
+		arcommands.NewD2CCommand(
+			1,
+			"ProductGPSVersionChanged",
+			[]interface{}{
+				"", // software,
+				"", // hardware,
+			},
+			handlers.productGPSVersionChanged,
+		),
+		arcommands.NewD2CCommand(
+			2,
+			"MotorErrorStateChanged",
+			[]interface{}{
+				uint8(0), // motorIds,
+				SettingsStateMotorErrorStateChangedMotorError(0), // motorError,
+			},
+			handlers.motorErrorStateChanged,
+		),
+		arcommands.NewD2CCommand(
+			3,
+			"MotorSoftwareVersionChanged",
+			[]interface{}{
+				"", // version,
+			},
+			handlers.motorSoftwareVersionChanged,
+		),
+		arcommands.NewD2CCommand(
+			4,
+			"MotorFlightsStatusChanged",
+			[]interface{}{
+				uint16(0), // nbFlights,
+				uint16(0), // lastFlightDuration,
+				uint32(0), // totalFlightDuration,
+			},
+			handlers.motorFlightsStatusChanged,
+		),
+		arcommands.NewD2CCommand(
+			5,
+			"MotorErrorLastErrorChanged",
+			[]interface{}{
+				SettingsStateMotorErrorLastErrorChangedMotorError(0), // motorError,
+			},
+			handlers.motorErrorLastErrorChanged,
+		),
+		arcommands.NewD2CCommand(
+			6,
+			"P7ID",
+			[]interface{}{
+				"", // serialID,
+			},
+			handlers.p7ID,
+		),
+		arcommands.NewD2CCommand(
+			7,
+			"CPUID",
+			[]interface{}{
+				"", // id,
+			},
+			handlers.cPUID,
+		),
+	}
+}
+
+// Title: Motor version
+// Description: Motor version.
+// Support:
+// Result:
+//
+// Deprecated: This command is deprecated by the device SDK.
+func (settingsStateDefaults) productMotorVersionListChanged(
+	args []interface{},
+) error {
+	// motor_number := args[0].(uint8)
+	//   Product Motor number
+	// type := args[1].(string)
+	//   Product Motor type
+	// software := args[2].(string)
+	//   Product Motors software version
+	// hardware := args[3].(string)
+	//   Product Motors hardware version
+	log.Info("ardrone3.productMotorVersionListChanged() called")
+	return nil
+}
+
+// Title: GPS version
+// Description: GPS version.
+// Support: 0901;090c;090e
+// Triggered: at connection.
+// Result:
+func (settingsStateDefaults) productGPSVersionChanged(
+	args []interface{},
+) error {
+	// software := args[0].(string)
+	//   Product GPS software version
+	// hardware := args[1].(string)
+	//   Product GPS hardware version
+	log.Info("ardrone3.productGPSVersionChanged() called")
+	return nil
+}
+
+// Title: Motor error
+// Description: Motor error.\n This event is sent back to *noError* as soon as
+// the motor error disappear. To get the last motor error, see
+// [LastMotorError](#1-16-5)
+// Support: 0901;090c;090e
+// Triggered: when a motor error occurs.
+// Result:
+func (settingsStateDefaults) motorErrorStateChanged(args []interface{}) error {
+	// motorIds := args[0].(uint8)
+	//   Bit field for concerned motor. If bit 0 = 1, motor 1 is affected by this
+	//   error. Same with bit 1, 2 and 3. Motor 1: front left Motor 2: front right
+	//   Motor 3: back right Motor 4: back left
+	// motorError := args[1].(SettingsStateMotorErrorStateChangedMotorError)
+	//   Enumeration of the motor error
+	//   0: noError: No error detected
+	//   1: errorEEPRom: EEPROM access failure
+	//   2: errorMotorStalled: Motor stalled
+	//   3: errorPropellerSecurity: Propeller cutout security triggered
+	//   4: errorCommLost: Communication with motor failed by timeout
+	//   5: errorRCEmergencyStop: RC emergency stop
+	//   6: errorRealTime: Motor controler scheduler real-time out of bounds
+	//   7: errorMotorSetting: One or several incorrect values in motor settings
+	//   8: errorTemperature: Too hot or too cold Cypress temperature
+	//   9: errorBatteryVoltage: Battery voltage out of bounds
+	//   10: errorLipoCells: Incorrect number of LIPO cells
+	//   11: errorMOSFET: Defectuous MOSFET or broken motor phases
+	//   12: errorBootloader: Not use for BLDC but useful for HAL
+	//   13: errorAssert: Error Made by BLDC_ASSERT()
+	log.Info("ardrone3.motorErrorStateChanged() called")
+	return nil
+}
+
+// Title: Motor version
+// Description: Motor version.
+// Support:
+// Result:
+//
+// Deprecated: This command is deprecated by the device SDK.
+func (settingsStateDefaults) motorSoftwareVersionChanged(
+	args []interface{},
+) error {
+	// version := args[0].(string)
+	//   name of the version : dot separated fields (major version - minor version
+	//   - firmware type - nb motors handled). Firmware types : Release, Debug,
+	//   Alpha, Test-bench
+	log.Info("ardrone3.motorSoftwareVersionChanged() called")
+	return nil
+}
+
+// Title: Motor flight status
+// Description: Motor flight status.
+// Support: 0901;090c;090e
+// Triggered: at connection.
+// Result:
+func (settingsStateDefaults) motorFlightsStatusChanged(
+	args []interface{},
+) error {
+	// nbFlights := args[0].(uint16)
+	//   total number of flights
+	// lastFlightDuration := args[1].(uint16)
+	//   Duration of the last flight (in seconds)
+	// totalFlightDuration := args[2].(uint32)
+	//   Duration of all flights (in seconds)
+	log.Info("ardrone3.motorFlightsStatusChanged() called")
+	return nil
+}
+
+// Title: Last motor error
+// Description: Last motor error.\n This is a reminder of the last error. To
+// know if a motor error is currently happening, see [MotorError](#1-16-2).
+// Support: 0901;090c;090e
+// Triggered: at connection and when an error occurs.
+// Result:
+func (settingsStateDefaults) motorErrorLastErrorChanged(
+	args []interface{},
+) error {
+	// motorError := args[0].(SettingsStateMotorErrorLastErrorChangedMotorError)
+	//   Enumeration of the motor error
+	//   0: noError: No error detected
+	//   1: errorEEPRom: EEPROM access failure
+	//   2: errorMotorStalled: Motor stalled
+	//   3: errorPropellerSecurity: Propeller cutout security triggered
+	//   4: errorCommLost: Communication with motor failed by timeout
+	//   5: errorRCEmergencyStop: RC emergency stop
+	//   6: errorRealTime: Motor controler scheduler real-time out of bounds
+	//   7: errorMotorSetting: One or several incorrect values in motor settings
+	//   8: errorBatteryVoltage: Battery voltage out of bounds
+	//   9: errorLipoCells: Incorrect number of LIPO cells
+	//   10: errorMOSFET: Defectuous MOSFET or broken motor phases
+	//   11: errorTemperature: Too hot or too cold Cypress temperature
+	//   12: errorBootloader: Not use for BLDC but useful for HAL
+	//   13: errorAssert: Error Made by BLDC_ASSERT()
+	log.Info("ardrone3.motorErrorLastErrorChanged() called")
+	return nil
+}
+
+// Title: P7ID
+// Description: P7ID.
+// Support:
+// Result:
+//
+// Deprecated: This command is deprecated by the device SDK.
+func (settingsStateDefaults) p7ID(args []interface{}) error {
+	// serialID := args[0].(string)
+	//   Product P7ID
+	log.Info("ardrone3.p7ID() called")
+	return nil
+}
+
+// Title: Product CPU ID
+// Description: Product CPU ID.
+// Support: none
+// Result:
+func (settingsStateDefaults) cPUID(args []interface{}) error {
+	// id := args[0].(string)
+	//   Product main cpu id
+	log.Info("ardrone3.cPUID() called")
+	return nil
+}
+
+// PictureSettingsStatePictureFormatChangedType is a type for constants used as
+// the type argument of the PictureSettingsState PictureFormatChanged command.
+// The type of photo format.
+type PictureSettingsStatePictureFormatChangedType int32
+
+const (
+	// PictureSettingsStatePictureFormatChangedTypeRaw is raw: Take raw image
+	PictureSettingsStatePictureFormatChangedTypeRaw PictureSettingsStatePictureFormatChangedType = iota
+	// PictureSettingsStatePictureFormatChangedTypeJpeg is jpeg: Take a 4:3 jpeg
+	// photo
+	PictureSettingsStatePictureFormatChangedTypeJpeg
+	// PictureSettingsStatePictureFormatChangedTypeSnapshot is snapshot: Take a
+	// 16:9 snapshot from camera
+	PictureSettingsStatePictureFormatChangedTypeSnapshot
+	// PictureSettingsStatePictureFormatChangedTypeJpegFisheye is jpeg_fisheye:
+	// Take jpeg fisheye image only
+	PictureSettingsStatePictureFormatChangedTypeJpegFisheye
+)
+
+// pictureSettingsStatePictureFormatChangedTypeNames maps each
+// PictureSettingsStatePictureFormatChangedType value to its name as defined by
+// the device SDK.
+var pictureSettingsStatePictureFormatChangedTypeNames = map[PictureSettingsStatePictureFormatChangedType]string{
+	PictureSettingsStatePictureFormatChangedTypeRaw:         "raw",
+	PictureSettingsStatePictureFormatChangedTypeJpeg:        "jpeg",
+	PictureSettingsStatePictureFormatChangedTypeSnapshot:    "snapshot",
+	PictureSettingsStatePictureFormatChangedTypeJpegFisheye: "jpeg_fisheye",
+}
+
+func (e PictureSettingsStatePictureFormatChangedType) String() string {
+	if name, ok := pictureSettingsStatePictureFormatChangedTypeNames[e]; ok {
+		return name
+	}
+	return fmt.Sprintf("PictureSettingsStatePictureFormatChangedType(%d)", int32(e))
+}
+
+// IsValid returns true if the PictureSettingsStatePictureFormatChangedType is
+// one defined by the device SDK.
+func (e PictureSettingsStatePictureFormatChangedType) IsValid() bool {
+	_, ok := pictureSettingsStatePictureFormatChangedTypeNames[e]
+	return ok
+}
+
+// PictureSettingsStateAutoWhiteBalanceChangedType is a type for constants used
+// as the type argument of the PictureSettingsState AutoWhiteBalanceChanged
+// command. The type auto white balance.
+type PictureSettingsStateAutoWhiteBalanceChangedType int32
+
+const (
+	// PictureSettingsStateAutoWhiteBalanceChangedTypeAuto is auto: Auto guess of
+	// best white balance params
+	PictureSettingsStateAutoWhiteBalanceChangedTypeAuto PictureSettingsStateAutoWhiteBalanceChangedType = iota
+	// PictureSettingsStateAutoWhiteBalanceChangedTypeTungsten is tungsten:
+	// Tungsten white balance
+	PictureSettingsStateAutoWhiteBalanceChangedTypeTungsten
+	// PictureSettingsStateAutoWhiteBalanceChangedTypeDaylight is daylight:
+	// Daylight white balance
+	PictureSettingsStateAutoWhiteBalanceChangedTypeDaylight
+	// PictureSettingsStateAutoWhiteBalanceChangedTypeCloudy is cloudy: Cloudy
+	// white balance
+	PictureSettingsStateAutoWhiteBalanceChangedTypeCloudy
+	// PictureSettingsStateAutoWhiteBalanceChangedTypeCoolWhite is cool_white:
+	// White balance for a flash
+	PictureSettingsStateAutoWhiteBalanceChangedTypeCoolWhite
+)
+
+// pictureSettingsStateAutoWhiteBalanceChangedTypeNames maps each
+// PictureSettingsStateAutoWhiteBalanceChangedType value to its name as defined
+// by the device SDK.
+var pictureSettingsStateAutoWhiteBalanceChangedTypeNames = map[PictureSettingsStateAutoWhiteBalanceChangedType]string{
+	PictureSettingsStateAutoWhiteBalanceChangedTypeAuto:      "auto",
+	PictureSettingsStateAutoWhiteBalanceChangedTypeTungsten:  "tungsten",
+	PictureSettingsStateAutoWhiteBalanceChangedTypeDaylight:  "daylight",
+	PictureSettingsStateAutoWhiteBalanceChangedTypeCloudy:    "cloudy",
+	PictureSettingsStateAutoWhiteBalanceChangedTypeCoolWhite: "cool_white",
+}
+
+func (e PictureSettingsStateAutoWhiteBalanceChangedType) String() string {
+	if name, ok := pictureSettingsStateAutoWhiteBalanceChangedTypeNames[e]; ok {
+		return name
+	}
+	return fmt.Sprintf("PictureSettingsStateAutoWhiteBalanceChangedType(%d)", int32(e))
+}
+
+// IsValid returns true if the PictureSettingsStateAutoWhiteBalanceChangedType
+// is one defined by the device SDK.
+func (e PictureSettingsStateAutoWhiteBalanceChangedType) IsValid() bool {
+	_, ok := pictureSettingsStateAutoWhiteBalanceChangedTypeNames[e]
+	return ok
+}
+
+// PictureSettingsStateVideoStabilizationModeChangedMode is a type for constants
+// used as the mode argument of the PictureSettingsState
+// VideoStabilizationModeChanged command. Video stabilization mode.
+type PictureSettingsStateVideoStabilizationModeChangedMode int32
+
+const (
+	// PictureSettingsStateVideoStabilizationModeChangedModeRollPitch is
+	// roll_pitch: Video flat on roll and pitch
+	PictureSettingsStateVideoStabilizationModeChangedModeRollPitch PictureSettingsStateVideoStabilizationModeChangedMode = iota
+	// PictureSettingsStateVideoStabilizationModeChangedModePitch is pitch: Video
+	// flat on pitch only
+	PictureSettingsStateVideoStabilizationModeChangedModePitch
+	// PictureSettingsStateVideoStabilizationModeChangedModeRoll is roll: Video
+	// flat on roll only
+	PictureSettingsStateVideoStabilizationModeChangedModeRoll
+	// PictureSettingsStateVideoStabilizationModeChangedModeNone is none: Video
+	// follows drone angles
+	PictureSettingsStateVideoStabilizationModeChangedModeNone
+)
+
+// pictureSettingsStateVideoStabilizationModeChangedModeNames maps each
+// PictureSettingsStateVideoStabilizationModeChangedMode value to its name as
+// defined by the device SDK.
+var pictureSettingsStateVideoStabilizationModeChangedModeNames = map[PictureSettingsStateVideoStabilizationModeChangedMode]string{
+	PictureSettingsStateVideoStabilizationModeChangedModeRollPitch: "roll_pitch",
+	PictureSettingsStateVideoStabilizationModeChangedModePitch:     "pitch",
+	PictureSettingsStateVideoStabilizationModeChangedModeRoll:      "roll",
+	PictureSettingsStateVideoStabilizationModeChangedModeNone:      "none",
+}
+
+func (e PictureSettingsStateVideoStabilizationModeChangedMode) String() string {
+	if name, ok := pictureSettingsStateVideoStabilizationModeChangedModeNames[e]; ok {
+		return name
+	}
+	return fmt.Sprintf("PictureSettingsStateVideoStabilizationModeChangedMode(%d)", int32(e))
+}
+
+// IsValid returns true if the
+// PictureSettingsStateVideoStabilizationModeChangedMode is one defined by the
+// device SDK.
+func (e PictureSettingsStateVideoStabilizationModeChangedMode) IsValid() bool {
+	_, ok := pictureSettingsStateVideoStabilizationModeChangedModeNames[e]
+	return ok
+}
+
+// PictureSettingsStateVideoRecordingModeChangedMode is a type for constants
+// used as the mode argument of the PictureSettingsState
+// VideoRecordingModeChanged command. Video recording mode.
+type PictureSettingsStateVideoRecordingModeChangedMode int32
+
+const (
+	// PictureSettingsStateVideoRecordingModeChangedModeQuality is quality:
+	// Maximize recording quality.
+	PictureSettingsStateVideoRecordingModeChangedModeQuality PictureSettingsStateVideoRecordingModeChangedMode = iota
+	// PictureSettingsStateVideoRecordingModeChangedModeTime is time: Maximize
+	// recording time.
+	PictureSettingsStateVideoRecordingModeChangedModeTime
+)
+
+// pictureSettingsStateVideoRecordingModeChangedModeNames maps each
+// PictureSettingsStateVideoRecordingModeChangedMode value to its name as
+// defined by the device SDK.
+var pictureSettingsStateVideoRecordingModeChangedModeNames = map[PictureSettingsStateVideoRecordingModeChangedMode]string{
+	PictureSettingsStateVideoRecordingModeChangedModeQuality: "quality",
+	PictureSettingsStateVideoRecordingModeChangedModeTime:    "time",
+}
+
+func (e PictureSettingsStateVideoRecordingModeChangedMode) String() string {
+	if name, ok := pictureSettingsStateVideoRecordingModeChangedModeNames[e]; ok {
+		return name
+	}
+	return fmt.Sprintf("PictureSettingsStateVideoRecordingModeChangedMode(%d)", int32(e))
+}
+
+// IsValid returns true if the PictureSettingsStateVideoRecordingModeChangedMode
+// is one defined by the device SDK.
+func (e PictureSettingsStateVideoRecordingModeChangedMode) IsValid() bool {
+	_, ok := pictureSettingsStateVideoRecordingModeChangedModeNames[e]
+	return ok
+}
+
+// PictureSettingsStateVideoFramerateChangedFramerate is a type for constants
+// used as the framerate argument of the PictureSettingsState
+// VideoFramerateChanged command. Video framerate.
+type PictureSettingsStateVideoFramerateChangedFramerate int32
+
+const (
+	// PictureSettingsStateVideoFramerateChangedFramerate24Fps is 24_FPS: 23.976
+	// frames per second.
+	PictureSettingsStateVideoFramerateChangedFramerate24Fps PictureSettingsStateVideoFramerateChangedFramerate = iota
+	// PictureSettingsStateVideoFramerateChangedFramerate25Fps is 25_FPS: 25 frames
+	// per second.
+	PictureSettingsStateVideoFramerateChangedFramerate25Fps
+	// PictureSettingsStateVideoFramerateChangedFramerate30Fps is 30_FPS: 29.97
+	// frames per second.
+	PictureSettingsStateVideoFramerateChangedFramerate30Fps
+)
+
+// pictureSettingsStateVideoFramerateChangedFramerateNames maps each
+// PictureSettingsStateVideoFramerateChangedFramerate value to its name as
+// defined by the device SDK.
+var pictureSettingsStateVideoFramerateChangedFramerateNames = map[PictureSettingsStateVideoFramerateChangedFramerate]string{
+	PictureSettingsStateVideoFramerateChangedFramerate24Fps: "24_FPS",
+	PictureSettingsStateVideoFramerateChangedFramerate25Fps: "25_FPS",
+	PictureSettingsStateVideoFramerateChangedFramerate30Fps: "30_FPS",
+}
+
+func (e PictureSettingsStateVideoFramerateChangedFramerate) String() string {
+	if name, ok := pictureSettingsStateVideoFramerateChangedFramerateNames[e]; ok {
+		return name
+	}
+	return fmt.Sprintf("PictureSettingsStateVideoFramerateChangedFramerate(%d)", int32(e))
+}
+
+// IsValid returns true if the
+// PictureSettingsStateVideoFramerateChangedFramerate is one defined by the
+// device SDK.
+func (e PictureSettingsStateVideoFramerateChangedFramerate) IsValid() bool {
+	_, ok := pictureSettingsStateVideoFramerateChangedFramerateNames[e]
+	return ok
+}
+
+// PictureSettingsStateVideoResolutionsChangedType is a type for constants used
+// as the type argument of the PictureSettingsState VideoResolutionsChanged
+// command. Video resolution type.
+type PictureSettingsStateVideoResolutionsChangedType int32
+
+const (
+	// PictureSettingsStateVideoResolutionsChangedTypeRec1080Stream480 is
+	// rec1080_stream480: 1080p recording, 480p streaming.
+	PictureSettingsStateVideoResolutionsChangedTypeRec1080Stream480 PictureSettingsStateVideoResolutionsChangedType = iota
+	// PictureSettingsStateVideoResolutionsChangedTypeRec720Stream720 is
+	// rec720_stream720: 720p recording, 720p streaming.
+	PictureSettingsStateVideoResolutionsChangedTypeRec720Stream720
+)
+
+// pictureSettingsStateVideoResolutionsChangedTypeNames maps each
+// PictureSettingsStateVideoResolutionsChangedType value to its name as defined
+// by the device SDK.
+var pictureSettingsStateVideoResolutionsChangedTypeNames = map[PictureSettingsStateVideoResolutionsChangedType]string{
+	PictureSettingsStateVideoResolutionsChangedTypeRec1080Stream480: "rec1080_stream480",
+	PictureSettingsStateVideoResolutionsChangedTypeRec720Stream720:  "rec720_stream720",
+}
+
+func (e PictureSettingsStateVideoResolutionsChangedType) String() string {
+	if name, ok := pictureSettingsStateVideoResolutionsChangedTypeNames[e]; ok {
+		return name
+	}
+	return fmt.Sprintf("PictureSettingsStateVideoResolutionsChangedType(%d)", int32(e))
+}
+
+// IsValid returns true if the PictureSettingsStateVideoResolutionsChangedType
+// is one defined by the device SDK.
+func (e PictureSettingsStateVideoResolutionsChangedType) IsValid() bool {
+	_, ok := pictureSettingsStateVideoResolutionsChangedTypeNames[e]
+	return ok
+}
+
+// pictureSettingsStateHandlers is implemented by the PictureSettingsState
+// class, which handles each of its commands. Photo settings state from product.
+type pictureSettingsStateHandlers interface {
+	pictureFormatChanged(args []interface{}) error
+	autoWhiteBalanceChanged(args []interface{}) error
+	expositionChanged(args []interface{}) error
+	saturationChanged(args []interface{}) error
+	timelapseChanged(args []interface{}) error
+	videoAutorecordChanged(args []interface{}) error
+	videoStabilizationModeChanged(args []interface{}) error
+	videoRecordingModeChanged(args []interface{}) error
+	videoFramerateChanged(args []interface{}) error
+	videoResolutionsChanged(args []interface{}) error
+}
+
+// pictureSettingsStateDefaults implements every handler of the
+// PictureSettingsState class by logging that the command was received. The
+// PictureSettingsState class can embed it and implement only the handlers for
+// commands it acts upon.
+type pictureSettingsStateDefaults struct{}
+
+// pictureSettingsStateD2CCommands returns the commands of the
+// PictureSettingsState class, each dispatched to the corresponding handler.
+func pictureSettingsStateD2CCommands(
+	handlers pictureSettingsStateHandlers,
+) []arcommands.D2CCommand {
+	return []arcommands.D2CCommand{
+		arcommands.NewD2CCommand(
+			0,
+			"PictureFormatChanged",
+			[]interface{}{
+				PictureSettingsStatePictureFormatChangedType(0), // type,
+			},
+			handlers.pictureFormatChanged,
+		),
+		arcommands.NewD2CCommand(
+			1,
+			"AutoWhiteBalanceChanged",
+			[]interface{}{
+				PictureSettingsStateAutoWhiteBalanceChangedType(0), // type,
+			},
+			handlers.autoWhiteBalanceChanged,
+		),
+		arcommands.NewD2CCommand(
+			2,
+			"ExpositionChanged",
+			[]interface{}{
+				float32(0), // value,
+				float32(0), // min,
+				float32(0), // max,
+			},
+			handlers.expositionChanged,
+		),
+		arcommands.NewD2CCommand(
+			3,
+			"SaturationChanged",
+			[]interface{}{
+				float32(0), // value,
+				float32(0), // min,
+				float32(0), // max,
+			},
+			handlers.saturationChanged,
+		),
+		arcommands.NewD2CCommand(
+			4,
+			"TimelapseChanged",
+			[]interface{}{
+				uint8(0),   // enabled,
+				float32(0), // interval,
+				float32(0), // minInterval,
+				float32(0), // maxInterval,
+			},
+			handlers.timelapseChanged,
+		),
+		arcommands.NewD2CCommand(
+			5,
+			"VideoAutorecordChanged",
+			[]interface{}{
+				uint8(0), // enabled,
+				uint8(0), // mass_storage_id,
+			},
+			handlers.videoAutorecordChanged,
+		),
+		arcommands.NewD2CCommand(
+			6,
+			"VideoStabilizationModeChanged",
+			[]interface{}{
+				PictureSettingsStateVideoStabilizationModeChangedMode(0), // mode,
+			},
+			handlers.videoStabilizationModeChanged,
+		),
+		arcommands.NewD2CCommand(
+			7,
+			"VideoRecordingModeChanged",
+			[]interface{}{
+				PictureSettingsStateVideoRecordingModeChangedMode(0), // mode,
+			},
+			handlers.videoRecordingModeChanged,
+		),
+		arcommands.NewD2CCommand(
+			8,
+			"VideoFramerateChanged",
+			[]interface{}{
+				PictureSettingsStateVideoFramerateChangedFramerate(0), // framerate,
+			},
+			handlers.videoFramerateChanged,
+		),
+		arcommands.NewD2CCommand(
+			9,
+			"VideoResolutionsChanged",
+			[]interface{}{
+				PictureSettingsStateVideoResolutionsChangedType(0), // type,
+			},
+			handlers.videoResolutionsChanged,
+		),
+	}
+}
+
+// Title: Picture format
+// Description: Picture format.
+// Support: 0901;090c;090e
+// Triggered: by [SetPictureFormat](#1-19-0).
+// Result:
+func (pictureSettingsStateDefaults) pictureFormatChanged(
+	args []interface{},
+) error {
+	// type := args[0].(PictureSettingsStatePictureFormatChangedType)
+	//   The type of photo format
+	//   0: raw: Take raw image
+	//   1: jpeg: Take a 4:3 jpeg photo
+	//   2: snapshot: Take a 16:9 snapshot from camera
+	//   3: jpeg_fisheye: Take jpeg fisheye image only
+	log.Info("ardrone3.pictureFormatChanged() called")
+	return nil
+}
+
+// Title: White balance mode
+// Description: White balance mode.
+// Support: 0901;090c;090e
+// Triggered: by [SetWhiteBalanceMode](#1-19-1).
+// Result:
+func (pictureSettingsStateDefaults) autoWhiteBalanceChanged(
+	args []interface{},
+) error {
+	// type := args[0].(PictureSettingsStateAutoWhiteBalanceChangedType)
+	//   The type auto white balance
+	//   0: auto: Auto guess of best white balance params
+	//   1: tungsten: Tungsten white balance
+	//   2: daylight: Daylight white balance
+	//   3: cloudy: Cloudy white balance
+	//   4: cool_white: White balance for a flash
+	log.Info("ardrone3.autoWhiteBalanceChanged() called")
+	return nil
+}
+
+// Title: Image exposure
+// Description: Image exposure.
+// Support: 0901;090c;090e
+// Triggered: by [SetImageExposure](#1-19-2).
+// Result:
+func (pictureSettingsStateDefaults) expositionChanged(
+	args []interface{},
+) error {
+	// value := args[0].(float32)
+	//   Exposure value
+	// min := args[1].(float32)
+	//   Min exposure value
+	// max := args[2].(float32)
+	//   Max exposure value
+	log.Info("ardrone3.expositionChanged() called")
+	return nil
+}
+
+// Title: Image saturation
+// Description: Image saturation.
+// Support: 0901;090c;090e
+// Triggered: by [SetImageSaturation](#1-19-3).
+// Result:
+func (pictureSettingsStateDefaults) saturationChanged(
+	args []interface{},
+) error {
+	// value := args[0].(float32)
+	//   Saturation value
+	// min := args[1].(float32)
+	//   Min saturation value
+	// max := args[2].(float32)
+	//   Max saturation value
+	log.Info("ardrone3.saturationChanged() called")
+	return nil
+}
+
+// Title: Timelapse mode
+// Description: Timelapse mode.
+// Support: 0901;090c;090e
+// Triggered: by [SetTimelapseMode](#1-19-4).
+// Result:
+func (pictureSettingsStateDefaults) timelapseChanged(args []interface{}) error {
+	// enabled := args[0].(uint8)
+	//   1 if timelapse is enabled, 0 otherwise
+	// interval := args[1].(float32)
+	//   interval in seconds for taking pictures
+	// minInterval := args[2].(float32)
+	//   Minimal interval for taking pictures
+	// maxInterval := args[3].(float32)
+	//   Maximal interval for taking pictures
+	log.Info("ardrone3.timelapseChanged() called")
+	return nil
+}
+
+// Title: Video Autorecord mode
+// Description: Video Autorecord mode.
+// Support: 0901;090c;090e
+// Triggered: by [SetVideoAutorecordMode](#1-19-5).
+// Result:
+func (pictureSettingsStateDefaults) videoAutorecordChanged(
+	args []interface{},
+) error {
+	// enabled := args[0].(uint8)
+	//   1 if video autorecord is enabled, 0 otherwise
+	// mass_storage_id := args[1].(uint8)
+	//   Mass storage id for the taken video
+	log.Info("ardrone3.videoAutorecordChanged() called")
+	return nil
+}
+
+// Title: Video stabilization mode
+// Description: Video stabilization mode.
+// Support: 0901:3.4.0;090c:3.4.0;090e
+// Triggered: by [SetVideoStabilizationMode](#1-19-6).
+// Result:
+func (pictureSettingsStateDefaults) videoStabilizationModeChanged(
+	args []interface{},
+) error {
+	// mode := args[0].(PictureSettingsStateVideoStabilizationModeChangedMode)
+	//   Video stabilization mode
+	//   0: roll_pitch: Video flat on roll and pitch
+	//   1: pitch: Video flat on pitch only
+	//   2: roll: Video flat on roll only
+	//   3: none: Video follows drone angles
+	log.Info("ardrone3.videoStabilizationModeChanged() called")
+	return nil
+}
+
+// Title: Video recording mode
+// Description: Video recording mode.
+// Support: 0901:3.4.0;090c:3.4.0;090e
+// Triggered: by [SetVideoRecordingMode](#1-19-7).
+// Result:
+func (pictureSettingsStateDefaults) videoRecordingModeChanged(
+	args []interface{},
+) error {
+	// mode := args[0].(PictureSettingsStateVideoRecordingModeChangedMode)
+	//   Video recording mode
+	//   0: quality: Maximize recording quality.
+	//   1: time: Maximize recording time.
+	log.Info("ardrone3.videoRecordingModeChanged() called")
+	return nil
+}
+
+// Title: Video framerate
+// Description: Video framerate.
+// Support: 0901:3.4.0;090c:3.4.0;090e
+// Triggered: by [SetVideoFramerateMode](#1-19-8).
+// Result:
+func (pictureSettingsStateDefaults) videoFramerateChanged(
+	args []interface{},
+) error {
+	// framerate := args[0].(PictureSettingsStateVideoFramerateChangedFramerate)
+	//   Video framerate
+	//   0: 24_FPS: 23.976 frames per second.
+	//   1: 25_FPS: 25 frames per second.
+	//   2: 30_FPS: 29.97 frames per second.
+	log.Info("ardrone3.videoFramerateChanged() called")
+	return nil
+}
+
+// Title: Video resolutions
+// Description: Video resolutions.\n This event informs about the recording AND
+// streaming resolutions.
+// Support: 0901:3.4.0;090c:3.4.0;090e
+// Triggered: by [SetVideResolutions](#1-19-9).
+// Result:
+func (pictureSettingsStateDefaults) videoResolutionsChanged(
+	args []interface{},
+) error {
+	// type := args[0].(PictureSettingsStateVideoResolutionsChangedType)
+	//   Video resolution type.
+	//   0: rec1080_stream480: 1080p recording, 480p streaming.
+	//   1: rec720_stream720: 720p recording, 720p streaming.
+	log.Info("ardrone3.videoResolutionsChanged() called")
+	return nil
+}
+
+// sendMediaStreamingVideoEnable sends the MediaStreaming VideoEnable command to
+// the device.
+// Title: Enable/disable video streaming
+// Description: Enable/disable video streaming.
+// Support: 0901;090c;090e
+// Result: The video stream is started or stopped.\n Then, event
+// [VideoStreamState](#1-22-0) is triggered.
+func sendMediaStreamingVideoEnable(
+	c2dCommandClient arcommands.C2DCommandClient,
+	enable uint8,
+) error {
+	// enable:
+	//   1 to enable, 0 to disable.
+	if err := c2dCommandClient.SendCommand(
+		arcommands.C2DAckBufferID,
+		1,  // ardrone3
+		21, // MediaStreaming
+		0,  // VideoEnable
+		enable,
+	); err != nil {
+		return errors.Wrap(err, "error sending VideoEnable command")
+	}
+	return nil
+}
+
+// sendMediaStreamingVideoEnableWithReceipt is like
+// sendMediaStreamingVideoEnable, but also returns an arnetwork.Receipt that is
+// resolved with the outcome of delivering the command.
+func sendMediaStreamingVideoEnableWithReceipt(
+	c2dCommandClient arcommands.C2DCommandClient,
+	enable uint8,
+) (*arnetwork.Receipt, error) {
+	receipt, err := c2dCommandClient.SendCommandWithReceipt(
+		arcommands.C2DAckBufferID,
+		1,  // ardrone3
+		21, // MediaStreaming
+		0,  // VideoEnable
+		enable,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "error sending VideoEnable command")
+	}
+	return receipt, nil
+}
+
+// MediaStreamingStateVideoEnableChangedEnabled is a type for constants used as
+// the enabled argument of the MediaStreamingState VideoEnableChanged command.
+// Current video streaming status.
+type MediaStreamingStateVideoEnableChangedEnabled int32
+
+const (
+	// MediaStreamingStateVideoEnableChangedEnabledEnabled is enabled: Video
+	// streaming is enabled.
+	MediaStreamingStateVideoEnableChangedEnabledEnabled MediaStreamingStateVideoEnableChangedEnabled = iota
+	// MediaStreamingStateVideoEnableChangedEnabledDisabled is disabled: Video
+	// streaming is disabled.
+	MediaStreamingStateVideoEnableChangedEnabledDisabled
+	// MediaStreamingStateVideoEnableChangedEnabledError is error: Video streaming
+	// failed to start.
+	MediaStreamingStateVideoEnableChangedEnabledError
+)
+
+// mediaStreamingStateVideoEnableChangedEnabledNames maps each
+// MediaStreamingStateVideoEnableChangedEnabled value to its name as defined by
+// the device SDK.
+var mediaStreamingStateVideoEnableChangedEnabledNames = map[MediaStreamingStateVideoEnableChangedEnabled]string{
+	MediaStreamingStateVideoEnableChangedEnabledEnabled:  "enabled",
+	MediaStreamingStateVideoEnableChangedEnabledDisabled: "disabled",
+	MediaStreamingStateVideoEnableChangedEnabledError:    "error",
+}
+
+func (e MediaStreamingStateVideoEnableChangedEnabled) String() string {
+	if name, ok := mediaStreamingStateVideoEnableChangedEnabledNames[e]; ok {
+		return name
+	}
+	return fmt.Sprintf("MediaStreamingStateVideoEnableChangedEnabled(%d)", int32(e))
+}
+
+// IsValid returns true if the MediaStreamingStateVideoEnableChangedEnabled is
+// one defined by the device SDK.
+func (e MediaStreamingStateVideoEnableChangedEnabled) IsValid() bool {
+	_, ok := mediaStreamingStateVideoEnableChangedEnabledNames[e]
+	return ok
+}
+
+// MediaStreamingStateVideoStreamModeChangedMode is a type for constants used as
+// the mode argument of the MediaStreamingState VideoStreamModeChanged command.
+// stream mode.
+type MediaStreamingStateVideoStreamModeChangedMode int32
+
+const (
+	// MediaStreamingStateVideoStreamModeChangedModeLowLatency is low_latency:
+	// Minimize latency with average reliability (best for piloting).
+	MediaStreamingStateVideoStreamModeChangedModeLowLatency MediaStreamingStateVideoStreamModeChangedMode = iota
+	// MediaStreamingStateVideoStreamModeChangedModeHighReliability is
+	// high_reliability: Maximize the reliability with an average latency (best
+	// when streaming quality is important but not the latency).
+	MediaStreamingStateVideoStreamModeChangedModeHighReliability
+	// MediaStreamingStateVideoStreamModeChangedModeHighReliabilityLowFramerate is
+	// high_reliability_low_framerate: Maximize the reliability using a framerate
+	// decimation with an average latency (best when streaming quality is important
+	// but not the latency).
+	MediaStreamingStateVideoStreamModeChangedModeHighReliabilityLowFramerate
+)
+
+// mediaStreamingStateVideoStreamModeChangedModeNames maps each
+// MediaStreamingStateVideoStreamModeChangedMode value to its name as defined by
+// the device SDK.
+var mediaStreamingStateVideoStreamModeChangedModeNames = map[MediaStreamingStateVideoStreamModeChangedMode]string{
+	MediaStreamingStateVideoStreamModeChangedModeLowLatency:                  "low_latency",
+	MediaStreamingStateVideoStreamModeChangedModeHighReliability:             "high_reliability",
+	MediaStreamingStateVideoStreamModeChangedModeHighReliabilityLowFramerate: "high_reliability_low_framerate",
+}
+
+func (e MediaStreamingStateVideoStreamModeChangedMode) String() string {
+	if name, ok := mediaStreamingStateVideoStreamModeChangedModeNames[e]; ok {
+		return name
+	}
+	return fmt.Sprintf("MediaStreamingStateVideoStreamModeChangedMode(%d)", int32(e))
+}
+
+// IsValid returns true if the MediaStreamingStateVideoStreamModeChangedMode is
+// one defined by the device SDK.
+func (e MediaStreamingStateVideoStreamModeChangedMode) IsValid() bool {
+	_, ok := mediaStreamingStateVideoStreamModeChangedModeNames[e]
+	return ok
+}
+
+// mediaStreamingStateHandlers is implemented by the MediaStreamingState class,
+// which handles each of its commands. Media streaming status.
+type mediaStreamingStateHandlers interface {
+	videoEnableChanged(args []interface{}) error
+	videoStreamModeChanged(args []interface{}) error
+}
+
+// mediaStreamingStateDefaults implements every handler of the
+// MediaStreamingState class by logging that the command was received. The
+// MediaStreamingState class can embed it and implement only the handlers for
+// commands it acts upon.
+type mediaStreamingStateDefaults struct{}
+
+// mediaStreamingStateD2CCommands returns the commands of the
+// MediaStreamingState class, each dispatched to the corresponding handler.
+func mediaStreamingStateD2CCommands(
+	handlers mediaStreamingStateHandlers,
+) []arcommands.D2CCommand {
+	return []arcommands.D2CCommand{
+		arcommands.NewD2CCommand(
+			0,
+			"VideoEnableChanged",
+			[]interface{}{
+				MediaStreamingStateVideoEnableChangedEnabled(0), // enabled,
+			},
+			handlers.videoEnableChanged,
+		),
+		arcommands.NewD2CCommand(
+			1,
+			"VideoStreamModeChanged",
+			[]interface{}{
+				MediaStreamingStateVideoStreamModeChangedMode(0), // mode,
+			},
+			handlers.videoStreamModeChanged,
+		),
+	}
+}
+
+// Title: Video stream state
+// Description: Video stream state.
+// Support: 0901;090c;090e
+// Triggered: by [EnableOrDisableVideoStream](#1-21-0).
+// Result:
+func (mediaStreamingStateDefaults) videoEnableChanged(
+	args []interface{},
+) error {
+	// enabled := args[0].(MediaStreamingStateVideoEnableChangedEnabled)
+	//   Current video streaming status.
+	//   0: enabled: Video streaming is enabled.
+	//   1: disabled: Video streaming is disabled.
+	//   2: error: Video streaming failed to start.
+	log.Info("ardrone3.videoEnableChanged() called")
+	return nil
+}
+
+// Title: Video stream mode state
+// Description: Current video streaming mode.
+// Support: 0901;090c;090e
+// Triggered: by [SetVideoStreamMode](#1-21-1).
+// Result:
+func (mediaStreamingStateDefaults) videoStreamModeChanged(
+	args []interface{},
+) error {
+	// mode := args[0].(MediaStreamingStateVideoStreamModeChangedMode)
+	//   stream mode
+	//   0: low_latency: Minimize latency with average reliability (best for
+	//      piloting).
+	//   1: high_reliability: Maximize the reliability with an average latency
+	//      (best when streaming quality is important but not the latency).
+	//   2: high_reliability_low_framerate: Maximize the reliability using a
+	//      framerate decimation with an average latency (best when streaming
+	//      quality is important but not the latency).
+	log.Info("ardrone3.videoStreamModeChanged() called")
+	return nil
+}
+
+// GPSSettingsStateGPSUpdateStateChangedState is a type for constants used as
+// the state argument of the GPSSettingsState GPSUpdateStateChanged command. The
+// state of the gps update.
+type GPSSettingsStateGPSUpdateStateChangedState int32
+
+const (
+	// GPSSettingsStateGPSUpdateStateChangedStateUpdated is updated: Drone GPS
+	// update succeed
+	GPSSettingsStateGPSUpdateStateChangedStateUpdated GPSSettingsStateGPSUpdateStateChangedState = iota
+	// GPSSettingsStateGPSUpdateStateChangedStateInProgress is inProgress: Drone
+	// GPS update In progress
+	GPSSettingsStateGPSUpdateStateChangedStateInProgress
+	// GPSSettingsStateGPSUpdateStateChangedStateFailed is failed: Drone GPS update
+	// failed
+	GPSSettingsStateGPSUpdateStateChangedStateFailed
+)
+
+// gpsSettingsStateGPSUpdateStateChangedStateNames maps each
+// GPSSettingsStateGPSUpdateStateChangedState value to its name as defined by
+// the device SDK.
+var gpsSettingsStateGPSUpdateStateChangedStateNames = map[GPSSettingsStateGPSUpdateStateChangedState]string{
+	GPSSettingsStateGPSUpdateStateChangedStateUpdated:    "updated",
+	GPSSettingsStateGPSUpdateStateChangedStateInProgress: "inProgress",
+	GPSSettingsStateGPSUpdateStateChangedStateFailed:     "failed",
+}
+
+func (e GPSSettingsStateGPSUpdateStateChangedState) String() string {
+	if name, ok := gpsSettingsStateGPSUpdateStateChangedStateNames[e]; ok {
+		return name
+	}
+	return fmt.Sprintf("GPSSettingsStateGPSUpdateStateChangedState(%d)", int32(e))
+}
+
+// IsValid returns true if the GPSSettingsStateGPSUpdateStateChangedState is one
+// defined by the device SDK.
+func (e GPSSettingsStateGPSUpdateStateChangedState) IsValid() bool {
+	_, ok := gpsSettingsStateGPSUpdateStateChangedStateNames[e]
+	return ok
+}
+
+// GPSSettingsStateHomeTypeChangedType is a type for constants used as the type
+// argument of the GPSSettingsState HomeTypeChanged command. The type of the
+// home position.
+type GPSSettingsStateHomeTypeChangedType int32
+
+const (
+	// GPSSettingsStateHomeTypeChangedTypeTAKEOFF is TAKEOFF: The drone will try to
+	// return to the take off position
+	GPSSettingsStateHomeTypeChangedTypeTAKEOFF GPSSettingsStateHomeTypeChangedType = iota
+	// GPSSettingsStateHomeTypeChangedTypePILOT is PILOT: The drone will try to
+	// return to the pilot position
+	GPSSettingsStateHomeTypeChangedTypePILOT
+	// GPSSettingsStateHomeTypeChangedTypeFOLLOWEE is FOLLOWEE: The drone will try
+	// to return to the target of the current (or last) follow me
+	GPSSettingsStateHomeTypeChangedTypeFOLLOWEE
+)
+
+// gpsSettingsStateHomeTypeChangedTypeNames maps each
+// GPSSettingsStateHomeTypeChangedType value to its name as defined by the
+// device SDK.
+var gpsSettingsStateHomeTypeChangedTypeNames = map[GPSSettingsStateHomeTypeChangedType]string{
+	GPSSettingsStateHomeTypeChangedTypeTAKEOFF:  "TAKEOFF",
+	GPSSettingsStateHomeTypeChangedTypePILOT:    "PILOT",
+	GPSSettingsStateHomeTypeChangedTypeFOLLOWEE: "FOLLOWEE",
+}
+
+func (e GPSSettingsStateHomeTypeChangedType) String() string {
+	if name, ok := gpsSettingsStateHomeTypeChangedTypeNames[e]; ok {
+		return name
+	}
+	return fmt.Sprintf("GPSSettingsStateHomeTypeChangedType(%d)", int32(e))
+}
+
+// IsValid returns true if the GPSSettingsStateHomeTypeChangedType is one
+// defined by the device SDK.
+func (e GPSSettingsStateHomeTypeChangedType) IsValid() bool {
+	_, ok := gpsSettingsStateHomeTypeChangedTypeNames[e]
+	return ok
+}
+
+// gpsSettingsStateHandlers is implemented by the GPSSettingsState class, which
+// handles each of its commands. GPS settings state.
+type gpsSettingsStateHandlers interface {
+	homeChanged(args []interface{}) error
+	resetHomeChanged(args []interface{}) error
+	gPSFixStateChanged(args []interface{}) error
+	gPSUpdateStateChanged(args []interface{}) error
+	homeTypeChanged(args []interface{}) error
+	returnHomeDelayChanged(args []interface{}) error
+	geofenceCenterChanged(args []interface{}) error
+}
+
+// gpsSettingsStateDefaults implements every handler of the GPSSettingsState
+// class by logging that the command was received. The GPSSettingsState class
+// can embed it and implement only the handlers for commands it acts upon.
+type gpsSettingsStateDefaults struct{}
+
+// gpsSettingsStateD2CCommands returns the commands of the GPSSettingsState
+// class, each dispatched to the corresponding handler.
+func gpsSettingsStateD2CCommands(
+	handlers gpsSettingsStateHandlers,
+) []arcommands.D2CCommand {
+	return []arcommands.D2CCommand{
+		arcommands.NewD2CCommand(
+			0,
+			"HomeChanged",
+			[]interface{}{
+				float64(0), // latitude,
+				float64(0), // longitude,
+				float64(0), // altitude,
+			},
+			handlers.homeChanged,
+		),
+		arcommands.NewD2CCommand(
+			1,
+			"ResetHomeChanged",
+			[]interface{}{
+				float64(0), // latitude,
+				float64(0), // longitude,
+				float64(0), // altitude,
+			},
+			handlers.resetHomeChanged,
+		),
+		arcommands.NewD2CCommand(
+			2,
+			"GPSFixStateChanged",
+			[]interface{}{
+				uint8(0), // fixed,
+			},
+			handlers.gPSFixStateChanged,
+		),
+		arcommands.NewD2CCommand(
+			3,
+			"GPSUpdateStateChanged",
+			[]interface{}{
+				GPSSettingsStateGPSUpdateStateChangedState(0), // state,
+			},
+			handlers.gPSUpdateStateChanged,
+		),
+		arcommands.NewD2CCommand(
+			4,
+			"HomeTypeChanged",
+			[]interface{}{
+				GPSSettingsStateHomeTypeChangedType(0), // type,
+			},
+			handlers.homeTypeChanged,
+		),
+		arcommands.NewD2CCommand(
+			5,
+			"ReturnHomeDelayChanged",
+			[]interface{}{
+				uint16(0), // delay,
+			},
+			handlers.returnHomeDelayChanged,
+		),
+		arcommands.NewD2CCommand(
+			6,
+			"GeofenceCenterChanged",
+			[]interface{}{
+				float64(0), // latitude,
+				float64(0), // longitude,
+			},
+			handlers.geofenceCenterChanged,
+		),
+	}
+}
+
+// Title: Home location
+// Description: Home location.
+// Support: 0901;090c;090e
+// Triggered: when [HomeType](#1-31-2) changes. Or by [SetHomeLocation](#1-23-2)
+// when [HomeType](#1-31-2) is Pilot. Or regularly after
+// [SetControllerGPS](#140-1) when [HomeType](#1-31-2) is FollowMeTarget. Or at
+// take off [HomeType](#1-31-2) is Takeoff. Or when the first fix occurs and the
+// [HomeType](#1-31-2) is FirstFix.
+// Result:
+func (gpsSettingsStateDefaults) homeChanged(args []interface{}) error {
+	// latitude := args[0].(float64)
+	//   Home latitude in decimal degrees
+	// longitude := args[1].(float64)
+	//   Home longitude in decimal degrees
+	// altitude := args[2].(float64)
+	//   Home altitude in meters
+	log.Info("ardrone3.homeChanged() called")
+	return nil
+}
+
+// Title: Home location has been reset
+// Description: Home location has been reset.
+// Support: 0901;090c
+// Triggered: by [ResetHomeLocation](#1-23-1).
+// Result:
+//
+// Deprecated: This command is deprecated by the device SDK.
+func (gpsSettingsStateDefaults) resetHomeChanged(args []interface{}) error {
+	// latitude := args[0].(float64)
+	//   Home latitude in decimal degrees
+	// longitude := args[1].(float64)
+	//   Home longitude in decimal degrees
+	// altitude := args[2].(float64)
+	//   Home altitude in meters
+	log.Info("ardrone3.resetHomeChanged() called")
+	return nil
+}
+
+// Title: Gps fix info
+// Description: Gps fix info.
+// Support: 0901;090c;090e
+// Triggered: on change.
+// Result:
+func (gpsSettingsStateDefaults) gPSFixStateChanged(args []interface{}) error {
+	// fixed := args[0].(uint8)
+	//   1 if gps on drone is fixed, 0 otherwise
+	log.Info("ardrone3.gPSFixStateChanged() called")
+	return nil
+}
+
+// Title: Gps update state
+// Description: Gps update state.
+// Support: 0901;090c;090e
+// Triggered: on change.
+// Result:
+//
+// Deprecated: This command is deprecated by the device SDK.
+func (gpsSettingsStateDefaults) gPSUpdateStateChanged(
+	args []interface{},
+) error {
+	// state := args[0].(GPSSettingsStateGPSUpdateStateChangedState)
+	//   The state of the gps update
+	//   0: updated: Drone GPS update succeed
+	//   1: inProgress: Drone GPS update In progress
+	//   2: failed: Drone GPS update failed
+	log.Info("ardrone3.gPSUpdateStateChanged() called")
+	return nil
+}
+
+// Title: Preferred home type
+// Description: User preference for the home type.\n See [HomeType](#1-31-2) to
+// get the drone actual home type.
+// Support: 0901;090c;090e
+// Triggered: by [SetPreferredHomeType](#1-23-3).
+// Result:
+func (gpsSettingsStateDefaults) homeTypeChanged(args []interface{}) error {
+	// type := args[0].(GPSSettingsStateHomeTypeChangedType)
+	//   The type of the home position
+	//   0: TAKEOFF: The drone will try to return to the take off position
+	//   1: PILOT: The drone will try to return to the pilot position
+	//   2: FOLLOWEE: The drone will try to return to the target of the current (or
+	//      last) follow me
+	log.Info("ardrone3.homeTypeChanged() called")
+	return nil
+}
+
+// Title: Return home delay
+// Description: Return home trigger delay. This delay represents the time after
+// which the return home is automatically triggered after a disconnection.
+// Support: 0901;090c;090e
+// Triggered: by [SetReturnHomeDelay](#1-23-4).
+// Result:
+func (gpsSettingsStateDefaults) returnHomeDelayChanged(
+	args []interface{},
+) error {
+	// delay := args[0].(uint16)
+	//   Delay in second
+	log.Info("ardrone3.returnHomeDelayChanged() called")
+	return nil
+}
+
+// Title: Geofence center
+// Description: Geofence center location. This location represents the center of
+// the geofence zone. This is updated at a maximum frequency of 1 Hz.
+// Support:
+// Triggered: when [HomeChanged](#1-24-0) and when [GpsLocationChanged](#1-4-9)
+// before takeoff.
+// Result:
+func (gpsSettingsStateDefaults) geofenceCenterChanged(
+	args []interface{},
+) error {
+	// latitude := args[0].(float64)
+	//   GPS latitude in decimal degrees
+	// longitude := args[1].(float64)
+	//   GPS longitude in decimal degrees
+	log.Info("ardrone3.geofenceCenterChanged() called")
+	return nil
+}
+
+// cameraStateHandlers is implemented by the CameraState class, which handles
+// each of its commands. Camera state.
+type cameraStateHandlers interface {
+	orientation(args []interface{}) error
+	defaultCameraOrientation(args []interface{}) error
+	orientationV2(args []interface{}) error
+	defaultCameraOrientationV2(args []interface{}) error
+	velocityRange(args []interface{}) error
+}
+
+// cameraStateDefaults implements every handler of the CameraState class by
+// logging that the command was received. The CameraState class can embed it and
+// implement only the handlers for commands it acts upon.
+type cameraStateDefaults struct{}
+
+// cameraStateD2CCommands returns the commands of the CameraState class, each
+// dispatched to the corresponding handler.
+func cameraStateD2CCommands(
+	handlers cameraStateHandlers,
+) []arcommands.D2CCommand {
+	return []arcommands.D2CCommand{
+		arcommands.NewD2CCommand(
+			0,
+			"Orientation",
+			[]interface{}{
+				int8(0), // tilt,
+				int8(0), // pan,
+			},
+			handlers.orientation,
+		),
+		arcommands.NewD2CCommand(
+			1,
+			"defaultCameraOrientation",
+			[]interface{}{
+				int8(0), // tilt,
+				int8(0), // pan,
+			},
+			handlers.defaultCameraOrientation,
+		),
+		arcommands.NewD2CCommand(
+			2,
+			"OrientationV2",
+			[]interface{}{
+				float32(0), // tilt,
+				float32(0), // pan,
+			},
+			handlers.orientationV2,
+		),
+		arcommands.NewD2CCommand(
+			3,
+			"defaultCameraOrientationV2",
+			[]interface{}{
+				float32(0), // tilt,
+				float32(0), // pan,
+			},
+			handlers.defaultCameraOrientationV2,
+		),
+		arcommands.NewD2CCommand(
+			4,
+			"VelocityRange",
+			[]interface{}{
+				float32(0), // max_tilt,
+				float32(0), // max_pan,
+			},
+			handlers.velocityRange,
+		),
+	}
+}
+
+// Title: Camera orientation
+// Description: Camera orientation.
+// Support: 0901;090c;090e
+// Triggered: by [SetCameraOrientation](#1-1-0).
+// Result:
+//
+// Deprecated: This command is deprecated by the device SDK.
+func (cameraStateDefaults) orientation(args []interface{}) error {
+	// tilt := args[0].(int8)
+	//   Tilt camera consign for the drone [-100;100]
+	// pan := args[1].(int8)
+	//   Pan camera consign for the drone [-100;100]
+	log.Info("ardrone3.orientation() called")
+	return nil
+}
+
+// Title: Orientation of the camera center
+// Description: Orientation of the center of the camera.\n This is the value to
+// send when you want to center the camera.
+// Support: 0901;090c;090e
+// Triggered: at connection.
+// Result:
+//
+// Deprecated: This command is deprecated by the device SDK.
+func (cameraStateDefaults) defaultCameraOrientation(args []interface{}) error {
+	// tilt := args[0].(int8)
+	//   Tilt value (in degree)
+	// pan := args[1].(int8)
+	//   Pan value (in degree)
+	log.Info("ardrone3.defaultCameraOrientation() called")
+	return nil
+}
+
+// Title: Camera orientation
+// Description: Camera orientation with float arguments.
+// Support: 0901;090c;090e
+// Triggered: by [SetCameraOrientationV2](#1-1-1)
+// Result:
+func (cameraStateDefaults) orientationV2(args []interface{}) error {
+	// tilt := args[0].(float32)
+	//   Tilt camera consign for the drone [deg]
+	// pan := args[1].(float32)
+	//   Pan camera consign for the drone [deg]
+	log.Info("ardrone3.orientationV2() called")
+	return nil
+}
+
+// Title: Orientation of the camera center
+// Description: Orientation of the center of the camera.\n This is the value to
+// send when you want to center the camera.
+// Support: 0901;090c;090e
+// Triggered: at connection.
+// Result:
+func (cameraStateDefaults) defaultCameraOrientationV2(
+	args []interface{},
+) error {
+	// tilt := args[0].(float32)
+	//   Tilt value [deg]
+	// pan := args[1].(float32)
+	//   Pan value [deg]
+	log.Info("ardrone3.defaultCameraOrientationV2() called")
+	return nil
+}
+
+// Title: Camera velocity range
+// Description: Camera Orientation velocity limits.
+// Support: 0901;090c;090e
+// Triggered: at connection.
+// Result:
+func (cameraStateDefaults) velocityRange(args []interface{}) error {
+	// max_tilt := args[0].(float32)
+	//   Absolute max tilt velocity [deg/s]
+	// max_pan := args[1].(float32)
+	//   Absolute max pan velocity [deg/s]
+	log.Info("ardrone3.velocityRange() called")
+	return nil
+}
+
+// AntiflickeringStateElectricFrequencyChangedFrequency is a type for constants
+// used as the frequency argument of the AntiflickeringState
+// electricFrequencyChanged command. Type of the electric frequency.
+type AntiflickeringStateElectricFrequencyChangedFrequency int32
+
+const (
+	// AntiflickeringStateElectricFrequencyChangedFrequencyFiftyHertz is
+	// fiftyHertz: Electric frequency of the country is 50hz
+	AntiflickeringStateElectricFrequencyChangedFrequencyFiftyHertz AntiflickeringStateElectricFrequencyChangedFrequency = iota
+	// AntiflickeringStateElectricFrequencyChangedFrequencySixtyHertz is
+	// sixtyHertz: Electric frequency of the country is 60hz
+	AntiflickeringStateElectricFrequencyChangedFrequencySixtyHertz
+)
+
+// antiflickeringStateElectricFrequencyChangedFrequencyNames maps each
+// AntiflickeringStateElectricFrequencyChangedFrequency value to its name as
+// defined by the device SDK.
+var antiflickeringStateElectricFrequencyChangedFrequencyNames = map[AntiflickeringStateElectricFrequencyChangedFrequency]string{
+	AntiflickeringStateElectricFrequencyChangedFrequencyFiftyHertz: "fiftyHertz",
+	AntiflickeringStateElectricFrequencyChangedFrequencySixtyHertz: "sixtyHertz",
+}
+
+func (e AntiflickeringStateElectricFrequencyChangedFrequency) String() string {
+	if name, ok := antiflickeringStateElectricFrequencyChangedFrequencyNames[e]; ok {
+		return name
+	}
+	return fmt.Sprintf("AntiflickeringStateElectricFrequencyChangedFrequency(%d)", int32(e))
+}
+
+// IsValid returns true if the
+// AntiflickeringStateElectricFrequencyChangedFrequency is one defined by the
+// device SDK.
+func (e AntiflickeringStateElectricFrequencyChangedFrequency) IsValid() bool {
+	_, ok := antiflickeringStateElectricFrequencyChangedFrequencyNames[e]
+	return ok
+}
+
+// AntiflickeringStateModeChangedMode is a type for constants used as the mode
+// argument of the AntiflickeringState modeChanged command. Mode of the anti
+// flickering functionnality.
+type AntiflickeringStateModeChangedMode int32
+
+const (
+	// AntiflickeringStateModeChangedModeAuto is auto: Anti flickering based on the
+	// electric frequency previously sent
+	AntiflickeringStateModeChangedModeAuto AntiflickeringStateModeChangedMode = iota
+	// AntiflickeringStateModeChangedModeFixedFiftyHertz is FixedFiftyHertz: Anti
+	// flickering based on a fixed frequency of 50Hz
+	AntiflickeringStateModeChangedModeFixedFiftyHertz
+	// AntiflickeringStateModeChangedModeFixedSixtyHertz is FixedSixtyHertz: Anti
+	// flickering based on a fixed frequency of 60Hz
+	AntiflickeringStateModeChangedModeFixedSixtyHertz
+)
+
+// antiflickeringStateModeChangedModeNames maps each
+// AntiflickeringStateModeChangedMode value to its name as defined by the device
+// SDK.
+var antiflickeringStateModeChangedModeNames = map[AntiflickeringStateModeChangedMode]string{
+	AntiflickeringStateModeChangedModeAuto:            "auto",
+	AntiflickeringStateModeChangedModeFixedFiftyHertz: "FixedFiftyHertz",
+	AntiflickeringStateModeChangedModeFixedSixtyHertz: "FixedSixtyHertz",
+}
+
+func (e AntiflickeringStateModeChangedMode) String() string {
+	if name, ok := antiflickeringStateModeChangedModeNames[e]; ok {
+		return name
+	}
+	return fmt.Sprintf("AntiflickeringStateModeChangedMode(%d)", int32(e))
+}
+
+// IsValid returns true if the AntiflickeringStateModeChangedMode is one defined
+// by the device SDK.
+func (e AntiflickeringStateModeChangedMode) IsValid() bool {
+	_, ok := antiflickeringStateModeChangedModeNames[e]
+	return ok
+}
+
+// antiflickeringStateHandlers is implemented by the AntiflickeringState class,
+// which handles each of its commands. Anti-flickering related states.
+type antiflickeringStateHandlers interface {
+	electricFrequencyChanged(args []interface{}) error
+	modeChanged(args []interface{}) error
+}
+
+// antiflickeringStateDefaults implements every handler of the
+// AntiflickeringState class by logging that the command was received. The
+// AntiflickeringState class can embed it and implement only the handlers for
+// commands it acts upon.
+type antiflickeringStateDefaults struct{}
+
+// antiflickeringStateD2CCommands returns the commands of the
+// AntiflickeringState class, each dispatched to the corresponding handler.
+func antiflickeringStateD2CCommands(
+	handlers antiflickeringStateHandlers,
+) []arcommands.D2CCommand {
+	return []arcommands.D2CCommand{
+		arcommands.NewD2CCommand(
+			0,
+			"electricFrequencyChanged",
+			[]interface{}{
+				AntiflickeringStateElectricFrequencyChangedFrequency(0), // frequency,
+			},
+			handlers.electricFrequencyChanged,
+		),
+		arcommands.NewD2CCommand(
+			1,
+			"modeChanged",
+			[]interface{}{
+				AntiflickeringStateModeChangedMode(0), // mode,
+			},
+			handlers.modeChanged,
+		),
+	}
+}
+
+// Title: Electric frequency
+// Description: Electric frequency.\n This piece of information is used for the
+// antiflickering when the [AntiflickeringMode](#1-30-1) is set to *auto*.
+// Support: 0901;090c
+// Triggered: by [SetElectricFrequency](#1-29-0).
+// Result:
+func (antiflickeringStateDefaults) electricFrequencyChanged(
+	args []interface{},
+) error {
+	// frequency := args[0].(AntiflickeringStateElectricFrequencyChangedFrequency)
+	//   Type of the electric frequency
+	//   0: fiftyHertz: Electric frequency of the country is 50hz
+	//   1: sixtyHertz: Electric frequency of the country is 60hz
+	log.Info("ardrone3.electricFrequencyChanged() called")
+	return nil
+}
+
+// Title: Antiflickering mode
+// Description: Antiflickering mode.
+// Support: 0901;090c
+// Triggered: by [SetAntiflickeringMode](#1-29-1).
+// Result:
+func (antiflickeringStateDefaults) modeChanged(args []interface{}) error {
+	// mode := args[0].(AntiflickeringStateModeChangedMode)
+	//   Mode of the anti flickering functionnality
+	//   0: auto: Anti flickering based on the electric frequency previously sent
+	//   1: FixedFiftyHertz: Anti flickering based on a fixed frequency of 50Hz
+	//   2: FixedSixtyHertz: Anti flickering based on a fixed frequency of 60Hz
+	log.Info("ardrone3.modeChanged() called")
+	return nil
+}
+
+// GPSStateHomeTypeAvailabilityChangedType is a type for constants used as the
+// type argument of the GPSState HomeTypeAvailabilityChanged command. The type
+// of the return home.
+type GPSStateHomeTypeAvailabilityChangedType int32
+
+const (
+	// GPSStateHomeTypeAvailabilityChangedTypeTAKEOFF is TAKEOFF: The drone has
+	// enough information to return to the take off position
+	GPSStateHomeTypeAvailabilityChangedTypeTAKEOFF GPSStateHomeTypeAvailabilityChangedType = iota
+	// GPSStateHomeTypeAvailabilityChangedTypePILOT is PILOT: The drone has enough
+	// information to return to the pilot position
+	GPSStateHomeTypeAvailabilityChangedTypePILOT
+	// GPSStateHomeTypeAvailabilityChangedTypeFirstFix is FIRST_FIX: The drone has
+	// not enough information, it will return to the first GPS fix
+	GPSStateHomeTypeAvailabilityChangedTypeFirstFix
+	// GPSStateHomeTypeAvailabilityChangedTypeFOLLOWEE is FOLLOWEE: The drone has
+	// enough information to return to the target of the current (or last) follow
+	// me
+	GPSStateHomeTypeAvailabilityChangedTypeFOLLOWEE
+)
+
+// gpsStateHomeTypeAvailabilityChangedTypeNames maps each
+// GPSStateHomeTypeAvailabilityChangedType value to its name as defined by the
+// device SDK.
+var gpsStateHomeTypeAvailabilityChangedTypeNames = map[GPSStateHomeTypeAvailabilityChangedType]string{
+	GPSStateHomeTypeAvailabilityChangedTypeTAKEOFF:  "TAKEOFF",
+	GPSStateHomeTypeAvailabilityChangedTypePILOT:    "PILOT",
+	GPSStateHomeTypeAvailabilityChangedTypeFirstFix: "FIRST_FIX",
+	GPSStateHomeTypeAvailabilityChangedTypeFOLLOWEE: "FOLLOWEE",
+}
+
+func (e GPSStateHomeTypeAvailabilityChangedType) String() string {
+	if name, ok := gpsStateHomeTypeAvailabilityChangedTypeNames[e]; ok {
+		return name
+	}
+	return fmt.Sprintf("GPSStateHomeTypeAvailabilityChangedType(%d)", int32(e))
+}
+
+// IsValid returns true if the GPSStateHomeTypeAvailabilityChangedType is one
+// defined by the device SDK.
+func (e GPSStateHomeTypeAvailabilityChangedType) IsValid() bool {
+	_, ok := gpsStateHomeTypeAvailabilityChangedTypeNames[e]
+	return ok
+}
+
+// GPSStateHomeTypeChosenChangedType is a type for constants used as the type
+// argument of the GPSState HomeTypeChosenChanged command. The type of the
+// return home chosen.
+type GPSStateHomeTypeChosenChangedType int32
+
+const (
+	// GPSStateHomeTypeChosenChangedTypeTAKEOFF is TAKEOFF: The drone will return
+	// to the take off position
+	GPSStateHomeTypeChosenChangedTypeTAKEOFF GPSStateHomeTypeChosenChangedType = iota
+	// GPSStateHomeTypeChosenChangedTypePILOT is PILOT: The drone will return to
+	// the pilot position In this case, the drone will use the position given by
+	// ARDrone3-SendControllerGPS
+	GPSStateHomeTypeChosenChangedTypePILOT
+	// GPSStateHomeTypeChosenChangedTypeFirstFix is FIRST_FIX: The drone has not
+	// enough information, it will return to the first GPS fix
+	GPSStateHomeTypeChosenChangedTypeFirstFix
+	// GPSStateHomeTypeChosenChangedTypeFOLLOWEE is FOLLOWEE: The drone will return
+	// to the target of the current (or last) follow me In this case, the drone
+	// will use the position of the target of the followMe (given by
+	// ControllerInfo-GPS)
+	GPSStateHomeTypeChosenChangedTypeFOLLOWEE
+)
+
+// gpsStateHomeTypeChosenChangedTypeNames maps each
+// GPSStateHomeTypeChosenChangedType value to its name as defined by the device
+// SDK.
+var gpsStateHomeTypeChosenChangedTypeNames = map[GPSStateHomeTypeChosenChangedType]string{
+	GPSStateHomeTypeChosenChangedTypeTAKEOFF:  "TAKEOFF",
+	GPSStateHomeTypeChosenChangedTypePILOT:    "PILOT",
+	GPSStateHomeTypeChosenChangedTypeFirstFix: "FIRST_FIX",
+	GPSStateHomeTypeChosenChangedTypeFOLLOWEE: "FOLLOWEE",
+}
+
+func (e GPSStateHomeTypeChosenChangedType) String() string {
+	if name, ok := gpsStateHomeTypeChosenChangedTypeNames[e]; ok {
+		return name
+	}
+	return fmt.Sprintf("GPSStateHomeTypeChosenChangedType(%d)", int32(e))
+}
+
+// IsValid returns true if the GPSStateHomeTypeChosenChangedType is one defined
+// by the device SDK.
+func (e GPSStateHomeTypeChosenChangedType) IsValid() bool {
+	_, ok := gpsStateHomeTypeChosenChangedTypeNames[e]
+	return ok
+}
+
+// gpsStateHandlers is implemented by the GPSState class, which handles each of
+// its commands. GPS related States.
+type gpsStateHandlers interface {
+	numberOfSatellitesChanged(args []interface{}) error
+	homeTypeAvailabilityChanged(args []interface{}) error
+	homeTypeChosenChanged(args []interface{}) error
+}
+
+// gpsStateDefaults implements every handler of the GPSState class by logging
+// that the command was received. The GPSState class can embed it and implement
+// only the handlers for commands it acts upon.
+type gpsStateDefaults struct{}
+
+// gpsStateD2CCommands returns the commands of the GPSState class, each
+// dispatched to the corresponding handler.
+func gpsStateD2CCommands(
+	handlers gpsStateHandlers,
+) []arcommands.D2CCommand {
+	return []arcommands.D2CCommand{
+		arcommands.NewD2CCommand(
+			0,
+			"NumberOfSatellitesChanged",
+			[]interface{}{
+				uint8(0), // numberOfSatellites,
+			},
+			handlers.numberOfSatellitesChanged,
+		),
+		arcommands.NewD2CCommand(
+			1,
+			"HomeTypeAvailabilityChanged",
+			[]interface{}{
+				GPSStateHomeTypeAvailabilityChangedType(0), // type,
+				uint8(0), // available,
+			},
+			handlers.homeTypeAvailabilityChanged,
+		),
+		arcommands.NewD2CCommand(
+			2,
+			"HomeTypeChosenChanged",
+			[]interface{}{
+				GPSStateHomeTypeChosenChangedType(0), // type,
+			},
+			handlers.homeTypeChosenChanged,
+		),
+	}
+}
+
+// Title: Number of GPS satellites
+// Description: Number of GPS satellites.
+// Support: 0901;090c;090e
+// Triggered: on change.
+// Result:
+func (gpsStateDefaults) numberOfSatellitesChanged(args []interface{}) error {
+	// numberOfSatellites := args[0].(uint8)
+	//   The number of satellite
+	log.Info("ardrone3.numberOfSatellitesChanged() called")
+	return nil
+}
+
+// Title: Home type availability
+// Description: Home type availability.
+// Support: 0901;090c;090e
+// Triggered: when the availability of, at least, one type changes.\n This might
+// be due to controller position availability, gps fix before take off or other
+// reason.
+// Result:
+func (gpsStateDefaults) homeTypeAvailabilityChanged(args []interface{}) error {
+	// type := args[0].(GPSStateHomeTypeAvailabilityChangedType)
+	//   The type of the return home
+	//   0: TAKEOFF: The drone has enough information to return to the take off
+	//      position
+	//   1: PILOT: The drone has enough information to return to the pilot position
+	//   2: FIRST_FIX: The drone has not enough information, it will return to the
+	//      first GPS fix
+	//   3: FOLLOWEE: The drone has enough information to return to the target of
+	//      the current (or last) follow me
+	// available := args[1].(uint8)
+	//   1 if this type is available, 0 otherwise
+	log.Info("ardrone3.homeTypeAvailabilityChanged() called")
+	return nil
+}
+
+// Title: Home type
+// Description: Home type.\n This choice is made by the drone, according to the
+// [PreferredHomeType](#1-24-4) and the [HomeTypeAvailability](#1-31-1). The
+// drone will choose the type matching with the user preference only if this
+// type is available. If not, it will chose a type in this order:\n FOLLOWEE ;
+// TAKEOFF ; PILOT ; FIRST_FIX
+// Support: 0901;090c;090e
+// Triggered: when the return home type chosen by the drone changes.\n This
+// might be produced by a user preference triggered by
+// [SetPreferedHomeType](#1-23-3) or by a change in the
+// [HomeTypesAvailabilityChanged](#1-31-1).
+// Result:
+func (gpsStateDefaults) homeTypeChosenChanged(args []interface{}) error {
+	// type := args[0].(GPSStateHomeTypeChosenChangedType)
+	//   The type of the return home chosen
+	//   0: TAKEOFF: The drone will return to the take off position
+	//   1: PILOT: The drone will return to the pilot position In this case, the
+	//      drone will use the position given by ARDrone3-SendControllerGPS
+	//   2: FIRST_FIX: The drone has not enough information, it will return to the
+	//      first GPS fix
+	//   3: FOLLOWEE: The drone will return to the target of the current (or last)
+	//      follow me In this case, the drone will use the position of the target
+	//      of the followMe (given by ControllerInfo-GPS)
+	log.Info("ardrone3.homeTypeChosenChanged() called")
+	return nil
+}
+
+// PilotingEventMoveByEndError is a type for constants used as the error
+// argument of the PilotingEvent moveByEnd command. Error to explain the event.
+type PilotingEventMoveByEndError int32
+
+const (
+	// PilotingEventMoveByEndErrorOk is ok: No Error ; The relative displacement
+	PilotingEventMoveByEndErrorOk PilotingEventMoveByEndError = iota
+	// PilotingEventMoveByEndErrorUnknown is unknown: Unknown generic error
+	PilotingEventMoveByEndErrorUnknown
+	// PilotingEventMoveByEndErrorBusy is busy: The Device is busy ; command moveBy
+	// ignored
+	PilotingEventMoveByEndErrorBusy
+	// PilotingEventMoveByEndErrorNotAvailable is notAvailable: Command moveBy is
+	// not available ; command moveBy ignored
+	PilotingEventMoveByEndErrorNotAvailable
+	// PilotingEventMoveByEndErrorInterrupted is interrupted: Command moveBy
+	// interrupted
+	PilotingEventMoveByEndErrorInterrupted
+)
+
+// pilotingEventMoveByEndErrorNames maps each PilotingEventMoveByEndError value
+// to its name as defined by the device SDK.
+var pilotingEventMoveByEndErrorNames = map[PilotingEventMoveByEndError]string{
+	PilotingEventMoveByEndErrorOk:           "ok",
+	PilotingEventMoveByEndErrorUnknown:      "unknown",
+	PilotingEventMoveByEndErrorBusy:         "busy",
+	PilotingEventMoveByEndErrorNotAvailable: "notAvailable",
+	PilotingEventMoveByEndErrorInterrupted:  "interrupted",
+}
+
+func (e PilotingEventMoveByEndError) String() string {
+	if name, ok := pilotingEventMoveByEndErrorNames[e]; ok {
+		return name
+	}
+	return fmt.Sprintf("PilotingEventMoveByEndError(%d)", int32(e))
+}
+
+// IsValid returns true if the PilotingEventMoveByEndError is one defined by the
+// device SDK.
+func (e PilotingEventMoveByEndError) IsValid() bool {
+	_, ok := pilotingEventMoveByEndErrorNames[e]
+	return ok
+}
+
+// pilotingEventHandlers is implemented by the PilotingEvent class, which
+// handles each of its commands. Events of Piloting.
+type pilotingEventHandlers interface {
+	moveByEnd(args []interface{}) error
+}
+
+// pilotingEventDefaults implements every handler of the PilotingEvent class by
+// logging that the command was received. The PilotingEvent class can embed it
+// and implement only the handlers for commands it acts upon.
+type pilotingEventDefaults struct{}
+
+// pilotingEventD2CCommands returns the commands of the PilotingEvent class,
+// each dispatched to the corresponding handler.
+func pilotingEventD2CCommands(
+	handlers pilotingEventHandlers,
+) []arcommands.D2CCommand {
+	return []arcommands.D2CCommand{
+		arcommands.NewD2CCommand(
+			0,
+			"moveByEnd",
+			[]interface{}{
+				float32(0),                     // dX,
+				float32(0),                     // dY,
+				float32(0),                     // dZ,
+				float32(0),                     // dPsi,
+				PilotingEventMoveByEndError(0), // error,
+			},
+			handlers.moveByEnd,
+		),
+	}
+}
+
+// Title: Relative move ended
+// Description: Relative move ended.\n Informs about the move that the drone
+// managed to do and why it stopped.
+// Support: 0901:3.3.0;090c:3.3.0
+// Triggered: when the drone reaches its target or when it is interrupted by
+// another [moveBy command](#1-0-7) or when an error occurs.
+// Result:
+func (pilotingEventDefaults) moveByEnd(args []interface{}) error {
+	// dX := args[0].(float32)
+	//   Distance traveled along the front axis [m]
+	// dY := args[1].(float32)
+	//   Distance traveled along the right axis [m]
+	// dZ := args[2].(float32)
+	//   Distance traveled along the down axis [m]
+	// dPsi := args[3].(float32)
+	//   Applied angle on heading [rad]
+	// error := args[4].(PilotingEventMoveByEndError)
+	//   Error to explain the event
+	//   0: ok: No Error ; The relative displacement
+	//   1: unknown: Unknown generic error
+	//   2: busy: The Device is busy ; command moveBy ignored
+	//   3: notAvailable: Command moveBy is not available ; command moveBy ignored
+	//   4: interrupted: Command moveBy interrupted
+	log.Info("ardrone3.moveByEnd() called")
+	return nil
+}
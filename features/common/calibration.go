@@ -0,0 +1,228 @@
+package common
+
+import (
+	"context"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/krancour/go-parrot/features/subscriptions"
+	"github.com/krancour/go-parrot/protocols/arcommands"
+)
+
+// Calibration commands
+
+// progressChSize is the buffer size of the channel over which magnetometer
+// calibration progress is delivered.
+const progressChSize = 10
+
+// MagnetoCalibrationResult is a type for constants used to indicate the outcome
+// of a magnetometer calibration process.
+type MagnetoCalibrationResult int
+
+const (
+	// MagnetoCalibrationResultSucceeded indicates the magnetometer was
+	// successfully calibrated on all axes.
+	MagnetoCalibrationResultSucceeded MagnetoCalibrationResult = 0
+	// MagnetoCalibrationResultFailed indicates the device reported that the
+	// calibration process failed.
+	MagnetoCalibrationResultFailed MagnetoCalibrationResult = 1
+	// MagnetoCalibrationResultAborted indicates the calibration process was
+	// stopped before the magnetometer was calibrated on all axes, either by the
+	// device or because the context given to CalibrateMagnetometer(...) was
+	// done.
+	MagnetoCalibrationResultAborted MagnetoCalibrationResult = 2
+)
+
+// MagnetoCalibrationProgress represents the progress of a magnetometer
+// calibration process at a moment in time.
+type MagnetoCalibrationProgress struct {
+	// XAxisCalibrated indicates whether calibration on the x axis (roll) is done
+	XAxisCalibrated bool
+	// YAxisCalibrated indicates whether calibration on the y axis (pitch) is
+	// done
+	YAxisCalibrated bool
+	// ZAxisCalibrated indicates whether calibration on the z axis (yaw) is done
+	ZAxisCalibrated bool
+	// AxisToCalibrate is the axis the user should currently rotate the device
+	// around
+	AxisToCalibrate MagnetoCalibrationAxis
+}
+
+// Calibration exposes commands related to calibrating the device's sensors.
+type Calibration interface {
+	// StartOrAbortMagnetoCalib asks the device to start (true) or abort (false)
+	// the magnetometer calibration process. The device reports progress using
+	// the events exposed by CalibrationState. Most callers will find
+	// CalibrateMagnetometer() more convenient.
+	StartOrAbortMagnetoCalib(start bool) error
+	// CalibrateMagnetometer starts the magnetometer calibration process and
+	// returns a MagnetoCalibration that follows it through to completion. Any
+	// state from a previous calibration process is discarded. If the given
+	// context is done before the outcome of the process is known (e.g. because
+	// it timed out or because the device stopped reporting progress), the
+	// device is asked to abort the process and MagnetoCalibrationResultAborted
+	// is delivered.
+	CalibrateMagnetometer(ctx context.Context) (MagnetoCalibration, error)
+}
+
+// MagnetoCalibration is the interface for following a single magnetometer
+// calibration process.
+type MagnetoCalibration interface {
+	// ProgressCh returns a channel over which progress is delivered each time it
+	// changes. The same channel is returned on every call. Delivery is
+	// non-blocking; if the channel's buffer is full, progress updates are
+	// dropped. The channel is closed once the process has ended.
+	ProgressCh() <-chan MagnetoCalibrationProgress
+	// ResultCh returns a channel over which the outcome of the process is
+	// delivered exactly once. The same channel is returned on every call. The
+	// channel is closed after the result is delivered.
+	ResultCh() <-chan MagnetoCalibrationResult
+	// Abort asks the device to abort the calibration process. If the device
+	// complies, MagnetoCalibrationResultAborted is delivered over the channel
+	// returned by ResultCh().
+	Abort() error
+}
+
+type calibration struct {
+	c2dCommandClient arcommands.C2DCommandClient
+	calibrationState *calibrationState
+}
+
+type magnetoCalibration struct {
+	calibration  *calibration
+	subscription subscriptions.Subscription
+	progressCh   chan MagnetoCalibrationProgress
+	resultCh     chan MagnetoCalibrationResult
+}
+
+func (c *calibration) ID() uint8 {
+	return 13
+}
+
+func (c *calibration) Name() string {
+	return "Calibration"
+}
+
+func (c *calibration) StartOrAbortMagnetoCalib(start bool) error {
+	log.WithField(
+		"start", start,
+	).Debug("sending common calibration StartOrAbortMagnetoCalib command")
+	var calibrate uint8
+	if start {
+		calibrate = 1
+	}
+	return sendCalibrationStartOrAbortMagnetoCalib(c.c2dCommandClient, calibrate)
+}
+
+func (c *calibration) CalibrateMagnetometer(
+	ctx context.Context,
+) (MagnetoCalibration, error) {
+	c.calibrationState.resetMagnetoCalibration()
+	// Subscribe before starting so that no state changes are missed
+	subscription, err := c.calibrationState.Subscribe()
+	if err != nil {
+		return nil, err
+	}
+	m := &magnetoCalibration{
+		calibration:  c,
+		subscription: subscription,
+		progressCh:   make(chan MagnetoCalibrationProgress, progressChSize),
+		resultCh:     make(chan MagnetoCalibrationResult, 1),
+	}
+	if err := c.StartOrAbortMagnetoCalib(true); err != nil {
+		m.subscription.Unsubscribe()
+		return nil, err
+	}
+	go m.follow(ctx)
+	return m, nil
+}
+
+func (m *magnetoCalibration) ProgressCh() <-chan MagnetoCalibrationProgress {
+	return m.progressCh
+}
+
+func (m *magnetoCalibration) ResultCh() <-chan MagnetoCalibrationResult {
+	return m.resultCh
+}
+
+func (m *magnetoCalibration) Abort() error {
+	return m.calibration.StartOrAbortMagnetoCalib(false)
+}
+
+// follow watches calibration state for changes, delivering progress until
+// the outcome of the process is known or the given context is done.
+func (m *magnetoCalibration) follow(ctx context.Context) {
+	defer m.subscription.Unsubscribe()
+	defer close(m.progressCh)
+	defer close(m.resultCh)
+	state := m.calibration.calibrationState
+	var lastProgress *MagnetoCalibrationProgress
+	var sawStarted bool
+	for {
+		select {
+		case _, ok := <-m.subscription.NotificationsCh():
+			if !ok {
+				return
+			}
+		case <-ctx.Done():
+			log.Warnf(
+				"gave up following magneto calibration process: %s",
+				ctx.Err(),
+			)
+			if err := m.Abort(); err != nil {
+				log.Errorf("error aborting magneto calibration process: %s", err)
+			}
+			m.resultCh <- MagnetoCalibrationResultAborted
+			return
+		}
+		// Notifications may be dropped, so always evaluate the latest state
+		// rather than the attributes named in the notification
+		state.RLock()
+		progress := MagnetoCalibrationProgress{}
+		progress.XAxisCalibrated, _ =
+			state.MagnetoCalibrationAxisCalibrated(MagnetoCalibrationAxisX)
+		progress.YAxisCalibrated, _ =
+			state.MagnetoCalibrationAxisCalibrated(MagnetoCalibrationAxisY)
+		progress.ZAxisCalibrated, _ =
+			state.MagnetoCalibrationAxisCalibrated(MagnetoCalibrationAxisZ)
+		var ok bool
+		progress.AxisToCalibrate, ok = state.MagnetoCalibrationAxisToCalibrate()
+		if !ok {
+			progress.AxisToCalibrate = MagnetoCalibrationAxisNone
+		}
+		failed, _ := state.MagnetoCalibrationFailed()
+		started, startedOK := state.MagnetoCalibrationStarted()
+		state.RUnlock()
+		if lastProgress == nil || progress != *lastProgress {
+			lastProgress = &progress
+			select {
+			case m.progressCh <- progress:
+			default:
+				log.Warn(
+					"magneto calibration progress channel is full; dropping progress",
+				)
+			}
+		}
+		allCalibrated := progress.XAxisCalibrated &&
+			progress.YAxisCalibrated &&
+			progress.ZAxisCalibrated
+		if started {
+			sawStarted = true
+		}
+		var result MagnetoCalibrationResult
+		switch {
+		case failed:
+			result = MagnetoCalibrationResultFailed
+		case startedOK && !started && allCalibrated:
+			result = MagnetoCalibrationResultSucceeded
+		case startedOK && !started && sawStarted:
+			result = MagnetoCalibrationResultAborted
+		default:
+			continue
+		}
+		log.WithField(
+			"result", result,
+		).Debug("magneto calibration process ended")
+		m.resultCh <- result
+		return
+	}
+}
@@ -1,17 +1,119 @@
 package common
 
 import (
+	"sync"
+
 	log "github.com/Sirupsen/logrus"
+	"github.com/krancour/go-parrot/features/subscriptions"
 	"github.com/krancour/go-parrot/protocols/arcommands"
+	"github.com/krancour/go-parrot/ptr"
 )
 
 // Status of the calibration
 
-// CalibrationState ...
-// TODO: Document this
-type CalibrationState interface{}
+// MagnetoCalibrationAxis is a type for constants used to identify the axes
+// the magnetometer is calibrated on.
+type MagnetoCalibrationAxis = CalibrationStateMagnetoCalibrationAxisToCalibrateChangedAxis
 
-type calibrationState struct{}
+const (
+	// MagnetoCalibrationAxisX identifies the x axis (roll).
+	MagnetoCalibrationAxisX = CalibrationStateMagnetoCalibrationAxisToCalibrateChangedAxisXAxis
+	// MagnetoCalibrationAxisY identifies the y axis (pitch).
+	MagnetoCalibrationAxisY = CalibrationStateMagnetoCalibrationAxisToCalibrateChangedAxisYAxis
+	// MagnetoCalibrationAxisZ identifies the z axis (yaw).
+	MagnetoCalibrationAxisZ = CalibrationStateMagnetoCalibrationAxisToCalibrateChangedAxisZAxis
+	// MagnetoCalibrationAxisNone indicates that no axis should currently be
+	// calibrated.
+	MagnetoCalibrationAxisNone = CalibrationStateMagnetoCalibrationAxisToCalibrateChangedAxisNone
+)
+
+// Attributes of CalibrationState that can be subscribed to. Each is named for
+// the getter function that returns it. See the subscriptions package.
+const (
+	CalibrationStateAttributeMagnetoCalibrationAxisCalibrated  = "MagnetoCalibrationAxisCalibrated"
+	CalibrationStateAttributeMagnetoCalibrationFailed          = "MagnetoCalibrationFailed"
+	CalibrationStateAttributeMagnetoCalibrationRequired        = "MagnetoCalibrationRequired"
+	CalibrationStateAttributeMagnetoCalibrationAxisToCalibrate = "MagnetoCalibrationAxisToCalibrate"
+	CalibrationStateAttributeMagnetoCalibrationStarted         = "MagnetoCalibrationStarted"
+)
+
+// calibrationStateAttributes are all attributes of CalibrationState.
+var calibrationStateAttributes = []string{
+	CalibrationStateAttributeMagnetoCalibrationAxisCalibrated,
+	CalibrationStateAttributeMagnetoCalibrationFailed,
+	CalibrationStateAttributeMagnetoCalibrationRequired,
+	CalibrationStateAttributeMagnetoCalibrationAxisToCalibrate,
+	CalibrationStateAttributeMagnetoCalibrationStarted,
+}
+
+// CalibrationState exposes the state of the device's calibration processes.
+type CalibrationState interface {
+	// RLock blocks until a read lock is obtained. This permits callers to procede
+	// with querying any or all attributes of the calibration state without
+	// worry that some attributes will be overwritten as others are read. i.e. It
+	// permits the possibility of taking an atomic snapshop of calibration state.
+	// Note that use of this function is not obligatory for applications that do
+	// not require such guarantees. Callers MUST call RUnlock() or else
+	// calibration state will never resume updating.
+	RLock()
+	// RUnlock releases a read lock on the calibration state. See RLock().
+	RUnlock()
+	// Subscribe returns a subscription to changes to the calibration state. See
+	// the subscriptions package.
+	subscriptions.Subscribable
+	// MagnetoCalibrationAxisCalibrated returns whether the magnetometer has been
+	// calibrated on the specified axis during the current (or most recent)
+	// calibration process. A boolean value is also returned, indicating whether
+	// the first value was reported by the device (true) or a default value
+	// (false). This permits callers to distinguish real zero values from default
+	// zero values.
+	MagnetoCalibrationAxisCalibrated(axis MagnetoCalibrationAxis) (bool, bool)
+	// MagnetoCalibrationFailed returns whether the current (or most recent)
+	// magnetometer calibration process has failed. A boolean value is also
+	// returned, indicating whether the first value was reported by the device
+	// (true) or a default value (false). This permits callers to distinguish
+	// real zero values from default zero values.
+	MagnetoCalibrationFailed() (bool, bool)
+	// MagnetoCalibrationRequired returns whether the magnetometer requires
+	// calibration. A boolean value is also returned, indicating whether the
+	// first value was reported by the device (true) or a default value (false).
+	// This permits callers to distinguish real zero values from default zero
+	// values.
+	MagnetoCalibrationRequired() (bool, bool)
+	// MagnetoCalibrationAxisToCalibrate returns the axis the user should
+	// currently rotate the device around during the magnetometer calibration
+	// process. A boolean value is also returned, indicating whether the first
+	// value was reported by the device (true) or a default value (false). This
+	// permits callers to distinguish real zero values from default zero values.
+	MagnetoCalibrationAxisToCalibrate() (MagnetoCalibrationAxis, bool)
+	// MagnetoCalibrationStarted returns whether the magnetometer calibration
+	// process is underway. A boolean value is also returned, indicating whether
+	// the first value was reported by the device (true) or a default value
+	// (false). This permits callers to distinguish real zero values from default
+	// zero values.
+	MagnetoCalibrationStarted() (bool, bool)
+}
+
+type calibrationState struct {
+	*subscriptions.Publisher
+	calibrationStateDefaults
+	// xAxisCalibrated indicates whether calibration on the x axis is done
+	xAxisCalibrated *bool
+	// yAxisCalibrated indicates whether calibration on the y axis is done
+	yAxisCalibrated *bool
+	// zAxisCalibrated indicates whether calibration on the z axis is done
+	zAxisCalibrated *bool
+	// calibrationFailed indicates whether the calibration process has failed
+	calibrationFailed *bool
+	// calibrationRequired indicates whether calibration is required
+	calibrationRequired *bool
+	// axisToCalibrate is the axis the user should currently rotate the device
+	// around
+	axisToCalibrate *MagnetoCalibrationAxis
+	// calibrationStarted indicates whether the calibration process is underway
+	calibrationStarted *bool
+	lock               sync.RWMutex
+}
 
 func (c *calibrationState) ID() uint8 {
 	return 14
@@ -22,143 +124,166 @@ func (c *calibrationState) Name() string {
 }
 
 func (c *calibrationState) D2CCommands() []arcommands.D2CCommand {
-	return []arcommands.D2CCommand{
-		arcommands.NewD2CCommand(
-			0,
-			"MagnetoCalibrationStateChanged",
-			[]interface{}{
-				uint8(0), // xAxisCalibration,
-				uint8(0), // yAxisCalibration,
-				uint8(0), // zAxisCalibration,
-				uint8(0), // calibrationFailed,
-			},
-			c.magnetoCalibrationStateChanged,
-		),
-		arcommands.NewD2CCommand(
-			1,
-			"MagnetoCalibrationRequiredState",
-			[]interface{}{
-				uint8(0), // required,
-			},
-			c.magnetoCalibrationRequiredState,
-		),
-		arcommands.NewD2CCommand(
-			2,
-			"MagnetoCalibrationAxisToCalibrateChanged",
-			[]interface{}{
-				int32(0), // axis,
-			},
-			c.magnetoCalibrationAxisToCalibrateChanged,
-		),
-		arcommands.NewD2CCommand(
-			3,
-			"MagnetoCalibrationStartedChanged",
-			[]interface{}{
-				uint8(0), // started,
-			},
-			c.magnetoCalibrationStartedChanged,
-		),
-		arcommands.NewD2CCommand(
-			4,
-			"PitotCalibrationStateChanged",
-			[]interface{}{
-				int32(0), // state,
-				uint8(0), // lastError,
-			},
-			c.pitotCalibrationStateChanged,
-		),
-	}
+	return calibrationStateD2CCommands(c)
 }
 
-// TODO: Implement this
-// Title: Magneto calib process axis state
-// Description: Magneto calib process axis state.
-// Support: 0901;090c;090e
-// Triggered: when the calibration process is started with
-//   [StartOrAbortMagnetoCalib](#0-13-0) and each time an axis calibration state
-//   changes.
-// Result:
+// magnetoCalibrationStateChanged is invoked when the calibration process is
+// started with StartOrAbortMagnetoCalib and each time an axis calibration
+// state changes.
 func (c *calibrationState) magnetoCalibrationStateChanged(
 	args []interface{},
 ) error {
-	// xAxisCalibration := args[0].(uint8)
-	//   State of the x axis (roll) calibration : 1 if calibration is done, 0
-	//   otherwise
-	// yAxisCalibration := args[1].(uint8)
-	//   State of the y axis (pitch) calibration : 1 if calibration is done, 0
-	//   otherwise
-	// zAxisCalibration := args[2].(uint8)
-	//   State of the z axis (yaw) calibration : 1 if calibration is done, 0
-	//   otherwise
-	// calibrationFailed := args[3].(uint8)
-	//   1 if calibration has failed, 0 otherwise. If this arg is 1, consider all
-	//   previous arg as 0
-	log.Info("common.magnetoCalibrationStateChanged() called")
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.calibrationFailed = ptr.ToBool(args[3].(uint8) == 1)
+	// If the calibration has failed, the device expects all axes to be
+	// considered uncalibrated, regardless of what was reported
+	c.xAxisCalibrated = ptr.ToBool(
+		!*c.calibrationFailed && args[0].(uint8) == 1,
+	)
+	c.yAxisCalibrated = ptr.ToBool(
+		!*c.calibrationFailed && args[1].(uint8) == 1,
+	)
+	c.zAxisCalibrated = ptr.ToBool(
+		!*c.calibrationFailed && args[2].(uint8) == 1,
+	)
+	c.Publish(
+		CalibrationStateAttributeMagnetoCalibrationAxisCalibrated,
+		CalibrationStateAttributeMagnetoCalibrationFailed,
+	)
+	log.WithField(
+		"xAxisCalibrated", *c.xAxisCalibrated,
+	).WithField(
+		"yAxisCalibrated", *c.yAxisCalibrated,
+	).WithField(
+		"zAxisCalibrated", *c.zAxisCalibrated,
+	).WithField(
+		"calibrationFailed", *c.calibrationFailed,
+	).Debug("magneto calibration state updated")
 	return nil
 }
 
-// TODO: Implement this
-// Title: Calibration required
-// Description: Calibration required.
-// Support: 0901;090c;090e
-// Triggered: when the calibration requirement changes.
-// Result:
+// magnetoCalibrationRequiredState is invoked when the device reports that the
+// magnetometer calibration requirement has changed.
 func (c *calibrationState) magnetoCalibrationRequiredState(
 	args []interface{},
 ) error {
-	// required := args[0].(uint8)
-	//   1 if calibration is required, 0 if current calibration is still valid
-	log.Info("common.magnetoCalibrationRequiredState() called")
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.calibrationRequired = ptr.ToBool(args[0].(uint8) == 1)
+	c.Publish(CalibrationStateAttributeMagnetoCalibrationRequired)
+	log.WithField(
+		"calibrationRequired", *c.calibrationRequired,
+	).Debug("magneto calibration required updated")
 	return nil
 }
 
-// TODO: Implement this
-// Title: Axis to calibrate during calibration process
-// Description: Axis to calibrate during calibration process.
-// Support: 0901;090c;090e
-// Triggered: during the calibration process when the axis to calibrate changes.
-// Result:
+// magnetoCalibrationAxisToCalibrateChanged is invoked during the calibration
+// process when the axis the user should rotate the device around changes.
 func (c *calibrationState) magnetoCalibrationAxisToCalibrateChanged(
 	args []interface{},
 ) error {
-	// axis := args[0].(int32)
-	//   The axis to calibrate
-	//   0: xAxis: If the current calibration axis should be the x axis
-	//   1: yAxis: If the current calibration axis should be the y axis
-	//   2: zAxis: If the current calibration axis should be the z axis
-	//   3: none: If none of the axis should be calibrated
-	log.Info("common.magnetoCalibrationAxisToCalibrateChanged() called")
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	axis := args[0].(MagnetoCalibrationAxis)
+	c.axisToCalibrate = &axis
+	c.Publish(CalibrationStateAttributeMagnetoCalibrationAxisToCalibrate)
+	log.WithField(
+		"axisToCalibrate", axis,
+	).Debug("magneto calibration axis to calibrate updated")
 	return nil
 }
 
-// TODO: Implement this
-// Title: Calibration process state
-// Description: Calibration process state.
-// Support: 0901;090c;090e
-// Triggered: by [StartOrAbortMagnetoCalib](#0-13-0) or when the process ends
-//   because it succeeded.
-// Result:
+// magnetoCalibrationStartedChanged is invoked when the calibration process is
+// started or aborted with StartOrAbortMagnetoCalib or when the process ends
+// because it succeeded.
 func (c *calibrationState) magnetoCalibrationStartedChanged(
 	args []interface{},
 ) error {
-	// started := args[0].(uint8)
-	//   1 if calibration has started, 0 otherwise
-	log.Info("common.magnetoCalibrationStartedChanged() called")
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.calibrationStarted = ptr.ToBool(args[0].(uint8) == 1)
+	c.Publish(CalibrationStateAttributeMagnetoCalibrationStarted)
+	log.WithField(
+		"calibrationStarted", *c.calibrationStarted,
+	).Debug("magneto calibration started updated")
 	return nil
 }
 
-// TODO: Implement this
-func (c *calibrationState) pitotCalibrationStateChanged(
-	args []interface{},
-) error {
-	// state := args[0].(int32)
-	//   State of pitot calibration
-	//   0: done: Calibration is ok
-	//   1: ready: Calibration is started, waiting user action
-	//   2: in_progress: Calibration is in progress
-	//   3: required: Calibration is required
-	// lastError := args[1].(uint8)
-	//   lastError : 1 if an error occured and 0 if not
-	log.Info("common.pitotCalibrationStateChanged() called")
-	return nil
+func (c *calibrationState) RLock() {
+	c.lock.RLock()
+}
+
+func (c *calibrationState) RUnlock() {
+	c.lock.RUnlock()
+}
+
+func (c *calibrationState) MagnetoCalibrationAxisCalibrated(
+	axis MagnetoCalibrationAxis,
+) (bool, bool) {
+	var calibrated *bool
+	switch axis {
+	case MagnetoCalibrationAxisX:
+		calibrated = c.xAxisCalibrated
+	case MagnetoCalibrationAxisY:
+		calibrated = c.yAxisCalibrated
+	case MagnetoCalibrationAxisZ:
+		calibrated = c.zAxisCalibrated
+	}
+	if calibrated == nil {
+		return false, false
+	}
+	return *calibrated, true
+}
+
+func (c *calibrationState) MagnetoCalibrationFailed() (bool, bool) {
+	if c.calibrationFailed == nil {
+		return false, false
+	}
+	return *c.calibrationFailed, true
+}
+
+func (c *calibrationState) MagnetoCalibrationRequired() (bool, bool) {
+	if c.calibrationRequired == nil {
+		return false, false
+	}
+	return *c.calibrationRequired, true
+}
+
+func (c *calibrationState) MagnetoCalibrationAxisToCalibrate() (
+	MagnetoCalibrationAxis,
+	bool,
+) {
+	if c.axisToCalibrate == nil {
+		return 0, false
+	}
+	return *c.axisToCalibrate, true
+}
+
+func (c *calibrationState) MagnetoCalibrationStarted() (bool, bool) {
+	if c.calibrationStarted == nil {
+		return false, false
+	}
+	return *c.calibrationStarted, true
+}
+
+// resetMagnetoCalibration forgets the outcome of any previous magnetometer
+// calibration process so that state reported for a new process can't be
+// confused with it.
+func (c *calibrationState) resetMagnetoCalibration() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.xAxisCalibrated = nil
+	c.yAxisCalibrated = nil
+	c.zAxisCalibrated = nil
+	c.calibrationFailed = nil
+	c.axisToCalibrate = nil
+	c.calibrationStarted = nil
+	c.Publish(
+		CalibrationStateAttributeMagnetoCalibrationAxisCalibrated,
+		CalibrationStateAttributeMagnetoCalibrationFailed,
+		CalibrationStateAttributeMagnetoCalibrationAxisToCalibrate,
+		CalibrationStateAttributeMagnetoCalibrationStarted,
+	)
+	log.Debug("magneto calibration state reset")
 }
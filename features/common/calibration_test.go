@@ -0,0 +1,161 @@
+package common
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/krancour/go-parrot/features/subscriptions"
+	"github.com/krancour/go-parrot/protocols/arcommands"
+	"github.com/krancour/go-parrot/protocols/arnetwork"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	startMagnetoCalibCommand = []byte{0, 13, 0, 0, 1}
+	abortMagnetoCalibCommand = []byte{0, 13, 0, 0, 0}
+)
+
+func TestCalibrateMagnetometer(t *testing.T) {
+	testCases := []struct {
+		name             string
+		steps            func(*testing.T, *calibrationState, context.CancelFunc)
+		expectedResult   MagnetoCalibrationResult
+		expectedCommands [][]byte
+	}{
+
+		{
+			name: "succeeded",
+			steps: func(t *testing.T, c *calibrationState, _ context.CancelFunc) {
+				err := c.magnetoCalibrationStateChanged(
+					[]interface{}{uint8(1), uint8(1), uint8(1), uint8(0)},
+				)
+				require.NoError(t, err)
+				err = c.magnetoCalibrationStartedChanged([]interface{}{uint8(0)})
+				require.NoError(t, err)
+			},
+			expectedResult:   MagnetoCalibrationResultSucceeded,
+			expectedCommands: [][]byte{startMagnetoCalibCommand},
+		},
+
+		{
+			name: "failed",
+			steps: func(t *testing.T, c *calibrationState, _ context.CancelFunc) {
+				err := c.magnetoCalibrationStateChanged(
+					[]interface{}{uint8(1), uint8(0), uint8(0), uint8(1)},
+				)
+				require.NoError(t, err)
+			},
+			expectedResult:   MagnetoCalibrationResultFailed,
+			expectedCommands: [][]byte{startMagnetoCalibCommand},
+		},
+
+		{
+			name: "aborted by device",
+			steps: func(t *testing.T, c *calibrationState, _ context.CancelFunc) {
+				err := c.magnetoCalibrationStateChanged(
+					[]interface{}{uint8(1), uint8(1), uint8(0), uint8(0)},
+				)
+				require.NoError(t, err)
+				err = c.magnetoCalibrationStartedChanged([]interface{}{uint8(0)})
+				require.NoError(t, err)
+			},
+			expectedResult:   MagnetoCalibrationResultAborted,
+			expectedCommands: [][]byte{startMagnetoCalibCommand},
+		},
+
+		{
+			name: "context done",
+			steps: func(
+				_ *testing.T,
+				_ *calibrationState,
+				cancel context.CancelFunc,
+			) {
+				cancel()
+			},
+			expectedResult: MagnetoCalibrationResultAborted,
+			expectedCommands: [][]byte{
+				startMagnetoCalibCommand,
+				abortMagnetoCalibCommand,
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			c2dCh := make(chan arnetwork.Frame, 2)
+			c2dCommandClient := arcommands.NewC2DCommandClient(
+				map[uint8]chan<- arnetwork.Frame{
+					arcommands.C2DAckBufferID: c2dCh,
+				},
+			)
+			defer c2dCommandClient.Close()
+			state := &calibrationState{
+				Publisher: subscriptions.NewPublisher(
+					"CalibrationState",
+					calibrationStateAttributes...,
+				),
+			}
+			c := &calibration{
+				c2dCommandClient: c2dCommandClient,
+				calibrationState: state,
+			}
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			m, err := c.CalibrateMagnetometer(ctx)
+			require.NoError(t, err)
+
+			// Wait for progress to be delivered so the process is known to have
+			// been seen starting before any further state changes are made
+			err = state.magnetoCalibrationStartedChanged([]interface{}{uint8(1)})
+			require.NoError(t, err)
+			select {
+			case <-m.ProgressCh():
+			case <-time.After(time.Second):
+				require.Fail(t, "timed out waiting for progress")
+			}
+
+			testCase.steps(t, state, cancel)
+			select {
+			case result := <-m.ResultCh():
+				require.Equal(t, testCase.expectedResult, result)
+			case <-time.After(time.Second):
+				require.Fail(t, "timed out waiting for result")
+			}
+			// The result is delivered exactly once
+			_, ok := <-m.ResultCh()
+			require.False(t, ok)
+
+			commands := [][]byte{}
+			for len(c2dCh) > 0 {
+				commands = append(commands, (<-c2dCh).Data)
+			}
+			require.Equal(t, testCase.expectedCommands, commands)
+		})
+	}
+}
+
+func TestResetMagnetoCalibration(t *testing.T) {
+	state := &calibrationState{
+		Publisher: subscriptions.NewPublisher(
+			"CalibrationState",
+			calibrationStateAttributes...,
+		),
+	}
+	err := state.magnetoCalibrationStartedChanged([]interface{}{uint8(1)})
+	require.NoError(t, err)
+	subscription, err := state.Subscribe(
+		CalibrationStateAttributeMagnetoCalibrationStarted,
+	)
+	require.NoError(t, err)
+	defer subscription.Unsubscribe()
+	state.resetMagnetoCalibration()
+	// Subscribers should learn that the process is no longer underway
+	select {
+	case <-subscription.NotificationsCh():
+	default:
+		require.Fail(t, "reset was not published")
+	}
+	_, ok := state.MagnetoCalibrationStarted()
+	require.False(t, ok)
+}
@@ -0,0 +1,33 @@
+package common
+
+import (
+	log "github.com/Sirupsen/logrus"
+	"github.com/krancour/go-parrot/protocols/arcommands"
+)
+
+// Common commands
+
+// Common exposes commands common to all devices.
+type Common interface {
+	// AllStates asks the device to send all of its states. The device responds
+	// by sending every state-related event, followed by the AllStatesChanged
+	// event.
+	AllStates() error
+}
+
+type common struct {
+	c2dCommandClient arcommands.C2DCommandClient
+}
+
+func (c *common) ID() uint8 {
+	return 4
+}
+
+func (c *common) Name() string {
+	return "Common"
+}
+
+func (c *common) AllStates() error {
+	log.Debug("requesting all states")
+	return sendCommonAllStates(c.c2dCommandClient)
+}
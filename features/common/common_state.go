@@ -2,14 +2,81 @@ package common
 
 import (
 	"sync"
+	"time"
 
 	log "github.com/Sirupsen/logrus"
+	"github.com/krancour/go-parrot/features/subscriptions"
 	"github.com/krancour/go-parrot/protocols/arcommands"
 	"github.com/krancour/go-parrot/ptr"
 )
 
 // Common state from product
 
+// Sensor is a type for constants used to identify the device's sensors.
+type Sensor = CommonStateSensorsStatesListChangedSensorName
+
+const (
+	// SensorIMU identifies the inertial measurement unit.
+	SensorIMU = CommonStateSensorsStatesListChangedSensorNameIMU
+	// SensorBarometer identifies the barometer.
+	SensorBarometer = CommonStateSensorsStatesListChangedSensorNameBarometer
+	// SensorUltrasound identifies the ultrasonic sensor.
+	SensorUltrasound = CommonStateSensorsStatesListChangedSensorNameUltrasound
+	// SensorGPS identifies the GPS.
+	SensorGPS = CommonStateSensorsStatesListChangedSensorNameGPS
+	// SensorMagnetometer identifies the magnetometer.
+	SensorMagnetometer = CommonStateSensorsStatesListChangedSensorNameMagnetometer
+	// SensorVerticalCamera identifies the vertical camera.
+	SensorVerticalCamera = CommonStateSensorsStatesListChangedSensorNameVerticalCamera
+)
+
+// MassStorage represents the state of one of the device's mass storage
+// devices.
+type MassStorage struct {
+	// ID uniquely identifies the mass storage device
+	ID uint8
+	// Name is the name of the mass storage device
+	Name string
+	// SizeMB is the size of the mass storage device in MB
+	SizeMB uint32
+	// UsedSizeMB is the amount of space used on the mass storage device in MB
+	UsedSizeMB uint32
+	// Plugged indicates whether the mass storage device is plugged in
+	Plugged bool
+	// Full indicates whether the mass storage device is full
+	Full bool
+	// Internal indicates whether the mass storage device is internal
+	Internal bool
+}
+
+// currentDateTimeLayouts are the layouts the device's current date and time,
+// concatenated, are known to be reported in. The device reports each in
+// ISO-8601 format, but different firmware versions use the basic and extended
+// formats for time.
+var currentDateTimeLayouts = []string{
+	"2006-01-02T150405-0700",
+	"2006-01-02T15:04:05-07:00",
+}
+
+// Attributes of CommonState that can be subscribed to. Each is named for the
+// getter function that returns it. See the subscriptions package.
+const (
+	CommonStateAttributeRSSI            = "RSSI"
+	CommonStateAttributeBatteryPercent  = "BatteryPercent"
+	CommonStateAttributeMassStorages    = "MassStorages"
+	CommonStateAttributeCurrentDateTime = "CurrentDateTime"
+	CommonStateAttributeSensorStates    = "SensorStates"
+)
+
+// commonStateAttributes are all attributes of CommonState.
+var commonStateAttributes = []string{
+	CommonStateAttributeRSSI,
+	CommonStateAttributeBatteryPercent,
+	CommonStateAttributeMassStorages,
+	CommonStateAttributeCurrentDateTime,
+	CommonStateAttributeSensorStates,
+}
+
 // CommonState ...
 // TODO: Document this
 type CommonState interface {
@@ -23,11 +90,37 @@ type CommonState interface {
 	RLock()
 	// RUnlock releases a read lock on the common state. See RLock().
 	RUnlock()
+	// Subscribe returns a subscription to changes to the common state. See the
+	// subscriptions package.
+	subscriptions.Subscribable
 	// RSSI returns the relative signal stength between the client and the device
 	// in dbm. A boolean value is also returned, indicating whether the first
 	// value was reported by the device (true) or a default value (false). This
 	// permits callers to distinguish real zero values from default zero values.
 	RSSI() (int16, bool)
+	// BatteryPercent returns the device's remaining battery charge as a
+	// percentage. A boolean value is also returned, indicating whether the
+	// first value was reported by the device (true) or a default value (false).
+	// This permits callers to distinguish real zero values from default zero
+	// values.
+	BatteryPercent() (uint8, bool)
+	// MassStorages returns the state of each of the device's mass storage
+	// devices, indexed by mass storage ID. The map is a copy and may be
+	// retained or modified by the caller. A mass storage device's name and the
+	// rest of its state are reported separately, so either may be missing if
+	// the device has only reported the other.
+	MassStorages() map[uint8]MassStorage
+	// CurrentDateTime returns the device's current date and time, as most
+	// recently reported by the device. A boolean value is also returned,
+	// indicating whether the first value was reported by the device (true) or a
+	// default value (false). Both the date and time must have been reported for
+	// this to be true.
+	CurrentDateTime() (time.Time, bool)
+	// SensorStates returns the state of each of the device's sensors. A value
+	// of true indicates the sensor is OK. Sensors whose state has not been
+	// reported by the device are absent. The map is a copy and may be retained
+	// or modified by the caller.
+	SensorStates() map[Sensor]bool
 }
 
 type commonState struct {
@@ -36,7 +129,19 @@ type commonState struct {
 	// rssi is the relative signal stength between the client and the device
 	// in dbm
 	rssi *int16
-	lock sync.RWMutex
+	// batteryPercent is the remaining battery charge as a percentage
+	batteryPercent *uint8
+	// massStorages is the state of each mass storage device, indexed by ID
+	massStorages map[uint8]MassStorage
+	// currentDate is the device's current date in ISO-8601 format
+	currentDate *string
+	// currentTime is the device's current time in ISO-8601 format
+	currentTime *string
+	// sensorStates indicates whether each sensor is OK
+	sensorStates map[Sensor]bool
+	lock         sync.RWMutex
+	*subscriptions.Publisher
+	commonStateDefaults
 }
 
 func (c *commonState) ID() uint8 {
@@ -48,439 +153,143 @@ func (c *commonState) Name() string {
 }
 
 func (c *commonState) D2CCommands() []arcommands.D2CCommand {
-	return []arcommands.D2CCommand{
-		arcommands.NewD2CCommand(
-			0,
-			"AllStatesChanged",
-			[]interface{}{},
-			c.allStatesChanged,
-		),
-		arcommands.NewD2CCommand(
-			1,
-			"BatteryStateChanged",
-			[]interface{}{
-				uint8(0), // percent,
-			},
-			c.batteryStateChanged,
-		),
-		arcommands.NewD2CCommand(
-			2,
-			"MassStorageStateListChanged",
-			[]interface{}{
-				uint8(0),  // mass_storage_id,
-				string(0), // name,
-			},
-			c.massStorageStateListChanged,
-		),
-		arcommands.NewD2CCommand(
-			3,
-			"MassStorageInfoStateListChanged",
-			[]interface{}{
-				uint8(0),  // mass_storage_id,
-				uint32(0), // size,
-				uint32(0), // used_size,
-				uint8(0),  // plugged,
-				uint8(0),  // full,
-				uint8(0),  // internal,
-			},
-			c.massStorageInfoStateListChanged,
-		),
-		arcommands.NewD2CCommand(
-			4,
-			"CurrentDateChanged",
-			[]interface{}{
-				string(0), // date,
-			},
-			c.currentDateChanged,
-		),
-		arcommands.NewD2CCommand(
-			5,
-			"CurrentTimeChanged",
-			[]interface{}{
-				string(0), // time,
-			},
-			c.currentTimeChanged,
-		),
-		// arcommands.NewD2CCommand(
-		// 	6,
-		// 	"MassStorageInfoRemainingListChanged",
-		// 	[]interface{}{
-		// 		uint32(0), // free_space,
-		// 		uint16(0), // rec_time,
-		// 		uint32(0), // photo_remaining,
-		// 	},
-		// 	c.massStorageInfoRemainingListChanged,
-		// ),
-		arcommands.NewD2CCommand(
-			7,
-			"WifiSignalChanged",
-			[]interface{}{
-				int16(0), // rssi,
-			},
-			c.wifiSignalChanged,
-		),
-		arcommands.NewD2CCommand(
-			8,
-			"SensorsStatesListChanged",
-			[]interface{}{
-				int32(0), // sensorName,
-				uint8(0), // sensorState,
-			},
-			c.sensorsStatesListChanged,
-		),
-		// arcommands.NewD2CCommand(
-		// 	9,
-		// 	"ProductModel",
-		// 	[]interface{}{
-		// 		int32(0), // model,
-		// 	},
-		// 	c.productModel,
-		// ),
-		// arcommands.NewD2CCommand(
-		// 	10,
-		// 	"CountryListKnown",
-		// 	[]interface{}{
-		// 		uint8(0),  // listFlags,
-		// 		string(0), // countryCodes,
-		// 	},
-		// 	c.countryListKnown,
-		// ),
-		// arcommands.NewD2CCommand(
-		// 	11,
-		// 	"DeprecatedMassStorageContentChanged",
-		// 	[]interface{}{
-		// 		uint8(0),  // mass_storage_id,
-		// 		uint16(0), // nbPhotos,
-		// 		uint16(0), // nbVideos,
-		// 		uint16(0), // nbPuds,
-		// 		uint16(0), // nbCrashLogs,
-		// 	},
-		// 	c.deprecatedMassStorageContentChanged,
-		// ),
-		// arcommands.NewD2CCommand(
-		// 	12,
-		// 	"MassStorageContent",
-		// 	[]interface{}{
-		// 		uint8(0),  // mass_storage_id,
-		// 		uint16(0), // nbPhotos,
-		// 		uint16(0), // nbVideos,
-		// 		uint16(0), // nbPuds,
-		// 		uint16(0), // nbCrashLogs,
-		// 		uint16(0), // nbRawPhotos,
-		// 	},
-		// 	c.massStorageContent,
-		// ),
-		// arcommands.NewD2CCommand(
-		// 	13,
-		// 	"MassStorageContentForCurrentRun",
-		// 	[]interface{}{
-		// 		uint8(0),  // mass_storage_id,
-		// 		uint16(0), // nbPhotos,
-		// 		uint16(0), // nbVideos,
-		// 		uint16(0), // nbRawPhotos,
-		// 	},
-		// 	c.massStorageContentForCurrentRun,
-		// ),
-		// arcommands.NewD2CCommand(
-		// 	14,
-		// 	"VideoRecordingTimestamp",
-		// 	[]interface{}{
-		// 		uint64(0), // startTimestamp,
-		// 		uint64(0), // stopTimestamp,
-		// 	},
-		// 	c.videoRecordingTimestamp,
-		// ),
-	}
-}
-
-// TODO: Implement this
-// Title: All states have been sent
-// Description: All states have been sent.\n\n **Please note that you should not
-//   care about this event if you are using the libARController API as this
-//   library is handling the connection process for you.**
-// Support: drones
-// Triggered: when all states values have been sent.
-// Result:
-func (c *commonState) allStatesChanged(args []interface{}) error {
-	log.Info("common.allStatesChanged() called")
-	return nil
+	return commonStateD2CCommands(c)
 }
 
-// TODO: Implement this
-// Title: Battery state
-// Description: Battery state.
-// Support: drones
-// Triggered: when the battery level changes.
-// Result:
+// batteryStateChanged is invoked when the device reports that its battery
+// level has changed.
 func (c *commonState) batteryStateChanged(args []interface{}) error {
-	// percent := args[0].(uint8)
-	//   Battery percentage
-	log.Info("common.batteryStateChanged() called")
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.batteryPercent = ptr.ToUint8(args[0].(uint8))
+	c.Publish(CommonStateAttributeBatteryPercent)
+	log.WithField(
+		"batteryPercent", c.batteryPercent,
+	).Debug("common state battery percent updated")
 	return nil
 }
 
-// TODO: Implement this
-// Title: Mass storage state list
-// Description: Mass storage state list.
-// Support: drones
-// Triggered: when a mass storage is inserted or ejected.
-// Result:
+// massStorageStateListChanged is invoked when the device reports that a mass
+// storage device has been inserted or ejected.
 func (c *commonState) massStorageStateListChanged(args []interface{}) error {
-	// mass_storage_id := args[0].(uint8)
-	//   Mass storage id (unique)
-	// name := args[1].(string)
-	//   Mass storage name
-	log.Info("common.massStorageStateListChanged() called")
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	massStorage := c.massStorage(args[0].(uint8))
+	massStorage.Name = args[1].(string)
+	c.massStorages[massStorage.ID] = massStorage
+	c.Publish(CommonStateAttributeMassStorages)
+	log.WithField(
+		"massStorageID", massStorage.ID,
+	).WithField(
+		"name", massStorage.Name,
+	).Debug("common state mass storage updated")
 	return nil
 }
 
-// TODO: Implement this
-// Title: Mass storage info state list
-// Description: Mass storage info state list.
-// Support: drones
-// Triggered: when a mass storage info changes.
-// Result:
-func (c *commonState) massStorageInfoStateListChanged(args []interface{}) error {
-	// mass_storage_id := args[0].(uint8)
-	//   Mass storage state id (unique)
-	// size := args[1].(uint32)
-	//   Mass storage size in MBytes
-	// used_size := args[2].(uint32)
-	//   Mass storage used size in MBytes
-	// plugged := args[3].(uint8)
-	//   Mass storage plugged (1 if mass storage is plugged, otherwise 0)
-	// full := args[4].(uint8)
-	//   Mass storage full information state (1 if mass storage full, 0
-	//   otherwise).
-	// internal := args[5].(uint8)
-	//   Mass storage internal type state (1 if mass storage is internal, 0
-	//   otherwise)
-	log.Info("common.massStorageInfoStateListChanged() called")
+// massStorageInfoStateListChanged is invoked when the device reports that
+// information about a mass storage device has changed.
+func (c *commonState) massStorageInfoStateListChanged(
+	args []interface{},
+) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	massStorage := c.massStorage(args[0].(uint8))
+	massStorage.SizeMB = args[1].(uint32)
+	massStorage.UsedSizeMB = args[2].(uint32)
+	massStorage.Plugged = args[3].(uint8) == 1
+	massStorage.Full = args[4].(uint8) == 1
+	massStorage.Internal = args[5].(uint8) == 1
+	c.massStorages[massStorage.ID] = massStorage
+	c.Publish(CommonStateAttributeMassStorages)
+	log.WithField(
+		"massStorageID", massStorage.ID,
+	).WithField(
+		"sizeMB", massStorage.SizeMB,
+	).WithField(
+		"usedSizeMB", massStorage.UsedSizeMB,
+	).WithField(
+		"plugged", massStorage.Plugged,
+	).WithField(
+		"full", massStorage.Full,
+	).WithField(
+		"internal", massStorage.Internal,
+	).Debug("common state mass storage info updated")
 	return nil
 }
 
-// TODO: Implement this
-// Title: Date changed
-// Description: Date changed.\n Corresponds to the latest date set on the drone.
-//   \n\n **Please note that you should not care about this event if you are
-//   using the libARController API as this library is handling the connection
-//   process for you.**
-// Support: drones
-// Triggered: by [SetDate](#0-4-1).
-// Result:
+// massStorage returns the current state of the specified mass storage device,
+// or a new one if the device has not previously reported on it. Callers must
+// hold the write lock.
+func (c *commonState) massStorage(id uint8) MassStorage {
+	if c.massStorages == nil {
+		c.massStorages = map[uint8]MassStorage{}
+	}
+	massStorage, ok := c.massStorages[id]
+	if !ok {
+		massStorage.ID = id
+	}
+	return massStorage
+}
+
+// currentDateChanged is invoked when the device reports its current date,
+// which happens at connection and in response to the SetDate command.
 func (c *commonState) currentDateChanged(args []interface{}) error {
-	// date := args[0].(string)
-	//   Date with ISO-8601 format
-	log.Info("common.currentDateChanged() called")
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.currentDate = ptr.ToString(args[0].(string))
+	c.Publish(CommonStateAttributeCurrentDateTime)
+	log.WithField(
+		"currentDate", c.currentDate,
+	).Debug("common state current date updated")
 	return nil
 }
 
-// TODO: Implement this
-// Title: Time changed
-// Description: Time changed.\n Corresponds to the latest time set on the drone.
-//   \n\n **Please note that you should not care about this event if you are
-//   using the libARController API as this library is handling the connection
-//   process for you.**
-// Support: drones
-// Triggered: by [SetTime](#0-4-2).
-// Result:
+// currentTimeChanged is invoked when the device reports its current time,
+// which happens at connection and in response to the SetTime command.
 func (c *commonState) currentTimeChanged(args []interface{}) error {
-	// time := args[0].(string)
-	//   Time with ISO-8601 format
-	log.Info("common.currentTimeChanged() called")
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.currentTime = ptr.ToString(args[0].(string))
+	c.Publish(CommonStateAttributeCurrentDateTime)
+	log.WithField(
+		"currentTime", c.currentTime,
+	).Debug("common state current time updated")
 	return nil
 }
 
-// // TODO: Implement this
-// // Title: Mass storage remaining data list
-// // Description: Mass storage remaining data list.
-// // Support:
-// // Triggered:
-// // Result:
-// // WARNING: Deprecated
-// func (c *commonState) massStorageInfoRemainingListChanged(
-// 	args []interface{},
-// ) error {
-// 	// free_space := args[0].(uint32)
-// 	//   Mass storage free space in MBytes
-// 	// rec_time := args[1].(uint16)
-// 	//   Mass storage record time reamining in minute
-// 	// photo_remaining := args[2].(uint32)
-// 	//   Mass storage photo remaining
-// 	log.Info("common.massStorageInfoRemainingListChanged() called")
-// 	return nil
-// }
-
 // wifiSignalChanged is invoked when the device reports relative wifi signal
 // strength at regular intervals.
 func (c *commonState) wifiSignalChanged(args []interface{}) error {
 	c.lock.Lock()
 	defer c.lock.Unlock()
 	c.rssi = ptr.ToInt16(args[0].(int16))
+	c.Publish(CommonStateAttributeRSSI)
 	log.WithField(
 		"rssi", c.rssi,
 	).Debug("common state wifi signal strength updated")
 	return nil
 }
 
-// TODO: Implement this
-// Title: Sensors state list
-// Description: Sensors state list.
-// Support: 0901:2.0.3;0902;0905;0906;0907;0909;090a;090c;090e
-// Triggered: at connection and when a sensor state changes.
-// Result:
+// sensorsStatesListChanged is invoked when the device reports the state of a
+// sensor, which happens at connection and whenever a sensor's state changes.
 func (c *commonState) sensorsStatesListChanged(args []interface{}) error {
-	// sensorName := args[0].(int32)
-	//   Sensor name
-	//   0: IMU: Inertial Measurement Unit sensor
-	//   1: barometer: Barometer sensor
-	//   2: ultrasound: Ultrasonic sensor
-	//   3: GPS: GPS sensor
-	//   4: magnetometer: Magnetometer sensor
-	//   5: vertical_camera: Vertical Camera sensor
-	// sensorState := args[1].(uint8)
-	//   Sensor state (1 if the sensor is OK, 0 if the sensor is NOT OK)
-	log.Info("common.sensorsStatesListChanged() called")
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	sensor := args[0].(Sensor)
+	ok := args[1].(uint8) == 1
+	if c.sensorStates == nil {
+		c.sensorStates = map[Sensor]bool{}
+	}
+	c.sensorStates[sensor] = ok
+	c.Publish(CommonStateAttributeSensorStates)
+	log.WithField(
+		"sensor", sensor,
+	).WithField(
+		"ok", ok,
+	).Debug("common state sensor state updated")
 	return nil
 }
 
-// // TODO: Implement this
-// // Title: Product sub-model
-// // Description: Product sub-model.\n This can be used to customize the UI
-// //   depending on the product.
-// // Support: 0905;0906;0907;0909
-// // Triggered: at connection.
-// // Result:
-// func (c *commonState) productModel(args []interface{}) error {
-// 	// model := args[0].(int32)
-// 	//   The Model of the product.
-// 	//   0: RS_TRAVIS: Travis (RS taxi) model.
-// 	//   1: RS_MARS: Mars (RS space) model
-// 	//   2: RS_SWAT: SWAT (RS SWAT) model
-// 	//   3: RS_MCLANE: Mc Lane (RS police) model
-// 	//   4: RS_BLAZE: Blaze (RS fire) model
-// 	//   5: RS_ORAK: Orak (RS carbon hydrofoil) model
-// 	//   6: RS_NEWZ: New Z (RS wooden hydrofoil) model
-// 	//   7: JS_MARSHALL: Marshall (JS fire) model
-// 	//   8: JS_DIESEL: Diesel (JS SWAT) model
-// 	//   9: JS_BUZZ: Buzz (JS space) model
-// 	//   10: JS_MAX: Max (JS F1) model
-// 	//   11: JS_JETT: Jett (JS flames) model
-// 	//   12: JS_TUKTUK: Tuk-Tuk (JS taxi) model
-// 	//   13: SW_BLACK: Swing black model
-// 	//   14: SW_WHITE: Swing white model
-// 	log.Info("common.productModel() called")
-// 	return nil
-// }
-
-// // TODO: Implement this
-// // Title: Country list
-// // Description: List of countries known by the drone.
-// // Support:
-// // Triggered:
-// // Result:
-// // WARNING: Deprecated
-// func (c *commonState) countryListKnown(args []interface{}) error {
-// 	// listFlags := args[0].(uint8)
-// 	//   List entry attribute Bitfield. 0x01: First: indicate it&#39;s the first
-// 	//   element of the list. 0x02: Last: indicate it&#39;s the last element of
-// 	//   the list. 0x04: Empty: indicate the list is empty (implies First/Last).
-// 	//   All other arguments should be ignored.
-// 	// countryCodes := args[1].(string)
-// 	//   Following of country code with ISO 3166 format, separated by &#34;;&#34;.
-// 	//   Be careful of the command size allowed by the network used. If necessary,
-// 	//   split the list in several commands.
-// 	log.Info("common.countryListKnown() called")
-// 	return nil
-// }
-
-// // TODO: Implement this
-// // Title: Mass storage content changed
-// // Description: Mass storage content changed.
-// // Support:
-// // Triggered:
-// // Result:
-// // WARNING: Deprecated
-// func (c *commonState) deprecatedMassStorageContentChanged(
-// 	args []interface{},
-// ) error {
-// 	// mass_storage_id := args[0].(uint8)
-// 	//   Mass storage id (unique)
-// 	// nbPhotos := args[1].(uint16)
-// 	//   Number of photos (does not include raw photos)
-// 	// nbVideos := args[2].(uint16)
-// 	//   Number of videos
-// 	// nbPuds := args[3].(uint16)
-// 	//   Number of puds
-// 	// nbCrashLogs := args[4].(uint16)
-// 	//   Number of crash logs
-// 	log.Info("common.deprecatedMassStorageContentChanged() called")
-// 	return nil
-// }
-
-// // TODO: Implement this
-// // Title: Mass storage content
-// // Description: Mass storage content.
-// // Support: 090c:4.0.0;090e:4.0.0
-// // Triggered: when the content of the mass storage changes.
-// // Result:
-// func (c *commonState) massStorageContent(args []interface{}) error {
-// 	// mass_storage_id := args[0].(uint8)
-// 	//   Mass storage id (unique)
-// 	// nbPhotos := args[1].(uint16)
-// 	//   Number of photos (does not include raw photos)
-// 	// nbVideos := args[2].(uint16)
-// 	//   Number of videos
-// 	// nbPuds := args[3].(uint16)
-// 	//   Number of puds
-// 	// nbCrashLogs := args[4].(uint16)
-// 	//   Number of crash logs
-// 	// nbRawPhotos := args[5].(uint16)
-// 	//   Number of raw photos
-// 	log.Info("common.massStorageContent() called")
-// 	return nil
-// }
-
-// // TODO: Implement this
-// // Title: Mass storage content for current run
-// // Description: Mass storage content for current run.\n Only counts the files
-// //   related to the current run (see [RunId](#0-30-0))
-// // Support: 090c:4.0.0;090e:4.0.0
-// // Triggered: when the content of the mass storage changes and this content is
-// //   related to the current run.
-// // Result:
-// func (c *commonState) massStorageContentForCurrentRun(args []interface{}) error {
-// 	// mass_storage_id := args[0].(uint8)
-// 	//   Mass storage id (unique)
-// 	// nbPhotos := args[1].(uint16)
-// 	//   Number of photos (does not include raw photos)
-// 	// nbVideos := args[2].(uint16)
-// 	//   Number of videos
-// 	// nbRawPhotos := args[3].(uint16)
-// 	//   Number of raw photos
-// 	log.Info("common.massStorageContentForCurrentRun() called")
-// 	return nil
-// }
-
-// // TODO: Implement this
-// // Title: Video recording timestamp
-// // Description: Current or last video recording timestamp.\n Timestamp in
-// //   milliseconds since 00:00:00 UTC on 1 January 1970.\n **Please note that
-// //   values don&#39;t persist after drone reboot**
-// // Support:
-// // Triggered: on video recording start and video recording stop or \n after that
-// //   the date/time of the drone changed.
-// // Result:
-// func (c *commonState) videoRecordingTimestamp(args []interface{}) error {
-// 	// startTimestamp := args[0].(uint64)
-// 	//   Timestamp in milliseconds since 00:00:00 UTC on 1 January 1970.
-// 	// stopTimestamp := args[1].(uint64)
-// 	//   Timestamp in milliseconds since 00:00:00 UTC on 1 January 1970. 0 mean
-// 	//   that video is still recording.
-// 	log.Info("common.videoRecordingTimestamp() called")
-// 	return nil
-// }
-
 func (c *commonState) RLock() {
 	c.lock.RLock()
 }
@@ -495,3 +304,47 @@ func (c *commonState) RSSI() (int16, bool) {
 	}
 	return *c.rssi, true
 }
+
+func (c *commonState) BatteryPercent() (uint8, bool) {
+	if c.batteryPercent == nil {
+		return 0, false
+	}
+	return *c.batteryPercent, true
+}
+
+func (c *commonState) MassStorages() map[uint8]MassStorage {
+	massStorages := map[uint8]MassStorage{}
+	for id, massStorage := range c.massStorages {
+		massStorages[id] = massStorage
+	}
+	return massStorages
+}
+
+func (c *commonState) CurrentDateTime() (time.Time, bool) {
+	if c.currentDate == nil || c.currentTime == nil {
+		return time.Time{}, false
+	}
+	for _, layout := range currentDateTimeLayouts {
+		currentDateTime, err := time.Parse(
+			layout,
+			*c.currentDate+*c.currentTime,
+		)
+		if err == nil {
+			return currentDateTime, true
+		}
+	}
+	log.WithField(
+		"currentDate", *c.currentDate,
+	).WithField(
+		"currentTime", *c.currentTime,
+	).Warn("unable to parse device's current date and time")
+	return time.Time{}, false
+}
+
+func (c *commonState) SensorStates() map[Sensor]bool {
+	sensorStates := map[Sensor]bool{}
+	for sensor, ok := range c.sensorStates {
+		sensorStates[sensor] = ok
+	}
+	return sensorStates
+}
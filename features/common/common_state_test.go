@@ -0,0 +1,235 @@
+package common
+
+import (
+	"testing"
+	"time"
+
+	"github.com/krancour/go-parrot/features/subscriptions"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestCommonState() *commonState {
+	return &commonState{
+		Publisher: subscriptions.NewPublisher(
+			"CommonState",
+			commonStateAttributes...,
+		),
+	}
+}
+
+func TestCurrentDateTime(t *testing.T) {
+	testCases := []struct {
+		name        string
+		currentDate []interface{}
+		currentTime []interface{}
+		expected    time.Time
+		expectedOK  bool
+	}{
+
+		{
+			name: "nothing reported",
+		},
+
+		{
+			name:        "only date reported",
+			currentDate: []interface{}{"2018-10-15"},
+		},
+
+		{
+			name:        "only time reported",
+			currentTime: []interface{}{"T101112+0200"},
+		},
+
+		{
+			name:        "basic time format",
+			currentDate: []interface{}{"2018-10-15"},
+			currentTime: []interface{}{"T101112+0200"},
+			expected: time.Date(
+				2018, time.October, 15, 10, 11, 12, 0,
+				time.FixedZone("", 2*60*60),
+			),
+			expectedOK: true,
+		},
+
+		{
+			name:        "extended time format",
+			currentDate: []interface{}{"2018-10-15"},
+			currentTime: []interface{}{"T10:11:12-05:00"},
+			expected: time.Date(
+				2018, time.October, 15, 10, 11, 12, 0,
+				time.FixedZone("", -5*60*60),
+			),
+			expectedOK: true,
+		},
+
+		{
+			name:        "unknown format",
+			currentDate: []interface{}{"15/10/2018"},
+			currentTime: []interface{}{"10:11:12"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := newTestCommonState()
+			if testCase.currentDate != nil {
+				require.NoError(t, c.currentDateChanged(testCase.currentDate))
+			}
+			if testCase.currentTime != nil {
+				require.NoError(t, c.currentTimeChanged(testCase.currentTime))
+			}
+			currentDateTime, ok := c.CurrentDateTime()
+			require.Equal(t, testCase.expectedOK, ok)
+			require.True(
+				t,
+				testCase.expected.Equal(currentDateTime),
+				"expected %s, got %s",
+				testCase.expected,
+				currentDateTime,
+			)
+		})
+	}
+}
+
+func TestMassStorages(t *testing.T) {
+	testCases := []struct {
+		name     string
+		setup    func(*testing.T, *commonState)
+		expected map[uint8]MassStorage
+	}{
+
+		{
+			name:     "nothing reported",
+			setup:    func(*testing.T, *commonState) {},
+			expected: map[uint8]MassStorage{},
+		},
+
+		{
+			name: "only name reported",
+			setup: func(t *testing.T, c *commonState) {
+				err := c.massStorageStateListChanged(
+					[]interface{}{uint8(0), "internal_000"},
+				)
+				require.NoError(t, err)
+			},
+			expected: map[uint8]MassStorage{
+				0: {ID: 0, Name: "internal_000"},
+			},
+		},
+
+		{
+			name: "only info reported",
+			setup: func(t *testing.T, c *commonState) {
+				err := c.massStorageInfoStateListChanged(
+					[]interface{}{
+						uint8(1), uint32(32000), uint32(100), uint8(1), uint8(0), uint8(0),
+					},
+				)
+				require.NoError(t, err)
+			},
+			expected: map[uint8]MassStorage{
+				1: {ID: 1, SizeMB: 32000, UsedSizeMB: 100, Plugged: true},
+			},
+		},
+
+		{
+			name: "name and info reported",
+			setup: func(t *testing.T, c *commonState) {
+				err := c.massStorageInfoStateListChanged(
+					[]interface{}{
+						uint8(0), uint32(8000), uint32(8000), uint8(1), uint8(1), uint8(1),
+					},
+				)
+				require.NoError(t, err)
+				err = c.massStorageStateListChanged(
+					[]interface{}{uint8(0), "internal_000"},
+				)
+				require.NoError(t, err)
+			},
+			expected: map[uint8]MassStorage{
+				0: {
+					ID:         0,
+					Name:       "internal_000",
+					SizeMB:     8000,
+					UsedSizeMB: 8000,
+					Plugged:    true,
+					Full:       true,
+					Internal:   true,
+				},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := newTestCommonState()
+			testCase.setup(t, c)
+			massStorages := c.MassStorages()
+			require.Equal(t, testCase.expected, massStorages)
+			// The returned map is a copy, so modifying it must not modify state
+			massStorages[42] = MassStorage{ID: 42}
+			delete(massStorages, 0)
+			require.Equal(t, testCase.expected, c.MassStorages())
+		})
+	}
+}
+
+func TestSensorStates(t *testing.T) {
+	testCases := []struct {
+		name     string
+		args     [][]interface{}
+		expected map[Sensor]bool
+	}{
+
+		{
+			name:     "nothing reported",
+			expected: map[Sensor]bool{},
+		},
+
+		{
+			name: "sensors reported",
+			args: [][]interface{}{
+				{CommonStateSensorsStatesListChangedSensorNameIMU, uint8(1)},
+				{CommonStateSensorsStatesListChangedSensorNameGPS, uint8(0)},
+				{
+					CommonStateSensorsStatesListChangedSensorNameMagnetometer,
+					uint8(0),
+				},
+			},
+			expected: map[Sensor]bool{
+				SensorIMU:          true,
+				SensorGPS:          false,
+				SensorMagnetometer: false,
+			},
+		},
+
+		{
+			name: "sensor state changed",
+			args: [][]interface{}{
+				{CommonStateSensorsStatesListChangedSensorNameGPS, uint8(0)},
+				{CommonStateSensorsStatesListChangedSensorNameGPS, uint8(1)},
+			},
+			expected: map[Sensor]bool{
+				SensorGPS: true,
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := newTestCommonState()
+			subscription, err := c.Subscribe(CommonStateAttributeSensorStates)
+			require.NoError(t, err)
+			defer subscription.Unsubscribe()
+			for _, args := range testCase.args {
+				require.NoError(t, c.sensorsStatesListChanged(args))
+			}
+			require.Len(t, subscription.NotificationsCh(), len(testCase.args))
+			sensorStates := c.SensorStates()
+			require.Equal(t, testCase.expected, sensorStates)
+			// The returned map is a copy, so modifying it must not modify state
+			sensorStates[SensorBarometer] = true
+			require.Equal(t, testCase.expected, c.SensorStates())
+		})
+	}
+}
@@ -1,3 +1,5 @@
 package common
 
+//go:generate go run ../../cmd/arsdkgen -xml ../../third_party/arsdk-xml/xml/common.xml -out zz_generated.go
+
 // TODO: Document this
@@ -1,13 +1,20 @@
 package common
 
 import (
+	"github.com/krancour/go-parrot/features/subscriptions"
 	"github.com/krancour/go-parrot/protocols/arcommands"
 )
 
+// featureID is the ID of the common feature.
+const featureID uint8 = 0
+
 // Feature ...
 // TODO: Document this
 type Feature interface {
 	arcommands.D2CFeature
+	Common() Common
+	Settings() Settings
+	Calibration() Calibration
 	// AccessoryState() AccessoryState
 	// AnimationsState() AnimationsState
 	ARLibsVersionsState() ARLibsVersionsState
@@ -29,6 +36,9 @@ type Feature interface {
 }
 
 type feature struct {
+	common      *common
+	settings    *settings
+	calibration *calibration
 	// accessoryState          *accessoryState
 	// animationsState         *animationsState
 	arLibsVersionsState *arLibsVersionsState
@@ -51,16 +61,37 @@ type feature struct {
 
 // NewFeature ...
 // TODO: Document this
-func NewFeature() Feature {
+func NewFeature(c2dCommandClient arcommands.C2DCommandClient) Feature {
+	calibrationState := &calibrationState{
+		Publisher: subscriptions.NewPublisher(
+			"CalibrationState",
+			calibrationStateAttributes...,
+		),
+	}
 	return &feature{
+		common: &common{
+			c2dCommandClient: c2dCommandClient,
+		},
+		settings: &settings{
+			c2dCommandClient: c2dCommandClient,
+		},
+		calibration: &calibration{
+			c2dCommandClient: c2dCommandClient,
+			calibrationState: calibrationState,
+		},
 		// accessoryState:          &accessoryState{},
 		// animationsState:         &animationsState{},
 		arLibsVersionsState: &arLibsVersionsState{},
 		// audioState:              &audioState{},
-		calibrationState:    &calibrationState{},
+		calibrationState:    calibrationState,
 		cameraSettingsState: &cameraSettingsState{},
 		// chargerState:            &chargerState{},
-		commonState:             &commonState{},
+		commonState: &commonState{
+			Publisher: subscriptions.NewPublisher(
+				"CommonState",
+				commonStateAttributes...,
+			),
+		},
 		flightPlanEvent:         &flightPlanEvent{},
 		flightPlanSettingsState: &flightPlanSettingsState{},
 		flightPlanState:         &flightPlanState{},
@@ -75,7 +106,7 @@ func NewFeature() Feature {
 }
 
 func (f *feature) ID() uint8 {
-	return 0
+	return featureID
 }
 
 func (f *feature) Name() string {
@@ -106,6 +137,18 @@ func (f *feature) D2CClasses() []arcommands.D2CClass {
 	}
 }
 
+func (f *feature) Common() Common {
+	return f.common
+}
+
+func (f *feature) Settings() Settings {
+	return f.settings
+}
+
+func (f *feature) Calibration() Calibration {
+	return f.calibration
+}
+
 // func (f *feature) AccessoryState() AccessoryState {
 // 	return f.accessoryState
 // }
@@ -0,0 +1,33 @@
+package common
+
+import (
+	log "github.com/Sirupsen/logrus"
+	"github.com/krancour/go-parrot/protocols/arcommands"
+)
+
+// Settings commands
+
+// Settings exposes commands related to device settings.
+type Settings interface {
+	// AllSettings asks the device to send all of its settings. The device
+	// responds by sending every settings-related event, followed by the
+	// AllSettingsChanged event.
+	AllSettings() error
+}
+
+type settings struct {
+	c2dCommandClient arcommands.C2DCommandClient
+}
+
+func (s *settings) ID() uint8 {
+	return 2
+}
+
+func (s *settings) Name() string {
+	return "Settings"
+}
+
+func (s *settings) AllSettings() error {
+	log.Debug("requesting all settings")
+	return sendSettingsAllSettings(s.c2dCommandClient)
+}
@@ -1,7 +1,6 @@
 package common
 
 import (
-	log "github.com/Sirupsen/logrus"
 	"github.com/krancour/go-parrot/protocols/arcommands"
 )
 
@@ -11,7 +10,9 @@ import (
 // TODO: Document this
 type WifiSettingsState interface{}
 
-type wifiSettingsState struct{}
+type wifiSettingsState struct {
+	wifiSettingsStateDefaults
+}
 
 func (w *wifiSettingsState) ID() uint8 {
 	return 10
@@ -22,27 +23,5 @@ func (w *wifiSettingsState) Name() string {
 }
 
 func (w *wifiSettingsState) D2CCommands() []arcommands.D2CCommand {
-	return []arcommands.D2CCommand{
-		arcommands.NewD2CCommand(
-			0,
-			"outdoorSettingsChanged",
-			[]interface{}{
-				uint8(0), // outdoor,
-			},
-			w.outdoorSettingsChanged,
-		),
-	}
-}
-
-// TODO: Implement this
-// Title: Wifi outdoor mode
-// Description: Wifi outdoor mode.
-// Support: 0901;0902;0905;0906;090c;090e
-// Triggered: by [SetWifiOutdoorMode](#0-9-0).
-// Result:
-func (w *wifiSettingsState) outdoorSettingsChanged(args []interface{}) error {
-	// outdoor := args[0].(uint8)
-	//   1 if it should use outdoor wifi settings, 0 otherwise
-	log.Info("common.outdoorSettingsChanged() called")
-	return nil
+	return wifiSettingsStateD2CCommands(w)
 }
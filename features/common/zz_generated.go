@@ -0,0 +1,1960 @@
+// Code generated by arsdkgen from common.xml. DO NOT EDIT.
+
+package common
+
+import (
+	"fmt"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/krancour/go-parrot/protocols/arcommands"
+	"github.com/krancour/go-parrot/protocols/arnetwork"
+	"github.com/pkg/errors"
+)
+
+// NetworkEventDisconnectionCause is a type for constants used as the cause
+// argument of the NetworkEvent Disconnection command. Cause of the
+// disconnection of the product.
+type NetworkEventDisconnectionCause int32
+
+const (
+	// NetworkEventDisconnectionCauseOffButton is off_button: The button off has
+	// been pressed
+	NetworkEventDisconnectionCauseOffButton NetworkEventDisconnectionCause = iota
+	// NetworkEventDisconnectionCauseUnknown is unknown: Unknown generic cause
+	NetworkEventDisconnectionCauseUnknown
+)
+
+// networkEventDisconnectionCauseNames maps each NetworkEventDisconnectionCause
+// value to its name as defined by the device SDK.
+var networkEventDisconnectionCauseNames = map[NetworkEventDisconnectionCause]string{
+	NetworkEventDisconnectionCauseOffButton: "off_button",
+	NetworkEventDisconnectionCauseUnknown:   "unknown",
+}
+
+func (e NetworkEventDisconnectionCause) String() string {
+	if name, ok := networkEventDisconnectionCauseNames[e]; ok {
+		return name
+	}
+	return fmt.Sprintf("NetworkEventDisconnectionCause(%d)", int32(e))
+}
+
+// IsValid returns true if the NetworkEventDisconnectionCause is one defined by
+// the device SDK.
+func (e NetworkEventDisconnectionCause) IsValid() bool {
+	_, ok := networkEventDisconnectionCauseNames[e]
+	return ok
+}
+
+// networkEventHandlers is implemented by the NetworkEvent class, which handles
+// each of its commands. Network Event from product.
+type networkEventHandlers interface {
+	disconnection(args []interface{}) error
+}
+
+// networkEventDefaults implements every handler of the NetworkEvent class by
+// logging that the command was received. The NetworkEvent class can embed it
+// and implement only the handlers for commands it acts upon.
+type networkEventDefaults struct{}
+
+// networkEventD2CCommands returns the commands of the NetworkEvent class, each
+// dispatched to the corresponding handler.
+func networkEventD2CCommands(
+	handlers networkEventHandlers,
+) []arcommands.D2CCommand {
+	return []arcommands.D2CCommand{
+		arcommands.NewD2CCommand(
+			0,
+			"Disconnection",
+			[]interface{}{
+				NetworkEventDisconnectionCause(0), // cause,
+			},
+			handlers.disconnection,
+		),
+	}
+}
+
+// Title: Drone will disconnect
+// Description: Drone will disconnect.\n This event is mainly triggered when the
+// user presses on the power button of the product.\n\n **This event is a
+// notification, you can't retrieve it in the cache of the device controller.**
+// Support: 0901;090c
+// Triggered: mainly when the user presses the power button of the drone.
+// Result:
+func (networkEventDefaults) disconnection(args []interface{}) error {
+	// cause := args[0].(NetworkEventDisconnectionCause)
+	//   Cause of the disconnection of the product
+	//   0: off_button: The button off has been pressed
+	//   1: unknown: Unknown generic cause
+	log.Info("common.disconnection() called")
+	return nil
+}
+
+// sendSettingsAllSettings sends the Settings AllSettings command to the device.
+// Title: Ask for all settings
+// Description: Ask for all settings.\n\n **Please note that you should not send
+// this command if you are using the libARController API as this library is
+// handling the connection process for you.**
+// Support: drones
+// Result: The product will trigger all settings events (such as
+// [CameraSettings](#0-15-0), or product specific settings as the
+// [MaxAltitude](#1-6-0) for the Bebop).\n Then, it will trigger
+// [AllSettingsEnd](#0-3-0).
+func sendSettingsAllSettings(
+	c2dCommandClient arcommands.C2DCommandClient,
+) error {
+	if err := c2dCommandClient.SendCommand(
+		arcommands.C2DAckBufferID,
+		0, // common
+		2, // Settings
+		0, // AllSettings
+	); err != nil {
+		return errors.Wrap(err, "error sending AllSettings command")
+	}
+	return nil
+}
+
+// sendSettingsAllSettingsWithReceipt is like sendSettingsAllSettings, but also
+// returns an arnetwork.Receipt that is resolved with the outcome of delivering
+// the command.
+func sendSettingsAllSettingsWithReceipt(
+	c2dCommandClient arcommands.C2DCommandClient,
+) (*arnetwork.Receipt, error) {
+	receipt, err := c2dCommandClient.SendCommandWithReceipt(
+		arcommands.C2DAckBufferID,
+		0, // common
+		2, // Settings
+		0, // AllSettings
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "error sending AllSettings command")
+	}
+	return receipt, nil
+}
+
+// settingsStateHandlers is implemented by the SettingsState class, which
+// handles each of its commands. Settings state from product.
+type settingsStateHandlers interface {
+	allSettingsChanged(args []interface{}) error
+	resetChanged(args []interface{}) error
+	productNameChanged(args []interface{}) error
+	productVersionChanged(args []interface{}) error
+	productSerialHighChanged(args []interface{}) error
+	productSerialLowChanged(args []interface{}) error
+	countryChanged(args []interface{}) error
+	autoCountryChanged(args []interface{}) error
+}
+
+// settingsStateDefaults implements every handler of the SettingsState class by
+// logging that the command was received. The SettingsState class can embed it
+// and implement only the handlers for commands it acts upon.
+type settingsStateDefaults struct{}
+
+// settingsStateD2CCommands returns the commands of the SettingsState class,
+// each dispatched to the corresponding handler.
+func settingsStateD2CCommands(
+	handlers settingsStateHandlers,
+) []arcommands.D2CCommand {
+	return []arcommands.D2CCommand{
+		arcommands.NewD2CCommand(
+			0,
+			"AllSettingsChanged",
+			[]interface{}{},
+			handlers.allSettingsChanged,
+		),
+		arcommands.NewD2CCommand(
+			1,
+			"ResetChanged",
+			[]interface{}{},
+			handlers.resetChanged,
+		),
+		arcommands.NewD2CCommand(
+			2,
+			"ProductNameChanged",
+			[]interface{}{
+				"", // name,
+			},
+			handlers.productNameChanged,
+		),
+		arcommands.NewD2CCommand(
+			3,
+			"ProductVersionChanged",
+			[]interface{}{
+				"", // software,
+				"", // hardware,
+			},
+			handlers.productVersionChanged,
+		),
+		arcommands.NewD2CCommand(
+			4,
+			"ProductSerialHighChanged",
+			[]interface{}{
+				"", // high,
+			},
+			handlers.productSerialHighChanged,
+		),
+		arcommands.NewD2CCommand(
+			5,
+			"ProductSerialLowChanged",
+			[]interface{}{
+				"", // low,
+			},
+			handlers.productSerialLowChanged,
+		),
+		arcommands.NewD2CCommand(
+			6,
+			"CountryChanged",
+			[]interface{}{
+				"", // code,
+			},
+			handlers.countryChanged,
+		),
+		arcommands.NewD2CCommand(
+			7,
+			"AutoCountryChanged",
+			[]interface{}{
+				uint8(0), // automatic,
+			},
+			handlers.autoCountryChanged,
+		),
+	}
+}
+
+// Title: All settings have been sent
+// Description: All settings have been sent.\n\n **Please note that you should
+// not care about this event if you are using the libARController API as this
+// library is handling the connection process for you.**
+// Support: drones
+// Triggered: when all settings values have been sent.
+// Result:
+func (settingsStateDefaults) allSettingsChanged(args []interface{}) error {
+	log.Info("common.allSettingsChanged() called")
+	return nil
+}
+
+// Title: All settings have been reset
+// Description: All settings have been reset.
+// Support: drones
+// Triggered: by [ResetSettings](#0-2-1).
+// Result:
+func (settingsStateDefaults) resetChanged(args []interface{}) error {
+	log.Info("common.resetChanged() called")
+	return nil
+}
+
+// Title: Product name changed
+// Description: Product name changed.
+// Support: drones
+// Triggered: by [SetProductName](#0-2-2).
+// Result:
+func (settingsStateDefaults) productNameChanged(args []interface{}) error {
+	// name := args[0].(string)
+	//   Product name
+	log.Info("common.productNameChanged() called")
+	return nil
+}
+
+// Title: Product version
+// Description: Product version.
+// Support: drones
+// Triggered: during the connection process.
+// Result:
+func (settingsStateDefaults) productVersionChanged(args []interface{}) error {
+	// software := args[0].(string)
+	//   Product software version
+	// hardware := args[1].(string)
+	//   Product hardware version
+	log.Info("common.productVersionChanged() called")
+	return nil
+}
+
+// Title: Product serial (1st part)
+// Description: Product serial (1st part).
+// Support: drones
+// Triggered: during the connection process.
+// Result:
+func (settingsStateDefaults) productSerialHighChanged(
+	args []interface{},
+) error {
+	// high := args[0].(string)
+	//   Serial high number (hexadecimal value)
+	log.Info("common.productSerialHighChanged() called")
+	return nil
+}
+
+// Title: Product serial (2nd part)
+// Description: Product serial (2nd part).
+// Support: drones
+// Triggered: during the connection process.
+// Result:
+func (settingsStateDefaults) productSerialLowChanged(args []interface{}) error {
+	// low := args[0].(string)
+	//   Serial low number (hexadecimal value)
+	log.Info("common.productSerialLowChanged() called")
+	return nil
+}
+
+// Title: Country changed
+// Description: Country changed.
+// Support: drones
+// Triggered: by [SetCountry](#0-2-3).
+// Result:
+func (settingsStateDefaults) countryChanged(args []interface{}) error {
+	// code := args[0].(string)
+	//   Country code with ISO 3166 format, empty string means unknown country.
+	log.Info("common.countryChanged() called")
+	return nil
+}
+
+// Title: Auto-country changed
+// Description: Auto-country changed.
+// Support: drones
+// Triggered: by [SetAutoCountry](#0-2-4).
+// Result:
+func (settingsStateDefaults) autoCountryChanged(args []interface{}) error {
+	// automatic := args[0].(uint8)
+	//   Boolean : 0 : Manual / 1 : Auto
+	log.Info("common.autoCountryChanged() called")
+	return nil
+}
+
+// sendCommonAllStates sends the Common AllStates command to the device.
+// Title: Ask for all states
+// Description: Ask for all states.\n\n **Please note that you should not send
+// this command if you are using the libARController API as this library is
+// handling the connection process for you.**
+// Support: drones
+// Result: The product will trigger all states events (such as
+// [FlyingState](#1-4-1) for the Bebop).\n Then, it will trigger
+// [AllStatesEnd](#0-5-0).
+func sendCommonAllStates(
+	c2dCommandClient arcommands.C2DCommandClient,
+) error {
+	if err := c2dCommandClient.SendCommand(
+		arcommands.C2DAckBufferID,
+		0, // common
+		4, // Common
+		0, // AllStates
+	); err != nil {
+		return errors.Wrap(err, "error sending AllStates command")
+	}
+	return nil
+}
+
+// sendCommonAllStatesWithReceipt is like sendCommonAllStates, but also returns
+// an arnetwork.Receipt that is resolved with the outcome of delivering the
+// command.
+func sendCommonAllStatesWithReceipt(
+	c2dCommandClient arcommands.C2DCommandClient,
+) (*arnetwork.Receipt, error) {
+	receipt, err := c2dCommandClient.SendCommandWithReceipt(
+		arcommands.C2DAckBufferID,
+		0, // common
+		4, // Common
+		0, // AllStates
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "error sending AllStates command")
+	}
+	return receipt, nil
+}
+
+// CommonStateSensorsStatesListChangedSensorName is a type for constants used as
+// the sensorName argument of the CommonState SensorsStatesListChanged command.
+// Sensor name.
+type CommonStateSensorsStatesListChangedSensorName int32
+
+const (
+	// CommonStateSensorsStatesListChangedSensorNameIMU is IMU: Inertial
+	// Measurement Unit sensor
+	CommonStateSensorsStatesListChangedSensorNameIMU CommonStateSensorsStatesListChangedSensorName = iota
+	// CommonStateSensorsStatesListChangedSensorNameBarometer is barometer:
+	// Barometer sensor
+	CommonStateSensorsStatesListChangedSensorNameBarometer
+	// CommonStateSensorsStatesListChangedSensorNameUltrasound is ultrasound:
+	// Ultrasonic sensor
+	CommonStateSensorsStatesListChangedSensorNameUltrasound
+	// CommonStateSensorsStatesListChangedSensorNameGPS is GPS: GPS sensor
+	CommonStateSensorsStatesListChangedSensorNameGPS
+	// CommonStateSensorsStatesListChangedSensorNameMagnetometer is magnetometer:
+	// Magnetometer sensor
+	CommonStateSensorsStatesListChangedSensorNameMagnetometer
+	// CommonStateSensorsStatesListChangedSensorNameVerticalCamera is
+	// vertical_camera: Vertical Camera sensor
+	CommonStateSensorsStatesListChangedSensorNameVerticalCamera
+)
+
+// commonStateSensorsStatesListChangedSensorNameNames maps each
+// CommonStateSensorsStatesListChangedSensorName value to its name as defined by
+// the device SDK.
+var commonStateSensorsStatesListChangedSensorNameNames = map[CommonStateSensorsStatesListChangedSensorName]string{
+	CommonStateSensorsStatesListChangedSensorNameIMU:            "IMU",
+	CommonStateSensorsStatesListChangedSensorNameBarometer:      "barometer",
+	CommonStateSensorsStatesListChangedSensorNameUltrasound:     "ultrasound",
+	CommonStateSensorsStatesListChangedSensorNameGPS:            "GPS",
+	CommonStateSensorsStatesListChangedSensorNameMagnetometer:   "magnetometer",
+	CommonStateSensorsStatesListChangedSensorNameVerticalCamera: "vertical_camera",
+}
+
+func (e CommonStateSensorsStatesListChangedSensorName) String() string {
+	if name, ok := commonStateSensorsStatesListChangedSensorNameNames[e]; ok {
+		return name
+	}
+	return fmt.Sprintf("CommonStateSensorsStatesListChangedSensorName(%d)", int32(e))
+}
+
+// IsValid returns true if the CommonStateSensorsStatesListChangedSensorName is
+// one defined by the device SDK.
+func (e CommonStateSensorsStatesListChangedSensorName) IsValid() bool {
+	_, ok := commonStateSensorsStatesListChangedSensorNameNames[e]
+	return ok
+}
+
+// CommonStateProductModelModel is a type for constants used as the model
+// argument of the CommonState ProductModel command. The Model of the product.
+type CommonStateProductModelModel int32
+
+const (
+	// CommonStateProductModelModelRsTravis is RS_TRAVIS: Travis (RS taxi) model.
+	CommonStateProductModelModelRsTravis CommonStateProductModelModel = iota
+	// CommonStateProductModelModelRsMars is RS_MARS: Mars (RS space) model
+	CommonStateProductModelModelRsMars
+	// CommonStateProductModelModelRsSwat is RS_SWAT: SWAT (RS SWAT) model
+	CommonStateProductModelModelRsSwat
+	// CommonStateProductModelModelRsMclane is RS_MCLANE: Mc Lane (RS police) model
+	CommonStateProductModelModelRsMclane
+	// CommonStateProductModelModelRsBlaze is RS_BLAZE: Blaze (RS fire) model
+	CommonStateProductModelModelRsBlaze
+	// CommonStateProductModelModelRsOrak is RS_ORAK: Orak (RS carbon hydrofoil)
+	// model
+	CommonStateProductModelModelRsOrak
+	// CommonStateProductModelModelRsNewz is RS_NEWZ: New Z (RS wooden hydrofoil)
+	// model
+	CommonStateProductModelModelRsNewz
+	// CommonStateProductModelModelJsMarshall is JS_MARSHALL: Marshall (JS fire)
+	// model
+	CommonStateProductModelModelJsMarshall
+	// CommonStateProductModelModelJsDiesel is JS_DIESEL: Diesel (JS SWAT) model
+	CommonStateProductModelModelJsDiesel
+	// CommonStateProductModelModelJsBuzz is JS_BUZZ: Buzz (JS space) model
+	CommonStateProductModelModelJsBuzz
+	// CommonStateProductModelModelJsMax is JS_MAX: Max (JS F1) model
+	CommonStateProductModelModelJsMax
+	// CommonStateProductModelModelJsJett is JS_JETT: Jett (JS flames) model
+	CommonStateProductModelModelJsJett
+	// CommonStateProductModelModelJsTuktuk is JS_TUKTUK: Tuk-Tuk (JS taxi) model
+	CommonStateProductModelModelJsTuktuk
+	// CommonStateProductModelModelSwBlack is SW_BLACK: Swing black model
+	CommonStateProductModelModelSwBlack
+	// CommonStateProductModelModelSwWhite is SW_WHITE: Swing white model
+	CommonStateProductModelModelSwWhite
+)
+
+// commonStateProductModelModelNames maps each CommonStateProductModelModel
+// value to its name as defined by the device SDK.
+var commonStateProductModelModelNames = map[CommonStateProductModelModel]string{
+	CommonStateProductModelModelRsTravis:   "RS_TRAVIS",
+	CommonStateProductModelModelRsMars:     "RS_MARS",
+	CommonStateProductModelModelRsSwat:     "RS_SWAT",
+	CommonStateProductModelModelRsMclane:   "RS_MCLANE",
+	CommonStateProductModelModelRsBlaze:    "RS_BLAZE",
+	CommonStateProductModelModelRsOrak:     "RS_ORAK",
+	CommonStateProductModelModelRsNewz:     "RS_NEWZ",
+	CommonStateProductModelModelJsMarshall: "JS_MARSHALL",
+	CommonStateProductModelModelJsDiesel:   "JS_DIESEL",
+	CommonStateProductModelModelJsBuzz:     "JS_BUZZ",
+	CommonStateProductModelModelJsMax:      "JS_MAX",
+	CommonStateProductModelModelJsJett:     "JS_JETT",
+	CommonStateProductModelModelJsTuktuk:   "JS_TUKTUK",
+	CommonStateProductModelModelSwBlack:    "SW_BLACK",
+	CommonStateProductModelModelSwWhite:    "SW_WHITE",
+}
+
+func (e CommonStateProductModelModel) String() string {
+	if name, ok := commonStateProductModelModelNames[e]; ok {
+		return name
+	}
+	return fmt.Sprintf("CommonStateProductModelModel(%d)", int32(e))
+}
+
+// IsValid returns true if the CommonStateProductModelModel is one defined by
+// the device SDK.
+func (e CommonStateProductModelModel) IsValid() bool {
+	_, ok := commonStateProductModelModelNames[e]
+	return ok
+}
+
+// commonStateHandlers is implemented by the CommonState class, which handles
+// each of its commands. Common state from product.
+type commonStateHandlers interface {
+	allStatesChanged(args []interface{}) error
+	batteryStateChanged(args []interface{}) error
+	massStorageStateListChanged(args []interface{}) error
+	massStorageInfoStateListChanged(args []interface{}) error
+	currentDateChanged(args []interface{}) error
+	currentTimeChanged(args []interface{}) error
+	massStorageInfoRemainingListChanged(args []interface{}) error
+	wifiSignalChanged(args []interface{}) error
+	sensorsStatesListChanged(args []interface{}) error
+	productModel(args []interface{}) error
+	countryListKnown(args []interface{}) error
+	deprecatedMassStorageContentChanged(args []interface{}) error
+	massStorageContent(args []interface{}) error
+	massStorageContentForCurrentRun(args []interface{}) error
+	videoRecordingTimestamp(args []interface{}) error
+}
+
+// commonStateDefaults implements every handler of the CommonState class by
+// logging that the command was received. The CommonState class can embed it and
+// implement only the handlers for commands it acts upon.
+type commonStateDefaults struct{}
+
+// commonStateD2CCommands returns the commands of the CommonState class, each
+// dispatched to the corresponding handler.
+func commonStateD2CCommands(
+	handlers commonStateHandlers,
+) []arcommands.D2CCommand {
+	return []arcommands.D2CCommand{
+		arcommands.NewD2CCommand(
+			0,
+			"AllStatesChanged",
+			[]interface{}{},
+			handlers.allStatesChanged,
+		),
+		arcommands.NewD2CCommand(
+			1,
+			"BatteryStateChanged",
+			[]interface{}{
+				uint8(0), // percent,
+			},
+			handlers.batteryStateChanged,
+		),
+		arcommands.NewD2CCommand(
+			2,
+			"MassStorageStateListChanged",
+			[]interface{}{
+				uint8(0), // mass_storage_id,
+				"",       // name,
+			},
+			handlers.massStorageStateListChanged,
+		),
+		arcommands.NewD2CCommand(
+			3,
+			"MassStorageInfoStateListChanged",
+			[]interface{}{
+				uint8(0),  // mass_storage_id,
+				uint32(0), // size,
+				uint32(0), // used_size,
+				uint8(0),  // plugged,
+				uint8(0),  // full,
+				uint8(0),  // internal,
+			},
+			handlers.massStorageInfoStateListChanged,
+		),
+		arcommands.NewD2CCommand(
+			4,
+			"CurrentDateChanged",
+			[]interface{}{
+				"", // date,
+			},
+			handlers.currentDateChanged,
+		),
+		arcommands.NewD2CCommand(
+			5,
+			"CurrentTimeChanged",
+			[]interface{}{
+				"", // time,
+			},
+			handlers.currentTimeChanged,
+		),
+		arcommands.NewD2CCommand(
+			6,
+			"MassStorageInfoRemainingListChanged",
+			[]interface{}{
+				uint32(0), // free_space,
+				uint16(0), // rec_time,
+				uint32(0), // photo_remaining,
+			},
+			handlers.massStorageInfoRemainingListChanged,
+		),
+		arcommands.NewD2CCommand(
+			7,
+			"WifiSignalChanged",
+			[]interface{}{
+				int16(0), // rssi,
+			},
+			handlers.wifiSignalChanged,
+		),
+		arcommands.NewD2CCommand(
+			8,
+			"SensorsStatesListChanged",
+			[]interface{}{
+				CommonStateSensorsStatesListChangedSensorName(0), // sensorName,
+				uint8(0), // sensorState,
+			},
+			handlers.sensorsStatesListChanged,
+		),
+		arcommands.NewD2CCommand(
+			9,
+			"ProductModel",
+			[]interface{}{
+				CommonStateProductModelModel(0), // model,
+			},
+			handlers.productModel,
+		),
+		arcommands.NewD2CCommand(
+			10,
+			"CountryListKnown",
+			[]interface{}{
+				uint8(0), // listFlags,
+				"",       // countryCodes,
+			},
+			handlers.countryListKnown,
+		),
+		arcommands.NewD2CCommand(
+			11,
+			"DeprecatedMassStorageContentChanged",
+			[]interface{}{
+				uint8(0),  // mass_storage_id,
+				uint16(0), // nbPhotos,
+				uint16(0), // nbVideos,
+				uint16(0), // nbPuds,
+				uint16(0), // nbCrashLogs,
+			},
+			handlers.deprecatedMassStorageContentChanged,
+		),
+		arcommands.NewD2CCommand(
+			12,
+			"MassStorageContent",
+			[]interface{}{
+				uint8(0),  // mass_storage_id,
+				uint16(0), // nbPhotos,
+				uint16(0), // nbVideos,
+				uint16(0), // nbPuds,
+				uint16(0), // nbCrashLogs,
+				uint16(0), // nbRawPhotos,
+			},
+			handlers.massStorageContent,
+		),
+		arcommands.NewD2CCommand(
+			13,
+			"MassStorageContentForCurrentRun",
+			[]interface{}{
+				uint8(0),  // mass_storage_id,
+				uint16(0), // nbPhotos,
+				uint16(0), // nbVideos,
+				uint16(0), // nbRawPhotos,
+			},
+			handlers.massStorageContentForCurrentRun,
+		),
+		arcommands.NewD2CCommand(
+			14,
+			"VideoRecordingTimestamp",
+			[]interface{}{
+				uint64(0), // startTimestamp,
+				uint64(0), // stopTimestamp,
+			},
+			handlers.videoRecordingTimestamp,
+		),
+	}
+}
+
+// Title: All states have been sent
+// Description: All states have been sent.\n\n **Please note that you should not
+// care about this event if you are using the libARController API as this
+// library is handling the connection process for you.**
+// Support: drones
+// Triggered: when all states values have been sent.
+// Result:
+func (commonStateDefaults) allStatesChanged(args []interface{}) error {
+	log.Info("common.allStatesChanged() called")
+	return nil
+}
+
+// Title: Battery state
+// Description: Battery state.
+// Support: drones
+// Triggered: when the battery level changes.
+// Result:
+func (commonStateDefaults) batteryStateChanged(args []interface{}) error {
+	// percent := args[0].(uint8)
+	//   Battery percentage
+	log.Info("common.batteryStateChanged() called")
+	return nil
+}
+
+// Title: Mass storage state list
+// Description: Mass storage state list.
+// Support: drones
+// Triggered: when a mass storage is inserted or ejected.
+// Result:
+func (commonStateDefaults) massStorageStateListChanged(
+	args []interface{},
+) error {
+	// mass_storage_id := args[0].(uint8)
+	//   Mass storage id (unique)
+	// name := args[1].(string)
+	//   Mass storage name
+	log.Info("common.massStorageStateListChanged() called")
+	return nil
+}
+
+// Title: Mass storage info state list
+// Description: Mass storage info state list.
+// Support: drones
+// Triggered: when a mass storage info changes.
+// Result:
+func (commonStateDefaults) massStorageInfoStateListChanged(
+	args []interface{},
+) error {
+	// mass_storage_id := args[0].(uint8)
+	//   Mass storage state id (unique)
+	// size := args[1].(uint32)
+	//   Mass storage size in MBytes
+	// used_size := args[2].(uint32)
+	//   Mass storage used size in MBytes
+	// plugged := args[3].(uint8)
+	//   Mass storage plugged (1 if mass storage is plugged, otherwise 0)
+	// full := args[4].(uint8)
+	//   Mass storage full information state (1 if mass storage full, 0 otherwise).
+	// internal := args[5].(uint8)
+	//   Mass storage internal type state (1 if mass storage is internal, 0
+	//   otherwise)
+	log.Info("common.massStorageInfoStateListChanged() called")
+	return nil
+}
+
+// Title: Date changed
+// Description: Date changed.\n Corresponds to the latest date set on the drone.
+// \n\n **Please note that you should not care about this event if you are using
+// the libARController API as this library is handling the connection process
+// for you.**
+// Support: drones
+// Triggered: by [SetDate](#0-4-1).
+// Result:
+func (commonStateDefaults) currentDateChanged(args []interface{}) error {
+	// date := args[0].(string)
+	//   Date with ISO-8601 format
+	log.Info("common.currentDateChanged() called")
+	return nil
+}
+
+// Title: Time changed
+// Description: Time changed.\n Corresponds to the latest time set on the drone.
+// \n\n **Please note that you should not care about this event if you are using
+// the libARController API as this library is handling the connection process
+// for you.**
+// Support: drones
+// Triggered: by [SetTime](#0-4-2).
+// Result:
+func (commonStateDefaults) currentTimeChanged(args []interface{}) error {
+	// time := args[0].(string)
+	//   Time with ISO-8601 format
+	log.Info("common.currentTimeChanged() called")
+	return nil
+}
+
+// Title: Mass storage remaining data list
+// Description: Mass storage remaining data list.
+// Support:
+// Result:
+//
+// Deprecated: This command is deprecated by the device SDK.
+func (commonStateDefaults) massStorageInfoRemainingListChanged(
+	args []interface{},
+) error {
+	// free_space := args[0].(uint32)
+	//   Mass storage free space in MBytes
+	// rec_time := args[1].(uint16)
+	//   Mass storage record time reamining in minute
+	// photo_remaining := args[2].(uint32)
+	//   Mass storage photo remaining
+	log.Info("common.massStorageInfoRemainingListChanged() called")
+	return nil
+}
+
+// Title: Rssi changed
+// Description: Rssi (Wifi Signal between controller and product) changed.
+// Support: 0901;090c;090e
+// Triggered: regularly.
+// Result:
+func (commonStateDefaults) wifiSignalChanged(args []interface{}) error {
+	// rssi := args[0].(int16)
+	//   RSSI of the signal between controller and the product (in dbm)
+	log.Info("common.wifiSignalChanged() called")
+	return nil
+}
+
+// Title: Sensors state list
+// Description: Sensors state list.
+// Support: 0901:2.0.3;0902;0905;0906;0907;0909;090a;090c;090e
+// Triggered: at connection and when a sensor state changes.
+// Result:
+func (commonStateDefaults) sensorsStatesListChanged(args []interface{}) error {
+	// sensorName := args[0].(CommonStateSensorsStatesListChangedSensorName)
+	//   Sensor name
+	//   0: IMU: Inertial Measurement Unit sensor
+	//   1: barometer: Barometer sensor
+	//   2: ultrasound: Ultrasonic sensor
+	//   3: GPS: GPS sensor
+	//   4: magnetometer: Magnetometer sensor
+	//   5: vertical_camera: Vertical Camera sensor
+	// sensorState := args[1].(uint8)
+	//   Sensor state (1 if the sensor is OK, 0 if the sensor is NOT OK)
+	log.Info("common.sensorsStatesListChanged() called")
+	return nil
+}
+
+// Title: Product sub-model
+// Description: Product sub-model.\n This can be used to customize the UI
+// depending on the product.
+// Support: 0905;0906;0907;0909
+// Triggered: at connection.
+// Result:
+func (commonStateDefaults) productModel(args []interface{}) error {
+	// model := args[0].(CommonStateProductModelModel)
+	//   The Model of the product.
+	//   0: RS_TRAVIS: Travis (RS taxi) model.
+	//   1: RS_MARS: Mars (RS space) model
+	//   2: RS_SWAT: SWAT (RS SWAT) model
+	//   3: RS_MCLANE: Mc Lane (RS police) model
+	//   4: RS_BLAZE: Blaze (RS fire) model
+	//   5: RS_ORAK: Orak (RS carbon hydrofoil) model
+	//   6: RS_NEWZ: New Z (RS wooden hydrofoil) model
+	//   7: JS_MARSHALL: Marshall (JS fire) model
+	//   8: JS_DIESEL: Diesel (JS SWAT) model
+	//   9: JS_BUZZ: Buzz (JS space) model
+	//   10: JS_MAX: Max (JS F1) model
+	//   11: JS_JETT: Jett (JS flames) model
+	//   12: JS_TUKTUK: Tuk-Tuk (JS taxi) model
+	//   13: SW_BLACK: Swing black model
+	//   14: SW_WHITE: Swing white model
+	log.Info("common.productModel() called")
+	return nil
+}
+
+// Title: Country list
+// Description: List of countries known by the drone.
+// Support:
+// Result:
+//
+// Deprecated: This command is deprecated by the device SDK.
+func (commonStateDefaults) countryListKnown(args []interface{}) error {
+	// listFlags := args[0].(uint8)
+	//   List entry attribute Bitfield. 0x01: First: indicate it's the first
+	//   element of the list. 0x02: Last: indicate it's the last element of the
+	//   list. 0x04: Empty: indicate the list is empty (implies First/Last). All
+	//   other arguments should be ignored.
+	// countryCodes := args[1].(string)
+	//   Following of country code with ISO 3166 format, separated by ";". Be
+	//   careful of the command size allowed by the network used. If necessary,
+	//   split the list in several commands.
+	log.Info("common.countryListKnown() called")
+	return nil
+}
+
+// Title: Mass storage content changed
+// Description: Mass storage content changed.
+// Support:
+// Result:
+//
+// Deprecated: This command is deprecated by the device SDK.
+func (commonStateDefaults) deprecatedMassStorageContentChanged(
+	args []interface{},
+) error {
+	// mass_storage_id := args[0].(uint8)
+	//   Mass storage id (unique)
+	// nbPhotos := args[1].(uint16)
+	//   Number of photos (does not include raw photos)
+	// nbVideos := args[2].(uint16)
+	//   Number of videos
+	// nbPuds := args[3].(uint16)
+	//   Number of puds
+	// nbCrashLogs := args[4].(uint16)
+	//   Number of crash logs
+	log.Info("common.deprecatedMassStorageContentChanged() called")
+	return nil
+}
+
+// Title: Mass storage content
+// Description: Mass storage content.
+// Support: 090c:4.0.0;090e:4.0.0
+// Triggered: when the content of the mass storage changes.
+// Result:
+func (commonStateDefaults) massStorageContent(args []interface{}) error {
+	// mass_storage_id := args[0].(uint8)
+	//   Mass storage id (unique)
+	// nbPhotos := args[1].(uint16)
+	//   Number of photos (does not include raw photos)
+	// nbVideos := args[2].(uint16)
+	//   Number of videos
+	// nbPuds := args[3].(uint16)
+	//   Number of puds
+	// nbCrashLogs := args[4].(uint16)
+	//   Number of crash logs
+	// nbRawPhotos := args[5].(uint16)
+	//   Number of raw photos
+	log.Info("common.massStorageContent() called")
+	return nil
+}
+
+// Title: Mass storage content for current run
+// Description: Mass storage content for current run.\n Only counts the files
+// related to the current run (see [RunId](#0-30-0))
+// Support: 090c:4.0.0;090e:4.0.0
+// Triggered: when the content of the mass storage changes and this content is
+// related to the current run.
+// Result:
+func (commonStateDefaults) massStorageContentForCurrentRun(
+	args []interface{},
+) error {
+	// mass_storage_id := args[0].(uint8)
+	//   Mass storage id (unique)
+	// nbPhotos := args[1].(uint16)
+	//   Number of photos (does not include raw photos)
+	// nbVideos := args[2].(uint16)
+	//   Number of videos
+	// nbRawPhotos := args[3].(uint16)
+	//   Number of raw photos
+	log.Info("common.massStorageContentForCurrentRun() called")
+	return nil
+}
+
+// Title: Video recording timestamp
+// Description: Current or last video recording timestamp.\n Timestamp in
+// milliseconds since 00:00:00 UTC on 1 January 1970.\n **Please note that
+// values don't persist after drone reboot**
+// Support:
+// Triggered: on video recording start and video recording stop or \n after that
+// the date/time of the drone changed.
+// Result:
+func (commonStateDefaults) videoRecordingTimestamp(args []interface{}) error {
+	// startTimestamp := args[0].(uint64)
+	//   Timestamp in milliseconds since 00:00:00 UTC on 1 January 1970.
+	// stopTimestamp := args[1].(uint64)
+	//   Timestamp in milliseconds since 00:00:00 UTC on 1 January 1970. 0 mean
+	//   that video is still recording.
+	log.Info("common.videoRecordingTimestamp() called")
+	return nil
+}
+
+// wifiSettingsStateHandlers is implemented by the WifiSettingsState class,
+// which handles each of its commands. Wifi settings state from product.
+type wifiSettingsStateHandlers interface {
+	outdoorSettingsChanged(args []interface{}) error
+}
+
+// wifiSettingsStateDefaults implements every handler of the WifiSettingsState
+// class by logging that the command was received. The WifiSettingsState class
+// can embed it and implement only the handlers for commands it acts upon.
+type wifiSettingsStateDefaults struct{}
+
+// wifiSettingsStateD2CCommands returns the commands of the WifiSettingsState
+// class, each dispatched to the corresponding handler.
+func wifiSettingsStateD2CCommands(
+	handlers wifiSettingsStateHandlers,
+) []arcommands.D2CCommand {
+	return []arcommands.D2CCommand{
+		arcommands.NewD2CCommand(
+			0,
+			"outdoorSettingsChanged",
+			[]interface{}{
+				uint8(0), // outdoor,
+			},
+			handlers.outdoorSettingsChanged,
+		),
+	}
+}
+
+// Title: Wifi outdoor mode
+// Description: Wifi outdoor mode.
+// Support: 0901;0902;0905;0906;090c;090e
+// Triggered: by [SetWifiOutdoorMode](#0-9-0).
+// Result:
+func (wifiSettingsStateDefaults) outdoorSettingsChanged(
+	args []interface{},
+) error {
+	// outdoor := args[0].(uint8)
+	//   1 if it should use outdoor wifi settings, 0 otherwise
+	log.Info("common.outdoorSettingsChanged() called")
+	return nil
+}
+
+// MavlinkStateMavlinkFilePlayingStateChangedState is a type for constants used
+// as the state argument of the MavlinkState MavlinkFilePlayingStateChanged
+// command. State of the mavlink.
+type MavlinkStateMavlinkFilePlayingStateChangedState int32
+
+const (
+	// MavlinkStateMavlinkFilePlayingStateChangedStatePlaying is playing: Mavlink
+	// file is playing
+	MavlinkStateMavlinkFilePlayingStateChangedStatePlaying MavlinkStateMavlinkFilePlayingStateChangedState = iota
+	// MavlinkStateMavlinkFilePlayingStateChangedStateStopped is stopped: Mavlink
+	// file is stopped (arg filepath and type are useless in this state)
+	MavlinkStateMavlinkFilePlayingStateChangedStateStopped
+	// MavlinkStateMavlinkFilePlayingStateChangedStatePaused is paused: Mavlink
+	// file is paused
+	MavlinkStateMavlinkFilePlayingStateChangedStatePaused
+	// MavlinkStateMavlinkFilePlayingStateChangedStateLoaded is loaded: Mavlink
+	// file is loaded (it will be played at take-off)
+	MavlinkStateMavlinkFilePlayingStateChangedStateLoaded
+)
+
+// mavlinkStateMavlinkFilePlayingStateChangedStateNames maps each
+// MavlinkStateMavlinkFilePlayingStateChangedState value to its name as defined
+// by the device SDK.
+var mavlinkStateMavlinkFilePlayingStateChangedStateNames = map[MavlinkStateMavlinkFilePlayingStateChangedState]string{
+	MavlinkStateMavlinkFilePlayingStateChangedStatePlaying: "playing",
+	MavlinkStateMavlinkFilePlayingStateChangedStateStopped: "stopped",
+	MavlinkStateMavlinkFilePlayingStateChangedStatePaused:  "paused",
+	MavlinkStateMavlinkFilePlayingStateChangedStateLoaded:  "loaded",
+}
+
+func (e MavlinkStateMavlinkFilePlayingStateChangedState) String() string {
+	if name, ok := mavlinkStateMavlinkFilePlayingStateChangedStateNames[e]; ok {
+		return name
+	}
+	return fmt.Sprintf("MavlinkStateMavlinkFilePlayingStateChangedState(%d)", int32(e))
+}
+
+// IsValid returns true if the MavlinkStateMavlinkFilePlayingStateChangedState
+// is one defined by the device SDK.
+func (e MavlinkStateMavlinkFilePlayingStateChangedState) IsValid() bool {
+	_, ok := mavlinkStateMavlinkFilePlayingStateChangedStateNames[e]
+	return ok
+}
+
+// MavlinkStateMavlinkFilePlayingStateChangedType is a type for constants used
+// as the type argument of the MavlinkState MavlinkFilePlayingStateChanged
+// command. type of the played mavlink file.
+type MavlinkStateMavlinkFilePlayingStateChangedType int32
+
+const (
+	// MavlinkStateMavlinkFilePlayingStateChangedTypeFlightPlan is flightPlan:
+	// Mavlink file for FlightPlan
+	MavlinkStateMavlinkFilePlayingStateChangedTypeFlightPlan MavlinkStateMavlinkFilePlayingStateChangedType = iota
+	// MavlinkStateMavlinkFilePlayingStateChangedTypeMapMyHouse is mapMyHouse:
+	// Mavlink file for MapMyHouse
+	MavlinkStateMavlinkFilePlayingStateChangedTypeMapMyHouse
+)
+
+// mavlinkStateMavlinkFilePlayingStateChangedTypeNames maps each
+// MavlinkStateMavlinkFilePlayingStateChangedType value to its name as defined
+// by the device SDK.
+var mavlinkStateMavlinkFilePlayingStateChangedTypeNames = map[MavlinkStateMavlinkFilePlayingStateChangedType]string{
+	MavlinkStateMavlinkFilePlayingStateChangedTypeFlightPlan: "flightPlan",
+	MavlinkStateMavlinkFilePlayingStateChangedTypeMapMyHouse: "mapMyHouse",
+}
+
+func (e MavlinkStateMavlinkFilePlayingStateChangedType) String() string {
+	if name, ok := mavlinkStateMavlinkFilePlayingStateChangedTypeNames[e]; ok {
+		return name
+	}
+	return fmt.Sprintf("MavlinkStateMavlinkFilePlayingStateChangedType(%d)", int32(e))
+}
+
+// IsValid returns true if the MavlinkStateMavlinkFilePlayingStateChangedType is
+// one defined by the device SDK.
+func (e MavlinkStateMavlinkFilePlayingStateChangedType) IsValid() bool {
+	_, ok := mavlinkStateMavlinkFilePlayingStateChangedTypeNames[e]
+	return ok
+}
+
+// MavlinkStateMavlinkPlayErrorStateChangedError is a type for constants used as
+// the error argument of the MavlinkState MavlinkPlayErrorStateChanged command.
+// State of play error.
+type MavlinkStateMavlinkPlayErrorStateChangedError int32
+
+const (
+	// MavlinkStateMavlinkPlayErrorStateChangedErrorNone is none: There is no error
+	MavlinkStateMavlinkPlayErrorStateChangedErrorNone MavlinkStateMavlinkPlayErrorStateChangedError = iota
+	// MavlinkStateMavlinkPlayErrorStateChangedErrorNotInOutDoorMode is
+	// notInOutDoorMode: The drone is not in outdoor mode
+	MavlinkStateMavlinkPlayErrorStateChangedErrorNotInOutDoorMode
+	// MavlinkStateMavlinkPlayErrorStateChangedErrorGpsNotFixed is gpsNotFixed: The
+	// gps is not fixed
+	MavlinkStateMavlinkPlayErrorStateChangedErrorGpsNotFixed
+	// MavlinkStateMavlinkPlayErrorStateChangedErrorNotCalibrated is notCalibrated:
+	// The magnetometer of the drone is not calibrated
+	MavlinkStateMavlinkPlayErrorStateChangedErrorNotCalibrated
+)
+
+// mavlinkStateMavlinkPlayErrorStateChangedErrorNames maps each
+// MavlinkStateMavlinkPlayErrorStateChangedError value to its name as defined by
+// the device SDK.
+var mavlinkStateMavlinkPlayErrorStateChangedErrorNames = map[MavlinkStateMavlinkPlayErrorStateChangedError]string{
+	MavlinkStateMavlinkPlayErrorStateChangedErrorNone:             "none",
+	MavlinkStateMavlinkPlayErrorStateChangedErrorNotInOutDoorMode: "notInOutDoorMode",
+	MavlinkStateMavlinkPlayErrorStateChangedErrorGpsNotFixed:      "gpsNotFixed",
+	MavlinkStateMavlinkPlayErrorStateChangedErrorNotCalibrated:    "notCalibrated",
+}
+
+func (e MavlinkStateMavlinkPlayErrorStateChangedError) String() string {
+	if name, ok := mavlinkStateMavlinkPlayErrorStateChangedErrorNames[e]; ok {
+		return name
+	}
+	return fmt.Sprintf("MavlinkStateMavlinkPlayErrorStateChangedError(%d)", int32(e))
+}
+
+// IsValid returns true if the MavlinkStateMavlinkPlayErrorStateChangedError is
+// one defined by the device SDK.
+func (e MavlinkStateMavlinkPlayErrorStateChangedError) IsValid() bool {
+	_, ok := mavlinkStateMavlinkPlayErrorStateChangedErrorNames[e]
+	return ok
+}
+
+// mavlinkStateHandlers is implemented by the MavlinkState class, which handles
+// each of its commands. Mavlink flight plans states commands.
+type mavlinkStateHandlers interface {
+	mavlinkFilePlayingStateChanged(args []interface{}) error
+	mavlinkPlayErrorStateChanged(args []interface{}) error
+	missionItemExecuted(args []interface{}) error
+}
+
+// mavlinkStateDefaults implements every handler of the MavlinkState class by
+// logging that the command was received. The MavlinkState class can embed it
+// and implement only the handlers for commands it acts upon.
+type mavlinkStateDefaults struct{}
+
+// mavlinkStateD2CCommands returns the commands of the MavlinkState class, each
+// dispatched to the corresponding handler.
+func mavlinkStateD2CCommands(
+	handlers mavlinkStateHandlers,
+) []arcommands.D2CCommand {
+	return []arcommands.D2CCommand{
+		arcommands.NewD2CCommand(
+			0,
+			"MavlinkFilePlayingStateChanged",
+			[]interface{}{
+				MavlinkStateMavlinkFilePlayingStateChangedState(0), // state,
+				"", // filepath,
+				MavlinkStateMavlinkFilePlayingStateChangedType(0), // type,
+			},
+			handlers.mavlinkFilePlayingStateChanged,
+		),
+		arcommands.NewD2CCommand(
+			1,
+			"MavlinkPlayErrorStateChanged",
+			[]interface{}{
+				MavlinkStateMavlinkPlayErrorStateChangedError(0), // error,
+			},
+			handlers.mavlinkPlayErrorStateChanged,
+		),
+		arcommands.NewD2CCommand(
+			2,
+			"MissionItemExecuted",
+			[]interface{}{
+				uint32(0), // idx,
+			},
+			handlers.missionItemExecuted,
+		),
+	}
+}
+
+// Title: Playing state of a FlightPlan
+// Description: Playing state of a FlightPlan.
+// Support: 0901:2.0.29;090c;090e
+// Triggered: by [StartFlightPlan](#0-11-0), [PauseFlightPlan](#0-11-1) or
+// [StopFlightPlan](#0-11-2).
+// Result:
+func (mavlinkStateDefaults) mavlinkFilePlayingStateChanged(
+	args []interface{},
+) error {
+	// state := args[0].(MavlinkStateMavlinkFilePlayingStateChangedState)
+	//   State of the mavlink
+	//   0: playing: Mavlink file is playing
+	//   1: stopped: Mavlink file is stopped (arg filepath and type are useless in
+	//      this state)
+	//   2: paused: Mavlink file is paused
+	//   3: loaded: Mavlink file is loaded (it will be played at take-off)
+	// filepath := args[1].(string)
+	//   flight plan file path from the mavlink ftp root
+	// type := args[2].(MavlinkStateMavlinkFilePlayingStateChangedType)
+	//   type of the played mavlink file
+	//   0: flightPlan: Mavlink file for FlightPlan
+	//   1: mapMyHouse: Mavlink file for MapMyHouse
+	log.Info("common.mavlinkFilePlayingStateChanged() called")
+	return nil
+}
+
+// Title: FlightPlan error
+// Description: FlightPlan error.
+// Support: 0901:2.0.29;090c;090e
+// Triggered: by [StartFlightPlan](#0-11-0) if an error occurs.
+// Result:
+//
+// Deprecated: This command is deprecated by the device SDK.
+func (mavlinkStateDefaults) mavlinkPlayErrorStateChanged(
+	args []interface{},
+) error {
+	// error := args[0].(MavlinkStateMavlinkPlayErrorStateChangedError)
+	//   State of play error
+	//   0: none: There is no error
+	//   1: notInOutDoorMode: The drone is not in outdoor mode
+	//   2: gpsNotFixed: The gps is not fixed
+	//   3: notCalibrated: The magnetometer of the drone is not calibrated
+	log.Info("common.mavlinkPlayErrorStateChanged() called")
+	return nil
+}
+
+// Title: Mission item executed
+// Description: Mission item has been executed.
+// Support: 090c:4.2.0;090e:1.4.0
+// Triggered: when a mission item has been executed during a flight plan.
+// Result:
+func (mavlinkStateDefaults) missionItemExecuted(args []interface{}) error {
+	// idx := args[0].(uint32)
+	//   Index of the mission item. This is the place of the mission item in the
+	//   list of the items of the mission. Begins at 0.
+	log.Info("common.missionItemExecuted() called")
+	return nil
+}
+
+// sendCalibrationStartOrAbortMagnetoCalib sends the Calibration
+// StartOrAbortMagnetoCalib command to the device.
+// Title: Start/Abort magnetometer calibration
+// Description: Start or abort magnetometer calibration process.\n
+// Support: 0901;090c;090e
+// Result: The magnetometer calibration process is started or aborted. Then,
+// event [MagnetoCalibrationStartedChanged](#0-14-3) is triggered.\n If started,
+// event [MagnetoCalibrationStateChanged](#0-14-3) is triggered with the current
+// calibration state: a list of all axis and their calibration states.\n It will
+// also trigger [MagnetoCalibrationAxisToCalibrateChanged](#0-14-2), that will
+// inform the controller about the current axis to calibrate.
+func sendCalibrationStartOrAbortMagnetoCalib(
+	c2dCommandClient arcommands.C2DCommandClient,
+	calibrate uint8,
+) error {
+	// calibrate:
+	//   1 to start the calibration, 0 to abort it
+	if err := c2dCommandClient.SendCommand(
+		arcommands.C2DAckBufferID,
+		0,  // common
+		13, // Calibration
+		0,  // StartOrAbortMagnetoCalib
+		calibrate,
+	); err != nil {
+		return errors.Wrap(err, "error sending StartOrAbortMagnetoCalib command")
+	}
+	return nil
+}
+
+// sendCalibrationStartOrAbortMagnetoCalibWithReceipt is like
+// sendCalibrationStartOrAbortMagnetoCalib, but also returns an
+// arnetwork.Receipt that is resolved with the outcome of delivering the
+// command.
+func sendCalibrationStartOrAbortMagnetoCalibWithReceipt(
+	c2dCommandClient arcommands.C2DCommandClient,
+	calibrate uint8,
+) (*arnetwork.Receipt, error) {
+	receipt, err := c2dCommandClient.SendCommandWithReceipt(
+		arcommands.C2DAckBufferID,
+		0,  // common
+		13, // Calibration
+		0,  // StartOrAbortMagnetoCalib
+		calibrate,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "error sending StartOrAbortMagnetoCalib command")
+	}
+	return receipt, nil
+}
+
+// CalibrationStateMagnetoCalibrationAxisToCalibrateChangedAxis is a type for
+// constants used as the axis argument of the CalibrationState
+// MagnetoCalibrationAxisToCalibrateChanged command. The axis to calibrate.
+type CalibrationStateMagnetoCalibrationAxisToCalibrateChangedAxis int32
+
+const (
+	// CalibrationStateMagnetoCalibrationAxisToCalibrateChangedAxisXAxis is xAxis:
+	// If the current calibration axis should be the x axis
+	CalibrationStateMagnetoCalibrationAxisToCalibrateChangedAxisXAxis CalibrationStateMagnetoCalibrationAxisToCalibrateChangedAxis = iota
+	// CalibrationStateMagnetoCalibrationAxisToCalibrateChangedAxisYAxis is yAxis:
+	// If the current calibration axis should be the y axis
+	CalibrationStateMagnetoCalibrationAxisToCalibrateChangedAxisYAxis
+	// CalibrationStateMagnetoCalibrationAxisToCalibrateChangedAxisZAxis is zAxis:
+	// If the current calibration axis should be the z axis
+	CalibrationStateMagnetoCalibrationAxisToCalibrateChangedAxisZAxis
+	// CalibrationStateMagnetoCalibrationAxisToCalibrateChangedAxisNone is none: If
+	// none of the axis should be calibrated
+	CalibrationStateMagnetoCalibrationAxisToCalibrateChangedAxisNone
+)
+
+// calibrationStateMagnetoCalibrationAxisToCalibrateChangedAxisNames maps each
+// CalibrationStateMagnetoCalibrationAxisToCalibrateChangedAxis value to its
+// name as defined by the device SDK.
+var calibrationStateMagnetoCalibrationAxisToCalibrateChangedAxisNames = map[CalibrationStateMagnetoCalibrationAxisToCalibrateChangedAxis]string{
+	CalibrationStateMagnetoCalibrationAxisToCalibrateChangedAxisXAxis: "xAxis",
+	CalibrationStateMagnetoCalibrationAxisToCalibrateChangedAxisYAxis: "yAxis",
+	CalibrationStateMagnetoCalibrationAxisToCalibrateChangedAxisZAxis: "zAxis",
+	CalibrationStateMagnetoCalibrationAxisToCalibrateChangedAxisNone:  "none",
+}
+
+func (e CalibrationStateMagnetoCalibrationAxisToCalibrateChangedAxis) String() string {
+	if name, ok := calibrationStateMagnetoCalibrationAxisToCalibrateChangedAxisNames[e]; ok {
+		return name
+	}
+	return fmt.Sprintf("CalibrationStateMagnetoCalibrationAxisToCalibrateChangedAxis(%d)", int32(e))
+}
+
+// IsValid returns true if the
+// CalibrationStateMagnetoCalibrationAxisToCalibrateChangedAxis is one defined
+// by the device SDK.
+func (e CalibrationStateMagnetoCalibrationAxisToCalibrateChangedAxis) IsValid() bool {
+	_, ok := calibrationStateMagnetoCalibrationAxisToCalibrateChangedAxisNames[e]
+	return ok
+}
+
+// CalibrationStatePitotCalibrationStateChangedState is a type for constants
+// used as the state argument of the CalibrationState
+// PitotCalibrationStateChanged command. State of pitot calibration.
+type CalibrationStatePitotCalibrationStateChangedState int32
+
+const (
+	// CalibrationStatePitotCalibrationStateChangedStateDone is done: Calibration
+	// is ok
+	CalibrationStatePitotCalibrationStateChangedStateDone CalibrationStatePitotCalibrationStateChangedState = iota
+	// CalibrationStatePitotCalibrationStateChangedStateReady is ready: Calibration
+	// is started, waiting user action
+	CalibrationStatePitotCalibrationStateChangedStateReady
+	// CalibrationStatePitotCalibrationStateChangedStateInProgress is in_progress:
+	// Calibration is in progress
+	CalibrationStatePitotCalibrationStateChangedStateInProgress
+	// CalibrationStatePitotCalibrationStateChangedStateRequired is required:
+	// Calibration is required
+	CalibrationStatePitotCalibrationStateChangedStateRequired
+)
+
+// calibrationStatePitotCalibrationStateChangedStateNames maps each
+// CalibrationStatePitotCalibrationStateChangedState value to its name as
+// defined by the device SDK.
+var calibrationStatePitotCalibrationStateChangedStateNames = map[CalibrationStatePitotCalibrationStateChangedState]string{
+	CalibrationStatePitotCalibrationStateChangedStateDone:       "done",
+	CalibrationStatePitotCalibrationStateChangedStateReady:      "ready",
+	CalibrationStatePitotCalibrationStateChangedStateInProgress: "in_progress",
+	CalibrationStatePitotCalibrationStateChangedStateRequired:   "required",
+}
+
+func (e CalibrationStatePitotCalibrationStateChangedState) String() string {
+	if name, ok := calibrationStatePitotCalibrationStateChangedStateNames[e]; ok {
+		return name
+	}
+	return fmt.Sprintf("CalibrationStatePitotCalibrationStateChangedState(%d)", int32(e))
+}
+
+// IsValid returns true if the CalibrationStatePitotCalibrationStateChangedState
+// is one defined by the device SDK.
+func (e CalibrationStatePitotCalibrationStateChangedState) IsValid() bool {
+	_, ok := calibrationStatePitotCalibrationStateChangedStateNames[e]
+	return ok
+}
+
+// calibrationStateHandlers is implemented by the CalibrationState class, which
+// handles each of its commands. Status of the calibration.
+type calibrationStateHandlers interface {
+	magnetoCalibrationStateChanged(args []interface{}) error
+	magnetoCalibrationRequiredState(args []interface{}) error
+	magnetoCalibrationAxisToCalibrateChanged(args []interface{}) error
+	magnetoCalibrationStartedChanged(args []interface{}) error
+	pitotCalibrationStateChanged(args []interface{}) error
+}
+
+// calibrationStateDefaults implements every handler of the CalibrationState
+// class by logging that the command was received. The CalibrationState class
+// can embed it and implement only the handlers for commands it acts upon.
+type calibrationStateDefaults struct{}
+
+// calibrationStateD2CCommands returns the commands of the CalibrationState
+// class, each dispatched to the corresponding handler.
+func calibrationStateD2CCommands(
+	handlers calibrationStateHandlers,
+) []arcommands.D2CCommand {
+	return []arcommands.D2CCommand{
+		arcommands.NewD2CCommand(
+			0,
+			"MagnetoCalibrationStateChanged",
+			[]interface{}{
+				uint8(0), // xAxisCalibration,
+				uint8(0), // yAxisCalibration,
+				uint8(0), // zAxisCalibration,
+				uint8(0), // calibrationFailed,
+			},
+			handlers.magnetoCalibrationStateChanged,
+		),
+		arcommands.NewD2CCommand(
+			1,
+			"MagnetoCalibrationRequiredState",
+			[]interface{}{
+				uint8(0), // required,
+			},
+			handlers.magnetoCalibrationRequiredState,
+		),
+		arcommands.NewD2CCommand(
+			2,
+			"MagnetoCalibrationAxisToCalibrateChanged",
+			[]interface{}{
+				CalibrationStateMagnetoCalibrationAxisToCalibrateChangedAxis(0), // axis,
+			},
+			handlers.magnetoCalibrationAxisToCalibrateChanged,
+		),
+		arcommands.NewD2CCommand(
+			3,
+			"MagnetoCalibrationStartedChanged",
+			[]interface{}{
+				uint8(0), // started,
+			},
+			handlers.magnetoCalibrationStartedChanged,
+		),
+		arcommands.NewD2CCommand(
+			4,
+			"PitotCalibrationStateChanged",
+			[]interface{}{
+				CalibrationStatePitotCalibrationStateChangedState(0), // state,
+				uint8(0), // lastError,
+			},
+			handlers.pitotCalibrationStateChanged,
+		),
+	}
+}
+
+// Title: Magneto calib process axis state
+// Description: Magneto calib process axis state.
+// Support: 0901;090c;090e
+// Triggered: when the calibration process is started with
+// [StartOrAbortMagnetoCalib](#0-13-0) and each time an axis calibration state
+// changes.
+// Result:
+func (calibrationStateDefaults) magnetoCalibrationStateChanged(
+	args []interface{},
+) error {
+	// xAxisCalibration := args[0].(uint8)
+	//   State of the x axis (roll) calibration : 1 if calibration is done, 0
+	//   otherwise
+	// yAxisCalibration := args[1].(uint8)
+	//   State of the y axis (pitch) calibration : 1 if calibration is done, 0
+	//   otherwise
+	// zAxisCalibration := args[2].(uint8)
+	//   State of the z axis (yaw) calibration : 1 if calibration is done, 0
+	//   otherwise
+	// calibrationFailed := args[3].(uint8)
+	//   1 if calibration has failed, 0 otherwise. If this arg is 1, consider all
+	//   previous arg as 0
+	log.Info("common.magnetoCalibrationStateChanged() called")
+	return nil
+}
+
+// Title: Calibration required
+// Description: Calibration required.
+// Support: 0901;090c;090e
+// Triggered: when the calibration requirement changes.
+// Result:
+func (calibrationStateDefaults) magnetoCalibrationRequiredState(
+	args []interface{},
+) error {
+	// required := args[0].(uint8)
+	//   1 if calibration is required, 0 if current calibration is still valid
+	log.Info("common.magnetoCalibrationRequiredState() called")
+	return nil
+}
+
+// Title: Axis to calibrate during calibration process
+// Description: Axis to calibrate during calibration process.
+// Support: 0901;090c;090e
+// Triggered: during the calibration process when the axis to calibrate changes.
+// Result:
+func (calibrationStateDefaults) magnetoCalibrationAxisToCalibrateChanged(
+	args []interface{},
+) error {
+	// axis := args[0].(CalibrationStateMagnetoCalibrationAxisToCalibrateChangedAxis)
+	//   The axis to calibrate
+	//   0: xAxis: If the current calibration axis should be the x axis
+	//   1: yAxis: If the current calibration axis should be the y axis
+	//   2: zAxis: If the current calibration axis should be the z axis
+	//   3: none: If none of the axis should be calibrated
+	log.Info("common.magnetoCalibrationAxisToCalibrateChanged() called")
+	return nil
+}
+
+// Title: Calibration process state
+// Description: Calibration process state.
+// Support: 0901;090c;090e
+// Triggered: by [StartOrAbortMagnetoCalib](#0-13-0) or when the process ends
+// because it succeeded.
+// Result:
+func (calibrationStateDefaults) magnetoCalibrationStartedChanged(
+	args []interface{},
+) error {
+	// started := args[0].(uint8)
+	//   1 if calibration has started, 0 otherwise
+	log.Info("common.magnetoCalibrationStartedChanged() called")
+	return nil
+}
+
+// Title: Pitot calibration state
+// Description: Sent when the state of the pitot calibration has changed.
+// Support: 090e
+// Triggered: by [StartOrAbortPitotCalib](#0-13-1) or when the calibration state
+// changes.
+// Result:
+func (calibrationStateDefaults) pitotCalibrationStateChanged(
+	args []interface{},
+) error {
+	// state := args[0].(CalibrationStatePitotCalibrationStateChangedState)
+	//   State of pitot calibration
+	//   0: done: Calibration is ok
+	//   1: ready: Calibration is started, waiting user action
+	//   2: in_progress: Calibration is in progress
+	//   3: required: Calibration is required
+	// lastError := args[1].(uint8)
+	//   lastError : 1 if an error occured and 0 if not
+	log.Info("common.pitotCalibrationStateChanged() called")
+	return nil
+}
+
+// cameraSettingsStateHandlers is implemented by the CameraSettingsState class,
+// which handles each of its commands. Status of the camera settings.
+type cameraSettingsStateHandlers interface {
+	cameraSettingsChanged(args []interface{}) error
+}
+
+// cameraSettingsStateDefaults implements every handler of the
+// CameraSettingsState class by logging that the command was received. The
+// CameraSettingsState class can embed it and implement only the handlers for
+// commands it acts upon.
+type cameraSettingsStateDefaults struct{}
+
+// cameraSettingsStateD2CCommands returns the commands of the
+// CameraSettingsState class, each dispatched to the corresponding handler.
+func cameraSettingsStateD2CCommands(
+	handlers cameraSettingsStateHandlers,
+) []arcommands.D2CCommand {
+	return []arcommands.D2CCommand{
+		arcommands.NewD2CCommand(
+			0,
+			"CameraSettingsChanged",
+			[]interface{}{
+				float32(0), // fov,
+				float32(0), // panMax,
+				float32(0), // panMin,
+				float32(0), // tiltMax,
+				float32(0), // tiltMin,
+			},
+			handlers.cameraSettingsChanged,
+		),
+	}
+}
+
+// Title: Camera info
+// Description: Camera info.
+// Support: 0901;090c;090e
+// Triggered: at connection.
+// Result:
+func (cameraSettingsStateDefaults) cameraSettingsChanged(
+	args []interface{},
+) error {
+	// fov := args[0].(float32)
+	//   Value of the camera horizontal fov (in degree)
+	// panMax := args[1].(float32)
+	//   Value of max pan (right pan) (in degree)
+	// panMin := args[2].(float32)
+	//   Value of min pan (left pan) (in degree)
+	// tiltMax := args[3].(float32)
+	//   Value of max tilt (top tilt) (in degree)
+	// tiltMin := args[4].(float32)
+	//   Value of min tilt (bottom tilt) (in degree)
+	log.Info("common.cameraSettingsChanged() called")
+	return nil
+}
+
+// FlightPlanStateComponentStateListChangedComponent is a type for constants
+// used as the component argument of the FlightPlanState
+// ComponentStateListChanged command. Drone FlightPlan component id (unique).
+type FlightPlanStateComponentStateListChangedComponent int32
+
+const (
+	// FlightPlanStateComponentStateListChangedComponentGPS is GPS: Drone GPS
+	// component. State is 0 when the drone needs a GPS fix.
+	FlightPlanStateComponentStateListChangedComponentGPS FlightPlanStateComponentStateListChangedComponent = iota
+	// FlightPlanStateComponentStateListChangedComponentCalibration is Calibration:
+	// Drone Calibration component. State is 0 when the sensors of the drone needs
+	// to be calibrated.
+	FlightPlanStateComponentStateListChangedComponentCalibration
+	// FlightPlanStateComponentStateListChangedComponentMavlinkFile is
+	// Mavlink_File: Mavlink file component. State is 0 when the mavlink file is
+	// missing or contains error.
+	FlightPlanStateComponentStateListChangedComponentMavlinkFile
+	// FlightPlanStateComponentStateListChangedComponentTakeOff is TakeOff: Drone
+	// Take off component. State is 0 when the drone cannot take-off.
+	FlightPlanStateComponentStateListChangedComponentTakeOff
+	// FlightPlanStateComponentStateListChangedComponentWaypointsBeyondGeofence is
+	// WaypointsBeyondGeofence: Component for waypoints beyond the geofence. State
+	// is 0 when one or more waypoints are beyond the geofence.
+	FlightPlanStateComponentStateListChangedComponentWaypointsBeyondGeofence
+)
+
+// flightPlanStateComponentStateListChangedComponentNames maps each
+// FlightPlanStateComponentStateListChangedComponent value to its name as
+// defined by the device SDK.
+var flightPlanStateComponentStateListChangedComponentNames = map[FlightPlanStateComponentStateListChangedComponent]string{
+	FlightPlanStateComponentStateListChangedComponentGPS:                     "GPS",
+	FlightPlanStateComponentStateListChangedComponentCalibration:             "Calibration",
+	FlightPlanStateComponentStateListChangedComponentMavlinkFile:             "Mavlink_File",
+	FlightPlanStateComponentStateListChangedComponentTakeOff:                 "TakeOff",
+	FlightPlanStateComponentStateListChangedComponentWaypointsBeyondGeofence: "WaypointsBeyondGeofence",
+}
+
+func (e FlightPlanStateComponentStateListChangedComponent) String() string {
+	if name, ok := flightPlanStateComponentStateListChangedComponentNames[e]; ok {
+		return name
+	}
+	return fmt.Sprintf("FlightPlanStateComponentStateListChangedComponent(%d)", int32(e))
+}
+
+// IsValid returns true if the FlightPlanStateComponentStateListChangedComponent
+// is one defined by the device SDK.
+func (e FlightPlanStateComponentStateListChangedComponent) IsValid() bool {
+	_, ok := flightPlanStateComponentStateListChangedComponentNames[e]
+	return ok
+}
+
+// flightPlanStateHandlers is implemented by the FlightPlanState class, which
+// handles each of its commands. FlightPlan state commands.
+type flightPlanStateHandlers interface {
+	availabilityStateChanged(args []interface{}) error
+	componentStateListChanged(args []interface{}) error
+	lockStateChanged(args []interface{}) error
+}
+
+// flightPlanStateDefaults implements every handler of the FlightPlanState class
+// by logging that the command was received. The FlightPlanState class can embed
+// it and implement only the handlers for commands it acts upon.
+type flightPlanStateDefaults struct{}
+
+// flightPlanStateD2CCommands returns the commands of the FlightPlanState class,
+// each dispatched to the corresponding handler.
+func flightPlanStateD2CCommands(
+	handlers flightPlanStateHandlers,
+) []arcommands.D2CCommand {
+	return []arcommands.D2CCommand{
+		arcommands.NewD2CCommand(
+			0,
+			"AvailabilityStateChanged",
+			[]interface{}{
+				uint8(0), // AvailabilityState,
+			},
+			handlers.availabilityStateChanged,
+		),
+		arcommands.NewD2CCommand(
+			1,
+			"ComponentStateListChanged",
+			[]interface{}{
+				FlightPlanStateComponentStateListChangedComponent(0), // component,
+				uint8(0), // State,
+			},
+			handlers.componentStateListChanged,
+		),
+		arcommands.NewD2CCommand(
+			2,
+			"LockStateChanged",
+			[]interface{}{
+				uint8(0), // LockState,
+			},
+			handlers.lockStateChanged,
+		),
+	}
+}
+
+// Title: FlightPlan availability
+// Description: FlightPlan availability.\n Availability is linked to GPS fix,
+// magnetometer calibration, sensor states...
+// Support: 0901:2.0.29;090c;090e
+// Triggered: on change.
+// Result:
+func (flightPlanStateDefaults) availabilityStateChanged(
+	args []interface{},
+) error {
+	// AvailabilityState := args[0].(uint8)
+	//   Running a flightPlan file is available (1 running a flightPlan file is
+	//   available, otherwise 0)
+	log.Info("common.availabilityStateChanged() called")
+	return nil
+}
+
+// Title: FlightPlan components state list
+// Description: FlightPlan components state list.
+// Support: 0901:2.0.29;090c;090e
+// Triggered: when the state of required components changes. \n GPS component is
+// triggered when the availability of the GPS of the drone changes. \n
+// Calibration component is triggered when the calibration state of the drone
+// sensors changes \n Mavlink_File component is triggered when the command
+// [StartFlightPlan](#0-11-0) is received. \n Takeoff component is triggered
+// when the drone needs to take-off to continue the FlightPlan. \n
+// WaypointsBeyondGeofence component is triggered when the command
+// [StartFlightPlan](#0-11-0) is received.
+// Result:
+func (flightPlanStateDefaults) componentStateListChanged(
+	args []interface{},
+) error {
+	// component := args[0].(FlightPlanStateComponentStateListChangedComponent)
+	//   Drone FlightPlan component id (unique)
+	//   0: GPS: Drone GPS component. State is 0 when the drone needs a GPS fix.
+	//   1: Calibration: Drone Calibration component. State is 0 when the sensors
+	//      of the drone needs to be calibrated.
+	//   2: Mavlink_File: Mavlink file component. State is 0 when the mavlink file
+	//      is missing or contains error.
+	//   3: TakeOff: Drone Take off component. State is 0 when the drone cannot
+	//      take-off.
+	//   4: WaypointsBeyondGeofence: Component for waypoints beyond the geofence.
+	//      State is 0 when one or more waypoints are beyond the geofence.
+	// State := args[1].(uint8)
+	//   State of the FlightPlan component (1 FlightPlan component OK, otherwise 0)
+	log.Info("common.componentStateListChanged() called")
+	return nil
+}
+
+// Title: FlightPlan lock state
+// Description: FlightPlan lock state.\n Represents the fact that the controller
+// is able or not to stop or pause a playing FlightPlan
+// Support: 0901:2.0.29;090c;090e
+// Triggered: when the lock changes.
+// Result:
+func (flightPlanStateDefaults) lockStateChanged(args []interface{}) error {
+	// LockState := args[0].(uint8)
+	//   1 if FlightPlan is locked: can't pause or stop FlightPlan. 0 if FlightPlan
+	//   is unlocked: pause or stop available.
+	log.Info("common.lockStateChanged() called")
+	return nil
+}
+
+// arLibsVersionsStateHandlers is implemented by the ARLibsVersionsState class,
+// which handles each of its commands. ARlibs Versions Commands.
+type arLibsVersionsStateHandlers interface {
+	controllerLibARCommandsVersion(args []interface{}) error
+	skyControllerLibARCommandsVersion(args []interface{}) error
+	deviceLibARCommandsVersion(args []interface{}) error
+}
+
+// arLibsVersionsStateDefaults implements every handler of the
+// ARLibsVersionsState class by logging that the command was received. The
+// ARLibsVersionsState class can embed it and implement only the handlers for
+// commands it acts upon.
+type arLibsVersionsStateDefaults struct{}
+
+// arLibsVersionsStateD2CCommands returns the commands of the
+// ARLibsVersionsState class, each dispatched to the corresponding handler.
+func arLibsVersionsStateD2CCommands(
+	handlers arLibsVersionsStateHandlers,
+) []arcommands.D2CCommand {
+	return []arcommands.D2CCommand{
+		arcommands.NewD2CCommand(
+			0,
+			"ControllerLibARCommandsVersion",
+			[]interface{}{
+				"", // version,
+			},
+			handlers.controllerLibARCommandsVersion,
+		),
+		arcommands.NewD2CCommand(
+			1,
+			"SkyControllerLibARCommandsVersion",
+			[]interface{}{
+				"", // version,
+			},
+			handlers.skyControllerLibARCommandsVersion,
+		),
+		arcommands.NewD2CCommand(
+			2,
+			"DeviceLibARCommandsVersion",
+			[]interface{}{
+				"", // version,
+			},
+			handlers.deviceLibARCommandsVersion,
+		),
+	}
+}
+
+// Title: Controller libARCommands version
+// Description: Controller libARCommands version.
+// Support: none
+// Result:
+func (arLibsVersionsStateDefaults) controllerLibARCommandsVersion(
+	args []interface{},
+) error {
+	// version := args[0].(string)
+	//   version of libARCommands ("1.2.3.4" format)
+	log.Info("common.controllerLibARCommandsVersion() called")
+	return nil
+}
+
+// Title: SkyController libARCommands version
+// Description: SkyController libARCommands version.
+// Support: none
+// Result:
+func (arLibsVersionsStateDefaults) skyControllerLibARCommandsVersion(
+	args []interface{},
+) error {
+	// version := args[0].(string)
+	//   version of libARCommands ("1.2.3.4" format)
+	log.Info("common.skyControllerLibARCommandsVersion() called")
+	return nil
+}
+
+// Title: Device libARCommands version
+// Description: Device libARCommands version.
+// Support: none
+// Result:
+func (arLibsVersionsStateDefaults) deviceLibARCommandsVersion(
+	args []interface{},
+) error {
+	// version := args[0].(string)
+	//   version of libARCommands ("1.2.3.4" format)
+	log.Info("common.deviceLibARCommandsVersion() called")
+	return nil
+}
+
+// flightPlanEventHandlers is implemented by the FlightPlanEvent class, which
+// handles each of its commands. FlightPlan Event commands.
+type flightPlanEventHandlers interface {
+	startingErrorEvent(args []interface{}) error
+	speedBridleEvent(args []interface{}) error
+}
+
+// flightPlanEventDefaults implements every handler of the FlightPlanEvent class
+// by logging that the command was received. The FlightPlanEvent class can embed
+// it and implement only the handlers for commands it acts upon.
+type flightPlanEventDefaults struct{}
+
+// flightPlanEventD2CCommands returns the commands of the FlightPlanEvent class,
+// each dispatched to the corresponding handler.
+func flightPlanEventD2CCommands(
+	handlers flightPlanEventHandlers,
+) []arcommands.D2CCommand {
+	return []arcommands.D2CCommand{
+		arcommands.NewD2CCommand(
+			0,
+			"StartingErrorEvent",
+			[]interface{}{},
+			handlers.startingErrorEvent,
+		),
+		arcommands.NewD2CCommand(
+			1,
+			"SpeedBridleEvent",
+			[]interface{}{},
+			handlers.speedBridleEvent,
+		),
+	}
+}
+
+// Title: FlightPlan start error
+// Description: FlightPlan start error.\n\n **This event is a notification, you
+// can't retrieve it in the cache of the device controller.**
+// Support: 0901:2.0.29;090c;090e
+// Triggered: on an error after a [StartFlightPlan](#0-11-0).
+// Result:
+func (flightPlanEventDefaults) startingErrorEvent(args []interface{}) error {
+	log.Info("common.startingErrorEvent() called")
+	return nil
+}
+
+// Title: FlightPlan speed clamping
+// Description: FlightPlan speed clamping.\n Sent when a speed specified in the
+// FlightPlan file is considered too high by the drone.\n\n **This event is a
+// notification, you can't retrieve it in the cache of the device controller.**
+// Support: none
+// Triggered: on an speed related clamping after a [StartFlightPlan](#0-11-0).
+// Result:
+func (flightPlanEventDefaults) speedBridleEvent(args []interface{}) error {
+	log.Info("common.speedBridleEvent() called")
+	return nil
+}
+
+// runStateHandlers is implemented by the RunState class, which handles each of
+// its commands. Commands sent by the drone to inform about the run or flight
+// state.
+type runStateHandlers interface {
+	runIdChanged(args []interface{}) error
+}
+
+// runStateDefaults implements every handler of the RunState class by logging
+// that the command was received. The RunState class can embed it and implement
+// only the handlers for commands it acts upon.
+type runStateDefaults struct{}
+
+// runStateD2CCommands returns the commands of the RunState class, each
+// dispatched to the corresponding handler.
+func runStateD2CCommands(
+	handlers runStateHandlers,
+) []arcommands.D2CCommand {
+	return []arcommands.D2CCommand{
+		arcommands.NewD2CCommand(
+			0,
+			"RunIdChanged",
+			[]interface{}{
+				"", // runId,
+			},
+			handlers.runIdChanged,
+		),
+	}
+}
+
+// Title: Current run id
+// Description: Current run id.\n A run id is uniquely identifying a run or a
+// flight.\n For each run is generated on the drone a file which can be used by
+// Academy to sum up the run.\n Also, each medias taken during a run has a
+// filename containing the run id.
+// Support: 0901:3.0.1;090c;090e
+// Triggered: when the drone generates a new run id (generally right after a
+// take off).
+// Result:
+func (runStateDefaults) runIdChanged(args []interface{}) error {
+	// runId := args[0].(string)
+	//   Id of the run
+	log.Info("common.runIdChanged() called")
+	return nil
+}
+
+// flightPlanSettingsStateHandlers is implemented by the FlightPlanSettingsState
+// class, which handles each of its commands.
+type flightPlanSettingsStateHandlers interface {
+	returnHomeOnDisconnectChanged(args []interface{}) error
+}
+
+// flightPlanSettingsStateDefaults implements every handler of the
+// FlightPlanSettingsState class by logging that the command was received. The
+// FlightPlanSettingsState class can embed it and implement only the handlers
+// for commands it acts upon.
+type flightPlanSettingsStateDefaults struct{}
+
+// flightPlanSettingsStateD2CCommands returns the commands of the
+// FlightPlanSettingsState class, each dispatched to the corresponding handler.
+func flightPlanSettingsStateD2CCommands(
+	handlers flightPlanSettingsStateHandlers,
+) []arcommands.D2CCommand {
+	return []arcommands.D2CCommand{
+		arcommands.NewD2CCommand(
+			0,
+			"ReturnHomeOnDisconnectChanged",
+			[]interface{}{
+				uint8(0), // state,
+				uint8(0), // isReadOnly,
+			},
+			handlers.returnHomeOnDisconnectChanged,
+		),
+	}
+}
+
+// Title: ReturnHome behavior during FlightPlan
+// Description: Define behavior of drone when disconnection occurs during a
+// flight plan
+// Support: 0901:4.1.0;090c:4.1.0;090e:1.4.0
+// Triggered: by [setReturnHomeOnDisconnectMode](#0-32-0).
+// Result:
+func (flightPlanSettingsStateDefaults) returnHomeOnDisconnectChanged(
+	args []interface{},
+) error {
+	// state := args[0].(uint8)
+	//   1 if enabled, 0 if disabled
+	// isReadOnly := args[1].(uint8)
+	//   1 if readOnly, 0 if writable
+	log.Info("common.returnHomeOnDisconnectChanged() called")
+	return nil
+}
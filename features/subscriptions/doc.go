@@ -0,0 +1,17 @@
+package subscriptions
+
+// The subscriptions package implements a mechanism for applications to be
+// notified of changes to feature state, as an alternative to polling state
+// getters. Each state class that supports subscriptions (e.g.
+// ardrone3.PilotingState) implements the Subscribable interface. Subscribers
+// may subscribe to changes to any attribute of a state class or only to
+// changes to specific attributes. Attributes are identified by the names of
+// the state class's getter functions-- e.g. "SpeedX". Each state class exports
+// a constant for each of its attributes-- e.g.
+// ardrone3.PilotingStateAttributeSpeedX.
+//
+// Notifications signal that state has changed; they do not carry the new
+// values. Upon receiving a notification, subscribers should read the current
+// values using the state class's getters. Since notifications carry no values,
+// a subscriber that falls behind and misses some notifications still observes
+// the latest state the next time it reads it.
@@ -0,0 +1,155 @@
+package subscriptions
+
+import (
+	"sync"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/pkg/errors"
+)
+
+// notificationsChSize is the buffer size of each subscription's notifications
+// channel.
+const notificationsChSize = 10
+
+// Notification describes a change to the state of a single state class.
+type Notification struct {
+	// Class is the name of the state class that changed-- e.g.
+	// "PilotingState".
+	Class string
+	// Attributes are the names of the attributes that were updated-- e.g.
+	// "SpeedX". These are the names of the state class's getter functions.
+	Attributes []string
+}
+
+// Subscribable is the interface for a state class that supports
+// subscriptions.
+type Subscribable interface {
+	// Subscribe returns a Subscription to changes to the specified attributes
+	// of the state class. If no attributes are specified, the subscription
+	// covers all attributes. Attributes are identified by the names of the
+	// state class's getter functions-- e.g. "SpeedX". Each state class exports
+	// a constant for each of its attributes. An error is returned if any of the
+	// specified attributes does not belong to the state class.
+	Subscribe(attributes ...string) (Subscription, error)
+}
+
+// Subscription is the interface for a single subscription to changes in
+// state.
+type Subscription interface {
+	// NotificationsCh returns a channel over which notifications are delivered
+	// in the order the corresponding changes occurred. The same channel is
+	// returned on every call. Delivery is non-blocking; if the channel's buffer
+	// is full, new notifications are dropped. The channel is closed when
+	// Unsubscribe() is called.
+	NotificationsCh() <-chan Notification
+	// Unsubscribe cancels the subscription. It is safe to call this more than
+	// once.
+	Unsubscribe()
+}
+
+// Publisher implements Subscribable on behalf of a state class and delivers
+// notifications to its subscribers. State classes are expected to embed a
+// *Publisher and to call Publish(...) whenever they update state.
+type Publisher struct {
+	class string
+	// attributes is the set of attributes that may be subscribed to
+	attributes    map[string]struct{}
+	subscriptions map[*subscription]struct{}
+	lock          sync.Mutex
+}
+
+type subscription struct {
+	publisher *Publisher
+	// attributes is the set of attributes subscribed to. If empty, the
+	// subscription covers all attributes.
+	attributes      map[string]struct{}
+	notificationsCh chan Notification
+}
+
+// NewPublisher returns a Publisher for the named state class having the given
+// attributes.
+func NewPublisher(class string, attributes ...string) *Publisher {
+	p := &Publisher{
+		class:         class,
+		attributes:    map[string]struct{}{},
+		subscriptions: map[*subscription]struct{}{},
+	}
+	for _, attribute := range attributes {
+		p.attributes[attribute] = struct{}{}
+	}
+	return p
+}
+
+// Subscribe implements Subscribable.
+func (p *Publisher) Subscribe(attributes ...string) (Subscription, error) {
+	for _, attribute := range attributes {
+		if _, ok := p.attributes[attribute]; !ok {
+			return nil, errors.Errorf(
+				"%s has no attribute %q",
+				p.class,
+				attribute,
+			)
+		}
+	}
+	s := &subscription{
+		publisher:       p,
+		attributes:      map[string]struct{}{},
+		notificationsCh: make(chan Notification, notificationsChSize),
+	}
+	for _, attribute := range attributes {
+		s.attributes[attribute] = struct{}{}
+	}
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.subscriptions[s] = struct{}{}
+	return s, nil
+}
+
+// Publish notifies subscribers that the specified attributes of the state
+// class have been updated. Only subscribers to all attributes or to at least
+// one of the specified attributes are notified. This never blocks.
+func (p *Publisher) Publish(attributes ...string) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	for s := range p.subscriptions {
+		if !s.isInterestedIn(attributes) {
+			continue
+		}
+		select {
+		case s.notificationsCh <- Notification{
+			Class:      p.class,
+			Attributes: attributes,
+		}:
+		default:
+			log.WithField(
+				"class", p.class,
+			).Debug("subscriber is falling behind; dropping notification")
+		}
+	}
+}
+
+func (s *subscription) isInterestedIn(attributes []string) bool {
+	if len(s.attributes) == 0 {
+		return true
+	}
+	for _, attribute := range attributes {
+		if _, ok := s.attributes[attribute]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *subscription) NotificationsCh() <-chan Notification {
+	return s.notificationsCh
+}
+
+func (s *subscription) Unsubscribe() {
+	s.publisher.lock.Lock()
+	defer s.publisher.lock.Unlock()
+	if _, ok := s.publisher.subscriptions[s]; !ok {
+		return
+	}
+	delete(s.publisher.subscriptions, s)
+	close(s.notificationsCh)
+}
@@ -0,0 +1,51 @@
+package subscriptions
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPublisher(t *testing.T) {
+	p := NewPublisher("PilotingState", "SpeedX", "SpeedY", "SpeedZ", "Altitude")
+	all, err := p.Subscribe()
+	require.NoError(t, err)
+	speed, err := p.Subscribe("SpeedX", "SpeedY")
+	require.NoError(t, err)
+	altitude, err := p.Subscribe("Altitude")
+	require.NoError(t, err)
+
+	p.Publish("SpeedX", "SpeedY", "SpeedZ")
+	expected := Notification{
+		Class:      "PilotingState",
+		Attributes: []string{"SpeedX", "SpeedY", "SpeedZ"},
+	}
+	require.Equal(t, expected, <-all.NotificationsCh())
+	require.Equal(t, expected, <-speed.NotificationsCh())
+	require.Len(t, altitude.NotificationsCh(), 0)
+
+	// Unsubscribing should close the channel and stop notifications
+	speed.Unsubscribe()
+	_, ok := <-speed.NotificationsCh()
+	require.False(t, ok)
+	speed.Unsubscribe() // Should be safe to call again
+	p.Publish("SpeedX")
+	require.Len(t, all.NotificationsCh(), 1)
+
+	// Delivery should never block, even if a subscriber falls behind
+	for i := 0; i < 2*notificationsChSize; i++ {
+		p.Publish("Altitude")
+	}
+	require.Len(t, altitude.NotificationsCh(), notificationsChSize)
+	require.Len(t, all.NotificationsCh(), notificationsChSize)
+}
+
+func TestSubscribeToUnknownAttribute(t *testing.T) {
+	p := NewPublisher("PilotingState", "SpeedX")
+	_, err := p.Subscribe("SpeedX", "Speedx")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `PilotingState has no attribute "Speedx"`)
+	// A failed subscription should not be notified of anything
+	p.Publish("SpeedX")
+	require.Len(t, p.subscriptions, 0)
+}
@@ -7,7 +7,9 @@ require (
 	github.com/pkg/errors v0.8.0
 	github.com/pmezard/go-difflib v1.0.0 // indirect
 	github.com/satori/go.uuid v1.2.0
-	github.com/stretchr/testify v1.2.2
+	github.com/stretchr/testify v1.8.0
+	go.uber.org/goleak v1.3.0
 	golang.org/x/crypto v0.0.0-20181015023909-0c41d7ab0a0e // indirect
 	golang.org/x/sys v0.0.0-20181011152604-fa43e7bc11ba // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
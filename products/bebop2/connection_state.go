@@ -0,0 +1,35 @@
+package bebop2
+
+// ConnectionState is a type for constants used to indicate the state of the
+// connection between a Controller and the device.
+type ConnectionState int
+
+const (
+	// ConnectionStateConnected indicates the Controller is connected to the
+	// device.
+	ConnectionStateConnected ConnectionState = iota
+	// ConnectionStateDisconnected indicates the Controller has detected a
+	// probable disconnection from the device-- for instance, because the device
+	// went out of range.
+	ConnectionStateDisconnected
+	// ConnectionStateReconnecting indicates the Controller is attempting to
+	// re-establish a lost connection to the device.
+	ConnectionStateReconnecting
+	// ConnectionStateClosed indicates the Controller was closed.
+	ConnectionStateClosed
+)
+
+func (c ConnectionState) String() string {
+	switch c {
+	case ConnectionStateConnected:
+		return "connected"
+	case ConnectionStateDisconnected:
+		return "disconnected"
+	case ConnectionStateReconnecting:
+		return "reconnecting"
+	case ConnectionStateClosed:
+		return "closed"
+	default:
+		return "unknown"
+	}
+}
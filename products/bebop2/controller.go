@@ -1,142 +1,355 @@
 package bebop2
 
 import (
+	"sync"
 	"time"
 
+	log "github.com/Sirupsen/logrus"
 	"github.com/krancour/go-parrot/features/ardrone3"
 	"github.com/krancour/go-parrot/features/common"
 	"github.com/krancour/go-parrot/protocols/arcommands"
-	"github.com/krancour/go-parrot/protocols/arnetwork"
-	"github.com/krancour/go-parrot/protocols/arnetworkal"
 	"github.com/krancour/go-parrot/protocols/arnetworkal/wifi"
+	"github.com/krancour/go-parrot/protocols/arstream"
 	"github.com/pkg/errors"
 )
 
-// Controller ...
-// TODO: Document this
+const (
+	// connectionStateChSize is the buffer size of the channel over which
+	// changes in connection state are delivered.
+	connectionStateChSize = 10
+	// videoFramesChSize is the buffer size of the channel over which video
+	// frames are delivered.
+	videoFramesChSize = 30
+	// minReconnectBackoff is how long the controller initially waits between
+	// failed reconnection attempts.
+	minReconnectBackoff = time.Second
+	// maxReconnectBackoff is the longest the controller will wait between
+	// failed reconnection attempts.
+	maxReconnectBackoff = 30 * time.Second
+)
+
+// Controller is the interface for monitoring and controlling a Parrot Bebop 2.
+// Device state and commands are grouped by feature, exactly as they are in the
+// Parrot developer documentation.
 type Controller interface {
-	// TODO: Add all supported commands to this interface
-	// The first ones we experiment with should be configuration related and
-	// not anything to do with flight. We want to be pretty sure that everything
-	// works before we try flying!
+	// Common returns the common feature, which exposes state and commands
+	// common to all Parrot devices.
+	Common() common.Feature
+	// ARDrone3 returns the ardrone3 feature, which exposes state and commands
+	// specific to quadcopters such as the Bebop 2-- including piloting commands.
+	ARDrone3() ardrone3.Feature
+	// StartPiloting starts a loop that sends the current piloting setpoint (roll,
+	// pitch, yaw, and gaz) to the device at the given interval using the PCMD
+	// command. The device expects this command to be sent continuously while
+	// piloting. DefaultPilotingInterval is a reasonable choice of interval. An
+	// error is returned if the loop is already running.
+	StartPiloting(interval time.Duration) error
+	// StopPiloting stops the piloting loop, if it is running, and zeroes the
+	// piloting setpoint. One final PCMD command is sent with the zeroed setpoint
+	// so that the device stops moving.
+	StopPiloting() error
+	// SetRoll sets the roll component of the piloting setpoint as a signed
+	// percentage of the max pitch/roll setting in the range [-100, 100]. Values
+	// outside that range are clamped. This is safe to call concurrently with
+	// any other setpoint function.
+	SetRoll(roll int8)
+	// SetPitch sets the pitch component of the piloting setpoint as a signed
+	// percentage of the max pitch/roll setting in the range [-100, 100]. Values
+	// outside that range are clamped. This is safe to call concurrently with
+	// any other setpoint function.
+	SetPitch(pitch int8)
+	// SetYaw sets the yaw component of the piloting setpoint as a signed
+	// percentage of the max yaw rotation speed setting in the range
+	// [-100, 100]. Values outside that range are clamped. This is safe to call
+	// concurrently with any other setpoint function.
+	SetYaw(yaw int8)
+	// SetGaz sets the gaz (throttle) component of the piloting setpoint as a
+	// signed percentage of the max vertical speed setting in the range
+	// [-100, 100]. Values outside that range are clamped. This is safe to call
+	// concurrently with any other setpoint function.
+	SetGaz(gaz int8)
+	// ConnectionState returns the current state of the connection to the
+	// device.
+	ConnectionState() ConnectionState
+	// ConnectionStateCh returns a channel over which changes in the state of the
+	// connection to the device are delivered. This permits applications to land
+	// or alert when a probable disconnection is detected. Note that the piloting
+	// loop, if running, is automatically stopped upon disconnection, after which
+	// the Controller automatically attempts to reconnect and, if successful,
+	// re-requests all settings and states from the device. The same channel is
+	// returned on every call. Delivery is non-blocking; if the channel's buffer
+	// is full, the oldest undelivered state is dropped in favor of the newest.
+	ConnectionStateCh() <-chan ConnectionState
+	// ConnectionParams returns the parameters the device communicated during
+	// negotiation of the most recently established connection. These remain
+	// available while the Controller is reconnecting.
+	ConnectionParams() wifi.ConnectionParams
+	// VideoFramesCh returns a channel over which video frames received from the
+	// device over the ARStream protocol are delivered. Video streaming must
+	// first be enabled using the ardrone3 feature's MediaStreaming commands. The
+	// same channel is returned on every call and remains valid across
+	// reconnections. Delivery is non-blocking; if the channel's buffer is full,
+	// newly received frames are dropped.
+	VideoFramesCh() <-chan arstream.Frame
+	// Close tears down the connection to the device and abandons any
+	// reconnection in progress. This closes all c2d buffers, the underlying
+	// network connections, and, consequently, all d2c buffers, which also stops
+	// the d2c command server. Once closed, a Controller cannot be re-used and
+	// attempts to send commands to the device will return an error.
+	Close()
 }
 
 type controller struct {
-	// common   common.Feature
-	// ardrone3 ardrone3.Feature
+	connectOptions      wifi.ConnectOptions
+	c2dCommandClient    *sessionC2DCommandClient
+	common              common.Feature
+	ardrone3            ardrone3.Feature
+	pilotingLoop        *pilotingLoop
+	session             *session
+	connectionParams    wifi.ConnectionParams
+	sessionLock         sync.Mutex
+	connectionState     ConnectionState
+	connectionStateCh   chan ConnectionState
+	connectionStateLock sync.RWMutex
+	videoFramesCh       chan arstream.Frame
+	closeCh             chan struct{}
+	closeOnce           sync.Once
 }
 
-// NewController ...
-// TODO: Document this
-func NewController() (Controller, error) {
-	frameSender, frameReceiver, err := wifi.Connect()
-	if err != nil {
-		return nil, errors.Wrap(err, "connection error")
+// NewController connects to a Parrot Bebop 2 and returns a Controller for
+// monitoring and controlling it. The given options determine how the device
+// is reached; the zero value is appropriate for a device on its own wifi
+// network. If the connection is subsequently lost, the Controller
+// automatically attempts to reconnect using the same options.
+func NewController(connectOptions wifi.ConnectOptions) (Controller, error) {
+	c2dCommandClient := &sessionC2DCommandClient{}
+	c := &controller{
+		connectOptions:    connectOptions,
+		c2dCommandClient:  c2dCommandClient,
+		common:            common.NewFeature(c2dCommandClient),
+		ardrone3:          ardrone3.NewFeature(c2dCommandClient),
+		connectionState:   ConnectionStateConnected,
+		connectionStateCh: make(chan ConnectionState, connectionStateChSize),
+		videoFramesCh:     make(chan arstream.Frame, videoFramesChSize),
+		closeCh:           make(chan struct{}),
 	}
-	_, d2cChs, err := arnetwork.NewBuffers(
-		frameSender,
-		frameReceiver,
-		[]arnetwork.C2DBufferConfig{
-			// Non ack data (periodic commands for piloting and camera orientation)
-			// This buffer transports arcommands
-			{
-				ID:            10,
-				FrameType:     arnetworkal.FrameTypeData,
-				Size:          2, // PCMD + camera
-				MaxDataSize:   128,
-				IsOverwriting: true, // Periodic data; most recent is better
-			},
-
-			// Ack data (events, settings, etc.)
-			// This buffer transports arcommands
-			{
-				ID:            11,
-				FrameType:     arnetworkal.FrameTypeDataWithAck,
-				AckTimeout:    150 * time.Millisecond,
-				MaxRetries:    5,
-				Size:          20,
-				MaxDataSize:   128,
-				IsOverwriting: false, // Events should not be dropped
-			},
-
-			// Emergency data (emergency commands only)
-			// This buffer transports arcommands
-			{
-				ID:            12,
-				FrameType:     arnetworkal.FrameTypeDataWithAck,
-				AckTimeout:    150 * time.Millisecond,
-				MaxRetries:    -1, // Infinite
-				Size:          1,
-				MaxDataSize:   128,
-				IsOverwriting: false, // Events should not be dropped
-			},
-
-			// // TODO: Do something about video streaming?
-			// // arstream video acks
-			// // This buffer transports arstream data
-			// {
-			// 	ID:            13,
-			// 	FrameType:     arnetworkal.FrameTypeLowLatencyData,
-			// 	Size:          1000, // Enough space
-			// 	MaxDataSize:   18,   // Size of an ack
-			// 	IsOverwriting: true, // New is always better
-			// },
-		},
-
-		[]arnetwork.D2CBufferConfig{
-			// Non ack data (periodic reports from the device)
-			// This buffer transports arcommands
-			{
-				ID:            127,
-				FrameType:     arnetworkal.FrameTypeData,
-				Size:          20,
-				MaxDataSize:   128,
-				IsOverwriting: true, // Periodic data: most recent is better
-			},
-
-			// Ack data (events, settings, etc.)
-			// This buffer transports arcommands
-			{
-				ID:            126,
-				FrameType:     arnetworkal.FrameTypeDataWithAck,
-				Size:          256,
-				MaxDataSize:   128,
-				IsOverwriting: false, // Events should not be dropped
-			},
-
-			// // TODO: Do something about video streaming?
-			// // arstream video data
-			// // This buffer transports arstream data
-			// {
-			// 	ID:        125,
-			// 	FrameType: arnetworkal.FrameTypeLowLatencyData,
-			// 	// TODO: According to documentation, size should be set to
-			// 	// "arstream_fragment_maximum_number * 2"
-			// 	// I think this is supposed to be determined during connection
-			// 	// negotiation???
-			// 	Size: 1000, // This value is a placeholder!
-			// 	// TODO: According to documentation, this should be set to
-			// 	// "arstream_fragment_size"
-			// 	// I think this is supposed to be determined during connection
-			// 	// negotiation???
-			// 	MaxDataSize:   256,  // This value is a placeholder!
-			// 	IsOverwriting: true, // New is always better
-			// },
-		},
-	)
+	c.pilotingLoop = newPilotingLoop(c.ardrone3.Piloting())
+	s, err := c.connect()
 	if err != nil {
-		return nil, errors.Wrap(err, "error creating buffer manager")
+		return nil, err
 	}
-	d2cCommandServer, err := arcommands.NewD2CCommandServer(
-		d2cChs,
+	go c.superviseSession(s)
+	return c, nil
+}
+
+// connect establishes a new session with the device, makes it the
+// controller's current session, and requests all settings and states from the
+// device so that the controller's view of the device is re-synchronized.
+func (c *controller) connect() (*session, error) {
+	s, err := connect(
+		c.connectOptions,
 		[]arcommands.D2CFeature{
-			common.NewFeature(),
-			ardrone3.NewFeature(),
+			c.common,
+			c.ardrone3,
 		},
 	)
 	if err != nil {
-		return nil, errors.Wrap(err, "error creating d2c command server")
+		return nil, err
+	}
+	c.sessionLock.Lock()
+	select {
+	case <-c.closeCh:
+		// The controller was closed while we were connecting
+		c.sessionLock.Unlock()
+		s.close()
+		return nil, errors.New("bebop2 controller is closed")
+	default:
+	}
+	c.session = s
+	c.connectionParams = s.connectionParams
+	c.c2dCommandClient.setClient(s.c2dCommandClient)
+	c.sessionLock.Unlock()
+	go c.forwardVideoFrames(s)
+	if err := c.common.Settings().AllSettings(); err != nil {
+		log.Errorf("error requesting all settings: %s", err)
+	}
+	if err := c.common.Common().AllStates(); err != nil {
+		log.Errorf("error requesting all states: %s", err)
+	}
+	return s, nil
+}
+
+// superviseSession waits for the given session to end. If it ended because of
+// a probable disconnection, the connection state is updated, the piloting loop
+// is stopped, and reconnection is attempted with exponential backoff until it
+// succeeds or the controller is closed.
+func (c *controller) superviseSession(s *session) {
+	for {
+		err, ok := <-s.errCh
+		if !ok {
+			// The session was closed deliberately
+			return
+		}
+		log.Warnf("bebop2 controller disconnected: %s", err)
+		c.setConnectionState(ConnectionStateDisconnected)
+		if err := c.pilotingLoop.stop(); err != nil {
+			log.Errorf("error stopping piloting loop: %s", err)
+		}
+		c.closeSession(s)
+		if s = c.reconnect(); s == nil {
+			return
+		}
+		c.setConnectionState(ConnectionStateConnected)
+	}
+}
+
+// reconnect repeatedly attempts to establish a new session with the device,
+// backing off exponentially between attempts. It returns nil if the controller
+// is closed before a new session is established.
+func (c *controller) reconnect() *session {
+	backoff := minReconnectBackoff
+	for {
+		c.setConnectionState(ConnectionStateReconnecting)
+		s, err := c.connect()
+		if err == nil {
+			log.Debug("bebop2 controller reconnected")
+			return s
+		}
+		log.WithField(
+			"backoff", backoff,
+		).Warnf("bebop2 controller reconnection failed: %s", err)
+		select {
+		case <-time.After(backoff):
+		case <-c.closeCh:
+			return nil
+		}
+		if backoff *= 2; backoff > maxReconnectBackoff {
+			backoff = maxReconnectBackoff
+		}
+	}
+}
+
+// closeSession closes the given session and, if it is still the controller's
+// current session, clears it.
+func (c *controller) closeSession(s *session) {
+	c.sessionLock.Lock()
+	defer c.sessionLock.Unlock()
+	if c.session == s {
+		c.session = nil
+		c.c2dCommandClient.setClient(nil)
+	}
+	s.close()
+}
+
+// forwardVideoFrames forwards video frames received over the given session to
+// the controller's video frames channel until the session ends.
+func (c *controller) forwardVideoFrames(s *session) {
+	for frame := range s.videoReader.FramesCh() {
+		select {
+		case c.videoFramesCh <- frame:
+		default:
+			log.WithField(
+				"frameNumber", frame.Number,
+			).Warn("video frames channel is full; dropping frame")
+		}
+	}
+}
+
+func (c *controller) Common() common.Feature {
+	return c.common
+}
+
+func (c *controller) ARDrone3() ardrone3.Feature {
+	return c.ardrone3
+}
+
+func (c *controller) StartPiloting(interval time.Duration) error {
+	return c.pilotingLoop.start(interval)
+}
+
+func (c *controller) StopPiloting() error {
+	return c.pilotingLoop.stop()
+}
+
+func (c *controller) SetRoll(roll int8) {
+	c.pilotingLoop.setRoll(roll)
+}
+
+func (c *controller) SetPitch(pitch int8) {
+	c.pilotingLoop.setPitch(pitch)
+}
+
+func (c *controller) SetYaw(yaw int8) {
+	c.pilotingLoop.setYaw(yaw)
+}
+
+func (c *controller) SetGaz(gaz int8) {
+	c.pilotingLoop.setGaz(gaz)
+}
+
+func (c *controller) ConnectionState() ConnectionState {
+	c.connectionStateLock.RLock()
+	defer c.connectionStateLock.RUnlock()
+	return c.connectionState
+}
+
+func (c *controller) ConnectionStateCh() <-chan ConnectionState {
+	return c.connectionStateCh
+}
+
+func (c *controller) ConnectionParams() wifi.ConnectionParams {
+	c.sessionLock.Lock()
+	defer c.sessionLock.Unlock()
+	return c.connectionParams
+}
+
+func (c *controller) VideoFramesCh() <-chan arstream.Frame {
+	return c.videoFramesCh
+}
+
+func (c *controller) setConnectionState(connectionState ConnectionState) {
+	c.connectionStateLock.Lock()
+	defer c.connectionStateLock.Unlock()
+	// Once closed, the connection state never changes again.
+	if c.connectionState == connectionState ||
+		c.connectionState == ConnectionStateClosed {
+		return
 	}
-	d2cCommandServer.Start()
-	return &controller{}, nil
+	log.WithField(
+		"connectionState", connectionState,
+	).Debug("bebop2 controller connection state changed")
+	c.connectionState = connectionState
+	for {
+		select {
+		case c.connectionStateCh <- connectionState:
+			return
+		default:
+			// The channel is full. Drop the oldest undelivered state to make room.
+			// Note that someone may drain the channel between the failed send above
+			// and the receive below, so we do not block on the receive.
+			select {
+			case <-c.connectionStateCh:
+			default:
+			}
+		}
+	}
+}
+
+func (c *controller) Close() {
+	c.closeOnce.Do(func() {
+		log.Debug("closing bebop2 controller")
+		// Signal any reconnection in progress to give up
+		close(c.closeCh)
+		if err := c.pilotingLoop.stop(); err != nil {
+			log.Errorf("error stopping piloting loop: %s", err)
+		}
+		c.sessionLock.Lock()
+		s := c.session
+		c.sessionLock.Unlock()
+		if s != nil {
+			c.closeSession(s)
+		}
+		c.setConnectionState(ConnectionStateClosed)
+		log.Debug("closed bebop2 controller")
+	})
 }
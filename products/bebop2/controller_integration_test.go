@@ -0,0 +1,179 @@
+package bebop2
+
+import (
+	"testing"
+	"time"
+
+	"github.com/krancour/go-parrot/features/ardrone3"
+	"github.com/krancour/go-parrot/protocols/arnetworkal/wifi"
+	"github.com/krancour/go-parrot/simulator"
+	"github.com/stretchr/testify/require"
+)
+
+func TestControllerWithSimulator(t *testing.T) {
+	sim, err := simulator.NewSimulator(
+		simulator.Config{
+			PingInterval: 50 * time.Millisecond,
+			ConnectionParams: wifi.ConnectionParams{
+				ARStreamMaxAckIntervalMillis: 100,
+			},
+			Script: []simulator.Step{
+				{
+					// Give the controller a moment to start listening
+					Delay:   100 * time.Millisecond,
+					Command: simulator.FlyingStateChanged(2),
+				},
+				{
+					Command: simulator.BatteryStateChanged(87),
+				},
+				{
+					Command: simulator.GpsLocationChanged(48.8, 2.3, 35, 1, 1, 2),
+				},
+			},
+		},
+	)
+	require.NoError(t, err)
+	defer sim.Close()
+
+	connectOpts := sim.ConnectOptions()
+	connectOpts.ControllerName = "integration-test"
+	connectOpts.ReadTimeout = 300 * time.Millisecond
+	c, err := NewController(connectOpts)
+	require.NoError(t, err)
+	defer c.Close()
+	require.Equal(t, ConnectionStateConnected, c.ConnectionState())
+	require.Equal(
+		t,
+		100*time.Millisecond,
+		c.ConnectionParams().ARStreamMaxAckInterval(),
+	)
+
+	// waitForLatitude waits until the controller's state reflects the scripted
+	// GPS location
+	waitForLatitude := func() {
+		pilotingState := c.ARDrone3().PilotingState()
+		timeoutCh := time.After(2 * time.Second)
+		for {
+			pilotingState.RLock()
+			latitude, ok := pilotingState.Latitude()
+			pilotingState.RUnlock()
+			if ok && latitude == 48.8 {
+				return
+			}
+			select {
+			case <-time.After(10 * time.Millisecond):
+			case <-timeoutCh:
+				require.FailNow(t, "timed out waiting for gps location")
+			}
+		}
+	}
+
+	// waitForCommand waits for the simulator to receive the specified command
+	waitForCommand := func(featureID, classID uint8, commandID uint16) {
+		timeoutCh := time.After(2 * time.Second)
+		for {
+			select {
+			case cmd := <-sim.ReceivedCommandsCh():
+				if cmd.FeatureID == featureID &&
+					cmd.ClassID == classID &&
+					cmd.CommandID == commandID {
+					return
+				}
+			case <-timeoutCh:
+				require.FailNow(
+					t,
+					"timed out waiting for command",
+					"%d:%d:%d",
+					featureID,
+					classID,
+					commandID,
+				)
+			}
+		}
+	}
+
+	waitForLatitude()
+	// The scripted flying state should also be reflected
+	timeoutCh := time.After(2 * time.Second)
+	for {
+		pilotingState := c.ARDrone3().PilotingState()
+		pilotingState.RLock()
+		flyingState, ok := pilotingState.FlyingState()
+		pilotingState.RUnlock()
+		if ok {
+			require.Equal(t, ardrone3.FlyingStateHovering, flyingState)
+			break
+		}
+		select {
+		case <-time.After(10 * time.Millisecond):
+		case <-timeoutCh:
+			require.FailNow(t, "timed out waiting for flying state")
+		}
+	}
+	// So should the scripted battery state
+	timeoutCh = time.After(2 * time.Second)
+	for {
+		commonState := c.Common().CommonState()
+		commonState.RLock()
+		batteryPercent, ok := commonState.BatteryPercent()
+		commonState.RUnlock()
+		if ok {
+			require.Equal(t, uint8(87), batteryPercent)
+			break
+		}
+		select {
+		case <-time.After(10 * time.Millisecond):
+		case <-timeoutCh:
+			require.FailNow(t, "timed out waiting for battery state")
+		}
+	}
+	// The controller should have requested all settings and states
+	waitForCommand(0, 2, 0) // common Settings AllSettings
+	waitForCommand(0, 4, 0) // common Common AllStates
+
+	// Piloting commands should reach the simulator
+	require.NoError(t, c.StartPiloting(DefaultPilotingInterval))
+	waitForCommand(1, 0, 2) // ardrone3 Piloting PCMD
+
+	// After the simulator goes silent, the controller should notice the
+	// disconnection, then reconnect and resync
+	latitudeSub, err := c.ARDrone3().PilotingState().Subscribe(
+		ardrone3.PilotingStateAttributeLatitude,
+	)
+	require.NoError(t, err)
+	defer latitudeSub.Unsubscribe()
+	sim.Disconnect()
+	waitForState := func(expectedState ConnectionState) {
+		timeoutCh := time.After(5 * time.Second)
+		for {
+			select {
+			case state := <-c.ConnectionStateCh():
+				if state == expectedState {
+					return
+				}
+			case <-timeoutCh:
+				require.FailNow(
+					t,
+					"timed out waiting for connection state",
+					"%s",
+					expectedState,
+				)
+			}
+		}
+	}
+	waitForState(ConnectionStateDisconnected)
+	waitForState(ConnectionStateConnected)
+	waitForCommand(0, 4, 0) // common Common AllStates
+	// Subscribers should be notified when the resynced state arrives
+	select {
+	case notification := <-latitudeSub.NotificationsCh():
+		require.Equal(t, "PilotingState", notification.Class)
+		require.Contains(t, notification.Attributes, "Latitude")
+	case <-time.After(2 * time.Second):
+		require.FailNow(t, "timed out waiting for latitude notification")
+	}
+	waitForLatitude()
+
+	c.Close()
+	require.Equal(t, ConnectionStateClosed, c.ConnectionState())
+}
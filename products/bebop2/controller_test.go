@@ -0,0 +1,29 @@
+package bebop2
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetConnectionState(t *testing.T) {
+	c := &controller{
+		connectionState:   ConnectionStateConnected,
+		connectionStateCh: make(chan ConnectionState, 1),
+	}
+	// Setting the current state again should be a no-op
+	c.setConnectionState(ConnectionStateConnected)
+	require.Len(t, c.connectionStateCh, 0)
+	// Nobody is reading from the channel, so only the newest state should be
+	// retained
+	c.setConnectionState(ConnectionStateDisconnected)
+	c.setConnectionState(ConnectionStateConnected)
+	require.Equal(t, ConnectionStateConnected, c.ConnectionState())
+	require.Equal(t, ConnectionStateConnected, <-c.ConnectionStateCh())
+	// Once closed, the connection state should never change again
+	c.setConnectionState(ConnectionStateClosed)
+	require.Equal(t, ConnectionStateClosed, <-c.ConnectionStateCh())
+	c.setConnectionState(ConnectionStateDisconnected)
+	require.Equal(t, ConnectionStateClosed, c.ConnectionState())
+	require.Len(t, c.connectionStateCh, 0)
+}
@@ -0,0 +1,160 @@
+package bebop2
+
+import (
+	"sync"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/krancour/go-parrot/features/ardrone3"
+	"github.com/pkg/errors"
+)
+
+// DefaultPilotingInterval is the interval at which Parrot's own SDK sends the
+// PCMD command to the device. It is a reasonable default for StartPiloting().
+const DefaultPilotingInterval = 40 * time.Millisecond // 25 Hz
+
+const (
+	maxSetpointValue int8 = 100
+	minSetpointValue int8 = -100
+)
+
+// setpoint is the desired movement of the device. Each attribute is a signed
+// percentage in the range [-100, 100]. See ardrone3.Piloting's PCMD function.
+type setpoint struct {
+	roll  int8
+	pitch int8
+	yaw   int8
+	gaz   int8
+}
+
+// pilotingLoop periodically sends the current setpoint to the device using
+// the PCMD command. The device expects this command to be sent continuously
+// while piloting.
+type pilotingLoop struct {
+	piloting  ardrone3.Piloting
+	setpoint  setpoint
+	seq       uint8
+	startTime time.Time
+	stopCh    chan struct{}
+	doneCh    chan struct{}
+	lock      sync.Mutex
+}
+
+func newPilotingLoop(piloting ardrone3.Piloting) *pilotingLoop {
+	return &pilotingLoop{
+		piloting: piloting,
+	}
+}
+
+func (p *pilotingLoop) start(interval time.Duration) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if interval <= 0 {
+		return errors.Errorf("invalid piloting interval %s", interval)
+	}
+	if p.stopCh != nil {
+		return errors.New("piloting loop is already running")
+	}
+	p.startTime = time.Now()
+	p.stopCh = make(chan struct{})
+	p.doneCh = make(chan struct{})
+	go p.run(interval, p.stopCh, p.doneCh)
+	log.WithField(
+		"interval", interval,
+	).Debug("started piloting loop")
+	return nil
+}
+
+func (p *pilotingLoop) run(
+	interval time.Duration,
+	stopCh <-chan struct{},
+	doneCh chan<- struct{},
+) {
+	defer close(doneCh)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := p.sendSetpoint(); err != nil {
+				log.Errorf("error sending piloting setpoint: %s", err)
+			}
+		case <-stopCh:
+			return
+		}
+	}
+}
+
+// stop stops the loop, if it is running, and zeroes the setpoint. One final
+// PCMD with the zeroed setpoint is sent so that the device stops moving.
+func (p *pilotingLoop) stop() error {
+	p.lock.Lock()
+	stopCh := p.stopCh
+	doneCh := p.doneCh
+	p.stopCh = nil
+	p.doneCh = nil
+	p.setpoint = setpoint{}
+	p.lock.Unlock()
+	if stopCh == nil {
+		return nil
+	}
+	close(stopCh)
+	<-doneCh
+	log.Debug("stopped piloting loop")
+	return p.sendSetpoint()
+}
+
+func (p *pilotingLoop) sendSetpoint() error {
+	p.lock.Lock()
+	sp := p.setpoint
+	p.seq++
+	// The low 24 bits are a timestamp in milliseconds. The high 8 bits are a
+	// sequence number.
+	timestampAndSeqNum :=
+		uint32(time.Since(p.startTime)/time.Millisecond)&0xffffff |
+			uint32(p.seq)<<24
+	p.lock.Unlock()
+	return p.piloting.PCMD(
+		// Roll and pitch are only taken into consideration when this flag is set
+		sp.roll != 0 || sp.pitch != 0,
+		sp.roll,
+		sp.pitch,
+		sp.yaw,
+		sp.gaz,
+		timestampAndSeqNum,
+	)
+}
+
+func (p *pilotingLoop) setRoll(roll int8) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.setpoint.roll = clampSetpointValue(roll)
+}
+
+func (p *pilotingLoop) setPitch(pitch int8) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.setpoint.pitch = clampSetpointValue(pitch)
+}
+
+func (p *pilotingLoop) setYaw(yaw int8) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.setpoint.yaw = clampSetpointValue(yaw)
+}
+
+func (p *pilotingLoop) setGaz(gaz int8) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.setpoint.gaz = clampSetpointValue(gaz)
+}
+
+func clampSetpointValue(val int8) int8 {
+	if val > maxSetpointValue {
+		return maxSetpointValue
+	}
+	if val < minSetpointValue {
+		return minSetpointValue
+	}
+	return val
+}
@@ -0,0 +1,122 @@
+package bebop2
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/krancour/go-parrot/features/ardrone3"
+	"github.com/stretchr/testify/require"
+)
+
+type pcmd struct {
+	flag               bool
+	setpoint           setpoint
+	timestampAndSeqNum uint32
+}
+
+// fakePiloting is a fake implementation of the ardrone3.Piloting interface
+// that records PCMD commands.
+type fakePiloting struct {
+	ardrone3.Piloting
+	pcmds []pcmd
+	lock  sync.Mutex
+}
+
+func (f *fakePiloting) PCMD(
+	flag bool,
+	roll int8,
+	pitch int8,
+	yaw int8,
+	gaz int8,
+	timestampAndSeqNum uint32,
+) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.pcmds = append(
+		f.pcmds,
+		pcmd{
+			flag: flag,
+			setpoint: setpoint{
+				roll:  roll,
+				pitch: pitch,
+				yaw:   yaw,
+				gaz:   gaz,
+			},
+			timestampAndSeqNum: timestampAndSeqNum,
+		},
+	)
+	return nil
+}
+
+func (f *fakePiloting) getPCMDs() []pcmd {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	pcmds := make([]pcmd, len(f.pcmds))
+	copy(pcmds, f.pcmds)
+	return pcmds
+}
+
+func TestPilotingLoop(t *testing.T) {
+	piloting := &fakePiloting{}
+	loop := newPilotingLoop(piloting)
+
+	err := loop.start(0)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid piloting interval")
+
+	err = loop.start(5 * time.Millisecond)
+	require.NoError(t, err)
+	err = loop.start(5 * time.Millisecond)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "already running")
+
+	loop.setRoll(-120) // Should be clamped
+	loop.setPitch(50)
+	loop.setYaw(25)
+	loop.setGaz(127) // Should be clamped
+
+	expectedSetpoint := setpoint{
+		roll:  -100,
+		pitch: 50,
+		yaw:   25,
+		gaz:   100,
+	}
+	timeoutCh := time.After(time.Second)
+	for {
+		pcmds := piloting.getPCMDs()
+		if len(pcmds) > 0 && pcmds[len(pcmds)-1].setpoint == expectedSetpoint {
+			break
+		}
+		select {
+		case <-time.After(5 * time.Millisecond):
+		case <-timeoutCh:
+			require.Fail(t, "timed out waiting for setpoint to be sent")
+		}
+	}
+
+	err = loop.stop()
+	require.NoError(t, err)
+	pcmds := piloting.getPCMDs()
+	// The last PCMD should have zeroed the setpoint
+	lastPCMD := pcmds[len(pcmds)-1]
+	require.False(t, lastPCMD.flag)
+	require.Equal(t, setpoint{}, lastPCMD.setpoint)
+	// Every PCMD should carry a new sequence number
+	for i := 1; i < len(pcmds); i++ {
+		require.Equal(
+			t,
+			uint8(pcmds[i-1].timestampAndSeqNum>>24)+1,
+			uint8(pcmds[i].timestampAndSeqNum>>24),
+		)
+	}
+
+	// No more PCMDs should be sent once the loop is stopped
+	<-time.After(20 * time.Millisecond)
+	require.Len(t, piloting.getPCMDs(), len(pcmds))
+
+	// Stopping a second time should be a no-op
+	err = loop.stop()
+	require.NoError(t, err)
+	require.Len(t, piloting.getPCMDs(), len(pcmds))
+}
@@ -0,0 +1,253 @@
+package bebop2
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/krancour/go-parrot/protocols/arcommands"
+	"github.com/krancour/go-parrot/protocols/arnetwork"
+	"github.com/krancour/go-parrot/protocols/arnetworkal"
+	"github.com/krancour/go-parrot/protocols/arnetworkal/wifi"
+	"github.com/krancour/go-parrot/protocols/arstream"
+	"github.com/pkg/errors"
+)
+
+// sessionCloseTimeout is how long closing a session waits for frames already
+// placed onto c2d buffers to be delivered before abandoning them.
+const sessionCloseTimeout = time.Second
+
+// session represents a single connection to the device. A new session is
+// established every time the controller (re)connects to the device.
+type session struct {
+	buffers *arnetwork.Buffers
+	// connectionParams are the parameters the device communicated during
+	// connection negotiation.
+	connectionParams wifi.ConnectionParams
+	c2dCommandClient arcommands.C2DCommandClient
+	// videoReader reassembles video frames received over the session's ARStream
+	// buffers. Its frames channel is closed when the session ends.
+	videoReader arstream.Reader
+	// errCh receives the error that caused the session to end, if the session
+	// ended because of a probable disconnection. It is closed when the session
+	// ends for any reason.
+	errCh     <-chan error
+	closeOnce sync.Once
+}
+
+// connect establishes a new session with the device. This (re)negotiates the
+// connection, (re)binds UDP sockets, and creates new buffers-- meaning every
+// buffer's sequence number starts over. d2c commands received over the new
+// session are executed against the given features.
+func connect(
+	connectOptions wifi.ConnectOptions,
+	d2cFeatures []arcommands.D2CFeature,
+) (*session, error) {
+	frameSender, frameReceiver, connectionParams, err :=
+		wifi.Connect(connectOptions)
+	if err != nil {
+		return nil, errors.Wrap(err, "connection error")
+	}
+	fragmentSize := connectionParams.ARStreamFragmentSize
+	if fragmentSize <= 0 {
+		fragmentSize = arstream.DefaultFragmentSize
+	}
+	maxFragmentsPerFrame := connectionParams.ARStreamFragmentMaximumNumber
+	if maxFragmentsPerFrame <= 0 {
+		maxFragmentsPerFrame = arstream.DefaultMaxFragmentsPerFrame
+	}
+	buffers, err := arnetwork.NewBuffers(
+		frameSender,
+		frameReceiver,
+		[]arnetwork.C2DBufferConfig{
+			// Non ack data (periodic commands for piloting and camera orientation)
+			// This buffer transports arcommands
+			{
+				ID:            arcommands.C2DNonAckBufferID,
+				FrameType:     arnetworkal.FrameTypeData,
+				Size:          2, // PCMD + camera
+				MaxDataSize:   128,
+				IsOverwriting: true, // Periodic data; most recent is better
+			},
+
+			// Ack data (events, settings, etc.)
+			// This buffer transports arcommands
+			{
+				ID:            arcommands.C2DAckBufferID,
+				FrameType:     arnetworkal.FrameTypeDataWithAck,
+				AckTimeout:    150 * time.Millisecond,
+				MaxRetries:    5,
+				Size:          20,
+				MaxDataSize:   128,
+				IsOverwriting: false, // Events should not be dropped
+			},
+
+			// Emergency data (emergency commands only)
+			// This buffer transports arcommands
+			{
+				ID:            arcommands.C2DEmergencyBufferID,
+				FrameType:     arnetworkal.FrameTypeDataWithAck,
+				AckTimeout:    150 * time.Millisecond,
+				MaxRetries:    -1, // Infinite
+				Size:          1,
+				MaxDataSize:   128,
+				IsOverwriting: false, // Events should not be dropped
+			},
+
+			// arstream video acks
+			// This buffer transports arstream data
+			{
+				ID:            arstream.C2DAckBufferID,
+				FrameType:     arnetworkal.FrameTypeLowLatencyData,
+				Size:          1000,             // Enough space
+				MaxDataSize:   arstream.AckSize, // Size of an ack
+				IsOverwriting: true,             // New is always better
+			},
+		},
+
+		[]arnetwork.D2CBufferConfig{
+			// Non ack data (periodic reports from the device)
+			// This buffer transports arcommands
+			{
+				ID:            arcommands.D2CNonAckBufferID,
+				FrameType:     arnetworkal.FrameTypeData,
+				Size:          20,
+				MaxDataSize:   128,
+				IsOverwriting: true, // Periodic data: most recent is better
+			},
+
+			// Ack data (events, settings, etc.)
+			// This buffer transports arcommands
+			{
+				ID:            arcommands.D2CAckBufferID,
+				FrameType:     arnetworkal.FrameTypeDataWithAck,
+				Size:          256,
+				MaxDataSize:   128,
+				IsOverwriting: false, // Events should not be dropped
+			},
+
+			// arstream video data
+			// This buffer transports arstream data
+			{
+				ID:        arstream.D2CDataBufferID,
+				FrameType: arnetworkal.FrameTypeLowLatencyData,
+				// Sized according to parameters negotiated with the device
+				Size:          int32(maxFragmentsPerFrame * 2),
+				MaxDataSize:   int32(fragmentSize + arstream.FragmentHeaderSize),
+				IsOverwriting: true, // New is always better
+			},
+		},
+	)
+	if err != nil {
+		frameSender.Close()
+		frameReceiver.Close()
+		return nil, errors.Wrap(err, "error creating buffer manager")
+	}
+	// The video buffers are handed to the video reader. They're removed from the
+	// maps given to the c2d command client and d2c command server so that those
+	// don't attempt to write video acks or to parse video fragments as commands.
+	c2dChs := buffers.C2DChs()
+	d2cChs := buffers.D2CChs()
+	videoReader := arstream.NewReader(
+		d2cChs[arstream.D2CDataBufferID],
+		c2dChs[arstream.C2DAckBufferID],
+		connectionParams.ARStreamMaxAckInterval(),
+	)
+	delete(d2cChs, arstream.D2CDataBufferID)
+	delete(c2dChs, arstream.C2DAckBufferID)
+	s := &session{
+		buffers:          buffers,
+		connectionParams: connectionParams,
+		c2dCommandClient: arcommands.NewC2DCommandClient(c2dChs),
+		videoReader:      videoReader,
+		errCh:            buffers.ErrCh(),
+	}
+	d2cCommandServer, err := arcommands.NewD2CCommandServer(d2cChs, d2cFeatures)
+	if err != nil {
+		s.close()
+		return nil, errors.Wrap(err, "error creating d2c command server")
+	}
+	d2cCommandServer.Start()
+	return s, nil
+}
+
+// close tears down the session. This stops everything that writes to c2d
+// buffers, then closes the buffers, which closes the underlying network
+// connections and all d2c buffers, which also stops the session's d2c command
+// server. It is safe to call this more than once.
+func (s *session) close() {
+	s.closeOnce.Do(func() {
+		log.Debug("closing bebop2 controller session")
+		s.c2dCommandClient.Close()
+		s.videoReader.Close()
+		ctx, cancel := context.WithTimeout(
+			context.Background(),
+			sessionCloseTimeout,
+		)
+		defer cancel()
+		if err := s.buffers.Close(ctx); err != nil {
+			log.Warnf("error closing bebop2 controller session buffers: %s", err)
+		}
+		log.Debug("closed bebop2 controller session")
+	})
+}
+
+// sessionC2DCommandClient is an arcommands.C2DCommandClient that delegates to
+// the c2d command client of the controller's current session. This permits
+// features to hold a reference to a single c2d command client that remains
+// valid across reconnections.
+type sessionC2DCommandClient struct {
+	client arcommands.C2DCommandClient
+	lock   sync.RWMutex
+}
+
+func (s *sessionC2DCommandClient) SendCommand(
+	bufferID uint8,
+	featureID uint8,
+	classID uint8,
+	commandID uint16,
+	args ...interface{},
+) error {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	if s.client == nil {
+		return errors.New("not connected")
+	}
+	return s.client.SendCommand(bufferID, featureID, classID, commandID, args...)
+}
+
+func (s *sessionC2DCommandClient) SendCommandWithReceipt(
+	bufferID uint8,
+	featureID uint8,
+	classID uint8,
+	commandID uint16,
+	args ...interface{},
+) (*arnetwork.Receipt, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	if s.client == nil {
+		return nil, errors.New("not connected")
+	}
+	return s.client.SendCommandWithReceipt(
+		bufferID,
+		featureID,
+		classID,
+		commandID,
+		args...,
+	)
+}
+
+func (s *sessionC2DCommandClient) Close() {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	if s.client != nil {
+		s.client.Close()
+	}
+}
+
+func (s *sessionC2DCommandClient) setClient(client arcommands.C2DCommandClient) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.client = client
+}
@@ -0,0 +1,34 @@
+package bebop2
+
+import (
+	"testing"
+
+	"github.com/krancour/go-parrot/protocols/arcommands"
+	"github.com/krancour/go-parrot/protocols/arnetwork"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSessionC2DCommandClient(t *testing.T) {
+	client := &sessionC2DCommandClient{}
+	// With no session, sending should fail
+	err := client.SendCommand(arcommands.C2DAckBufferID, 0, 2, 0)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "not connected")
+	// With a session, sending should be delegated to the session's client
+	c2dCh := make(chan arnetwork.Frame, 1)
+	client.setClient(
+		arcommands.NewC2DCommandClient(
+			map[uint8]chan<- arnetwork.Frame{
+				arcommands.C2DAckBufferID: c2dCh,
+			},
+		),
+	)
+	err = client.SendCommand(arcommands.C2DAckBufferID, 0, 2, 0)
+	require.NoError(t, err)
+	require.Equal(t, []byte{0, 2, 0, 0}, (<-c2dCh).Data)
+	// Once the session is cleared, sending should fail again
+	client.setClient(nil)
+	err = client.SendCommand(arcommands.C2DAckBufferID, 0, 2, 0)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "not connected")
+}
@@ -1,33 +1,243 @@
 package arcommands
 
-// C2DCommandClient ...
-// TODO: Document this
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"reflect"
+	"sync"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/krancour/go-parrot/protocols/arnetwork"
+	"github.com/pkg/errors"
+)
+
+const (
+	// C2DNonAckBufferID is the ID of the c2d buffer conventionally used for
+	// transporting periodic arcommands (e.g. piloting and camera orientation)
+	// that do not require acknowledgement of receipt.
+	C2DNonAckBufferID uint8 = 10
+	// C2DAckBufferID is the ID of the c2d buffer conventionally used for
+	// transporting arcommands (events, settings, etc.) that require
+	// acknowledgement of receipt.
+	C2DAckBufferID uint8 = 11
+	// C2DEmergencyBufferID is the ID of the c2d buffer conventionally used for
+	// transporting emergency arcommands only.
+	C2DEmergencyBufferID uint8 = 12
+	// D2CNonAckBufferID is the ID of the d2c buffer conventionally used for
+	// transporting periodic arcommands (e.g. position and speed) that do not
+	// require acknowledgement of receipt.
+	D2CNonAckBufferID uint8 = 127
+	// D2CAckBufferID is the ID of the d2c buffer conventionally used for
+	// transporting arcommands (events, settings, etc.) that require
+	// acknowledgement of receipt.
+	D2CAckBufferID uint8 = 126
+)
+
+// C2DCommandClient is an interface implemented by any component capable of
+// sending arcommands from the client to the device.
 type C2DCommandClient interface {
+	// SendCommand encodes the command identified by the given feature, class,
+	// and command IDs, along with its arguments, and places the result on the c2d
+	// buffer having the given ID. Arguments must be of the exact types expected
+	// by the device for the command in question-- i.e. uint8, int8, uint16,
+	// int16, uint32, int32, uint64, int64, float32, float64, string, or an
+	// Enum.
 	SendCommand(
+		bufferID uint8,
 		featureID uint8,
 		classID uint8,
 		commandID uint16,
 		args ...interface{},
 	) error
+	// SendCommandWithReceipt is like SendCommand, but also returns an
+	// arnetwork.Receipt that is resolved with the outcome of delivering the
+	// command to the device. This permits callers to learn whether the device
+	// acknowledged receipt of a command sent over a buffer that requests
+	// acknowledgement, such as the one identified by C2DAckBufferID.
+	SendCommandWithReceipt(
+		bufferID uint8,
+		featureID uint8,
+		classID uint8,
+		commandID uint16,
+		args ...interface{},
+	) (*arnetwork.Receipt, error)
+	// Close stops the client. Sends blocked waiting for room in a c2d channel
+	// are abandoned and return an error. Once Close returns, the client is
+	// guaranteed not to write to any c2d channel again, so the
+	// arnetwork.Buffers the channels came from may safely be closed. Subsequent
+	// attempts to send commands will return an error.
+	Close()
 }
 
-type c2dCommandClient struct{}
+type c2dCommandClient struct {
+	c2dChs map[uint8]chan<- arnetwork.Frame
+	// doneCh is closed when the client is closed to abandon any sends that are
+	// blocked waiting for room in a c2d channel
+	doneCh chan struct{}
+	// sendsWG tracks sends that are in progress so that Close can wait for them
+	// to complete or be abandoned
+	sendsWG    sync.WaitGroup
+	closed     bool
+	closedLock sync.RWMutex
+}
 
-// NewC2DCommandClient ...
-// TODO: Document this
-// TODO: What should we pass to this?
-func NewC2DCommandClient() C2DCommandClient {
-	return &c2dCommandClient{}
+// NewC2DCommandClient returns a C2DCommandClient that places encoded commands
+// onto the provided c2d channels. Channels are indexed by buffer ID, as
+// returned from arnetwork.Buffers.C2DChs(). The client never closes these
+// channels.
+func NewC2DCommandClient(
+	c2dChs map[uint8]chan<- arnetwork.Frame,
+) C2DCommandClient {
+	return &c2dCommandClient{
+		c2dChs: c2dChs,
+		doneCh: make(chan struct{}),
+	}
 }
 
-// SendC2DCommand ...
-// TODO: Document this
-// TODO: Implement this
 func (c *c2dCommandClient) SendCommand(
+	bufferID uint8,
 	featureID uint8,
 	classID uint8,
 	commandID uint16,
 	args ...interface{},
 ) error {
+	return c.sendCommand(bufferID, featureID, classID, commandID, nil, args)
+}
+
+func (c *c2dCommandClient) SendCommandWithReceipt(
+	bufferID uint8,
+	featureID uint8,
+	classID uint8,
+	commandID uint16,
+	args ...interface{},
+) (*arnetwork.Receipt, error) {
+	receipt := arnetwork.NewReceipt()
+	if err := c.sendCommand(
+		bufferID,
+		featureID,
+		classID,
+		commandID,
+		receipt,
+		args,
+	); err != nil {
+		return nil, err
+	}
+	return receipt, nil
+}
+
+// sendCommand encodes a command and places it onto the c2d buffer having the
+// given ID, with the given receipt, if any, attached.
+func (c *c2dCommandClient) sendCommand(
+	bufferID uint8,
+	featureID uint8,
+	classID uint8,
+	commandID uint16,
+	receipt *arnetwork.Receipt,
+	args []interface{},
+) error {
+	log := log.WithField(
+		"buffer", bufferID,
+	).WithField(
+		"featureID", featureID,
+	).WithField(
+		"classID", classID,
+	).WithField(
+		"commandID", commandID,
+	)
+	c2dCh, ok := c.c2dChs[bufferID]
+	if !ok {
+		return errors.Errorf("no c2d buffer with id %d", bufferID)
+	}
+	data, err := EncodeCommand(featureID, classID, commandID, args)
+	if err != nil {
+		return errors.Wrap(err, "error encoding command")
+	}
+	// Register the send while holding the lock so that Close can't miss it, but
+	// don't hold the lock while blocked on the send, which would block Close
+	// for as long as the c2d channel is full.
+	c.closedLock.RLock()
+	if c.closed {
+		c.closedLock.RUnlock()
+		return errors.New("c2d command client is closed")
+	}
+	c.sendsWG.Add(1)
+	c.closedLock.RUnlock()
+	defer c.sendsWG.Done()
+	log.Debug("sending command")
+	select {
+	case c2dCh <- arnetwork.Frame{
+		Data:    data,
+		Receipt: receipt,
+	}:
+		return nil
+	case <-c.doneCh:
+		return errors.New("c2d command client was closed before command was sent")
+	}
+}
+
+func (c *c2dCommandClient) Close() {
+	c.closedLock.Lock()
+	if c.closed {
+		c.closedLock.Unlock()
+		return
+	}
+	c.closed = true
+	close(c.doneCh)
+	c.closedLock.Unlock()
+	// Wait for sends in progress to complete or be abandoned
+	c.sendsWG.Wait()
+}
+
+// EncodeCommand encodes the feature, class, and command IDs, followed by the
+// command's arguments. This is the inverse of ParseIDs(...) and
+// decodeArgs(...). Most callers should use a C2DCommandClient instead, but
+// this is useful for implementing the device end of the protocol-- e.g. in a
+// simulator.
+func EncodeCommand(
+	featureID uint8,
+	classID uint8,
+	commandID uint16,
+	args []interface{},
+) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	buf.WriteByte(featureID) // 1 byte
+	buf.WriteByte(classID)   // 1 byte
+	if err := binary.Write(buf, binary.LittleEndian, commandID); err != nil {
+		return nil, errors.Wrap(err, "error encoding commandID")
+	}
+	if err := encodeArgs(buf, args); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func encodeArgs(buf *bytes.Buffer, args []interface{}) error {
+	var err error
+	for _, argIface := range args {
+		switch arg := argIface.(type) {
+		case uint8, int8, uint16, int16, uint32, int32, uint64, int64, float32,
+			float64:
+			err = binary.Write(buf, binary.LittleEndian, arg)
+		case string:
+			buf.WriteString(arg)
+			// Strings are null terminated
+			err = buf.WriteByte(0x00)
+		case Enum:
+			var value int32
+			if value, err = encodeEnum(arg); err == nil {
+				err = binary.Write(buf, binary.LittleEndian, value)
+			}
+		default:
+			err = fmt.Errorf("unknown type: %s", reflect.TypeOf(argIface))
+		}
+		if err != nil {
+			return errors.Wrapf(
+				err,
+				"error encoding command arguments; args: %v",
+				args,
+			)
+		}
+	}
 	return nil
 }
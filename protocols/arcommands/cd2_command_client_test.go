@@ -0,0 +1,192 @@
+package arcommands
+
+import (
+	"testing"
+	"time"
+
+	"github.com/krancour/go-parrot/protocols/arnetwork"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeCommand(t *testing.T) {
+	args := []interface{}{
+		uint8(1),
+		int8(2),
+		uint16(3),
+		int16(4),
+		uint32(5),
+		int32(6),
+		uint64(7),
+		int64(8),
+		"foo",
+		float32(9.1),
+		float64(10.2),
+	}
+	expected := []byte{
+		1,    // FeatureID
+		4,    // ClassID
+		9, 0, // CommandID (little endian)
+		1,    // uint8
+		2,    // int8
+		3, 0, // uint16 (little endian)
+		4, 0, // int16 (little endian)
+		5, 0, 0, 0, // uint32 (little endian)
+		6, 0, 0, 0, // int32 (little endian)
+		7, 0, 0, 0, 0, 0, 0, 0, // uint64 (little endian)
+		8, 0, 0, 0, 0, 0, 0, 0, // int164 (little endian)
+		102, 111, 111, 0, // null terminated string
+		154, 153, 17, 65, // float32 (little endian)
+		102, 102, 102, 102, 102, 102, 36, 64, // float64 (little endian)
+	}
+	data, err := EncodeCommand(1, 4, 9, args)
+	require.NoError(t, err)
+	require.Equal(t, expected, data)
+	// Decoding should give us back what we started with
+	decodedArgs := make([]interface{}, len(args))
+	copy(decodedArgs, args)
+	err = decodeArgs(data, decodedArgs)
+	require.NoError(t, err)
+	require.Equal(t, args, decodedArgs)
+}
+
+func TestEncodeCommandWithUnknownArgType(t *testing.T) {
+	_, err := EncodeCommand(1, 4, 9, []interface{}{true})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "unknown type")
+}
+
+func TestSendCommand(t *testing.T) {
+	testCases := []struct {
+		name       string
+		bufferID   uint8
+		args       []interface{}
+		assertions func(*testing.T, <-chan arnetwork.Frame, error)
+	}{
+
+		{
+			name:     "unknown buffer",
+			bufferID: 42,
+			assertions: func(t *testing.T, _ <-chan arnetwork.Frame, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "no c2d buffer")
+			},
+		},
+
+		{
+			name:     "invalid argument",
+			bufferID: C2DAckBufferID,
+			args:     []interface{}{true},
+			assertions: func(t *testing.T, _ <-chan arnetwork.Frame, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "error encoding command")
+			},
+		},
+
+		{
+			name:     "valid command",
+			bufferID: C2DAckBufferID,
+			args:     []interface{}{uint8(1)},
+			assertions: func(
+				t *testing.T,
+				c2dCh <-chan arnetwork.Frame,
+				err error,
+			) {
+				require.NoError(t, err)
+				select {
+				case frame := <-c2dCh:
+					require.Equal(t, []byte{1, 0, 2, 0, 1}, frame.Data)
+				case <-time.After(time.Second):
+					require.Fail(t, "timed out waiting for frame")
+				}
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			c2dCh := make(chan arnetwork.Frame, 1)
+			client := NewC2DCommandClient(
+				map[uint8]chan<- arnetwork.Frame{
+					C2DAckBufferID: c2dCh,
+				},
+			)
+			err := client.SendCommand(testCase.bufferID, 1, 0, 2, testCase.args...)
+			testCase.assertions(t, c2dCh, err)
+		})
+	}
+}
+
+func TestSendCommandWithReceipt(t *testing.T) {
+	c2dCh := make(chan arnetwork.Frame, 1)
+	client := NewC2DCommandClient(
+		map[uint8]chan<- arnetwork.Frame{
+			C2DAckBufferID: c2dCh,
+		},
+	)
+	// No receipt is returned if the command can't be sent
+	receipt, err := client.SendCommandWithReceipt(42, 1, 0, 2)
+	require.Error(t, err)
+	require.Nil(t, receipt)
+	// Otherwise, the returned receipt is the one attached to the frame
+	receipt, err = client.SendCommandWithReceipt(C2DAckBufferID, 1, 0, 2)
+	require.NoError(t, err)
+	require.NotNil(t, receipt)
+	select {
+	case frame := <-c2dCh:
+		require.Equal(t, []byte{1, 0, 2, 0}, frame.Data)
+		require.True(t, receipt == frame.Receipt)
+	case <-time.After(time.Second):
+		require.Fail(t, "timed out waiting for frame")
+	}
+}
+
+func TestSendCommandAfterClose(t *testing.T) {
+	c2dCh := make(chan arnetwork.Frame, 1)
+	client := NewC2DCommandClient(
+		map[uint8]chan<- arnetwork.Frame{
+			C2DAckBufferID: c2dCh,
+		},
+	)
+	client.Close()
+	select {
+	case <-c2dCh:
+		require.Fail(t, "c2d channel was written to or closed, but should not have been")
+	default:
+	}
+	// Closing a second time should be a no-op
+	client.Close()
+	err := client.SendCommand(C2DAckBufferID, 1, 0, 2)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "closed")
+}
+
+func TestCloseAbandonsBlockedSend(t *testing.T) {
+	// An unbuffered channel that is never read from simulates a full c2d buffer
+	c2dCh := make(chan arnetwork.Frame)
+	client := NewC2DCommandClient(
+		map[uint8]chan<- arnetwork.Frame{
+			C2DAckBufferID: c2dCh,
+		},
+	)
+	errCh := make(chan error)
+	go func() {
+		errCh <- client.SendCommand(C2DAckBufferID, 1, 0, 2)
+	}()
+	closedCh := make(chan struct{})
+	go func() {
+		client.Close()
+		close(closedCh)
+	}()
+	select {
+	case <-closedCh:
+	case <-time.After(time.Second):
+		require.Fail(t, "timed out waiting for Close to return")
+	}
+	select {
+	case err := <-errCh:
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "closed")
+	case <-time.After(time.Second):
+		require.Fail(t, "timed out waiting for blocked send to be abandoned")
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"reflect"
 
+	log "github.com/Sirupsen/logrus"
 	"github.com/pkg/errors"
 )
 
@@ -55,6 +56,11 @@ func (d *d2cCommand) execute(data []byte) error {
 	if err := decodeArgs(data, args); err != nil {
 		return errors.Wrap(err, "error decoding command arguments")
 	}
+	log.WithField(
+		"command", d.name,
+	).WithField(
+		"args", args,
+	).Debug("executing command")
 	if err := d.callback(args); err != nil {
 		return errors.Wrap(err, "error executing command")
 	}
@@ -101,6 +107,11 @@ func decodeArgs(data []byte, args []interface{}) error {
 			bytes, err = buf.ReadBytes(0x00)
 			bytes = bytes[0 : len(bytes)-1]
 			args[i] = string(bytes)
+		case Enum:
+			var value int32
+			if err = binary.Read(buf, binary.LittleEndian, &value); err == nil {
+				args[i], err = decodeEnum(arg, value)
+			}
 		default:
 			err = fmt.Errorf("unknown type: %s", reflect.TypeOf(argIface))
 		}
@@ -60,7 +60,7 @@ func (d *d2cCommandServer) receiveCommands(
 	d2cCh <-chan arnetwork.Frame,
 ) {
 	for frame := range d2cCh {
-		featureID, classID, commandID, err := parseIDS(frame.Data)
+		featureID, classID, commandID, err := ParseIDs(frame.Data)
 		if err != nil {
 			log.Error(err)
 			continue
@@ -84,7 +84,10 @@ func (d *d2cCommandServer) receiveCommands(
 	}
 }
 
-func parseIDS(data []byte) (
+// ParseIDs parses the feature, class, and command IDs from the beginning of an
+// encoded command. This is the inverse of the first step of
+// EncodeCommand(...).
+func ParseIDs(data []byte) (
 	featureID uint8,
 	classID uint8,
 	commandID uint16,
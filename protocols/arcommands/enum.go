@@ -0,0 +1,56 @@
+package arcommands
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/pkg/errors"
+)
+
+// Enum is implemented by the types of enum arguments. Every enum is encoded
+// as a 32 bit signed integer, so such types must have int32 as their
+// underlying type. When an Enum appears in an argument template, the
+// corresponding decoded argument will be of the same type.
+type Enum interface {
+	// String returns the name of the value, as defined by the device SDK. This
+	// permits enum values to be logged by name.
+	fmt.Stringer
+	// IsValid returns true if the value is one defined by the device SDK and
+	// false otherwise.
+	IsValid() bool
+}
+
+// decodeEnum returns a value of the same type as the given template having
+// the given integer value. An error is returned if the value is not valid for
+// the type.
+func decodeEnum(template Enum, value int32) (Enum, error) {
+	enumVal := reflect.New(reflect.TypeOf(template)).Elem()
+	if enumVal.Kind() != reflect.Int32 {
+		return nil, errors.Errorf(
+			"enum type %s does not have int32 as its underlying type",
+			enumVal.Type(),
+		)
+	}
+	enumVal.SetInt(int64(value))
+	enum := enumVal.Interface().(Enum)
+	if !enum.IsValid() {
+		return nil, errors.Errorf(
+			"invalid value %d for enum type %s",
+			value,
+			enumVal.Type(),
+		)
+	}
+	return enum, nil
+}
+
+// encodeEnum returns the integer value of the given enum.
+func encodeEnum(enum Enum) (int32, error) {
+	enumVal := reflect.ValueOf(enum)
+	if enumVal.Kind() != reflect.Int32 {
+		return 0, errors.Errorf(
+			"enum type %s does not have int32 as its underlying type",
+			enumVal.Type(),
+		)
+	}
+	return int32(enumVal.Int()), nil
+}
@@ -0,0 +1,90 @@
+package arcommands
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// testEnum is an Enum used for testing.
+type testEnum int32
+
+const (
+	testEnumFoo testEnum = 0
+	testEnumBar testEnum = 1
+)
+
+var testEnumNames = map[testEnum]string{
+	testEnumFoo: "foo",
+	testEnumBar: "bar",
+}
+
+func (t testEnum) String() string {
+	if name, ok := testEnumNames[t]; ok {
+		return name
+	}
+	return fmt.Sprintf("testEnum(%d)", int32(t))
+}
+
+func (t testEnum) IsValid() bool {
+	_, ok := testEnumNames[t]
+	return ok
+}
+
+// badTestEnum is an Enum whose underlying type is not int32.
+type badTestEnum string
+
+func (b badTestEnum) String() string {
+	return string(b)
+}
+
+func (b badTestEnum) IsValid() bool {
+	return true
+}
+
+func TestEncodeAndDecodeEnum(t *testing.T) {
+	data, err := EncodeCommand(1, 4, 9, []interface{}{testEnumBar})
+	require.NoError(t, err)
+	require.Equal(
+		t,
+		[]byte{
+			1,    // FeatureID
+			4,    // ClassID
+			9, 0, // CommandID (little endian)
+			1, 0, 0, 0, // enum (little endian int32)
+		},
+		data,
+	)
+	args := []interface{}{testEnumFoo}
+	err = decodeArgs(data, args)
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{testEnumBar}, args)
+	require.Equal(t, "[bar]", fmt.Sprintf("%v", args))
+}
+
+func TestDecodeInvalidEnum(t *testing.T) {
+	data := []byte{
+		1,    // FeatureID
+		4,    // ClassID
+		9, 0, // CommandID (little endian)
+		7, 0, 0, 0, // enum (little endian int32)
+	}
+	args := []interface{}{testEnumFoo}
+	err := decodeArgs(data, args)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid value 7 for enum type")
+}
+
+func TestEnumWithBadUnderlyingType(t *testing.T) {
+	_, err := EncodeCommand(1, 4, 9, []interface{}{badTestEnum("foo")})
+	require.Error(t, err)
+	data := []byte{
+		1,    // FeatureID
+		4,    // ClassID
+		9, 0, // CommandID (little endian)
+		1, 0, 0, 0, // enum (little endian int32)
+	}
+	err = decodeArgs(data, []interface{}{badTestEnum("")})
+	require.Error(t, err)
+}
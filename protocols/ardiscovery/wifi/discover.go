@@ -0,0 +1,288 @@
+package wifi
+
+import (
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	arnetworkal "github.com/krancour/go-parrot/protocols/arnetworkal/wifi"
+	"github.com/pkg/errors"
+)
+
+const (
+	// DefaultDiscoveryTimeout is how long Discover(...) will wait for devices
+	// to respond, unless otherwise specified.
+	DefaultDiscoveryTimeout = 2 * time.Second
+	// servicesEnumerationName is the DNS-SD name that, when queried, enumerates
+	// all service types advertised on the network.
+	servicesEnumerationName = "_services._dns-sd._udp.local."
+	// Parrot devices advertise service types of the form _arsdk-XXXX._udp where
+	// XXXX is the product ID in hexadecimal.
+	serviceTypePrefix = "_arsdk-"
+	serviceTypeSuffix = "._udp.local."
+	// maxMDNSMessageBytes is the maximum size of an mDNS message.
+	maxMDNSMessageBytes = 9000
+)
+
+// DefaultMDNSAddr is the well-known multicast address and port of mDNS.
+var DefaultMDNSAddr = &net.UDPAddr{
+	IP:   net.IPv4(224, 0, 0, 251),
+	Port: 5353,
+}
+
+// Device represents a device found through discovery.
+type Device struct {
+	// Name is the name the device advertises-- e.g. "Bebop2-123456".
+	Name string
+	// ProductID identifies the type of the device-- e.g. 0x090c for a Bebop 2.
+	ProductID uint16
+	// IP is the IP address of the device.
+	IP net.IP
+	// DiscoveryPort is the TCP port on which the device listens for connection
+	// negotiation requests.
+	DiscoveryPort int
+}
+
+// ConnectOptions returns options for connecting to the device. Options not
+// determined through discovery are left at their zero values (i.e. defaults)
+// and may be further customized by the caller.
+func (d Device) ConnectOptions() arnetworkal.ConnectOptions {
+	return arnetworkal.ConnectOptions{
+		DeviceIP:      d.IP,
+		DiscoveryPort: d.DiscoveryPort,
+	}
+}
+
+// DiscoverOptions represents options for discovering devices. The zero value
+// for any field selects a sensible default.
+type DiscoverOptions struct {
+	// Timeout is how long to wait for devices to respond. If zero,
+	// DefaultDiscoveryTimeout is used.
+	Timeout time.Duration
+	// MDNSAddr is the address to which mDNS queries are sent. If nil,
+	// DefaultMDNSAddr is used.
+	MDNSAddr *net.UDPAddr
+}
+
+// Discover browses the local network using mDNS / DNS-SD for devices
+// advertising any of the _arsdk-*._udp service types. It waits for the
+// duration of the discovery timeout and returns all devices that responded,
+// sorted by name.
+func Discover(opts DiscoverOptions) ([]Device, error) {
+	if opts.Timeout == 0 {
+		opts.Timeout = DefaultDiscoveryTimeout
+	}
+	if opts.Timeout < 0 {
+		return nil, errors.Errorf("invalid discovery timeout %s", opts.Timeout)
+	}
+	if opts.MDNSAddr == nil {
+		opts.MDNSAddr = DefaultMDNSAddr
+	}
+	log.WithField(
+		"mdnsAddr", opts.MDNSAddr,
+	).WithField(
+		"timeout", opts.Timeout,
+	).Debug("discovering devices")
+	// Queries are sent from an ephemeral port. Per RFC 6762 section 6.7,
+	// responders reply to such "legacy" queries using unicast, so there is no
+	// need to join the multicast group.
+	conn, err := net.ListenUDP("udp4", nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "error opening mdns connection")
+	}
+	defer conn.Close() // nolint: errcheck
+	b := newBrowser(conn, opts.MDNSAddr)
+	if err := b.query(servicesEnumerationName, dnsTypePTR); err != nil {
+		return nil, err
+	}
+	if err := b.run(time.Now().Add(opts.Timeout)); err != nil {
+		return nil, err
+	}
+	devices := b.devices()
+	log.WithField(
+		"deviceCount", len(devices),
+	).Debug("discovered devices")
+	return devices, nil
+}
+
+// browser accumulates DNS-SD records from mDNS responses and issues follow-up
+// queries for any records needed to fully resolve a device.
+type browser struct {
+	conn     *net.UDPConn
+	mdnsAddr *net.UDPAddr
+	queried  map[dnsQuestion]bool
+	// instances maps service instance names to service types
+	instances map[string]string
+	// srvs maps service instance names to SRV records
+	srvs map[string]dnsRecord
+	// hostIPs maps host names to IP addresses from A records
+	hostIPs map[string]net.IP
+	// srcIPs maps service instance names to the IP address from which the
+	// instance's SRV record was received. This is used if no A record is
+	// received for the instance's host.
+	srcIPs map[string]net.IP
+}
+
+func newBrowser(conn *net.UDPConn, mdnsAddr *net.UDPAddr) *browser {
+	return &browser{
+		conn:      conn,
+		mdnsAddr:  mdnsAddr,
+		queried:   map[dnsQuestion]bool{},
+		instances: map[string]string{},
+		srvs:      map[string]dnsRecord{},
+		hostIPs:   map[string]net.IP{},
+		srcIPs:    map[string]net.IP{},
+	}
+}
+
+// query sends a query for the given name and record type unless such a query
+// has already been sent.
+func (b *browser) query(name string, qtype uint16) error {
+	question := dnsQuestion{name: name, qtype: qtype}
+	if b.queried[question] {
+		return nil
+	}
+	b.queried[question] = true
+	data, err := dnsMessage{
+		questions: []dnsQuestion{question},
+	}.marshal()
+	if err != nil {
+		return errors.Wrap(err, "error encoding mdns query")
+	}
+	log.WithField(
+		"name", name,
+	).WithField(
+		"type", qtype,
+	).Debug("sending mdns query")
+	if _, err := b.conn.WriteToUDP(data, b.mdnsAddr); err != nil {
+		return errors.Wrap(err, "error sending mdns query")
+	}
+	return nil
+}
+
+// run processes responses until the deadline is reached.
+func (b *browser) run(deadline time.Time) error {
+	if err := b.conn.SetReadDeadline(deadline); err != nil {
+		return errors.Wrap(err, "error setting read deadline for mdns responses")
+	}
+	buf := make([]byte, maxMDNSMessageBytes)
+	for {
+		bytesRead, srcAddr, err := b.conn.ReadFromUDP(buf)
+		if err != nil {
+			if err, ok := err.(net.Error); ok && err.Timeout() {
+				return nil
+			}
+			return errors.Wrap(err, "error receiving mdns response")
+		}
+		msg, err := parseDNSMessage(buf[:bytesRead])
+		if err != nil {
+			// Other hosts may be sending anything at all. Ignore what we can't
+			// parse.
+			log.Debugf("ignoring malformed mdns message: %s", err)
+			continue
+		}
+		if !msg.response {
+			continue
+		}
+		if err := b.handleRecords(msg.records, srcAddr.IP); err != nil {
+			return err
+		}
+	}
+}
+
+func (b *browser) handleRecords(records []dnsRecord, srcIP net.IP) error {
+	// A records are handled first so that follow-up queries for hosts whose
+	// addresses are in the same message can be avoided
+	for _, record := range records {
+		if record.rtype == dnsTypeA {
+			b.hostIPs[strings.ToLower(record.name)] = record.ip
+		}
+	}
+	for _, record := range records {
+		switch record.rtype {
+		case dnsTypePTR:
+			if record.name == servicesEnumerationName {
+				if !isServiceType(record.target) {
+					continue
+				}
+				if err := b.query(record.target, dnsTypePTR); err != nil {
+					return err
+				}
+				continue
+			}
+			if !isServiceType(record.name) {
+				continue
+			}
+			b.instances[record.target] = record.name
+			if _, ok := b.srvs[record.target]; !ok {
+				if err := b.query(record.target, dnsTypeSRV); err != nil {
+					return err
+				}
+			}
+		case dnsTypeSRV:
+			b.srvs[record.name] = record
+			b.srcIPs[record.name] = srcIP
+			if _, ok := b.hostIPs[strings.ToLower(record.target)]; !ok {
+				if err := b.query(record.target, dnsTypeA); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// devices returns all fully resolved devices, sorted by name.
+func (b *browser) devices() []Device {
+	devices := []Device{}
+	for instance, serviceType := range b.instances {
+		srv, ok := b.srvs[instance]
+		if !ok {
+			continue
+		}
+		productID, err := productIDFromServiceType(serviceType)
+		if err != nil {
+			log.Debugf("ignoring service instance %s: %s", instance, err)
+			continue
+		}
+		ip, ok := b.hostIPs[strings.ToLower(srv.target)]
+		if !ok {
+			ip = b.srcIPs[instance]
+		}
+		devices = append(
+			devices,
+			Device{
+				Name:          strings.TrimSuffix(instance, "."+serviceType),
+				ProductID:     productID,
+				IP:            ip,
+				DiscoveryPort: int(srv.port),
+			},
+		)
+	}
+	sort.Slice(devices, func(i, j int) bool {
+		return devices[i].Name < devices[j].Name
+	})
+	return devices
+}
+
+func isServiceType(name string) bool {
+	return strings.HasPrefix(name, serviceTypePrefix) &&
+		strings.HasSuffix(name, serviceTypeSuffix)
+}
+
+// productIDFromServiceType extracts the hexadecimal product ID from a service
+// type of the form _arsdk-XXXX._udp.local.
+func productIDFromServiceType(serviceType string) (uint16, error) {
+	hexProductID := strings.TrimSuffix(
+		strings.TrimPrefix(serviceType, serviceTypePrefix),
+		serviceTypeSuffix,
+	)
+	productID, err := strconv.ParseUint(hexProductID, 16, 16)
+	if err != nil {
+		return 0, errors.Wrapf(err, "invalid product ID %q", hexProductID)
+	}
+	return uint16(productID), nil
+}
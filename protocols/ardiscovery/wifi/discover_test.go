@@ -0,0 +1,116 @@
+package wifi
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiscover(t *testing.T) {
+	// Mock out an mDNS responder that answers each question individually so
+	// that discovery is forced to issue follow-up queries.
+	responderConn, err := net.ListenUDP(
+		"udp4",
+		&net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)},
+	)
+	require.NoError(t, err)
+	defer responderConn.Close()
+	answers := map[dnsQuestion][]dnsRecord{
+		{name: servicesEnumerationName, qtype: dnsTypePTR}: {
+			{
+				name:   servicesEnumerationName,
+				rtype:  dnsTypePTR,
+				target: "_http._tcp.local.", // Should be ignored
+			},
+			{
+				name:   servicesEnumerationName,
+				rtype:  dnsTypePTR,
+				target: "_arsdk-090c._udp.local.",
+			},
+		},
+		{name: "_arsdk-090c._udp.local.", qtype: dnsTypePTR}: {
+			{
+				name:   "_arsdk-090c._udp.local.",
+				rtype:  dnsTypePTR,
+				target: "Bebop2-123456._arsdk-090c._udp.local.",
+			},
+		},
+		{name: "Bebop2-123456._arsdk-090c._udp.local.", qtype: dnsTypeSRV}: {
+			{
+				name:   "Bebop2-123456._arsdk-090c._udp.local.",
+				rtype:  dnsTypeSRV,
+				port:   44444,
+				target: "Bebop2-123456.local.",
+			},
+		},
+		{name: "Bebop2-123456.local.", qtype: dnsTypeA}: {
+			{
+				name:  "Bebop2-123456.local.",
+				rtype: dnsTypeA,
+				ip:    net.IPv4(192, 168, 42, 1).To4(),
+			},
+		},
+	}
+	go func() {
+		buf := make([]byte, maxMDNSMessageBytes)
+		for {
+			bytesRead, addr, err := responderConn.ReadFromUDP(buf)
+			if err != nil {
+				return
+			}
+			query, err := parseDNSMessage(buf[:bytesRead])
+			if err != nil {
+				continue
+			}
+			for _, question := range query.questions {
+				data, err := dnsMessage{
+					response: true,
+					records:  answers[question],
+				}.marshal()
+				if err != nil {
+					continue
+				}
+				responderConn.WriteToUDP(data, addr) // nolint: errcheck
+			}
+		}
+	}()
+
+	devices, err := Discover(
+		DiscoverOptions{
+			Timeout:  500 * time.Millisecond,
+			MDNSAddr: responderConn.LocalAddr().(*net.UDPAddr),
+		},
+	)
+	require.NoError(t, err)
+	require.Equal(
+		t,
+		[]Device{
+			{
+				Name:          "Bebop2-123456",
+				ProductID:     0x090c,
+				IP:            net.IPv4(192, 168, 42, 1).To4(),
+				DiscoveryPort: 44444,
+			},
+		},
+		devices,
+	)
+	connectOpts := devices[0].ConnectOptions()
+	require.Equal(t, devices[0].IP, connectOpts.DeviceIP)
+	require.Equal(t, 44444, connectOpts.DiscoveryPort)
+}
+
+func TestDiscoverWithInvalidTimeout(t *testing.T) {
+	_, err := Discover(DiscoverOptions{Timeout: -time.Second})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid discovery timeout")
+}
+
+func TestProductIDFromServiceType(t *testing.T) {
+	productID, err := productIDFromServiceType("_arsdk-090c._udp.local.")
+	require.NoError(t, err)
+	require.Equal(t, uint16(0x090c), productID)
+	_, err = productIDFromServiceType("_arsdk-zzzz._udp.local.")
+	require.Error(t, err)
+}
@@ -0,0 +1,282 @@
+package wifi
+
+import (
+	"bytes"
+	"encoding/binary"
+	"net"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// DNS resource record types used for mDNS / DNS-SD discovery
+const (
+	dnsTypeA   uint16 = 1
+	dnsTypePTR uint16 = 12
+	dnsTypeSRV uint16 = 33
+)
+
+const (
+	dnsClassIN uint16 = 1
+	// dnsHeaderBytesLength is the length of a DNS message header in bytes.
+	dnsHeaderBytesLength = 12
+	// dnsFlagResponse is the bit of the DNS header flags that indicates a
+	// message is a response.
+	dnsFlagResponse uint16 = 0x8000
+	// dnsMaxPointers limits how many compression pointers will be followed
+	// when decoding a single name. This guards against malicious or malformed
+	// messages containing pointer loops.
+	dnsMaxPointers = 16
+)
+
+// dnsQuestion is a question from the question section of a DNS message.
+type dnsQuestion struct {
+	name  string
+	qtype uint16
+}
+
+// dnsRecord is a resource record from the answer, authority, or additional
+// sections of a DNS message. Only the attributes relevant to a given record
+// type are populated.
+type dnsRecord struct {
+	name   string
+	rtype  uint16
+	ttl    uint32
+	target string // PTR and SRV records only
+	port   uint16 // SRV records only
+	ip     net.IP // A records only
+}
+
+// dnsMessage is a (minimal) DNS message. Only the portions of the protocol
+// required for mDNS / DNS-SD discovery are supported.
+type dnsMessage struct {
+	id        uint16
+	response  bool
+	questions []dnsQuestion
+	records   []dnsRecord
+}
+
+// marshal encodes the message in DNS wire format. Names are never compressed.
+// All records are encoded in the answer section.
+func (d dnsMessage) marshal() ([]byte, error) {
+	buf := &bytes.Buffer{}
+	var flags uint16
+	if d.response {
+		flags |= dnsFlagResponse
+	}
+	for _, val := range []uint16{
+		d.id,
+		flags,
+		uint16(len(d.questions)),
+		uint16(len(d.records)),
+		0, // Authority count
+		0, // Additional count
+	} {
+		if err := binary.Write(buf, binary.BigEndian, val); err != nil {
+			return nil, errors.Wrap(err, "error encoding dns message header")
+		}
+	}
+	for _, question := range d.questions {
+		if err := encodeDNSName(buf, question.name); err != nil {
+			return nil, err
+		}
+		if err := binary.Write(
+			buf,
+			binary.BigEndian,
+			[]uint16{question.qtype, dnsClassIN},
+		); err != nil {
+			return nil, errors.Wrap(err, "error encoding dns question")
+		}
+	}
+	for _, record := range d.records {
+		if err := encodeDNSName(buf, record.name); err != nil {
+			return nil, err
+		}
+		rdataBuf := &bytes.Buffer{}
+		switch record.rtype {
+		case dnsTypePTR:
+			if err := encodeDNSName(rdataBuf, record.target); err != nil {
+				return nil, err
+			}
+		case dnsTypeSRV:
+			// Priority and weight are always encoded as zero
+			if err := binary.Write(
+				rdataBuf,
+				binary.BigEndian,
+				[]uint16{0, 0, record.port},
+			); err != nil {
+				return nil, errors.Wrap(err, "error encoding dns srv record")
+			}
+			if err := encodeDNSName(rdataBuf, record.target); err != nil {
+				return nil, err
+			}
+		case dnsTypeA:
+			ip := record.ip.To4()
+			if ip == nil {
+				return nil, errors.Errorf("%s is not an IPv4 address", record.ip)
+			}
+			rdataBuf.Write(ip)
+		default:
+			return nil,
+				errors.Errorf("unsupported dns record type %d", record.rtype)
+		}
+		if err := binary.Write(
+			buf,
+			binary.BigEndian,
+			struct {
+				Type        uint16
+				Class       uint16
+				TTL         uint32
+				RDataLength uint16
+			}{
+				Type:        record.rtype,
+				Class:       dnsClassIN,
+				TTL:         record.ttl,
+				RDataLength: uint16(rdataBuf.Len()),
+			},
+		); err != nil {
+			return nil, errors.Wrap(err, "error encoding dns record")
+		}
+		buf.Write(rdataBuf.Bytes())
+	}
+	return buf.Bytes(), nil
+}
+
+// parseDNSMessage decodes a message in DNS wire format. Records from the
+// answer, authority, and additional sections are all returned together.
+// Records of types that are irrelevant to discovery are skipped.
+func parseDNSMessage(data []byte) (dnsMessage, error) {
+	msg := dnsMessage{}
+	if len(data) < dnsHeaderBytesLength {
+		return msg, errors.New("dns message is shorter than its header")
+	}
+	msg.id = binary.BigEndian.Uint16(data[0:2])
+	msg.response = binary.BigEndian.Uint16(data[2:4])&dnsFlagResponse != 0
+	qdCount := int(binary.BigEndian.Uint16(data[4:6]))
+	rrCount := int(binary.BigEndian.Uint16(data[6:8])) +
+		int(binary.BigEndian.Uint16(data[8:10])) +
+		int(binary.BigEndian.Uint16(data[10:12]))
+	offset := dnsHeaderBytesLength
+	for i := 0; i < qdCount; i++ {
+		name, next, err := decodeDNSName(data, offset)
+		if err != nil {
+			return msg, errors.Wrap(err, "error decoding dns question")
+		}
+		if next+4 > len(data) {
+			return msg, errors.New("dns question is truncated")
+		}
+		msg.questions = append(
+			msg.questions,
+			dnsQuestion{
+				name:  name,
+				qtype: binary.BigEndian.Uint16(data[next : next+2]),
+			},
+		)
+		offset = next + 4
+	}
+	for i := 0; i < rrCount; i++ {
+		name, next, err := decodeDNSName(data, offset)
+		if err != nil {
+			return msg, errors.Wrap(err, "error decoding dns record")
+		}
+		if next+10 > len(data) {
+			return msg, errors.New("dns record is truncated")
+		}
+		record := dnsRecord{
+			name:  name,
+			rtype: binary.BigEndian.Uint16(data[next : next+2]),
+			ttl:   binary.BigEndian.Uint32(data[next+4 : next+8]),
+		}
+		rdataLength := int(binary.BigEndian.Uint16(data[next+8 : next+10]))
+		rdataStart := next + 10
+		offset = rdataStart + rdataLength
+		if offset > len(data) {
+			return msg, errors.New("dns record data is truncated")
+		}
+		switch record.rtype {
+		case dnsTypePTR:
+			if record.target, _, err =
+				decodeDNSName(data, rdataStart); err != nil {
+				return msg, errors.Wrap(err, "error decoding dns ptr record")
+			}
+		case dnsTypeSRV:
+			if rdataLength < 7 {
+				return msg, errors.New("dns srv record is truncated")
+			}
+			record.port =
+				binary.BigEndian.Uint16(data[rdataStart+4 : rdataStart+6])
+			if record.target, _, err =
+				decodeDNSName(data, rdataStart+6); err != nil {
+				return msg, errors.Wrap(err, "error decoding dns srv record")
+			}
+		case dnsTypeA:
+			if rdataLength != net.IPv4len {
+				return msg, errors.New("dns a record has an invalid length")
+			}
+			record.ip = net.IP(append([]byte{}, data[rdataStart:offset]...))
+		default:
+			// Not relevant to discovery
+			continue
+		}
+		msg.records = append(msg.records, record)
+	}
+	return msg, nil
+}
+
+// encodeDNSName encodes a fully qualified domain name as a sequence of length
+// prefixed labels terminated by a zero length label.
+func encodeDNSName(buf *bytes.Buffer, name string) error {
+	for _, label := range strings.Split(strings.TrimSuffix(name, "."), ".") {
+		if label == "" {
+			continue
+		}
+		if len(label) > 63 {
+			return errors.Errorf("dns label %q is too long", label)
+		}
+		buf.WriteByte(byte(len(label)))
+		buf.WriteString(label)
+	}
+	return buf.WriteByte(0)
+}
+
+// decodeDNSName decodes the (possibly compressed) name found at the given
+// offset. It returns the name, in fully qualified form, and the offset of the
+// first byte following the name.
+func decodeDNSName(data []byte, offset int) (string, int, error) {
+	labels := []string{}
+	next := -1
+	pointers := 0
+	for {
+		if offset >= len(data) {
+			return "", 0, errors.New("dns name is truncated")
+		}
+		length := int(data[offset])
+		switch {
+		case length == 0:
+			if next < 0 {
+				next = offset + 1
+			}
+			return strings.Join(labels, ".") + ".", next, nil
+		case length&0xc0 == 0xc0:
+			// This is a compression pointer
+			if offset+1 >= len(data) {
+				return "", 0, errors.New("dns name pointer is truncated")
+			}
+			if pointers++; pointers > dnsMaxPointers {
+				return "", 0, errors.New("too many dns name pointers")
+			}
+			if next < 0 {
+				next = offset + 2
+			}
+			offset = int(binary.BigEndian.Uint16(data[offset:offset+2]) & 0x3fff)
+		case length&0xc0 != 0:
+			return "", 0, errors.Errorf("invalid dns label length %d", length)
+		default:
+			if offset+1+length > len(data) {
+				return "", 0, errors.New("dns label is truncated")
+			}
+			labels = append(labels, string(data[offset+1:offset+1+length]))
+			offset += 1 + length
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package wifi
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDNSMessageRoundTrip(t *testing.T) {
+	msg := dnsMessage{
+		id:       42,
+		response: true,
+		questions: []dnsQuestion{
+			{
+				name:  "_arsdk-090c._udp.local.",
+				qtype: dnsTypePTR,
+			},
+		},
+		records: []dnsRecord{
+			{
+				name:   "_arsdk-090c._udp.local.",
+				rtype:  dnsTypePTR,
+				ttl:    120,
+				target: "Bebop2-123456._arsdk-090c._udp.local.",
+			},
+			{
+				name:   "Bebop2-123456._arsdk-090c._udp.local.",
+				rtype:  dnsTypeSRV,
+				ttl:    120,
+				port:   44444,
+				target: "Bebop2-123456.local.",
+			},
+			{
+				name:  "Bebop2-123456.local.",
+				rtype: dnsTypeA,
+				ttl:   120,
+				ip:    net.IPv4(192, 168, 42, 1).To4(),
+			},
+		},
+	}
+	data, err := msg.marshal()
+	require.NoError(t, err)
+	parsedMsg, err := parseDNSMessage(data)
+	require.NoError(t, err)
+	require.Equal(t, msg, parsedMsg)
+}
+
+func TestParseDNSMessageWithCompressedNames(t *testing.T) {
+	data := []byte{
+		0x00, 0x00, // ID
+		0x84, 0x00, // Flags (authoritative response)
+		0x00, 0x00, // Question count
+		0x00, 0x01, // Answer count
+		0x00, 0x00, // Authority count
+		0x00, 0x00, // Additional count
+		// Name: _arsdk-090c._udp.local.
+		0x0b, '_', 'a', 'r', 's', 'd', 'k', '-', '0', '9', '0', 'c',
+		0x04, '_', 'u', 'd', 'p',
+		0x05, 'l', 'o', 'c', 'a', 'l',
+		0x00,
+		0x00, 0x0c, // Type PTR
+		0x00, 0x01, // Class IN
+		0x00, 0x00, 0x00, 0x78, // TTL
+		0x00, 0x06, // RData length
+		// Target: foo + pointer to offset 12 (_arsdk-090c._udp.local.)
+		0x03, 'f', 'o', 'o',
+		0xc0, 0x0c,
+	}
+	msg, err := parseDNSMessage(data)
+	require.NoError(t, err)
+	require.True(t, msg.response)
+	require.Len(t, msg.records, 1)
+	require.Equal(t, "_arsdk-090c._udp.local.", msg.records[0].name)
+	require.Equal(t, "foo._arsdk-090c._udp.local.", msg.records[0].target)
+}
+
+func TestParseMalformedDNSMessage(t *testing.T) {
+	testCases := []struct {
+		name string
+		data []byte
+	}{
+		{
+			name: "truncated header",
+			data: []byte{0x00, 0x00, 0x84},
+		},
+		{
+			name: "truncated record",
+			data: []byte{
+				0x00, 0x00, 0x84, 0x00,
+				0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00,
+				0x03, 'f', 'o', 'o', 0x00,
+				0x00, 0x0c,
+			},
+		},
+		{
+			name: "pointer loop",
+			data: []byte{
+				0x00, 0x00, 0x84, 0x00,
+				0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00,
+				0xc0, 0x0c, // Points at itself
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			_, err := parseDNSMessage(testCase.data)
+			require.Error(t, err)
+		})
+	}
+}
@@ -1,7 +1,10 @@
 package wifi
 
-// The wifi package provides TCP/IP based connection negotiation and establishes
-// UDP/IP based connections for sending and receiving data to a device.
+// The wifi package provides mDNS / DNS-SD based discovery of devices on a
+// network. Discovered devices can be connected to using the connection options
+// each provides to the wifi package found under the arnetworkal package, which
+// provides TCP/IP based connection negotiation and establishes UDP/IP based
+// connections for sending and receiving data to a device.
 //
 // Note that this package and the (partial) implementation of the ARDiscovery
 // protocol found within is abbreviated as "wifi" for traceability to relevant
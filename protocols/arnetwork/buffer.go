@@ -51,12 +51,14 @@ func (b *buffer) bufferFrames() {
 					"buffer is full and overwriting is not enabled; dropping new " +
 						"arnetwork frame",
 				)
+				resolveDropped(frame)
 				continue
 			}
 			select {
 			// Try to remove the oldest frame from the outCh.
-			case <-b.outCh:
+			case oldFrame := <-b.outCh:
 				// Success! We made room on the outCh.
+				resolveDropped(oldFrame)
 				log.Debug(
 					"buffer is full and overwriting is enabled; dropping oldest " +
 						"arnetwork frame",
@@ -73,10 +75,19 @@ func (b *buffer) bufferFrames() {
 			b.outCh <- frame
 		}
 	}
-	// Signal that we're done buffering. This is really only used in testing.
-	// We want to know that all data that was going to be buffered has been
-	// before we start reading from that buffer and making assertions.
+	// Signal that we're done buffering. This is used when closing Buffers to
+	// know that a buffer has stopped and in testing to know that all data that
+	// was going to be buffered has been before we start reading from that
+	// buffer and making assertions.
 	close(b.doneCh)
 	// Signal anyone listening to the outCh that there's no more data coming!
 	close(b.outCh)
 }
+
+// resolveDropped resolves the given frame's receipt, if it has one, to reflect
+// that the frame was dropped from a full buffer.
+func resolveDropped(frame Frame) {
+	if frame.Receipt != nil {
+		frame.Receipt.resolve(FrameDelivery{Err: ErrFrameDropped})
+	}
+}
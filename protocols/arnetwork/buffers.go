@@ -1,23 +1,79 @@
 package arnetwork
 
 import (
+	"context"
+	"sync"
+
 	log "github.com/Sirupsen/logrus"
 
 	"github.com/krancour/go-parrot/protocols/arnetworkal"
+	"github.com/pkg/errors"
 )
 
-// NewBuffers returns maps of write-only channels for placing frames onto c2d
-// buffers and read-only channels for receiving frames from d2c buffers. All
-// channels are indexed by buffer ID.
+// Buffers is a set of c2d and d2c buffers sharing a single arnetworkal
+// connection. Buffers owns the arnetworkal frame sender and frame receiver it
+// was created with, as well as all of its channels. Callers must not close any
+// of these themselves; they are all closed by Close(...).
+type Buffers struct {
+	frameSender   arnetworkal.FrameSender
+	frameReceiver arnetworkal.FrameReceiver
+	// c2dBufs are the c2d buffers configured by the caller
+	c2dBufs []*c2dBuffer
+	// internalC2DBufs are the c2d buffers used internally-- i.e. for responding
+	// to pings and for acknowledging receipt of frames. Their input channels
+	// are closed automatically once frame receipt stops.
+	internalC2DBufs []*c2dBuffer
+	// d2cBufs are all d2c buffers, including those used internally
+	d2cBufs   []*d2cBuffer
+	c2dInChs  map[uint8]chan<- Frame
+	d2cOutChs map[uint8]<-chan Frame
+	errCh     chan error
+	// stopCh is closed to stop frame receipt
+	stopCh chan struct{}
+	// receiverDoneCh is closed once frame receipt has stopped
+	receiverDoneCh chan struct{}
+	// pongerDoneCh is closed once the goroutine responding to pings has stopped
+	pongerDoneCh chan struct{}
+	closeOnce    sync.Once
+	closeErr     error
+}
+
+// NewBuffers returns Buffers for placing frames onto c2d buffers and receiving
+// frames from d2c buffers over the connection represented by the given frame
+// sender and frame receiver. Goroutines are started to service every buffer;
+// Close(...) must be called to stop them. If an error is returned, no
+// goroutines were started and the caller remains responsible for closing the
+// frame sender and frame receiver.
 func NewBuffers(
 	frameSender arnetworkal.FrameSender,
 	frameReceiver arnetworkal.FrameReceiver,
 	c2dBufCfgs []C2DBufferConfig,
 	d2cBufCfgs []D2CBufferConfig,
-) (map[uint8]chan<- Frame, map[uint8]<-chan Frame, error) {
-	c2dInChs := map[uint8]chan<- Frame{}
+) (*Buffers, error) {
+	// Validate all configuration before creating any buffers so that no
+	// goroutines are left behind if any of it is invalid.
+	for _, bufCfg := range c2dBufCfgs {
+		if err := bufCfg.validate(); err != nil {
+			return nil, err
+		}
+	}
+	for _, bufCfg := range d2cBufCfgs {
+		if err := bufCfg.validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	b := &Buffers{
+		frameSender:    frameSender,
+		frameReceiver:  frameReceiver,
+		c2dInChs:       map[uint8]chan<- Frame{},
+		d2cOutChs:      map[uint8]<-chan Frame{},
+		errCh:          make(chan error, 1),
+		stopCh:         make(chan struct{}),
+		receiverDoneCh: make(chan struct{}),
+		pongerDoneCh:   make(chan struct{}),
+	}
 	d2cInChs := map[uint8]chan<- Frame{}
-	d2cOutChs := map[uint8]<-chan Frame{}
 
 	// TODO: This is a GUESS at how this buffer should be configured. The details
 	// of this buffer are not well documented.
@@ -30,6 +86,7 @@ func NewBuffers(
 			IsOverwriting: true,
 		},
 	)
+	b.d2cBufs = append(b.d2cBufs, pingBuf)
 	// This is for arnetwork internal use only. We'll add the input channel of
 	// this d2cBuffer to d2cInChs so that receiveFrames(...) can add frames to
 	// this buffer, BUT we'll refrain from adding the output channel of this
@@ -53,13 +110,12 @@ func NewBuffers(
 	// The above is for arnetwork internal use only. We won't add its input
 	// channel to c2dInChs, because those channels are returned from this function
 	// and can be written to by the caller-- which we do not want in this case.
+	b.internalC2DBufs = append(b.internalC2DBufs, pongBuf)
 
 	for _, bufCfg := range c2dBufCfgs {
-		if err := bufCfg.validate(); err != nil {
-			return nil, nil, err
-		}
 		buf := newC2DBuffer(bufCfg, frameSender)
-		c2dInChs[bufCfg.ID] = buf.inCh
+		b.c2dBufs = append(b.c2dBufs, buf)
+		b.c2dInChs[bufCfg.ID] = buf.inCh
 		if bufCfg.FrameType == arnetworkal.FrameTypeDataWithAck {
 			// Automatically create an ack buffer...
 			ackBufID := bufCfg.ID + ackBufferOffset
@@ -73,17 +129,17 @@ func NewBuffers(
 					IsOverwriting: false, // Useless by design: there is only one ack waiting at a time
 				},
 			)
+			b.d2cBufs = append(b.d2cBufs, ackBuf)
+			d2cInChs[ackBufID] = ackBuf.inCh
 			buf.ackCh = ackBuf.buffer.outCh
 		}
 	}
 
 	for _, bufCfg := range d2cBufCfgs {
-		if err := bufCfg.validate(); err != nil {
-			return nil, nil, err
-		}
 		buf := newD2CBuffer(bufCfg)
+		b.d2cBufs = append(b.d2cBufs, buf)
 		d2cInChs[bufCfg.ID] = buf.inCh
-		d2cOutChs[bufCfg.ID] = buf.buffer.outCh
+		b.d2cOutChs[bufCfg.ID] = buf.buffer.outCh
 		if bufCfg.FrameType == arnetworkal.FrameTypeDataWithAck {
 			// Automatically create an ack buffer...
 			ackBufID := bufCfg.ID + ackBufferOffset
@@ -100,12 +156,16 @@ func NewBuffers(
 				},
 				frameSender,
 			)
-			buf.ackCh = ackBuf.buffer.inCh
+			b.internalC2DBufs = append(b.internalC2DBufs, ackBuf)
+			buf.ackCh = ackBuf.inCh
 		}
 	}
 
 	// Mux received frames into the appropriate buffers
-	go receiveFrames(frameReceiver, d2cInChs)
+	go func() {
+		defer close(b.receiverDoneCh)
+		receiveFrames(frameReceiver, d2cInChs, b.errCh, b.stopCh)
+	}()
 
 	// Respond to pings. This turns out to be very important for avoiding
 	// disconnects! Why? The arnetwork protocol (on the device end) assumes a
@@ -118,25 +178,163 @@ func NewBuffers(
 	// to send more often than every five seconds, our best bet for avoiding
 	// disconnects is to simply respond to pings.
 	go func() {
+		defer close(b.pongerDoneCh)
 		for frame := range pingBuf.buffer.outCh {
 			log.Debug("received ping; sending pong")
 			pongBuf.inCh <- Frame{
 				Data: frame.Data,
 			}
 		}
+		close(pongBuf.inCh)
 	}()
 
-	return c2dInChs, d2cOutChs, nil
+	return b, nil
+}
+
+// C2DChs returns a map of write-only channels for placing frames onto c2d
+// buffers, indexed by buffer ID. Callers placing a frame onto a c2d buffer may
+// learn the outcome of its delivery by attaching a Receipt to it. A new map is
+// returned on every call, but the channels are always the same. Callers must
+// not close these channels and must stop writing to them before calling
+// Close(...).
+func (b *Buffers) C2DChs() map[uint8]chan<- Frame {
+	c2dInChs := make(map[uint8]chan<- Frame, len(b.c2dInChs))
+	for id, ch := range b.c2dInChs {
+		c2dInChs[id] = ch
+	}
+	return c2dInChs
+}
+
+// D2CChs returns a map of read-only channels for receiving frames from d2c
+// buffers, indexed by buffer ID. A new map is returned on every call, but the
+// channels are always the same. The channels are closed when frame receipt
+// stops.
+func (b *Buffers) D2CChs() map[uint8]<-chan Frame {
+	d2cOutChs := make(map[uint8]<-chan Frame, len(b.d2cOutChs))
+	for id, ch := range b.d2cOutChs {
+		d2cOutChs[id] = ch
+	}
+	return d2cOutChs
+}
+
+// ErrCh returns a read-only error channel. If frame receipt stops because a
+// probable disconnection was detected, the *arnetworkal.DisconnectedError
+// describing the disconnection is sent over this channel. The error channel is
+// closed when frame receipt stops for any reason, including the frame
+// receiver's underlying connection having been closed and Buffers having been
+// closed.
+func (b *Buffers) ErrCh() <-chan error {
+	return b.errCh
+}
+
+// Close shuts down all buffers. First, all c2d channels are closed and c2d
+// buffers are given until the given context is done to deliver any frames
+// they've already accepted, including frames still awaiting acknowledgement.
+// Frames still undelivered after that are abandoned and their Receipts are
+// resolved with ErrBuffersClosed. Then frame receipt is stopped, which closes
+// all d2c channels, and the arnetworkal frame sender and frame receiver are
+// closed. Close returns only once every goroutine started by NewBuffers(...)
+// has stopped. If undelivered frames had to be abandoned, the context's error
+// is returned. It is safe to call this more than once; subsequent calls
+// return the same result as the first.
+func (b *Buffers) Close(ctx context.Context) error {
+	b.closeOnce.Do(func() {
+		b.closeErr = b.close(ctx)
+	})
+	return b.closeErr
+}
+
+func (b *Buffers) close(ctx context.Context) error {
+	log.Debug("closing arnetwork buffers")
+	// Frame receipt is left running while the c2d buffers drain so that
+	// acknowledgements of frames they're still delivering can arrive.
+	for _, buf := range b.c2dBufs {
+		close(buf.inCh)
+	}
+	var err error
+	for _, buf := range b.c2dBufs {
+		select {
+		case <-buf.doneCh:
+			continue
+		case <-ctx.Done():
+		}
+		err = ctx.Err()
+		log.Warnf(
+			"%s; abandoning frames not yet delivered by arnetwork buffers",
+			err,
+		)
+		for _, buf := range b.c2dBufs {
+			buf.abort()
+		}
+		break
+	}
+	// Once aborted, every c2d buffer finishes promptly.
+	for _, buf := range b.c2dBufs {
+		<-buf.doneCh
+	}
+	// Stopping frame receipt closes all d2c buffers. In turn, that stops the
+	// goroutine responding to pings and closes the input channels of all
+	// internal c2d buffers.
+	close(b.stopCh)
+	// Receive() may be blocked, so closing the frame receiver is what actually
+	// makes frame receipt stop.
+	b.frameReceiver.Close()
+	<-b.receiverDoneCh
+	for _, buf := range b.d2cBufs {
+		<-buf.buffer.doneCh
+	}
+	<-b.pongerDoneCh
+	for _, buf := range b.internalC2DBufs {
+		<-buf.doneCh
+	}
+	b.frameSender.Close()
+	log.Debug("closed arnetwork buffers")
+	return err
 }
 
-// receiveFrames muxes frames into the appropriate buffers.
+// receiveFrames muxes frames into the appropriate buffers. Frame receipt stops
+// when the given stop channel is closed, when the frame receiver's underlying
+// connection is closed, or when a probable disconnection is detected. In the
+// latter case, the error describing the disconnection is sent over the given
+// error channel. In every case, the error channel is closed and all d2c
+// buffers' input channels are closed. This causes all d2c buffers to close
+// their output channels in turn, which signals to anyone listening that there
+// is no more data coming.
 func receiveFrames(
 	frameReceiver arnetworkal.FrameReceiver,
 	d2cInChs map[uint8]chan<- Frame,
+	errCh chan<- error,
+	stopCh <-chan struct{},
 ) {
+	defer func() {
+		for _, d2cInCh := range d2cInChs {
+			close(d2cInCh)
+		}
+		close(errCh)
+	}()
 	for {
+		select {
+		case <-stopCh:
+			log.Debug("arnetwork buffers closed; no longer receiving frames")
+			return
+		default:
+		}
 		netFrames, err := frameReceiver.Receive()
 		if err != nil {
+			if errors.Cause(err) == arnetworkal.ErrClosed {
+				log.Debug("arnetworkal connection closed; no longer receiving frames")
+				return
+			}
+			if arnetworkal.IsDisconnected(err) {
+				log.Warnf(
+					"%s; no longer receiving arnetworkal frames",
+					err,
+				)
+				// errCh is buffered and this is the only error ever sent to it, so
+				// this cannot block.
+				errCh <- err
+				return
+			}
 			log.Errorf("error receiving arnetworkal frames: %s", err)
 			continue
 		}
@@ -1,6 +1,7 @@
 package arnetwork
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -8,6 +9,7 @@ import (
 	"github.com/krancour/go-parrot/protocols/arnetworkal/fake"
 
 	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
 )
 
 func TestNewBuffers(t *testing.T) {
@@ -15,12 +17,7 @@ func TestNewBuffers(t *testing.T) {
 		name       string
 		c2dBufCfgs []C2DBufferConfig
 		d2cBufCfgs []D2CBufferConfig
-		assertions func(
-			*testing.T,
-			map[uint8]chan<- Frame,
-			map[uint8]<-chan Frame,
-			error,
-		)
+		assertions func(*testing.T, *Buffers, error)
 	}{
 
 		{
@@ -31,12 +28,7 @@ func TestNewBuffers(t *testing.T) {
 					Size:      0,
 				},
 			},
-			assertions: func(
-				t *testing.T,
-				_ map[uint8]chan<- Frame,
-				_ map[uint8]<-chan Frame,
-				err error,
-			) {
+			assertions: func(t *testing.T, _ *Buffers, err error) {
 				require.Error(t, err)
 				require.Contains(t, err.Error(), "invalid")
 			},
@@ -50,12 +42,7 @@ func TestNewBuffers(t *testing.T) {
 					Size:      0,
 				},
 			},
-			assertions: func(
-				t *testing.T,
-				_ map[uint8]chan<- Frame,
-				_ map[uint8]<-chan Frame,
-				err error,
-			) {
+			assertions: func(t *testing.T, _ *Buffers, err error) {
 				require.Error(t, err)
 				require.Contains(t, err.Error(), "invalid")
 			},
@@ -77,13 +64,12 @@ func TestNewBuffers(t *testing.T) {
 					Size:      1,
 				},
 			},
-			assertions: func(
-				t *testing.T,
-				c2dChs map[uint8]chan<- Frame,
-				d2cChs map[uint8]<-chan Frame,
-				err error,
-			) {
+			assertions: func(t *testing.T, buffers *Buffers, err error) {
 				require.NoError(t, err)
+				defer buffers.Close(context.Background()) // nolint: errcheck
+
+				c2dChs := buffers.C2DChs()
+				d2cChs := buffers.D2CChs()
 
 				require.Len(t, c2dChs, 1)
 				_, ok := c2dChs[5]
@@ -98,13 +84,13 @@ func TestNewBuffers(t *testing.T) {
 
 	for _, testCase := range testCases {
 		t.Run(testCase.name, func(t *testing.T) {
-			c2dChs, d2cChs, err := NewBuffers(
+			buffers, err := NewBuffers(
 				&fake.FrameSender{},
 				&fake.FrameReceiver{},
 				testCase.c2dBufCfgs,
 				testCase.d2cBufCfgs,
 			)
-			testCase.assertions(t, c2dChs, d2cChs, err)
+			testCase.assertions(t, buffers, err)
 		})
 	}
 }
@@ -131,7 +117,14 @@ func TestReceiveFrames(t *testing.T) {
 		},
 	}
 	testCh := make(chan Frame)
-	go receiveFrames(frameReceiver, map[uint8]chan<- Frame{1: testCh})
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	go receiveFrames(
+		frameReceiver,
+		map[uint8]chan<- Frame{1: testCh},
+		make(chan error, 1),
+		stopCh,
+	)
 	for i := 0; i < numFrames; i++ {
 		select {
 		case frame, ok := <-testCh:
@@ -148,3 +141,283 @@ func TestReceiveFrames(t *testing.T) {
 	case <-time.After(2 * time.Second):
 	}
 }
+
+func TestReceiveFramesStops(t *testing.T) {
+	testCases := []struct {
+		name       string
+		receiveErr error
+		assertions func(*testing.T, <-chan error)
+	}{
+
+		{
+			name:       "connection closed",
+			receiveErr: arnetworkal.ErrClosed,
+			assertions: func(t *testing.T, errCh <-chan error) {
+				_, ok := <-errCh
+				require.False(t, ok, "error channel should have been closed")
+			},
+		},
+
+		{
+			name: "probable disconnection",
+			receiveErr: &arnetworkal.DisconnectedError{
+				Timeout: time.Second,
+			},
+			assertions: func(t *testing.T, errCh <-chan error) {
+				err, ok := <-errCh
+				require.True(t, ok, "error channel should have received an error")
+				require.True(t, arnetworkal.IsDisconnected(err))
+				_, ok = <-errCh
+				require.False(t, ok, "error channel should have been closed")
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			frameReceiver := &fake.FrameReceiver{
+				ReceiveBehavior: func() ([]arnetworkal.Frame, error) {
+					return nil, testCase.receiveErr
+				},
+			}
+			testCh := make(chan Frame)
+			errCh := make(chan error, 1)
+			doneCh := make(chan struct{})
+			go func() {
+				receiveFrames(
+					frameReceiver,
+					map[uint8]chan<- Frame{1: testCh},
+					errCh,
+					make(chan struct{}),
+				)
+				close(doneCh)
+			}()
+			select {
+			case <-doneCh:
+			case <-time.After(time.Second):
+				require.Fail(t, "timed out waiting for frame receipt to stop")
+			}
+			_, ok := <-testCh
+			require.False(t, ok, "d2c input channel should have been closed")
+			testCase.assertions(t, errCh)
+		})
+	}
+}
+
+// loopbackConnection is a fake arnetworkal connection whose frame receiver
+// receives whatever frames are placed on its frames channel. If ack is true,
+// frames requiring acknowledgement that are sent using its frame sender are
+// acknowledged.
+type loopbackConnection struct {
+	frameSender   *fake.FrameSender
+	frameReceiver *fake.FrameReceiver
+	framesCh      chan arnetworkal.Frame
+	closedCh      chan struct{}
+	senderClosed  bool
+}
+
+func newLoopbackConnection(ack bool) *loopbackConnection {
+	l := &loopbackConnection{
+		framesCh: make(chan arnetworkal.Frame, 10),
+		closedCh: make(chan struct{}),
+	}
+	l.frameSender = &fake.FrameSender{
+		SendBehavior: func(netFrame arnetworkal.Frame) error {
+			if ack && netFrame.Type == arnetworkal.FrameTypeDataWithAck {
+				l.framesCh <- arnetworkal.Frame{
+					Type: arnetworkal.FrameTypeAck,
+					ID:   netFrame.ID + ackBufferOffset,
+					Seq:  netFrame.Seq,
+					Data: []byte{netFrame.Seq},
+				}
+			}
+			return nil
+		},
+		CloseBehavior: func() {
+			l.senderClosed = true
+		},
+	}
+	l.frameReceiver = &fake.FrameReceiver{
+		ReceiveBehavior: func() ([]arnetworkal.Frame, error) {
+			select {
+			case netFrame := <-l.framesCh:
+				return []arnetworkal.Frame{netFrame}, nil
+			case <-l.closedCh:
+				return nil, arnetworkal.ErrClosed
+			}
+		},
+		CloseBehavior: func() {
+			close(l.closedCh)
+		},
+	}
+	return l
+}
+
+func TestBuffersClose(t *testing.T) {
+	defer goleak.VerifyNone(t, goleak.IgnoreCurrent())
+	conn := newLoopbackConnection(true)
+	buffers, err := NewBuffers(
+		conn.frameSender,
+		conn.frameReceiver,
+		[]C2DBufferConfig{
+			{
+				ID:         10,
+				FrameType:  arnetworkal.FrameTypeDataWithAck,
+				Size:       10,
+				AckTimeout: time.Second,
+				MaxRetries: -1, // Infinite
+			},
+		},
+		[]D2CBufferConfig{
+			{
+				ID:        20,
+				FrameType: arnetworkal.FrameTypeDataWithAck,
+				Size:      10,
+			},
+		},
+	)
+	require.NoError(t, err)
+	receipt := NewReceipt()
+	buffers.C2DChs()[10] <- Frame{
+		Data:    []byte("foo"),
+		Receipt: receipt,
+	}
+	conn.framesCh <- arnetworkal.Frame{
+		Type: arnetworkal.FrameTypeDataWithAck,
+		ID:   20,
+		Seq:  1,
+		Data: []byte("bar"),
+	}
+	d2cCh := buffers.D2CChs()[20]
+	frame := <-d2cCh
+	require.Equal(t, []byte("bar"), frame.Data)
+
+	require.NoError(t, buffers.Close(context.Background()))
+	// The frame placed on the c2d buffer was delivered before Close returned
+	select {
+	case <-receipt.Done():
+		require.True(t, receipt.Delivery().Acked)
+	default:
+		require.Fail(t, "frame was not delivered before buffers were closed")
+	}
+	_, ok := <-d2cCh
+	require.False(t, ok, "d2c channel should have been closed")
+	_, ok = <-buffers.ErrCh()
+	require.False(t, ok, "error channel should have been closed")
+	require.True(t, conn.senderClosed)
+	// Closing a second time should be a no-op
+	require.NoError(t, buffers.Close(context.Background()))
+}
+
+func TestBuffersCloseAbandonsUndeliveredFrames(t *testing.T) {
+	defer goleak.VerifyNone(t, goleak.IgnoreCurrent())
+	// Frames are never acknowledged
+	conn := newLoopbackConnection(false)
+	buffers, err := NewBuffers(
+		conn.frameSender,
+		conn.frameReceiver,
+		[]C2DBufferConfig{
+			{
+				ID:         10,
+				FrameType:  arnetworkal.FrameTypeDataWithAck,
+				Size:       10,
+				AckTimeout: 10 * time.Millisecond,
+				MaxRetries: -1, // Infinite
+			},
+		},
+		nil,
+	)
+	require.NoError(t, err)
+	receipts := []*Receipt{NewReceipt(), NewReceipt()}
+	c2dCh := buffers.C2DChs()[10]
+	for _, receipt := range receipts {
+		c2dCh <- Frame{
+			Data:    []byte("foo"),
+			Receipt: receipt,
+		}
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	err = buffers.Close(ctx)
+	require.Equal(t, context.DeadlineExceeded, err)
+	for _, receipt := range receipts {
+		select {
+		case <-receipt.Done():
+			require.Equal(t, ErrBuffersClosed, receipt.Delivery().Err)
+		default:
+			require.Fail(t, "receipt was not resolved before buffers were closed")
+		}
+	}
+	// Subsequent calls return the same result
+	require.Equal(t, context.DeadlineExceeded, buffers.Close(context.Background()))
+}
+
+func TestBuffersCloseAfterDisconnection(t *testing.T) {
+	defer goleak.VerifyNone(t, goleak.IgnoreCurrent())
+	frameReceiver := &fake.FrameReceiver{
+		ReceiveBehavior: func() ([]arnetworkal.Frame, error) {
+			return nil, &arnetworkal.DisconnectedError{Timeout: time.Second}
+		},
+	}
+	buffers, err := NewBuffers(
+		&fake.FrameSender{},
+		frameReceiver,
+		[]C2DBufferConfig{
+			{
+				ID:         10,
+				FrameType:  arnetworkal.FrameTypeDataWithAck,
+				Size:       10,
+				AckTimeout: time.Second,
+				MaxRetries: -1, // Infinite
+			},
+		},
+		nil,
+	)
+	require.NoError(t, err)
+	err, ok := <-buffers.ErrCh()
+	require.True(t, ok)
+	require.True(t, arnetworkal.IsDisconnected(err))
+	// With frame receipt stopped, no ack can arrive, so this frame's delivery
+	// fails promptly instead of being retried forever
+	receipt := NewReceipt()
+	buffers.C2DChs()[10] <- Frame{
+		Data:    []byte("foo"),
+		Receipt: receipt,
+	}
+	require.NoError(t, buffers.Close(context.Background()))
+	require.Error(t, receipt.Wait())
+}
+
+func TestReceiveFramesStopsWhenStopped(t *testing.T) {
+	defer goleak.VerifyNone(t, goleak.IgnoreCurrent())
+	// This receiver never blocks, so only the stop channel can stop frame
+	// receipt
+	frameReceiver := &fake.FrameReceiver{
+		ReceiveBehavior: func() ([]arnetworkal.Frame, error) {
+			return nil, nil
+		},
+	}
+	testCh := make(chan Frame)
+	errCh := make(chan error, 1)
+	stopCh := make(chan struct{})
+	doneCh := make(chan struct{})
+	go func() {
+		receiveFrames(
+			frameReceiver,
+			map[uint8]chan<- Frame{1: testCh},
+			errCh,
+			stopCh,
+		)
+		close(doneCh)
+	}()
+	close(stopCh)
+	select {
+	case <-doneCh:
+	case <-time.After(time.Second):
+		require.Fail(t, "timed out waiting for frame receipt to stop")
+	}
+	_, ok := <-testCh
+	require.False(t, ok, "d2c input channel should have been closed")
+	_, ok = <-errCh
+	require.False(t, ok, "error channel should have been closed")
+}
@@ -1,8 +1,7 @@
 package arnetwork
 
 import (
-	"bytes"
-	"fmt"
+	"sync"
 	"time"
 
 	log "github.com/Sirupsen/logrus"
@@ -18,17 +17,36 @@ type c2dBuffer struct {
 	frameSender arnetworkal.FrameSender
 	seq         uint8
 	ackCh       chan Frame
+	monitor     *C2DBufferMonitor
+	// abortCh is closed to make the buffer give up on frames it is still trying
+	// to deliver
+	abortCh   chan struct{}
+	abortOnce sync.Once
+	// fragmentsWG tracks goroutines waiting to resolve the Receipts of frames
+	// that were split into fragments
+	fragmentsWG sync.WaitGroup
+	// doneCh is closed once the buffer has finished writing frames
+	doneCh chan struct{}
 }
 
 func newC2DBuffer(
 	bufCfg C2DBufferConfig,
 	frameSender arnetworkal.FrameSender,
 ) *c2dBuffer {
+	monitor := bufCfg.Monitor
+	if monitor == nil {
+		// Use a monitor nobody else has a reference to so we needn't check for nil
+		// everywhere
+		monitor = NewC2DBufferMonitor()
+	}
 	buf := &c2dBuffer{
 		C2DBufferConfig: bufCfg,
 		buffer:          newBuffer(bufCfg.ID, bufCfg.Size, bufCfg.IsOverwriting),
 		inCh:            make(chan Frame),
 		frameSender:     frameSender,
+		monitor:         monitor,
+		abortCh:         make(chan struct{}),
+		doneCh:          make(chan struct{}),
 	}
 
 	log.WithField(
@@ -44,29 +62,113 @@ func newC2DBuffer(
 }
 
 func (c *c2dBuffer) receiveFrames() {
+	maxDataSize := effectiveMaxDataSize(c.MaxDataSize)
 	for frame := range c.inCh {
-		if log.GetLevel() == log.DebugLevel {
-			frame.uuid = uuid.NewV4().String()
+		if len(frame.Data) > int(maxDataSize) {
+			if c.Fragmentation != FragmentationSplit {
+				c.rejectFrame(frame, maxDataSize)
+				continue
+			}
+			fragments, resolve := splitFrame(frame, maxDataSize)
+			if resolve != nil {
+				c.fragmentsWG.Add(1)
+				go func() {
+					defer c.fragmentsWG.Done()
+					resolve()
+				}()
+			}
+			for _, fragment := range fragments {
+				c.bufferFrame(fragment)
+			}
+			continue
 		}
-		c.buffer.inCh <- frame
+		c.bufferFrame(frame)
 	}
 	close(c.buffer.inCh)
 }
 
+func (c *c2dBuffer) bufferFrame(frame Frame) {
+	if log.GetLevel() == log.DebugLevel {
+		frame.uuid = uuid.NewV4().String()
+	}
+	c.buffer.inCh <- frame
+}
+
+// rejectFrame drops a frame whose data exceeds the given maximum size,
+// resolving its Receipt, if any, with a *FrameTooLargeError.
+func (c *c2dBuffer) rejectFrame(frame Frame, maxDataSize int32) {
+	delivery := FrameDelivery{
+		Err: &FrameTooLargeError{
+			BufferID:    c.ID,
+			Size:        len(frame.Data),
+			MaxDataSize: maxDataSize,
+		},
+	}
+	log.WithField(
+		"id", c.buffer.id,
+	).Error(delivery.Err)
+	c.monitor.record(delivery)
+	if frame.Receipt != nil {
+		frame.Receipt.resolve(delivery)
+	}
+}
+
 func (c *c2dBuffer) writeFrames() {
 	log := log.WithField("id", c.buffer.id)
 	log.Debug("c2d buffer is now buffering frames")
 	for frame := range c.buffer.outCh {
-		// Note that there's nothing we could do with the error here other than
-		// log it, and we don't bother because writeFrame() already logs any
-		// error that occurs since it's able to provide greater context than
-		// this functions could-- i.e. how many attempt were made to deliver
-		// the frame, etc.
-		c.writeFrame(frame) // nolint: errcheck
+		// writeFrame() already logs any error that occurs since it's able to
+		// provide greater context than this function could-- i.e. how many
+		// attempts were made to deliver the frame, etc.
+		delivery := c.writeFrame(frame)
+		c.monitor.record(delivery)
+		if frame.Receipt != nil {
+			frame.Receipt.resolve(delivery)
+		}
 	}
+	// Every fragment has been resolved by now, so this won't block for long
+	c.fragmentsWG.Wait()
+	close(c.doneCh)
+}
+
+// abort makes the buffer give up on delivering any frames it has already
+// accepted. Frames still awaiting acknowledgement are not retried again and
+// frames not yet sent are never sent. All such frames' Receipts are resolved
+// with ErrBuffersClosed.
+func (c *c2dBuffer) abort() {
+	c.abortOnce.Do(func() {
+		close(c.abortCh)
+	})
 }
 
-func (c *c2dBuffer) writeFrame(frame Frame) error { // nolint: unparam
+// aborted returns a bool indicating whether abort() has been called.
+func (c *c2dBuffer) aborted() bool {
+	select {
+	case <-c.abortCh:
+		return true
+	default:
+		return false
+	}
+}
+
+// writeFrame sends the given frame to the device. If the buffer requests
+// acknowledgement, the frame is re-sent each time AckTimeout elapses without
+// the device acknowledging its receipt, up to MaxRetries times. If the frame
+// has a Receipt that is canceled, no further attempts are made. The returned
+// FrameDelivery describes the outcome.
+func (c *c2dBuffer) writeFrame(frame Frame) FrameDelivery {
+	// A nil cancelCh never becomes ready, so frames without a Receipt can't be
+	// canceled
+	var cancelCh <-chan struct{}
+	if frame.Receipt != nil {
+		if frame.Receipt.canceled() {
+			return FrameDelivery{Err: ErrFrameCanceled}
+		}
+		cancelCh = frame.Receipt.cancelCh
+	}
+	if c.aborted() {
+		return FrameDelivery{Err: ErrBuffersClosed}
+	}
 	c.seq++ // Only increment seq once, no matter how many tries it takes
 	netFrame := arnetworkal.Frame{
 		UUID: frame.uuid,
@@ -85,6 +187,13 @@ func (c *c2dBuffer) writeFrame(frame Frame) error { // nolint: unparam
 		"seq",
 		netFrame.Seq,
 	)
+	if netFrame.Type == arnetworkal.FrameTypeDataWithAck {
+		// Any ack already waiting is for an earlier frame-- e.g. the device
+		// acknowledging a retry that turned out to be unnecessary. Left alone,
+		// it would occupy the ack buffer and cause the ack for this frame to be
+		// dropped.
+		c.drainStaleAcks(netFrame.Seq)
+	}
 	var attempts int
 	for attempts = 0; attempts <= c.MaxRetries || c.MaxRetries == -1; attempts++ { // nolint: lll
 		log.WithField(
@@ -96,28 +205,51 @@ func (c *c2dBuffer) writeFrame(frame Frame) error { // nolint: unparam
 				"attempt",
 				attempts,
 			).Errorf("error sending arnetworkal frame: %s", err)
-			return errors.Wrap(err, "error sending arnetworkal frame")
+			return FrameDelivery{
+				Retries: attempts,
+				Err:     errors.Wrap(err, "error sending arnetworkal frame"),
+			}
 		}
 		if netFrame.Type != arnetworkal.FrameTypeDataWithAck {
-			return nil
+			return FrameDelivery{}
 		}
-		select {
-		case ack := <-c.ackCh:
-			if bytes.Equal(
-				[]byte(fmt.Sprintf("%d", netFrame.Seq)),
-				ack.Data,
-			) {
-				return nil
+		acked, err := c.awaitAck(netFrame.Seq, cancelCh)
+		if err == ErrFrameCanceled || err == ErrBuffersClosed {
+			log.WithField(
+				"attempt",
+				attempts,
+			).Debug("delivery of arnetworkal frame canceled")
+			return FrameDelivery{
+				Retries: attempts,
+				Err:     err,
 			}
-		case <-time.After(c.AckTimeout):
+		}
+		if err != nil {
 			log.WithField(
 				"attempt",
 				attempts,
-			).Debug(
-				"timed out waiting for acknowledgment of arnetworkal frame " +
-					"receipt",
-			)
+			).Errorf("error awaiting acknowledgment of arnetworkal frame: %s", err)
+			return FrameDelivery{
+				Retries: attempts,
+				Err:     err,
+			}
 		}
+		if acked {
+			log.WithField(
+				"attempt",
+				attempts,
+			).Debug("device acknowledged receipt of arnetworkal frame")
+			return FrameDelivery{
+				Acked:   true,
+				Retries: attempts,
+			}
+		}
+		log.WithField(
+			"attempt",
+			attempts,
+		).Debug(
+			"timed out waiting for acknowledgment of arnetworkal frame receipt",
+		)
 	}
 	log.WithField(
 		"attempts",
@@ -126,8 +258,80 @@ func (c *c2dBuffer) writeFrame(frame Frame) error { // nolint: unparam
 		"retries",
 		c.MaxRetries,
 	).Error("exhausted retries sending arnetworkal frame")
-	return errors.Errorf(
-		"exhausted %d retries sending arnetworkal frame",
-		c.MaxRetries,
-	)
+	return FrameDelivery{
+		Retries: c.MaxRetries,
+		Err: &RetriesExhaustedError{
+			BufferID: c.ID,
+			Retries:  c.MaxRetries,
+		},
+	}
+}
+
+// awaitAck waits up to AckTimeout for the device to acknowledge receipt of the
+// frame with the given sequence number. It returns true if the ack was
+// received and false if the timeout elapsed. Acks for other sequence numbers
+// are late acks for earlier frames; they're discarded without restarting the
+// timeout. ErrFrameCanceled is returned if the given cancel channel is closed,
+// ErrBuffersClosed is returned if the buffer is aborted, and another error is
+// returned if the ack channel is closed.
+func (c *c2dBuffer) awaitAck(
+	seq uint8,
+	cancelCh <-chan struct{},
+) (bool, error) {
+	timer := time.NewTimer(c.AckTimeout)
+	defer timer.Stop()
+	for {
+		select {
+		case ack, ok := <-c.ackCh:
+			if !ok {
+				return false, errors.New("ack channel closed")
+			}
+			if isAckFor(ack, seq) {
+				return true, nil
+			}
+			log.WithField(
+				"id", c.buffer.id,
+			).WithField(
+				"seq", seq,
+			).WithField(
+				"ackData", ack.Data,
+			).Debug("discarding stale acknowledgment")
+		case <-timer.C:
+			return false, nil
+		case <-cancelCh:
+			return false, ErrFrameCanceled
+		case <-c.abortCh:
+			return false, ErrBuffersClosed
+		}
+	}
+}
+
+// drainStaleAcks discards any acks that are already waiting. It is called
+// before the frame with the given sequence number is first sent, so any such
+// ack cannot possibly be for that frame.
+func (c *c2dBuffer) drainStaleAcks(seq uint8) {
+	for {
+		select {
+		case ack, ok := <-c.ackCh:
+			if !ok {
+				return
+			}
+			log.WithField(
+				"id", c.buffer.id,
+			).WithField(
+				"seq", seq,
+			).WithField(
+				"ackData", ack.Data,
+			).Debug("discarding stale acknowledgment")
+		default:
+			return
+		}
+	}
+}
+
+// isAckFor returns a bool indicating whether the given ack frame acknowledges
+// receipt of the frame with the given sequence number. Ack frames carry the
+// acknowledged sequence number as a single byte of data.
+func isAckFor(ack Frame, seq uint8) bool {
+	return len(ack.Data) == 1 && ack.Data[0] == seq
 }
@@ -19,6 +19,8 @@ type C2DBufferConfig struct {
 	IsOverwriting bool                  // What to do when data is received and the fifo is full
 	AckTimeout    time.Duration         // Time before considering a frame lost
 	MaxRetries    int                   // Number of retries before considering a frame lost
+	Monitor       *C2DBufferMonitor     // Optional; for observing the outcome of frame deliveries
+	Fragmentation FragmentationPolicy   // What to do with frames whose data exceeds MaxDataSize
 }
 
 // validate validates buffer configuration. This is used internally to
@@ -42,6 +44,23 @@ func (c C2DBufferConfig) validate() error {
 			c.Size,
 		)
 	}
+	if c.MaxDataSize < 0 || c.MaxDataSize > MaxFrameDataSize {
+		return errors.Errorf(
+			"c2d buffer %d defined with invalid max data size %d; must be between "+
+				"0 (no limit) and %d",
+			c.ID,
+			c.MaxDataSize,
+			MaxFrameDataSize,
+		)
+	}
+	if c.Fragmentation != FragmentationReject &&
+		c.Fragmentation != FragmentationSplit {
+		return errors.Errorf(
+			"c2d buffer %d defined with invalid fragmentation policy %d",
+			c.ID,
+			c.Fragmentation,
+		)
+	}
 	log.WithField("id", c.ID).Debug("c2d buffer config is valid")
 	return nil
 }
@@ -38,6 +38,45 @@ func TestValidateC2DBufferConfig(t *testing.T) {
 			},
 		},
 
+		{
+			name: "negative max data size",
+			bufCfg: C2DBufferConfig{
+				FrameType:   arnetworkal.FrameTypeData,
+				Size:        1,
+				MaxDataSize: -1,
+			},
+			assertions: func(t *testing.T, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "invalid max data size")
+			},
+		},
+
+		{
+			name: "max data size exceeding udp payload limit",
+			bufCfg: C2DBufferConfig{
+				FrameType:   arnetworkal.FrameTypeData,
+				Size:        1,
+				MaxDataSize: MaxFrameDataSize + 1,
+			},
+			assertions: func(t *testing.T, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "invalid max data size")
+			},
+		},
+
+		{
+			name: "invalid fragmentation policy",
+			bufCfg: C2DBufferConfig{
+				FrameType:     arnetworkal.FrameTypeData,
+				Size:          1,
+				Fragmentation: 42,
+			},
+			assertions: func(t *testing.T, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "invalid fragmentation policy")
+			},
+		},
+
 		{
 			name: "valid config",
 			bufCfg: C2DBufferConfig{
@@ -0,0 +1,74 @@
+package arnetwork
+
+import "sync/atomic"
+
+// C2DBufferStats is a snapshot of statistics about the frames sent by a c2d
+// buffer.
+type C2DBufferStats struct {
+	// Sent is the number of frames sent without error. For buffers that request
+	// acknowledgement, frames are only counted once acknowledged.
+	Sent uint64
+	// Retries is the total number of times frames were re-sent after their
+	// initial attempts.
+	Retries uint64
+	// Exhausted is the number of frames whose receipt the device never
+	// acknowledged, despite the maximum number of retries.
+	Exhausted uint64
+	// Oversize is the number of frames rejected because their data exceeded
+	// the buffer's MaxDataSize.
+	Oversize uint64
+	// Canceled is the number of frames whose delivery was canceled using their
+	// Receipts.
+	Canceled uint64
+	// Failed is the number of frames that could not be sent for any other
+	// reason-- e.g. a network error.
+	Failed uint64
+}
+
+// C2DBufferMonitor permits callers to observe a c2d buffer while it is sending
+// frames. A monitor is attached to a buffer using the Monitor field of
+// C2DBufferConfig. It is safe for concurrent use.
+type C2DBufferMonitor struct {
+	sent      uint64
+	retries   uint64
+	exhausted uint64
+	oversize  uint64
+	canceled  uint64
+	failed    uint64
+}
+
+// NewC2DBufferMonitor returns a new C2DBufferMonitor.
+func NewC2DBufferMonitor() *C2DBufferMonitor {
+	return &C2DBufferMonitor{}
+}
+
+// Stats returns a snapshot of statistics about the frames sent by the
+// monitored buffer.
+func (c *C2DBufferMonitor) Stats() C2DBufferStats {
+	return C2DBufferStats{
+		Sent:      atomic.LoadUint64(&c.sent),
+		Retries:   atomic.LoadUint64(&c.retries),
+		Exhausted: atomic.LoadUint64(&c.exhausted),
+		Oversize:  atomic.LoadUint64(&c.oversize),
+		Canceled:  atomic.LoadUint64(&c.canceled),
+		Failed:    atomic.LoadUint64(&c.failed),
+	}
+}
+
+// record updates statistics to reflect the outcome of an attempt to deliver a
+// frame.
+func (c *C2DBufferMonitor) record(delivery FrameDelivery) {
+	atomic.AddUint64(&c.retries, uint64(delivery.Retries))
+	switch {
+	case delivery.Err == nil:
+		atomic.AddUint64(&c.sent, 1)
+	case IsRetriesExhausted(delivery.Err):
+		atomic.AddUint64(&c.exhausted, 1)
+	case IsFrameTooLarge(delivery.Err):
+		atomic.AddUint64(&c.oversize, 1)
+	case delivery.Err == ErrFrameCanceled:
+		atomic.AddUint64(&c.canceled, 1)
+	default:
+		atomic.AddUint64(&c.failed, 1)
+	}
+}
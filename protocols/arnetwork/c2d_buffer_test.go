@@ -1,7 +1,6 @@
 package arnetwork
 
 import (
-	"fmt"
 	"testing"
 	"time"
 
@@ -20,7 +19,7 @@ func TestWriteFrame(t *testing.T) {
 			callCount int,
 			ackCh chan<- Frame,
 		) error
-		assertions func(t *testing.T, err error, sendCallCount int)
+		assertions func(t *testing.T, delivery FrameDelivery, sendCallCount int)
 	}{
 
 		{
@@ -34,8 +33,13 @@ func TestWriteFrame(t *testing.T) {
 				// Simulate no error sending
 				return nil
 			},
-			assertions: func(t *testing.T, err error, sendCallCount int) {
-				require.NoError(t, err)
+			assertions: func(
+				t *testing.T,
+				delivery FrameDelivery,
+				sendCallCount int,
+			) {
+				require.NoError(t, delivery.Err)
+				require.False(t, delivery.Acked)
 				require.Equal(t, 1, sendCallCount)
 			},
 		},
@@ -50,9 +54,13 @@ func TestWriteFrame(t *testing.T) {
 			sendBehavior: func(arnetworkal.Frame, int, chan<- Frame) error {
 				return errors.New("error sending arnetworkal frame")
 			},
-			assertions: func(t *testing.T, err error, sendCallCount int) {
-				require.Error(t, err)
-				require.Contains(t, err.Error(), "arnetworkal")
+			assertions: func(
+				t *testing.T,
+				delivery FrameDelivery,
+				sendCallCount int,
+			) {
+				require.Error(t, delivery.Err)
+				require.Contains(t, delivery.Err.Error(), "arnetworkal")
 				require.Equal(t, 1, sendCallCount)
 			},
 		},
@@ -72,13 +80,19 @@ func TestWriteFrame(t *testing.T) {
 			) error {
 				go func() {
 					ackCh <- Frame{
-						Data: []byte(fmt.Sprintf("%d", netFrame.Seq)),
+						Data: []byte{netFrame.Seq},
 					}
 				}()
 				return nil
 			},
-			assertions: func(t *testing.T, err error, sendCallCount int) {
-				require.NoError(t, err)
+			assertions: func(
+				t *testing.T,
+				delivery FrameDelivery,
+				sendCallCount int,
+			) {
+				require.NoError(t, delivery.Err)
+				require.True(t, delivery.Acked)
+				require.Equal(t, 0, delivery.Retries)
 				require.Equal(t, 1, sendCallCount)
 			},
 		},
@@ -103,13 +117,19 @@ func TestWriteFrame(t *testing.T) {
 				}
 				go func() {
 					ackCh <- Frame{
-						Data: []byte(fmt.Sprintf("%d", netFrame.Seq)),
+						Data: []byte{netFrame.Seq},
 					}
 				}()
 				return nil
 			},
-			assertions: func(t *testing.T, err error, sendCallCount int) {
-				require.NoError(t, err)
+			assertions: func(
+				t *testing.T,
+				delivery FrameDelivery,
+				sendCallCount int,
+			) {
+				require.NoError(t, delivery.Err)
+				require.True(t, delivery.Acked)
+				require.Equal(t, 1, delivery.Retries)
 				require.Equal(t, 2, sendCallCount)
 			},
 		},
@@ -127,12 +147,114 @@ func TestWriteFrame(t *testing.T) {
 				// Simulate no error sending-- but no ack of receipt from the device
 				return nil
 			},
-			assertions: func(t *testing.T, err error, sendCallCount int) {
-				require.Error(t, err)
-				require.Contains(t, err.Error(), "exhausted")
+			assertions: func(
+				t *testing.T,
+				delivery FrameDelivery,
+				sendCallCount int,
+			) {
+				require.Error(t, delivery.Err)
+				require.True(t, IsRetriesExhausted(delivery.Err))
+				require.False(t, delivery.Acked)
+				require.Equal(t, 1, delivery.Retries)
 				require.Equal(t, 2, sendCallCount)
 			},
 		},
+
+		{
+			name: "write frame with ack required and receive stale ack first",
+			bufCfg: C2DBufferConfig{
+				ID:         1,
+				FrameType:  arnetworkal.FrameTypeDataWithAck,
+				Size:       1,
+				AckTimeout: time.Second,
+			},
+			sendBehavior: func(
+				netFrame arnetworkal.Frame,
+				_ int,
+				ackCh chan<- Frame,
+			) error {
+				go func() {
+					// A late ack for the previous frame
+					ackCh <- Frame{
+						Data: []byte{netFrame.Seq - 1},
+					}
+					ackCh <- Frame{
+						Data: []byte{netFrame.Seq},
+					}
+				}()
+				return nil
+			},
+			assertions: func(
+				t *testing.T,
+				delivery FrameDelivery,
+				sendCallCount int,
+			) {
+				require.NoError(t, delivery.Err)
+				require.True(t, delivery.Acked)
+				require.Equal(t, 0, delivery.Retries)
+				require.Equal(t, 1, sendCallCount)
+			},
+		},
+
+		{
+			name: "write frame with ack required and receive only stale acks",
+			bufCfg: C2DBufferConfig{
+				ID:         1,
+				FrameType:  arnetworkal.FrameTypeDataWithAck,
+				Size:       1,
+				AckTimeout: 100 * time.Millisecond,
+				MaxRetries: 1,
+			},
+			sendBehavior: func(
+				netFrame arnetworkal.Frame,
+				_ int,
+				ackCh chan<- Frame,
+			) error {
+				go func() {
+					ackCh <- Frame{
+						Data: []byte{netFrame.Seq - 1},
+					}
+				}()
+				return nil
+			},
+			assertions: func(
+				t *testing.T,
+				delivery FrameDelivery,
+				sendCallCount int,
+			) {
+				require.True(t, IsRetriesExhausted(delivery.Err))
+				require.Equal(t, 2, sendCallCount)
+			},
+		},
+
+		{
+			name: "write frame with ack required and ack channel closed",
+			bufCfg: C2DBufferConfig{
+				ID:         1,
+				FrameType:  arnetworkal.FrameTypeDataWithAck,
+				Size:       1,
+				AckTimeout: time.Second,
+				MaxRetries: -1,
+			},
+			sendBehavior: func(
+				netFrame arnetworkal.Frame,
+				_ int,
+				ackCh chan<- Frame,
+			) error {
+				close(ackCh)
+				return nil
+			},
+			assertions: func(
+				t *testing.T,
+				delivery FrameDelivery,
+				sendCallCount int,
+			) {
+				require.Error(t, delivery.Err)
+				require.False(t, IsRetriesExhausted(delivery.Err))
+				require.Contains(t, delivery.Err.Error(), "ack channel closed")
+				require.Equal(t, 1, sendCallCount)
+			},
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -155,9 +277,54 @@ func TestWriteFrame(t *testing.T) {
 				require.Equal(t, frame.Data, netFrame.Data)
 				return testCase.sendBehavior(netFrame, sendCallCount, buf.ackCh)
 			}
-			err = buf.writeFrame(frame)
+			delivery := buf.writeFrame(frame)
 			require.Equal(t, initialSeq+1, buf.seq)
-			testCase.assertions(t, err, sendCallCount)
+			testCase.assertions(t, delivery, sendCallCount)
 		})
 	}
 }
+
+func TestWriteFrameDrainsStaleAcks(t *testing.T) {
+	frameSender := &fake.FrameSender{}
+	buf := newC2DBuffer(
+		C2DBufferConfig{
+			ID:         1,
+			FrameType:  arnetworkal.FrameTypeDataWithAck,
+			Size:       1,
+			AckTimeout: 100 * time.Millisecond,
+		},
+		frameSender,
+	)
+	ackCh := make(chan Frame, 1)
+	buf.ackCh = ackCh
+	// An ack that is already waiting before the frame is sent must be for an
+	// earlier frame, even if its sequence number happens to match
+	ackCh <- Frame{
+		Data: []byte{buf.seq + 1},
+	}
+	delivery := buf.writeFrame(Frame{Data: []byte("foo")})
+	require.True(t, IsRetriesExhausted(delivery.Err))
+}
+
+func TestC2DBufferMonitor(t *testing.T) {
+	monitor := NewC2DBufferMonitor()
+	monitor.record(FrameDelivery{Acked: true, Retries: 2})
+	monitor.record(FrameDelivery{})
+	monitor.record(
+		FrameDelivery{
+			Retries: 5,
+			Err:     &RetriesExhaustedError{Retries: 5},
+		},
+	)
+	monitor.record(FrameDelivery{Err: errors.New("foo")})
+	require.Equal(
+		t,
+		C2DBufferStats{
+			Sent:      2,
+			Retries:   7,
+			Exhausted: 1,
+			Failed:    1,
+		},
+		monitor.Stats(),
+	)
+}
@@ -9,15 +9,30 @@ type d2cBuffer struct {
 	D2CBufferConfig
 	buffer *buffer
 	inCh   chan Frame
-	seq    uint8
-	ackCh  chan Frame
+	// seqs tracks the sequence numbers of recently received frames so that
+	// duplicate and out of order frames can be dropped
+	seqs    *seqWindow
+	monitor *D2CBufferMonitor
+	ackCh   chan Frame
 }
 
 func newD2CBuffer(bufCfg D2CBufferConfig) *d2cBuffer {
+	seqWindowSize := bufCfg.SeqWindow
+	if seqWindowSize == 0 {
+		seqWindowSize = DefaultSeqWindow
+	}
+	monitor := bufCfg.Monitor
+	if monitor == nil {
+		// Use a monitor nobody else has a reference to so we needn't check for nil
+		// everywhere
+		monitor = NewD2CBufferMonitor()
+	}
 	buf := &d2cBuffer{
 		D2CBufferConfig: bufCfg,
 		buffer:          newBuffer(bufCfg.ID, bufCfg.Size, bufCfg.IsOverwriting),
 		inCh:            make(chan Frame),
+		seqs:            newSeqWindow(seqWindowSize),
+		monitor:         monitor,
 	}
 
 	log.WithField(
@@ -32,6 +47,7 @@ func newD2CBuffer(bufCfg D2CBufferConfig) *d2cBuffer {
 
 func (d *d2cBuffer) receiveFrames() {
 	log := log.WithField("id", d.buffer.id)
+	maxDataSize := effectiveMaxDataSize(d.MaxDataSize)
 	for frame := range d.inCh {
 		// If acknowledgement was requested, send it...
 		if d.FrameType == arnetworkal.FrameTypeDataWithAck && d.ackCh != nil {
@@ -42,30 +58,44 @@ func (d *d2cBuffer) receiveFrames() {
 				Data: []byte{frame.seq},
 			}
 		}
-		// If this buffer doesn't yet have a reference sequence number or...
-		//
-		// The frame's sequence number is higher than the buffer's reference
-		// sequence number or...
-		//
-		// The gap between the frame sequence number and the buffer's reference
-		// sequence number is bigger than 10...
-		//
-		// Then we should accept this frame and update the buffer's reference
-		// sequence number.
-		//
-		// Otherwise, we're dealing with a frame that has arrived out of order.
-		// We'll drop such frames.
-		if frame.seq > d.seq || d.seq-frame.seq >= 10 {
+		// Oversize frames are acknowledged anyway, since the device re-sending
+		// them wouldn't help
+		if len(frame.Data) > int(maxDataSize) {
 			log.WithField(
-				"refSeq", d.seq,
-			).Debug("accepting frame")
-			d.seq = frame.seq
+				"seq", frame.seq,
+			).WithField(
+				"size", len(frame.Data),
+			).WithField(
+				"maxDataSize", maxDataSize,
+			).Warn("frame data exceeds buffer's max data size; dropping it")
+			d.monitor.recordOversize()
+			continue
+		}
+		if d.monitor.takeResetRequest() {
+			log.Debug("resetting sequence window")
+			d.seqs.reset()
+		}
+		// Acks are sent above regardless of whether the frame is accepted, since
+		// a duplicate usually means the device never received our previous ack
+		result := d.seqs.push(frame.seq)
+		d.monitor.record(result)
+		frameLog := log.WithField(
+			"seq", frame.seq,
+		).WithField(
+			"refSeq", d.seqs.highest,
+		)
+		switch result {
+		case seqAccepted:
+			frameLog.Debug("accepting frame")
 			d.buffer.inCh <- frame
-		} else {
-			log.WithField(
-				"refSeq", d.seq,
-			).Debug("frame appears to be a duplicate or out of sequence; " +
-				"dropping it")
+		case seqResynced:
+			frameLog.Debug("frame is far behind the sequence window; assuming the " +
+				"device's sequence numbers started over and accepting frame")
+			d.buffer.inCh <- frame
+		case seqDuplicate:
+			frameLog.Debug("frame is a duplicate; dropping it")
+		case seqOutOfOrder:
+			frameLog.Debug("frame arrived out of sequence; dropping it")
 		}
 	}
 	if d.ackCh != nil {
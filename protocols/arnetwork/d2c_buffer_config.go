@@ -15,6 +15,8 @@ type D2CBufferConfig struct {
 	Size          int32                 // Size of the internal fifo
 	MaxDataSize   int32                 // Maximum size of an element in the fifo
 	IsOverwriting bool                  // What to do when data is received and the fifo is full
+	SeqWindow     uint8                 // Number of recent sequence numbers tracked for duplicate detection; 0 means DefaultSeqWindow
+	Monitor       *D2CBufferMonitor     // Optional; for observing statistics and resetting the sequence window
 }
 
 // validate validates buffer configuration. This is used internally to
@@ -38,6 +40,23 @@ func (d D2CBufferConfig) validate() error {
 			d.Size,
 		)
 	}
+	if d.MaxDataSize < 0 || d.MaxDataSize > MaxFrameDataSize {
+		return errors.Errorf(
+			"d2c buffer %d defined with invalid max data size %d; must be between "+
+				"0 (no limit) and %d",
+			d.ID,
+			d.MaxDataSize,
+			MaxFrameDataSize,
+		)
+	}
+	if d.SeqWindow > maxSeqWindow {
+		return errors.Errorf(
+			"d2c buffer %d defined with invalid sequence window %d; maximum is %d",
+			d.ID,
+			d.SeqWindow,
+			maxSeqWindow,
+		)
+	}
 	log.WithField("id", d.ID).Debug("d2c buffer config is valid")
 	return nil
 }
@@ -38,6 +38,45 @@ func TestValidateD2CBufferConfig(t *testing.T) {
 			},
 		},
 
+		{
+			name: "invalid sequence window",
+			bufCfg: D2CBufferConfig{
+				FrameType: arnetworkal.FrameTypeData,
+				Size:      1,
+				SeqWindow: 128,
+			},
+			assertions: func(t *testing.T, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "invalid sequence window")
+			},
+		},
+
+		{
+			name: "negative max data size",
+			bufCfg: D2CBufferConfig{
+				FrameType:   arnetworkal.FrameTypeData,
+				Size:        1,
+				MaxDataSize: -1,
+			},
+			assertions: func(t *testing.T, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "invalid max data size")
+			},
+		},
+
+		{
+			name: "max data size exceeding udp payload limit",
+			bufCfg: D2CBufferConfig{
+				FrameType:   arnetworkal.FrameTypeData,
+				Size:        1,
+				MaxDataSize: MaxFrameDataSize + 1,
+			},
+			assertions: func(t *testing.T, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "invalid max data size")
+			},
+		},
+
 		{
 			name: "valid config",
 			bufCfg: D2CBufferConfig{
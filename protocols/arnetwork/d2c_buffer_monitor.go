@@ -0,0 +1,91 @@
+package arnetwork
+
+import "sync/atomic"
+
+// D2CBufferStats is a snapshot of statistics about the frames received by a
+// d2c buffer.
+type D2CBufferStats struct {
+	// Accepted is the number of frames accepted into the buffer.
+	Accepted uint64
+	// Dropped is the number of frames dropped because they were duplicates,
+	// arrived out of order, or were oversize.
+	Dropped uint64
+	// Duplicates is the number of dropped frames that were duplicates of
+	// frames already accepted. This is a subset of Dropped.
+	Duplicates uint64
+	// Oversize is the number of dropped frames whose data exceeded the
+	// buffer's MaxDataSize. This is a subset of Dropped.
+	Oversize uint64
+	// Resyncs is the number of times the buffer concluded that the device's
+	// sequence numbers had started over and reset its sequence window.
+	Resyncs uint64
+}
+
+// D2CBufferMonitor permits callers to observe and influence a d2c buffer
+// while it is receiving frames. A monitor is attached to a buffer using the
+// Monitor field of D2CBufferConfig. It is safe for concurrent use.
+type D2CBufferMonitor struct {
+	accepted   uint64
+	dropped    uint64
+	duplicates uint64
+	oversize   uint64
+	resyncs    uint64
+	// resetRequested is 1 if the buffer should reset its sequence window
+	// before accepting its next frame and 0 otherwise
+	resetRequested int32
+}
+
+// NewD2CBufferMonitor returns a new D2CBufferMonitor.
+func NewD2CBufferMonitor() *D2CBufferMonitor {
+	return &D2CBufferMonitor{}
+}
+
+// Stats returns a snapshot of statistics about the frames received by the
+// monitored buffer.
+func (d *D2CBufferMonitor) Stats() D2CBufferStats {
+	return D2CBufferStats{
+		Accepted:   atomic.LoadUint64(&d.accepted),
+		Dropped:    atomic.LoadUint64(&d.dropped),
+		Duplicates: atomic.LoadUint64(&d.duplicates),
+		Oversize:   atomic.LoadUint64(&d.oversize),
+		Resyncs:    atomic.LoadUint64(&d.resyncs),
+	}
+}
+
+// ResetSeqWindow asks the monitored buffer to forget the sequence numbers of
+// frames it has already received, so that the next frame received is accepted
+// regardless of its sequence number. This should be used when a new session
+// with the device begins, since the device's sequence numbers start over.
+func (d *D2CBufferMonitor) ResetSeqWindow() {
+	atomic.StoreInt32(&d.resetRequested, 1)
+}
+
+// takeResetRequest returns true if a reset of the sequence window was
+// requested since the last call and false otherwise.
+func (d *D2CBufferMonitor) takeResetRequest() bool {
+	return atomic.CompareAndSwapInt32(&d.resetRequested, 1, 0)
+}
+
+// record updates statistics to reflect the outcome of pushing a frame's
+// sequence number onto the buffer's sequence window.
+func (d *D2CBufferMonitor) record(result seqResult) {
+	switch result {
+	case seqAccepted:
+		atomic.AddUint64(&d.accepted, 1)
+	case seqResynced:
+		atomic.AddUint64(&d.accepted, 1)
+		atomic.AddUint64(&d.resyncs, 1)
+	case seqDuplicate:
+		atomic.AddUint64(&d.dropped, 1)
+		atomic.AddUint64(&d.duplicates, 1)
+	case seqOutOfOrder:
+		atomic.AddUint64(&d.dropped, 1)
+	}
+}
+
+// recordOversize updates statistics to reflect that a frame was dropped
+// because its data exceeded the buffer's MaxDataSize.
+func (d *D2CBufferMonitor) recordOversize() {
+	atomic.AddUint64(&d.dropped, 1)
+	atomic.AddUint64(&d.oversize, 1)
+}
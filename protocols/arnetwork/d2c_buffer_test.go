@@ -2,6 +2,7 @@ package arnetwork
 
 import (
 	"testing"
+	"time"
 
 	"github.com/krancour/go-parrot/protocols/arnetworkal"
 	"github.com/stretchr/testify/require"
@@ -40,7 +41,7 @@ func TestD2CBufferReceiveFrames(t *testing.T) {
 			) {
 				_, ok := <-buf.buffer.outCh
 				require.True(t, ok, "frame was not accepted, but should have been")
-				require.Equal(t, expectedFrame.seq, buf.seq)
+				require.Equal(t, expectedFrame.seq, buf.seqs.highest)
 			},
 		},
 
@@ -64,7 +65,7 @@ func TestD2CBufferReceiveFrames(t *testing.T) {
 			) {
 				_, ok := <-buf.buffer.outCh
 				require.False(t, ok, "frame was accepted and should not have been")
-				require.Equal(t, uint8(10), buf.seq)
+				require.Equal(t, uint8(10), buf.seqs.highest)
 			},
 		},
 
@@ -88,7 +89,7 @@ func TestD2CBufferReceiveFrames(t *testing.T) {
 			) {
 				_, ok := <-buf.buffer.outCh
 				require.False(t, ok, "frame was accepted and should not have been")
-				require.Equal(t, uint8(10), buf.seq)
+				require.Equal(t, uint8(10), buf.seqs.highest)
 			},
 		},
 
@@ -112,7 +113,55 @@ func TestD2CBufferReceiveFrames(t *testing.T) {
 			) {
 				_, ok := <-buf.buffer.outCh
 				require.True(t, ok, "frame was not accepted, but should have been")
-				require.Equal(t, expectedFrame.seq, buf.seq)
+				require.Equal(t, expectedFrame.seq, buf.seqs.highest)
+			},
+		},
+
+		{
+			name: "receive frame not needing acknowledgement and frame sequence " +
+				"number following buffer reference sequence number after wraparound",
+			bufCfg: D2CBufferConfig{
+				ID:        1,
+				FrameType: arnetworkal.FrameTypeData,
+				Size:      1,
+			},
+			initialBufRefSeq: 250,
+			frame: Frame{
+				seq:  3,
+				Data: []byte("foo"),
+			},
+			assertions: func(
+				t *testing.T,
+				expectedFrame Frame,
+				buf *d2cBuffer,
+			) {
+				_, ok := <-buf.buffer.outCh
+				require.True(t, ok, "frame was not accepted, but should have been")
+				require.Equal(t, expectedFrame.seq, buf.seqs.highest)
+			},
+		},
+
+		{
+			name: "receive frame not needing acknowledgement and frame sequence " +
+				"number preceding buffer reference sequence number across wraparound",
+			bufCfg: D2CBufferConfig{
+				ID:        1,
+				FrameType: arnetworkal.FrameTypeData,
+				Size:      1,
+			},
+			initialBufRefSeq: 3,
+			frame: Frame{
+				seq:  250,
+				Data: []byte("foo"),
+			},
+			assertions: func(
+				t *testing.T,
+				expectedFrame Frame,
+				buf *d2cBuffer,
+			) {
+				_, ok := <-buf.buffer.outCh
+				require.False(t, ok, "frame was accepted and should not have been")
+				require.Equal(t, uint8(3), buf.seqs.highest)
 			},
 		},
 
@@ -142,7 +191,7 @@ func TestD2CBufferReceiveFrames(t *testing.T) {
 				)
 				_, ok = <-buf.buffer.outCh
 				require.True(t, ok, "frame was not accepted, but should have been")
-				require.Equal(t, expectedFrame.seq, buf.seq)
+				require.Equal(t, expectedFrame.seq, buf.seqs.highest)
 			},
 		},
 	}
@@ -154,7 +203,7 @@ func TestD2CBufferReceiveFrames(t *testing.T) {
 			// Remember that a new buffer will automatically begin receiving frame.
 			// There's no need to explicitly call buf.receiveFrames()
 			buf := newD2CBuffer(testCase.bufCfg)
-			buf.seq = testCase.initialBufRefSeq
+			buf.seqs.push(testCase.initialBufRefSeq)
 			// Set the channel that acks are written to so we can
 			// make some assertions on it
 			buf.ackCh = make(chan Frame)
@@ -167,3 +216,41 @@ func TestD2CBufferReceiveFrames(t *testing.T) {
 		})
 	}
 }
+
+func TestD2CBufferMonitor(t *testing.T) {
+	monitor := NewD2CBufferMonitor()
+	buf := newD2CBuffer(
+		D2CBufferConfig{
+			ID:        1,
+			FrameType: arnetworkal.FrameTypeData,
+			Size:      10,
+			Monitor:   monitor,
+		},
+	)
+	// 5 and 6 are accepted, the second 6 is a duplicate, and 4 is out of order
+	for _, seq := range []uint8{5, 6, 6, 4} {
+		buf.inCh <- Frame{seq: seq}
+	}
+	// Wait for the last of those to be processed before requesting a reset
+	for monitor.Stats().Dropped < 2 {
+		time.Sleep(time.Millisecond)
+	}
+	// After a reset, a frame that would otherwise be a duplicate is accepted
+	monitor.ResetSeqWindow()
+	buf.inCh <- Frame{seq: 5}
+	close(buf.inCh)
+	var seqs []uint8
+	for frame := range buf.buffer.outCh {
+		seqs = append(seqs, frame.seq)
+	}
+	require.Equal(t, []uint8{5, 6, 5}, seqs)
+	require.Equal(
+		t,
+		D2CBufferStats{
+			Accepted:   3,
+			Dropped:    2,
+			Duplicates: 1,
+		},
+		monitor.Stats(),
+	)
+}
@@ -0,0 +1,61 @@
+package arnetwork
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+// RetriesExhaustedError is the error describing a failure to deliver a frame
+// because the device did not acknowledge its receipt after the maximum number
+// of retries.
+type RetriesExhaustedError struct {
+	// BufferID is the ID of the c2d buffer the frame was sent from.
+	BufferID uint8
+	// Retries is the number of times the frame was re-sent after the initial
+	// attempt.
+	Retries int
+}
+
+func (r *RetriesExhaustedError) Error() string {
+	return fmt.Sprintf(
+		"exhausted %d retries sending arnetworkal frame from buffer %d",
+		r.Retries,
+		r.BufferID,
+	)
+}
+
+// IsRetriesExhausted returns a bool indicating whether the given error, or the
+// error that caused it, is a *RetriesExhaustedError.
+func IsRetriesExhausted(err error) bool {
+	_, ok := errors.Cause(err).(*RetriesExhaustedError)
+	return ok
+}
+
+// FrameTooLargeError is the error describing a failure to deliver a frame
+// because its data exceeds the MaxDataSize of the c2d buffer it was placed on.
+type FrameTooLargeError struct {
+	// BufferID is the ID of the c2d buffer the frame was placed on.
+	BufferID uint8
+	// Size is the size of the frame's data, in bytes.
+	Size int
+	// MaxDataSize is the maximum size of a frame's data, in bytes, permitted by
+	// the buffer.
+	MaxDataSize int32
+}
+
+func (f *FrameTooLargeError) Error() string {
+	return fmt.Sprintf(
+		"frame data of %d bytes exceeds maximum of %d bytes for buffer %d",
+		f.Size,
+		f.MaxDataSize,
+		f.BufferID,
+	)
+}
+
+// IsFrameTooLarge returns a bool indicating whether the given error, or the
+// error that caused it, is a *FrameTooLargeError.
+func IsFrameTooLarge(err error) bool {
+	_, ok := errors.Cause(err).(*FrameTooLargeError)
+	return ok
+}
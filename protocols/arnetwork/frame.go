@@ -7,4 +7,8 @@ type Frame struct {
 	uuid string
 	Data []byte
 	seq  uint8
+	// Receipt is optional. If non-nil, it is resolved with the outcome of
+	// delivering the frame once the frame has been placed onto a c2d buffer.
+	// It is ignored for frames received from d2c buffers.
+	Receipt *Receipt
 }
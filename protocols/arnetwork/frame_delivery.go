@@ -0,0 +1,34 @@
+package arnetwork
+
+// FrameDelivery describes the outcome of a c2d buffer's attempt to deliver a
+// frame to the device.
+type FrameDelivery struct {
+	// Acked is true if the device acknowledged receipt of the frame. It is
+	// always false for frames sent from buffers that don't request
+	// acknowledgement.
+	Acked bool
+	// Retries is the number of times the frame was re-sent after the initial
+	// attempt.
+	Retries int
+	// Err is non-nil if the frame could not be delivered. It is one of:
+	//
+	//   - a *RetriesExhaustedError if the device never acknowledged receipt of
+	//     the frame
+	//   - a *FrameTooLargeError if the frame's data exceeds the buffer's
+	//     MaxDataSize
+	//   - ErrFrameDropped if the frame was dropped from a full buffer before
+	//     being sent
+	//   - ErrFrameCanceled if delivery was canceled using the Receipt's Cancel()
+	//     function
+	//   - ErrBuffersClosed if the Buffers were closed before the frame could be
+	//     delivered
+	//   - an error wrapping the underlying network error if sending the frame
+	//     failed
+	//   - an error reporting that the buffer's ack channel was closed while
+	//     awaiting acknowledgement
+	//
+	// The first two may be tested for using IsRetriesExhausted(...) and
+	// IsFrameTooLarge(...). ErrFrameDropped, ErrFrameCanceled, and
+	// ErrBuffersClosed may be compared directly to Err.
+	Err error
+}
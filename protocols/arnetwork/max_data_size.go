@@ -0,0 +1,120 @@
+package arnetwork
+
+import (
+	"github.com/krancour/go-parrot/protocols/arnetworkal/wifi"
+	"github.com/krancour/go-parrot/protocols/internal/udp"
+)
+
+// MaxFrameDataSize is the largest amount of data, in bytes, that a single
+// frame may carry. This is the practical maximum number of data bytes in a UDP
+// datagram less the length of the ARNetworkAL frame headers. A buffer
+// configured with a MaxDataSize of zero permits frames of up to this size.
+const MaxFrameDataSize = int32(udp.MaxDataBytes - wifi.HeaderBytesLength)
+
+// FragmentationPolicy is a type for constants used to indicate what a c2d
+// buffer should do with frames whose data exceeds the buffer's MaxDataSize.
+type FragmentationPolicy int
+
+const (
+	// FragmentationReject indicates oversize frames should be rejected. Their
+	// Receipts, if any, are resolved with a *FrameTooLargeError. This is the
+	// default.
+	FragmentationReject FragmentationPolicy = iota
+	// FragmentationSplit indicates oversize frames should be split into as many
+	// frames of at most MaxDataSize bytes as are necessary, to be sent in
+	// order. The ARNetwork protocol itself offers no means of marking or
+	// reassembling such fragments, so this is only suitable for bulk data
+	// buffers whose consumers on the device end treat the data as a stream.
+	FragmentationSplit
+)
+
+// effectiveMaxDataSize returns the maximum size of a frame's data, in bytes,
+// given a buffer's configured MaxDataSize, where zero means no limit beyond
+// MaxFrameDataSize.
+func effectiveMaxDataSize(maxDataSize int32) int32 {
+	if maxDataSize == 0 {
+		return MaxFrameDataSize
+	}
+	return maxDataSize
+}
+
+// splitFrame splits the given frame into consecutive fragments having at most
+// maxSize bytes of data each. If the frame has a Receipt, each fragment is
+// given a Receipt of its own and a function is also returned that blocks until
+// all of the fragments' Receipts are resolved and then resolves the original
+// Receipt. The caller is responsible for running that function.
+func splitFrame(frame Frame, maxSize int32) ([]Frame, func()) {
+	chunks := splitData(frame.Data, maxSize)
+	fragments := make([]Frame, len(chunks))
+	if frame.Receipt == nil {
+		for i, chunk := range chunks {
+			fragments[i] = Frame{Data: chunk}
+		}
+		return fragments, nil
+	}
+	receipts := make([]*Receipt, len(chunks))
+	cancelAll := func() {
+		for _, receipt := range receipts {
+			receipt.Cancel()
+		}
+	}
+	for i := range chunks {
+		receipts[i] = NewReceipt()
+	}
+	for i, chunk := range chunks {
+		// The device can't make sense of what remains of a frame once any
+		// fragment is lost, so the remaining fragments are canceled as soon as
+		// one fails. This happens synchronously, before the buffer moves on to
+		// the next fragment.
+		receipts[i].onResolve = func(delivery FrameDelivery) {
+			if delivery.Err != nil {
+				cancelAll()
+			}
+		}
+		fragments[i] = Frame{
+			Data:    chunk,
+			Receipt: receipts[i],
+		}
+	}
+	return fragments, func() {
+		resolveFragmented(frame.Receipt, receipts, cancelAll)
+	}
+}
+
+// resolveFragmented waits for all of the given fragments' Receipts to be
+// resolved and then resolves the original frame's Receipt. Delivery succeeds
+// only if every fragment was delivered. Canceling the original Receipt cancels
+// all fragments.
+func resolveFragmented(
+	receipt *Receipt,
+	fragmentReceipts []*Receipt,
+	cancelAll func(),
+) {
+	delivery := FrameDelivery{Acked: true}
+	for _, fragmentReceipt := range fragmentReceipts {
+		select {
+		case <-fragmentReceipt.Done():
+		case <-receipt.cancelCh:
+			cancelAll()
+			<-fragmentReceipt.Done()
+		}
+		fragmentDelivery := fragmentReceipt.Delivery()
+		delivery.Acked = delivery.Acked && fragmentDelivery.Acked
+		delivery.Retries += fragmentDelivery.Retries
+		if delivery.Err == nil {
+			delivery.Err = fragmentDelivery.Err
+		}
+	}
+	receipt.resolve(delivery)
+}
+
+// splitData splits the given data into consecutive chunks of at most maxSize
+// bytes.
+func splitData(data []byte, maxSize int32) [][]byte {
+	chunks := [][]byte{}
+	for len(data) > int(maxSize) {
+		chunks = append(chunks, data[:maxSize])
+		data = data[maxSize:]
+	}
+	return append(chunks, data)
+}
@@ -0,0 +1,242 @@
+package arnetwork
+
+import (
+	"testing"
+	"time"
+
+	"github.com/krancour/go-parrot/protocols/arnetworkal"
+	"github.com/krancour/go-parrot/protocols/arnetworkal/fake"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitData(t *testing.T) {
+	testCases := []struct {
+		name     string
+		data     []byte
+		maxSize  int32
+		expected [][]byte
+	}{
+
+		{
+			name:     "data smaller than max size",
+			data:     []byte("foo"),
+			maxSize:  5,
+			expected: [][]byte{[]byte("foo")},
+		},
+
+		{
+			name:     "data exactly a multiple of max size",
+			data:     []byte("foobar"),
+			maxSize:  3,
+			expected: [][]byte{[]byte("foo"), []byte("bar")},
+		},
+
+		{
+			name:     "data not a multiple of max size",
+			data:     []byte("foobarbaz!"),
+			maxSize:  3,
+			expected: [][]byte{[]byte("foo"), []byte("bar"), []byte("baz"), []byte("!")},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			require.Equal(
+				t,
+				testCase.expected,
+				splitData(testCase.data, testCase.maxSize),
+			)
+		})
+	}
+}
+
+func TestC2DBufferMaxDataSize(t *testing.T) {
+	testCases := []struct {
+		name       string
+		bufCfg     C2DBufferConfig
+		data       []byte
+		assertions func(*testing.T, *Receipt, [][]byte, C2DBufferStats)
+	}{
+
+		{
+			name: "frame within max data size",
+			bufCfg: C2DBufferConfig{
+				ID:          1,
+				FrameType:   arnetworkal.FrameTypeData,
+				Size:        10,
+				MaxDataSize: 3,
+			},
+			data: []byte("foo"),
+			assertions: func(
+				t *testing.T,
+				receipt *Receipt,
+				sent [][]byte,
+				stats C2DBufferStats,
+			) {
+				require.NoError(t, receipt.Wait())
+				require.Equal(t, [][]byte{[]byte("foo")}, sent)
+				require.Equal(t, uint64(1), stats.Sent)
+			},
+		},
+
+		{
+			name: "oversize frame rejected",
+			bufCfg: C2DBufferConfig{
+				ID:          1,
+				FrameType:   arnetworkal.FrameTypeData,
+				Size:        10,
+				MaxDataSize: 3,
+			},
+			data: []byte("foobar"),
+			assertions: func(
+				t *testing.T,
+				receipt *Receipt,
+				sent [][]byte,
+				stats C2DBufferStats,
+			) {
+				err := receipt.Wait()
+				require.True(t, IsFrameTooLarge(err))
+				tooLargeErr := err.(*FrameTooLargeError)
+				require.Equal(t, 6, tooLargeErr.Size)
+				require.Equal(t, int32(3), tooLargeErr.MaxDataSize)
+				require.Empty(t, sent)
+				require.Equal(t, uint64(1), stats.Oversize)
+			},
+		},
+
+		{
+			name: "oversize frame split",
+			bufCfg: C2DBufferConfig{
+				ID:            1,
+				FrameType:     arnetworkal.FrameTypeDataWithAck,
+				Size:          10,
+				MaxDataSize:   3,
+				AckTimeout:    time.Second,
+				Fragmentation: FragmentationSplit,
+			},
+			data: []byte("foobarbaz"),
+			assertions: func(
+				t *testing.T,
+				receipt *Receipt,
+				sent [][]byte,
+				stats C2DBufferStats,
+			) {
+				require.NoError(t, receipt.Wait())
+				require.True(t, receipt.Delivery().Acked)
+				require.Equal(
+					t,
+					[][]byte{[]byte("foo"), []byte("bar"), []byte("baz")},
+					sent,
+				)
+				require.Equal(t, uint64(3), stats.Sent)
+			},
+		},
+
+		{
+			name: "oversize frame split and a fragment fails",
+			bufCfg: C2DBufferConfig{
+				ID:            1,
+				FrameType:     arnetworkal.FrameTypeDataWithAck,
+				Size:          10,
+				MaxDataSize:   3,
+				AckTimeout:    100 * time.Millisecond,
+				Fragmentation: FragmentationSplit,
+			},
+			// The fake frame sender never acks "bad"
+			data: []byte("foobadbaz"),
+			assertions: func(
+				t *testing.T,
+				receipt *Receipt,
+				sent [][]byte,
+				stats C2DBufferStats,
+			) {
+				require.True(t, IsRetriesExhausted(receipt.Wait()))
+				// The last fragment is canceled and never sent
+				require.Equal(t, [][]byte{[]byte("foo"), []byte("bad")}, sent)
+				require.Equal(t, uint64(1), stats.Sent)
+				require.Equal(t, uint64(1), stats.Exhausted)
+				require.Equal(t, uint64(1), stats.Canceled)
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			require.NoError(t, testCase.bufCfg.validate())
+			monitor := NewC2DBufferMonitor()
+			testCase.bufCfg.Monitor = monitor
+			ackCh := make(chan Frame, 1)
+			sentCh := make(chan []byte, 10)
+			// Block the buffer from sending anything until all fragments have been
+			// buffered, so that the failure of one fragment is guaranteed to cancel
+			// the next
+			startCh := make(chan struct{})
+			frameSender := &fake.FrameSender{
+				SendBehavior: func(netFrame arnetworkal.Frame) error {
+					<-startCh
+					sentCh <- netFrame.Data
+					if string(netFrame.Data) != "bad" {
+						ackCh <- Frame{Data: []byte{netFrame.Seq}}
+					}
+					return nil
+				},
+			}
+			buf := newC2DBuffer(testCase.bufCfg, frameSender)
+			buf.ackCh = ackCh
+			receipt := NewReceipt()
+			buf.inCh <- Frame{
+				Data:    testCase.data,
+				Receipt: receipt,
+			}
+			close(buf.inCh)
+			<-buf.buffer.doneCh
+			close(startCh)
+			select {
+			case <-receipt.Done():
+			case <-time.After(2 * time.Second):
+				require.Fail(t, "timed out waiting for receipt to be resolved")
+			}
+			// Receipts are resolved only after frames are sent and their outcomes
+			// recorded, so there's nothing more to wait for
+			close(sentCh)
+			sent := [][]byte{}
+			for data := range sentCh {
+				sent = append(sent, data)
+			}
+			testCase.assertions(t, receipt, sent, monitor.Stats())
+		})
+	}
+}
+
+func TestD2CBufferDropsOversizeFrames(t *testing.T) {
+	monitor := NewD2CBufferMonitor()
+	buf := newD2CBuffer(
+		D2CBufferConfig{
+			ID:          1,
+			FrameType:   arnetworkal.FrameTypeDataWithAck,
+			Size:        10,
+			MaxDataSize: 3,
+			Monitor:     monitor,
+		},
+	)
+	buf.ackCh = make(chan Frame, 10)
+	buf.inCh <- Frame{seq: 1, Data: []byte("foobar")}
+	buf.inCh <- Frame{seq: 2, Data: []byte("foo")}
+	close(buf.inCh)
+	frames := []Frame{}
+	for frame := range buf.buffer.outCh {
+		frames = append(frames, frame)
+	}
+	require.Equal(t, []Frame{{seq: 2, Data: []byte("foo")}}, frames)
+	// Both frames are acknowledged
+	require.Len(t, buf.ackCh, 2)
+	require.Equal(
+		t,
+		D2CBufferStats{
+			Accepted: 1,
+			Dropped:  1,
+			Oversize: 1,
+		},
+		monitor.Stats(),
+	)
+}
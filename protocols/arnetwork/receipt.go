@@ -0,0 +1,105 @@
+package arnetwork
+
+import (
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	// ErrFrameDropped is the error a Receipt is resolved with when its frame is
+	// dropped before being sent because the c2d buffer it was placed on was
+	// full.
+	ErrFrameDropped = errors.New("frame dropped from full buffer")
+	// ErrFrameCanceled is the error a Receipt is resolved with when delivery of
+	// its frame was canceled using the Receipt's Cancel() function.
+	ErrFrameCanceled = errors.New("frame delivery canceled")
+	// ErrBuffersClosed is the error a Receipt is resolved with when its frame
+	// is abandoned because Buffers were closed before the frame could be
+	// delivered.
+	ErrBuffersClosed = errors.New("arnetwork buffers closed")
+)
+
+// Receipt permits a caller placing a frame onto a c2d buffer to learn the
+// outcome of delivering that frame to the device. A Receipt is attached to a
+// frame using the frame's Receipt field and is resolved exactly once: when the
+// frame is sent (and, if the buffer requests it, acknowledged), when delivery
+// fails (including after exhausting retries), when the frame is dropped from a
+// full buffer, or when delivery is canceled. A Receipt must not be attached to
+// more than one frame. It is safe for concurrent use.
+type Receipt struct {
+	doneCh      chan struct{}
+	resolveOnce sync.Once
+	delivery    FrameDelivery
+	cancelCh    chan struct{}
+	cancelOnce  sync.Once
+	// onResolve, if non-nil, is called synchronously with the outcome of
+	// delivering the frame when the Receipt is resolved
+	onResolve func(FrameDelivery)
+}
+
+// NewReceipt returns a new, unresolved Receipt.
+func NewReceipt() *Receipt {
+	return &Receipt{
+		doneCh:   make(chan struct{}),
+		cancelCh: make(chan struct{}),
+	}
+}
+
+// Done returns a channel that is closed when the Receipt is resolved.
+func (r *Receipt) Done() <-chan struct{} {
+	return r.doneCh
+}
+
+// Delivery returns the outcome of delivering the frame. The result is only
+// meaningful once the channel returned by Done() has been closed.
+func (r *Receipt) Delivery() FrameDelivery {
+	select {
+	case <-r.doneCh:
+		return r.delivery
+	default:
+		return FrameDelivery{}
+	}
+}
+
+// Wait blocks until the Receipt is resolved and returns nil if the frame was
+// delivered or an error describing why it wasn't. Callers wishing to give up
+// waiting after some time should select on the channel returned by Done()
+// instead.
+func (r *Receipt) Wait() error {
+	<-r.doneCh
+	return r.delivery.Err
+}
+
+// Cancel asks the c2d buffer to stop trying to deliver the frame. If the frame
+// has not yet been sent, it never will be. If the frame was sent and the buffer
+// is waiting for the device to acknowledge its receipt, no further retries are
+// attempted. In either case, the Receipt is resolved with ErrFrameCanceled.
+// Canceling a Receipt that was already resolved has no effect.
+func (r *Receipt) Cancel() {
+	r.cancelOnce.Do(func() {
+		close(r.cancelCh)
+	})
+}
+
+// canceled returns a bool indicating whether Cancel() has been called.
+func (r *Receipt) canceled() bool {
+	select {
+	case <-r.cancelCh:
+		return true
+	default:
+		return false
+	}
+}
+
+// resolve records the outcome of delivering the frame and unblocks anyone
+// waiting on the Receipt. Only the first call has any effect.
+func (r *Receipt) resolve(delivery FrameDelivery) {
+	r.resolveOnce.Do(func() {
+		r.delivery = delivery
+		if r.onResolve != nil {
+			r.onResolve(delivery)
+		}
+		close(r.doneCh)
+	})
+}
@@ -0,0 +1,175 @@
+package arnetwork
+
+import (
+	"testing"
+	"time"
+
+	"github.com/krancour/go-parrot/protocols/arnetworkal"
+	"github.com/krancour/go-parrot/protocols/arnetworkal/fake"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReceiptResolve(t *testing.T) {
+	receipt := NewReceipt()
+	select {
+	case <-receipt.Done():
+		require.Fail(t, "receipt was resolved, but should not have been")
+	default:
+	}
+	receipt.resolve(FrameDelivery{Acked: true, Retries: 1})
+	// Only the first resolution counts
+	receipt.resolve(FrameDelivery{Err: ErrFrameDropped})
+	require.NoError(t, receipt.Wait())
+	require.Equal(t, FrameDelivery{Acked: true, Retries: 1}, receipt.Delivery())
+}
+
+func TestReceiptResolvedByC2DBuffer(t *testing.T) {
+	testCases := []struct {
+		name       string
+		bufCfg     C2DBufferConfig
+		cancel     bool
+		assertions func(*testing.T, *Receipt, int)
+	}{
+
+		{
+			name: "frame acknowledged",
+			bufCfg: C2DBufferConfig{
+				ID:         1,
+				FrameType:  arnetworkal.FrameTypeDataWithAck,
+				Size:       1,
+				AckTimeout: time.Second,
+			},
+			assertions: func(t *testing.T, receipt *Receipt, sendCallCount int) {
+				require.NoError(t, receipt.Wait())
+				require.True(t, receipt.Delivery().Acked)
+				require.Equal(t, 1, sendCallCount)
+			},
+		},
+
+		{
+			name: "frame canceled before it was sent",
+			bufCfg: C2DBufferConfig{
+				ID:         1,
+				FrameType:  arnetworkal.FrameTypeDataWithAck,
+				Size:       1,
+				AckTimeout: time.Second,
+			},
+			cancel: true,
+			assertions: func(t *testing.T, receipt *Receipt, sendCallCount int) {
+				require.Equal(t, ErrFrameCanceled, receipt.Wait())
+				require.Equal(t, 0, sendCallCount)
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ackCh := make(chan Frame, 1)
+			sendCallCount := 0
+			frameSender := &fake.FrameSender{
+				SendBehavior: func(netFrame arnetworkal.Frame) error {
+					sendCallCount++
+					ackCh <- Frame{Data: []byte{netFrame.Seq}}
+					return nil
+				},
+			}
+			buf := newC2DBuffer(testCase.bufCfg, frameSender)
+			buf.ackCh = ackCh
+			receipt := NewReceipt()
+			if testCase.cancel {
+				receipt.Cancel()
+			}
+			buf.inCh <- Frame{
+				Data:    []byte("foo"),
+				Receipt: receipt,
+			}
+			select {
+			case <-receipt.Done():
+			case <-time.After(2 * time.Second):
+				require.Fail(t, "timed out waiting for receipt to be resolved")
+			}
+			testCase.assertions(t, receipt, sendCallCount)
+		})
+	}
+}
+
+func TestReceiptCanceledWhileAwaitingAck(t *testing.T) {
+	frameSender := &fake.FrameSender{}
+	buf := newC2DBuffer(
+		C2DBufferConfig{
+			ID:         1,
+			FrameType:  arnetworkal.FrameTypeDataWithAck,
+			Size:       1,
+			AckTimeout: time.Second,
+			MaxRetries: -1, // Infinite
+		},
+		frameSender,
+	)
+	buf.ackCh = make(chan Frame)
+	receipt := NewReceipt()
+	sentCh := make(chan struct{}, 1)
+	frameSender.SendBehavior = func(arnetworkal.Frame) error {
+		select {
+		case sentCh <- struct{}{}:
+		default:
+		}
+		return nil
+	}
+	go func() {
+		<-sentCh
+		receipt.Cancel()
+	}()
+	delivery := buf.writeFrame(
+		Frame{
+			Data:    []byte("foo"),
+			Receipt: receipt,
+		},
+	)
+	require.Equal(t, ErrFrameCanceled, delivery.Err)
+	require.False(t, delivery.Acked)
+}
+
+func TestReceiptResolvedWhenFrameDropped(t *testing.T) {
+	testCases := []struct {
+		name          string
+		isOverwriting bool
+		// droppedIndex is the index of the frame expected to be dropped
+		droppedIndex int
+	}{
+		{
+			name:          "no overwrite drops newest frame",
+			isOverwriting: false,
+			droppedIndex:  1,
+		},
+		{
+			name:          "overwrite drops oldest frame",
+			isOverwriting: true,
+			droppedIndex:  0,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			buf := newBuffer(1, 1, testCase.isOverwriting)
+			receipts := []*Receipt{NewReceipt(), NewReceipt()}
+			for _, receipt := range receipts {
+				buf.inCh <- Frame{Receipt: receipt}
+			}
+			close(buf.inCh)
+			<-buf.doneCh
+			dropped := receipts[testCase.droppedIndex]
+			select {
+			case <-dropped.Done():
+				require.Equal(t, ErrFrameDropped, dropped.Delivery().Err)
+			default:
+				require.Fail(t, "receipt of dropped frame was not resolved")
+			}
+			kept := receipts[1-testCase.droppedIndex]
+			select {
+			case <-kept.Done():
+				require.Fail(t, "receipt of buffered frame was resolved")
+			default:
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package arnetwork
+
+const (
+	// DefaultSeqWindow is the number of recent sequence numbers a d2c buffer
+	// tracks for the purpose of detecting duplicate and out of order frames,
+	// unless otherwise specified.
+	DefaultSeqWindow uint8 = 10
+	// maxSeqWindow is the largest supported sequence window. Since sequence
+	// numbers are a single byte and wrap around, it is only possible to tell
+	// whether one sequence number comes before or after another if they are
+	// less than half of the sequence number space apart.
+	maxSeqWindow uint8 = 127
+)
+
+// seqResult describes the outcome of pushing a sequence number onto a
+// seqWindow.
+type seqResult int
+
+const (
+	// seqAccepted indicates a frame is newer than any previously accepted
+	// frame.
+	seqAccepted seqResult = iota
+	// seqResynced indicates a frame is so far behind the window that the
+	// device's sequence numbers are assumed to have started over-- e.g. after
+	// a reconnect. The window was reset and the frame was accepted.
+	seqResynced
+	// seqDuplicate indicates a frame was already accepted.
+	seqDuplicate
+	// seqOutOfOrder indicates a frame arrived after a newer frame was already
+	// accepted.
+	seqOutOfOrder
+)
+
+// seqWindow is a sliding window duplicate detector for sequence numbers. It
+// remembers which of the most recent size sequence numbers, up to and
+// including the highest accepted, were received. Comparisons use wraparound
+// arithmetic so that, for instance, 3 follows 250.
+type seqWindow struct {
+	size        uint8
+	initialized bool
+	highest     uint8
+	// seen records whether each sequence number was received. Only entries
+	// within the window are meaningful. Entries are cleared as the window
+	// advances past them.
+	seen [256]bool
+}
+
+func newSeqWindow(size uint8) *seqWindow {
+	return &seqWindow{
+		size: size,
+	}
+}
+
+// push classifies a sequence number relative to the window and advances the
+// window if the sequence number is accepted.
+func (s *seqWindow) push(seq uint8) seqResult {
+	if !s.initialized {
+		s.resync(seq)
+		return seqAccepted
+	}
+	// Interpreting the difference as signed accounts for wraparound
+	distance := int8(seq - s.highest)
+	if distance > 0 {
+		// Clear every sequence number the window advances past, including any
+		// that were skipped, so that stale entries from the previous lap through
+		// the sequence number space aren't mistaken for received frames
+		for skipped := s.highest + 1; skipped != seq; skipped++ {
+			s.seen[skipped] = false
+		}
+		s.seen[seq] = true
+		s.highest = seq
+		return seqAccepted
+	}
+	if -int(distance) >= int(s.size) {
+		s.resync(seq)
+		return seqResynced
+	}
+	if s.seen[seq] {
+		return seqDuplicate
+	}
+	return seqOutOfOrder
+}
+
+// reset forgets all sequence numbers. The next sequence number pushed will be
+// accepted unconditionally.
+func (s *seqWindow) reset() {
+	s.initialized = false
+}
+
+func (s *seqWindow) resync(seq uint8) {
+	s.seen = [256]bool{}
+	s.seen[seq] = true
+	s.highest = seq
+	s.initialized = true
+}
@@ -0,0 +1,125 @@
+package arnetwork
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSeqWindowPush(t *testing.T) {
+	testCases := []struct {
+		name     string
+		size     uint8
+		pushed   []uint8
+		seq      uint8
+		expected seqResult
+	}{
+
+		{
+			name:     "first sequence number",
+			size:     DefaultSeqWindow,
+			seq:      200,
+			expected: seqAccepted,
+		},
+
+		{
+			name:     "next sequence number",
+			size:     DefaultSeqWindow,
+			pushed:   []uint8{5},
+			seq:      6,
+			expected: seqAccepted,
+		},
+
+		{
+			name:     "sequence number after a gap",
+			size:     DefaultSeqWindow,
+			pushed:   []uint8{5},
+			seq:      9,
+			expected: seqAccepted,
+		},
+
+		{
+			name:     "sequence number after wraparound",
+			size:     DefaultSeqWindow,
+			pushed:   []uint8{254, 255},
+			seq:      0,
+			expected: seqAccepted,
+		},
+
+		{
+			name:     "duplicate of highest sequence number",
+			size:     DefaultSeqWindow,
+			pushed:   []uint8{5, 6},
+			seq:      6,
+			expected: seqDuplicate,
+		},
+
+		{
+			name:     "duplicate within window",
+			size:     DefaultSeqWindow,
+			pushed:   []uint8{5, 6, 7},
+			seq:      5,
+			expected: seqDuplicate,
+		},
+
+		{
+			name:     "duplicate within window across wraparound",
+			size:     DefaultSeqWindow,
+			pushed:   []uint8{254, 255, 0, 1},
+			seq:      255,
+			expected: seqDuplicate,
+		},
+
+		{
+			name:     "skipped sequence number arriving late",
+			size:     DefaultSeqWindow,
+			pushed:   []uint8{5, 7},
+			seq:      6,
+			expected: seqOutOfOrder,
+		},
+
+		{
+			name: "stale entry from previous lap is not a duplicate",
+			size: DefaultSeqWindow,
+			// 100 is seen on the first lap, then skipped on the second
+			pushed:   []uint8{100, 160, 220, 24, 88, 99, 101},
+			seq:      100,
+			expected: seqOutOfOrder,
+		},
+
+		{
+			name:     "sequence number far behind window",
+			size:     DefaultSeqWindow,
+			pushed:   []uint8{25},
+			seq:      15,
+			expected: seqResynced,
+		},
+
+		{
+			name:     "sequence number just inside a larger window",
+			size:     20,
+			pushed:   []uint8{25},
+			seq:      15,
+			expected: seqOutOfOrder,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			s := newSeqWindow(testCase.size)
+			for _, seq := range testCase.pushed {
+				s.push(seq)
+			}
+			require.Equal(t, testCase.expected, s.push(testCase.seq))
+		})
+	}
+}
+
+func TestSeqWindowReset(t *testing.T) {
+	s := newSeqWindow(DefaultSeqWindow)
+	require.Equal(t, seqAccepted, s.push(5))
+	require.Equal(t, seqDuplicate, s.push(5))
+	s.reset()
+	require.Equal(t, seqAccepted, s.push(5))
+	require.Equal(t, uint8(5), s.highest)
+}
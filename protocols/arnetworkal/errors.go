@@ -0,0 +1,36 @@
+package arnetworkal
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ErrClosed is the error returned by a FrameReceiver's Receive() function once
+// the underlying network connection has been closed. Callers may test for it
+// using errors.Cause(err) == ErrClosed.
+var ErrClosed = errors.New("connection closed")
+
+// DisconnectedError is the error returned by a FrameReceiver's Receive()
+// function when a probable disconnection from the device has been detected
+// because no data was received within some period of time.
+type DisconnectedError struct {
+	// Timeout is the period of time after which no data having been received
+	// was considered a probable disconnection.
+	Timeout time.Duration
+}
+
+func (d *DisconnectedError) Error() string {
+	return fmt.Sprintf(
+		"probable disconnection; no data received for %s",
+		d.Timeout,
+	)
+}
+
+// IsDisconnected returns a bool indicating whether the given error, or the
+// error that caused it, is a *DisconnectedError.
+func IsDisconnected(err error) bool {
+	_, ok := errors.Cause(err).(*DisconnectedError)
+	return ok
+}
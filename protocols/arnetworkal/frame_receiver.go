@@ -6,7 +6,10 @@ package arnetworkal
 type FrameReceiver interface {
 	// Receive receives 1 or more ARNetworkAL frames over a network connection
 	// of some type. Calls to this functions should expect to block until data
-	// is received.
+	// is received. Once the underlying network connection has been closed,
+	// calls to this function should return ErrClosed. If a probable
+	// disconnection from the device is detected, calls to this function should
+	// return a *DisconnectedError.
 	Receive() ([]Frame, error)
 	// Close closes the underlying network connection.
 	Close()
@@ -4,65 +4,173 @@ import (
 	"bufio"
 	"encoding/json"
 	"net"
+	"time"
 
 	log "github.com/Sirupsen/logrus"
 	"github.com/krancour/go-parrot/protocols/arnetworkal"
+	"github.com/krancour/go-parrot/protocols/internal/udp"
 	"github.com/phayes/freeport"
 	"github.com/pkg/errors"
 )
 
 const (
-	discoveryPort = 44444
-	// maxUDPDataBytes represents the practical maximum numbers of data bytes in a
-	// UDP datagram.
-	maxUDPDataBytes = 65507
+	// DefaultDiscoveryPort is the TCP port on which Parrot devices listen for
+	// connection negotiation requests.
+	DefaultDiscoveryPort = 44444
+	// DefaultControllerName is the name by which the client identifies itself to
+	// the device during connection negotiation, unless otherwise specified.
+	DefaultControllerName = "go-parrot"
+	// DefaultReadTimeout is how long the client will wait to receive data from
+	// the device before assuming a disconnection has occurred, unless otherwise
+	// specified. This value is in line with the arnetwork protocol (on the device
+	// end), which assumes a disconnection has occurred after five seconds of
+	// receiving no data from the controller.
+	DefaultReadTimeout = 5 * time.Second
+	controllerType     = "computer"
 )
 
-var deviceIP = net.ParseIP("192.168.42.1")
+// DefaultDeviceIP is the IP address Parrot devices assign to themselves on
+// the wifi networks they host.
+var DefaultDeviceIP = net.ParseIP("192.168.42.1")
 
+// ConnectOptions represents options for connecting to a device. The zero
+// value for any field selects a sensible default, so the zero value of
+// ConnectOptions connects to a device on its own wifi network exactly as
+// Parrot's own SDK would.
+type ConnectOptions struct {
+	// DeviceIP is the IP address of the device. If nil, DefaultDeviceIP is used.
+	// Use 127.0.0.1 to connect to a simulator running locally or an address on
+	// another network to connect to a device behind NAT / port forwarding.
+	DeviceIP net.IP
+	// DiscoveryPort is the TCP port on which the device listens for connection
+	// negotiation requests. If zero, DefaultDiscoveryPort is used.
+	DiscoveryPort int
+	// D2CPort is the UDP port on which the client will listen for data from the
+	// device. If zero, an available port is selected automatically. Specifying
+	// a fixed port is useful when the device must reach the client through a
+	// forwarded port.
+	D2CPort int
+	// ControllerName is the name by which the client identifies itself to the
+	// device. If empty, DefaultControllerName is used. Distinct names permit
+	// multiple controller identities to be distinguished.
+	ControllerName string
+	// ReadTimeout is how long the client will wait to receive data from the
+	// device before assuming a disconnection has occurred. If zero,
+	// DefaultReadTimeout is used. Lossy links may warrant a longer timeout.
+	ReadTimeout time.Duration
+	// StreamPort is the UDP port on which the client will listen for ARStream2
+	// (RTP) video data. If zero, the client does not request an ARStream2 video
+	// stream.
+	StreamPort int
+	// StreamControlPort is the UDP port on which the client will send and
+	// receive ARStream2 (RTCP) control data. It is only meaningful if
+	// StreamPort is also specified.
+	StreamControlPort int
+}
+
+// withDefaults returns a copy of the options with defaults applied to all
+// unspecified fields.
+func (c ConnectOptions) withDefaults() ConnectOptions {
+	if c.DeviceIP == nil {
+		c.DeviceIP = DefaultDeviceIP
+	}
+	if c.DiscoveryPort == 0 {
+		c.DiscoveryPort = DefaultDiscoveryPort
+	}
+	if c.ControllerName == "" {
+		c.ControllerName = DefaultControllerName
+	}
+	if c.ReadTimeout == 0 {
+		c.ReadTimeout = DefaultReadTimeout
+	}
+	return c
+}
+
+// nolint: lll
 type connectionNegotiationRequest struct {
-	D2CPort        int    `json:"d2c_port"`
-	ControllerType string `json:"controller_type"`
-	ControllerName string `json:"controller_name"`
+	D2CPort                    int    `json:"d2c_port"`
+	ControllerType             string `json:"controller_type"`
+	ControllerName             string `json:"controller_name"`
+	ARStream2ClientStreamPort  int    `json:"arstream2_client_stream_port,omitempty"`
+	ARStream2ClientControlPort int    `json:"arstream2_client_control_port,omitempty"`
 }
 
 type connectionNegotiationResponse struct {
-	Status  int `json:"status"`
-	C2DPort int `json:"c2d_port"`
+	Status int `json:"status"`
+	ConnectionParams
 }
 
 // Connect returns UDP/IP based implementations of the arnetworkal.FrameSender
-// and arnetworkal.FrameReceiver interfaces.
-func Connect() (arnetworkal.FrameSender, arnetworkal.FrameReceiver, error) {
+// and arnetworkal.FrameReceiver interfaces, along with the parameters the
+// device communicated during connection negotiation. See ConnectOptions for
+// details of how the connection may be customized.
+func Connect(
+	opts ConnectOptions,
+) (
+	arnetworkal.FrameSender,
+	arnetworkal.FrameReceiver,
+	ConnectionParams,
+	error,
+) {
 	log.Debug("starting new connection process")
+	opts = opts.withDefaults()
+	if opts.ReadTimeout < 0 {
+		return nil, nil, ConnectionParams{},
+			errors.Errorf("invalid read timeout %s", opts.ReadTimeout)
+	}
+	deviceIP := opts.DeviceIP
 
-	// Select an available port
-	log.Debug("selecting available port for d2c communication")
-	d2cPort, err := freeport.GetFreePort()
-	if err != nil {
-		return nil, nil,
-			errors.Wrap(err, "error selecting available client-side port")
+	d2cPort := opts.D2CPort
+	if d2cPort == 0 {
+		// Select an available port
+		log.Debug("selecting available port for d2c communication")
+		var err error
+		d2cPort, err = freeport.GetFreePort()
+		if err != nil {
+			return nil, nil, ConnectionParams{},
+				errors.Wrap(err, "error selecting available client-side port")
+		}
+		log.WithField(
+			"port", d2cPort,
+		).Debug("selected port for d2c communication")
 	}
-	log.WithField(
-		"port", d2cPort,
-	).Debug("selected port for d2c communication")
 
 	// Negotiate the connection
-	c2dPort, err := negotiateConnection(deviceIP, discoveryPort, d2cPort)
+	connectionParams, err := negotiateConnection(
+		deviceIP,
+		opts.DiscoveryPort,
+		connectionNegotiationRequest{
+			D2CPort:                    d2cPort,
+			ControllerType:             controllerType,
+			ControllerName:             opts.ControllerName,
+			ARStream2ClientStreamPort:  opts.StreamPort,
+			ARStream2ClientControlPort: opts.StreamControlPort,
+		},
+	)
 	if err != nil {
-		return nil, nil, errors.Wrap(err, "connection negotiation failed")
+		return nil, nil, ConnectionParams{},
+			errors.Wrap(err, "connection negotiation failed")
 	}
+	c2dPort := connectionParams.C2DPort
 
 	// Establish the c2d connection...
 	c2dConn, err := establishC2DConnection(deviceIP, c2dPort)
 	if err != nil {
-		return nil, nil, errors.Wrap(err, "error establishing c2d connection")
+		return nil, nil, ConnectionParams{},
+			errors.Wrap(err, "error establishing c2d connection")
 	}
 
 	// Establish the d2c connection...
 	d2cConn, err := establishD2CConnection(d2cPort)
 	if err != nil {
-		return nil, nil, errors.Wrap(err, "error establishing d2c connection")
+		// Don't leak the c2d connection
+		if c2dConn != nil {
+			if cerr := c2dConn.Close(); cerr != nil {
+				log.Errorf("error closing c2d connection: %s", cerr)
+			}
+		}
+		return nil, nil, ConnectionParams{},
+			errors.Wrap(err, "error establishing d2c connection")
 	}
 
 	log.WithField(
@@ -74,31 +182,36 @@ func Connect() (arnetworkal.FrameSender, arnetworkal.FrameReceiver, error) {
 	).Debug("c2d and d2c connections ready for use")
 	return &frameSender{
 			conn:        c2dConn,
-			encodeFrame: defaultEncodeFrame,
+			encodeFrame: EncodeFrame,
 		},
 		&frameReceiver{
 			conn:           d2cConn,
-			decodeDatagram: defaultDecodeDatagram,
-			datagramBuffer: make([]byte, maxUDPDataBytes),
+			readTimeout:    opts.ReadTimeout,
+			decodeDatagram: DecodeDatagram,
+			datagramBuffer: make([]byte, udp.MaxDataBytes),
 		},
+		connectionParams,
 		nil
 }
 
 var negotiateConnection = defaultNegotiateConnection
 
 // negotiateConnection negotiates the connection. This is how the client informs
-// the device of the UDP port it will listen on. In response, the device informs
-// the client of which UDP port it will listen on.
+// the device of the UDP port(s) it will listen on. In response, the device
+// informs the client of which UDP port it will listen on and of other
+// parameters pertinent to the connection.
 // TODO: Should this be moved into its own protocol packages?
 func defaultNegotiateConnection(
 	deviceIP net.IP,
-	discoveryPort,
-	d2cPort int,
-) (int, error) {
+	discoveryPort int,
+	req connectionNegotiationRequest,
+) (ConnectionParams, error) {
 	log.WithField(
 		"deviceIP", deviceIP,
 	).WithField(
 		"discoveryPort", discoveryPort,
+	).WithField(
+		"controllerName", req.ControllerName,
 	).Debug("negotiating connection")
 
 	conn, err := net.DialTCP(
@@ -110,20 +223,14 @@ func defaultNegotiateConnection(
 		},
 	)
 	if err != nil {
-		return 0, err
+		return ConnectionParams{}, err
 	}
 	defer conn.Close() // nolint: errcheck
 
 	log.Debug("marshaling connection negotiation request")
-	jsonBytes, err := json.Marshal(
-		connectionNegotiationRequest{
-			D2CPort:        d2cPort,
-			ControllerType: "computer",
-			ControllerName: "go-parrot",
-		},
-	)
+	jsonBytes, err := json.Marshal(req)
 	if err != nil {
-		return 0,
+		return ConnectionParams{},
 			errors.Wrap(err, "error marshaling connection negotiation request")
 	}
 	log.Debug("marshaled connection negotiation request")
@@ -132,7 +239,7 @@ func defaultNegotiateConnection(
 
 	log.Debug("sending connection negotiation request")
 	if _, err = conn.Write(jsonBytes); err != nil {
-		return 0,
+		return ConnectionParams{},
 			errors.Wrap(err, "error sending connection negotiation request")
 	}
 	log.Debug("sent connection negotiation request")
@@ -145,7 +252,7 @@ func defaultNegotiateConnection(
 	// terminate all strings in C) as a delimiter.
 	data, err := bufio.NewReader(conn).ReadBytes(0x00)
 	if err != nil {
-		return 0,
+		return ConnectionParams{},
 			errors.Wrap(err, "error receiving connection negotiation response")
 	}
 	log.Debug("got connection negotiation response")
@@ -153,7 +260,7 @@ func defaultNegotiateConnection(
 	var res connectionNegotiationResponse
 	log.Debug("unmarshaling connection negotiation response")
 	if err := json.Unmarshal(data[:len(data)-1], &res); err != nil {
-		return 0,
+		return ConnectionParams{},
 			errors.Wrap(
 				err,
 				"error unmarshaling connection negotiation response",
@@ -162,7 +269,7 @@ func defaultNegotiateConnection(
 	log.Debug("unmarshaled connection negotiation response")
 	// Any non-zero status is a refused connection.
 	if res.Status != 0 {
-		return 0, errors.New("connection refused by device")
+		return ConnectionParams{}, errors.New("connection refused by device")
 	}
 
 	log.WithField(
@@ -170,10 +277,16 @@ func defaultNegotiateConnection(
 	).WithField(
 		"c2dPort", res.C2DPort,
 	).WithField(
-		"d2cPort", d2cPort,
+		"d2cPort", req.D2CPort,
+	).WithField(
+		"arstreamFragmentSize", res.ARStreamFragmentSize,
+	).WithField(
+		"arstreamFragmentMaximumNumber", res.ARStreamFragmentMaximumNumber,
+	).WithField(
+		"qosMode", res.QoSMode,
 	).Debug("connection negotiation complete")
 
-	return res.C2DPort, nil
+	return res.ConnectionParams, nil
 }
 
 var establishC2DConnection = defaultEstablishC2DConnection
@@ -15,13 +15,14 @@ import (
 )
 
 func TestConnect(t *testing.T) {
+	var c2dConn *net.UDPConn
 	testCases := []struct {
 		name                        string
 		negotiateConnectionBehavior func(
 			deviceIP net.IP,
-			discoveryPort,
-			d2cPort int,
-		) (int, error)
+			discoveryPort int,
+			req connectionNegotiationRequest,
+		) (ConnectionParams, error)
 		establishC2DConnectionBehavior func(
 			deviceIP net.IP,
 			c2dPort int,
@@ -31,19 +32,25 @@ func TestConnect(t *testing.T) {
 			*testing.T,
 			arnetworkal.FrameSender,
 			arnetworkal.FrameReceiver,
+			ConnectionParams,
 			error,
 		)
 	}{
 
 		{
 			name: "connection negotiation fails",
-			negotiateConnectionBehavior: func(net.IP, int, int) (int, error) {
-				return 0, errors.New("foo")
+			negotiateConnectionBehavior: func(
+				net.IP,
+				int,
+				connectionNegotiationRequest,
+			) (ConnectionParams, error) {
+				return ConnectionParams{}, errors.New("foo")
 			},
 			assertions: func(
 				t *testing.T,
 				_ arnetworkal.FrameSender,
 				_ arnetworkal.FrameReceiver,
+				_ ConnectionParams,
 				err error,
 			) {
 				assert.Error(t, err)
@@ -54,8 +61,12 @@ func TestConnect(t *testing.T) {
 
 		{
 			name: "establish c2d connection fails",
-			negotiateConnectionBehavior: func(net.IP, int, int) (int, error) {
-				return 12345, nil
+			negotiateConnectionBehavior: func(
+				net.IP,
+				int,
+				connectionNegotiationRequest,
+			) (ConnectionParams, error) {
+				return ConnectionParams{C2DPort: 12345}, nil
 			},
 			establishC2DConnectionBehavior: func(net.IP, int) (*net.UDPConn, error) {
 				return nil, errors.New("bar")
@@ -64,6 +75,7 @@ func TestConnect(t *testing.T) {
 				t *testing.T,
 				_ arnetworkal.FrameSender,
 				_ arnetworkal.FrameReceiver,
+				_ ConnectionParams,
 				err error,
 			) {
 				assert.Error(t, err)
@@ -74,11 +86,20 @@ func TestConnect(t *testing.T) {
 
 		{
 			name: "establish d2c connection fails",
-			negotiateConnectionBehavior: func(net.IP, int, int) (int, error) {
-				return 12345, nil
+			negotiateConnectionBehavior: func(
+				net.IP,
+				int,
+				connectionNegotiationRequest,
+			) (ConnectionParams, error) {
+				return ConnectionParams{C2DPort: 12345}, nil
 			},
 			establishC2DConnectionBehavior: func(net.IP, int) (*net.UDPConn, error) {
-				return nil, nil
+				var err error
+				c2dConn, err = net.ListenUDP(
+					"udp",
+					&net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)},
+				)
+				return c2dConn, err
 			},
 			establishD2CConnectionBehavior: func(int) (*net.UDPConn, error) {
 				return nil, errors.New("bat")
@@ -87,18 +108,26 @@ func TestConnect(t *testing.T) {
 				t *testing.T,
 				_ arnetworkal.FrameSender,
 				_ arnetworkal.FrameReceiver,
+				_ ConnectionParams,
 				err error,
 			) {
 				assert.Error(t, err)
 				assert.Contains(t, err.Error(), "error establishing d2c connection")
 				assert.Contains(t, err.Error(), "bat")
+				// The c2d connection should not have been leaked
+				require.NotNil(t, c2dConn)
+				assert.Error(t, c2dConn.Close())
 			},
 		},
 
 		{
 			name: "establishing a connection succeeds",
-			negotiateConnectionBehavior: func(net.IP, int, int) (int, error) {
-				return 12345, nil
+			negotiateConnectionBehavior: func(
+				net.IP,
+				int,
+				connectionNegotiationRequest,
+			) (ConnectionParams, error) {
+				return ConnectionParams{C2DPort: 12345}, nil
 			},
 			establishC2DConnectionBehavior: func(net.IP, int) (*net.UDPConn, error) {
 				return nil, nil
@@ -110,6 +139,7 @@ func TestConnect(t *testing.T) {
 				t *testing.T,
 				_ arnetworkal.FrameSender,
 				_ arnetworkal.FrameReceiver,
+				_ ConnectionParams,
 				err error,
 			) {
 				assert.NoError(t, err)
@@ -131,8 +161,15 @@ func TestConnect(t *testing.T) {
 			if testCase.establishD2CConnectionBehavior != nil {
 				establishD2CConnection = testCase.establishD2CConnectionBehavior
 			}
-			frameSender, frameReceiver, err := Connect()
-			testCase.assertions(t, frameSender, frameReceiver, err)
+			frameSender, frameReceiver, connectionParams, err :=
+				Connect(ConnectOptions{})
+			testCase.assertions(
+				t,
+				frameSender,
+				frameReceiver,
+				connectionParams,
+				err,
+			)
 			if frameSender != nil {
 				frameSender.Close()
 			}
@@ -143,6 +180,24 @@ func TestConnect(t *testing.T) {
 	}
 }
 
+func TestConnectOptionsWithDefaults(t *testing.T) {
+	opts := ConnectOptions{}.withDefaults()
+	require.Equal(t, DefaultDeviceIP, opts.DeviceIP)
+	require.Equal(t, DefaultDiscoveryPort, opts.DiscoveryPort)
+	require.Equal(t, 0, opts.D2CPort)
+	require.Equal(t, DefaultControllerName, opts.ControllerName)
+	require.Equal(t, DefaultReadTimeout, opts.ReadTimeout)
+	// Specified options should not be overridden
+	customOpts := ConnectOptions{
+		DeviceIP:       net.ParseIP("127.0.0.1"),
+		DiscoveryPort:  12345,
+		D2CPort:        54321,
+		ControllerName: "foo",
+		ReadTimeout:    time.Minute,
+	}
+	require.Equal(t, customOpts, customOpts.withDefaults())
+}
+
 func TestCannotConnectToNegotiate(t *testing.T) {
 	// Pick a port where we're sure nothing else is listening
 	discoveryPort, err := freeport.GetFreePort()
@@ -150,7 +205,12 @@ func TestCannotConnectToNegotiate(t *testing.T) {
 	_, err = defaultNegotiateConnection(
 		net.ParseIP("127.0.0.1"),
 		discoveryPort,
-		12345, // Dummy port number-- we'll never connect to this, so it's ok
+		connectionNegotiationRequest{
+			// Dummy port number-- we'll never connect to this, so it's ok
+			D2CPort:        12345,
+			ControllerType: controllerType,
+			ControllerName: DefaultControllerName,
+		},
 	)
 	require.Error(t, err)
 }
@@ -182,8 +242,10 @@ func TestRefusedConnectionNegotiation(t *testing.T) {
 		// Send a response
 		jsonBytes, err := json.Marshal(
 			connectionNegotiationResponse{
-				Status:  1, // Non-zero == connection refused
-				C2DPort: c2dPort,
+				Status: 1, // Non-zero == connection refused
+				ConnectionParams: ConnectionParams{
+					C2DPort: c2dPort,
+				},
 			},
 		)
 		require.NoError(t, err)
@@ -209,9 +271,13 @@ func TestRefusedConnectionNegotiation(t *testing.T) {
 	_, err = defaultNegotiateConnection(
 		net.ParseIP("127.0.0.1"),
 		discoveryPort,
-		// Dummy port number-- this is ok since the mock server won't do anything
-		// with it
-		54321,
+		connectionNegotiationRequest{
+			// Dummy port number-- this is ok since the mock server won't do
+			// anything with it
+			D2CPort:        54321,
+			ControllerType: controllerType,
+			ControllerName: DefaultControllerName,
+		},
 	)
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "refused")
@@ -220,8 +286,18 @@ func TestRefusedConnectionNegotiation(t *testing.T) {
 func TestSuccessfulConnectionNegotiation(t *testing.T) {
 	discoveryPort, err := freeport.GetFreePort()
 	require.NoError(t, err)
-	// Dummy port number-- we'll never connect to this, so it's ok
-	c2dPort := 12345
+	expectedConnectionParams := ConnectionParams{
+		// Dummy port number-- we'll never connect to this, so it's ok
+		C2DPort:                       12345,
+		C2DUpdatePort:                 51,
+		C2DUserPort:                   21,
+		QoSMode:                       QoSModeEnabled,
+		ARStreamFragmentSize:          65000,
+		ARStreamFragmentMaximumNumber: 4,
+		ARStreamMaxAckIntervalMillis:  -1,
+		ARStream2ServerStreamPort:     5004,
+		ARStream2ServerControlPort:    5005,
+	}
 	// Mock out the device's connection negotiation. Run it in its on goroutine so
 	// we can move on to trying to talk to it.
 	listeningCh := make(chan struct{})
@@ -241,14 +317,18 @@ func TestSuccessfulConnectionNegotiation(t *testing.T) {
 		var negReq connectionNegotiationRequest
 		err = json.Unmarshal(data[:len(data)-1], &negReq)
 		require.NoError(t, err)
-		// Send a response
-		jsonBytes, err := json.Marshal(
-			connectionNegotiationResponse{
-				Status:  0,
-				C2DPort: c2dPort,
-			},
+		require.Equal(t, "foo", negReq.ControllerName)
+		require.Equal(t, 55004, negReq.ARStream2ClientStreamPort)
+		require.Equal(t, 55005, negReq.ARStream2ClientControlPort)
+		// Send a response exactly as a real device would
+		jsonBytes := []byte(
+			`{"status":0,"c2d_port":12345,"c2d_update_port":51,` +
+				`"c2d_user_port":21,"qos_mode":1,"arstream_fragment_size":65000,` +
+				`"arstream_fragment_maximum_number":4,` +
+				`"arstream_max_ack_interval":-1,` +
+				`"arstream2_server_stream_port":5004,` +
+				`"arstream2_server_control_port":5005}`,
 		)
-		require.NoError(t, err)
 		jsonBytes = append(jsonBytes, 0x00)
 		_, err = conn.Write(jsonBytes)
 		require.NoError(t, err)
@@ -268,13 +348,19 @@ func TestSuccessfulConnectionNegotiation(t *testing.T) {
 		)
 	}
 
-	negotiatedC2DPort, err := defaultNegotiateConnection(
+	connectionParams, err := defaultNegotiateConnection(
 		net.ParseIP("127.0.0.1"),
 		discoveryPort,
-		// Dummy port number-- this is ok since the mock server won't do anything
-		// with it
-		54321,
+		connectionNegotiationRequest{
+			// Dummy port number-- this is ok since the mock server won't do
+			// anything with it
+			D2CPort:                    54321,
+			ControllerType:             controllerType,
+			ControllerName:             "foo",
+			ARStream2ClientStreamPort:  55004,
+			ARStream2ClientControlPort: 55005,
+		},
 	)
 	require.NoError(t, err)
-	require.Equal(t, c2dPort, negotiatedC2DPort)
+	require.Equal(t, expectedConnectionParams, connectionParams)
 }
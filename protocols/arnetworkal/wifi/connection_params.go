@@ -0,0 +1,55 @@
+package wifi
+
+import "time"
+
+// QoSMode is a type for constants used to indicate the quality of service mode
+// the device has negotiated for the connection.
+type QoSMode int
+
+const (
+	// QoSModeDisabled indicates QoS is not in use.
+	QoSModeDisabled QoSMode = iota
+	// QoSModeEnabled indicates the device has enabled QoS tagging of the
+	// traffic it sends.
+	QoSModeEnabled
+)
+
+// ConnectionParams represents the parameters the device communicates to the
+// client during connection negotiation. Some of these are needed to size
+// arnetwork buffers and to receive video streams correctly. Fields the device
+// did not include in its response are left at their zero values.
+type ConnectionParams struct {
+	// C2DPort is the UDP port on which the device listens for data from the
+	// client.
+	C2DPort int `json:"c2d_port"`
+	// C2DUpdatePort is the port the device uses for software updates.
+	C2DUpdatePort int `json:"c2d_update_port"`
+	// C2DUserPort is the port the device uses for user (media) file transfer.
+	C2DUserPort int `json:"c2d_user_port"`
+	// QoSMode is the quality of service mode in use for the connection.
+	QoSMode QoSMode `json:"qos_mode"`
+	// ARStreamFragmentSize is the maximum size, in bytes, of a single ARStream
+	// (v1) video fragment. The d2c video data buffer's MaxDataSize should be
+	// sized accordingly.
+	ARStreamFragmentSize int `json:"arstream_fragment_size"`
+	// ARStreamFragmentMaximumNumber is the maximum number of fragments that
+	// make up a single ARStream (v1) video frame. The d2c video data buffer's
+	// Size should be twice this value.
+	ARStreamFragmentMaximumNumber int `json:"arstream_fragment_maximum_number"`
+	// ARStreamMaxAckIntervalMillis is the maximum interval, in milliseconds, at
+	// which the client should acknowledge ARStream (v1) video fragments. A value
+	// of zero or less indicates acknowledgements need not be sent periodically.
+	ARStreamMaxAckIntervalMillis int `json:"arstream_max_ack_interval"`
+	// ARStream2ServerStreamPort is the UDP port from which the device sends
+	// ARStream2 (RTP) video data.
+	ARStream2ServerStreamPort int `json:"arstream2_server_stream_port"`
+	// ARStream2ServerControlPort is the UDP port on which the device sends and
+	// receives ARStream2 (RTCP) control data.
+	ARStream2ServerControlPort int `json:"arstream2_server_control_port"`
+}
+
+// ARStreamMaxAckInterval returns ARStreamMaxAckIntervalMillis as a
+// time.Duration.
+func (c ConnectionParams) ARStreamMaxAckInterval() time.Duration {
+	return time.Duration(c.ARStreamMaxAckIntervalMillis) * time.Millisecond
+}
@@ -10,11 +10,15 @@ import (
 	uuid "github.com/satori/go.uuid"
 )
 
-// headerBytesLength is the combined length of all ARNetworkAL frame headers
+// HeaderBytesLength is the combined length of all ARNetworkAL frame headers
 // in bytes.
-const headerBytesLength = 7
+const HeaderBytesLength = 7
 
-func defaultEncodeFrame(frame arnetworkal.Frame) ([]byte, error) {
+// EncodeFrame encodes an arnetworkal.Frame as a datagram. Most callers should
+// use the arnetworkal.FrameSender returned from Connect(...) instead, but this
+// is useful for implementing the device end of the protocol-- e.g. in a
+// simulator.
+func EncodeFrame(frame arnetworkal.Frame) ([]byte, error) {
 	log := log.WithField(
 		"uuid", frame.UUID,
 	).WithField(
@@ -33,7 +37,7 @@ func defaultEncodeFrame(frame arnetworkal.Frame) ([]byte, error) {
 	if err := binary.Write(
 		&sizeBuf,
 		binary.LittleEndian,
-		uint32(headerBytesLength+len(frame.Data)),
+		uint32(HeaderBytesLength+len(frame.Data)),
 	); err != nil {
 		return nil,
 			errors.Wrap(err, "error encoding arnetworkal frame as datagram")
@@ -44,7 +48,12 @@ func defaultEncodeFrame(frame arnetworkal.Frame) ([]byte, error) {
 	return datagramBuf.Bytes(), nil
 }
 
-func defaultDecodeDatagram(datagram []byte) ([]arnetworkal.Frame, error) {
+// DecodeDatagram decodes a datagram into one or more arnetworkal.Frames. This
+// is the inverse of EncodeFrame(...). Most callers should use the
+// arnetworkal.FrameReceiver returned from Connect(...) instead, but this is
+// useful for implementing the device end of the protocol-- e.g. in a
+// simulator.
+func DecodeDatagram(datagram []byte) ([]arnetworkal.Frame, error) {
 	log.Debug("decoding datagram")
 	data := datagram
 	frames := []arnetworkal.Frame{}
@@ -55,7 +64,7 @@ func defaultDecodeDatagram(datagram []byte) ([]arnetworkal.Frame, error) {
 			).Debug("extracted arnetworkal frames from datagram")
 			return frames, nil
 		}
-		if len(data) < headerBytesLength {
+		if len(data) < HeaderBytesLength {
 			// We are clearly dealing with a malformed datagram. We can't trust
 			// ANY of these frames. Discard them all and return an error.
 			return nil, errors.New("error decoding malformed datagram")
@@ -12,10 +12,15 @@ import (
 
 type frameReceiver struct {
 	conn *net.UDPConn
+	// readTimeout is how long the frameReceiver will wait to receive data before
+	// assuming a disconnection has occurred.
+	readTimeout time.Duration
 	// This function is overridable by unit tests
 	decodeDatagram     func(data []byte) ([]arnetworkal.Frame, error)
 	datagramBuffer     []byte
 	datagramBufferLock sync.Mutex
+	closed             bool
+	closedLock         sync.RWMutex
 }
 
 func (f *frameReceiver) Receive() ([]arnetworkal.Frame, error) {
@@ -23,14 +28,22 @@ func (f *frameReceiver) Receive() ([]arnetworkal.Frame, error) {
 	defer f.datagramBufferLock.Unlock()
 	log.Debug("reading / waiting for datagram from d2c connection")
 	if err :=
-		f.conn.SetReadDeadline(time.Now().Add(5 * time.Second)); err != nil {
+		f.conn.SetReadDeadline(time.Now().Add(f.readTimeout)); err != nil {
+		if f.isClosed() {
+			return nil, arnetworkal.ErrClosed
+		}
 		return nil, errors.Wrap(err, "error setting read deadline for datagram")
 	}
 	bytesRead, _, err := f.conn.ReadFromUDP(f.datagramBuffer) // nolint: errcheck
 	if err != nil {
+		if f.isClosed() {
+			return nil, arnetworkal.ErrClosed
+		}
 		if err, ok := err.(net.Error); ok && err.Timeout() {
-			// TODO: Fix this-- handle more elegantly and reconnect, if possible!
-			log.Fatal("detected a probable disconnection")
+			log.Warn("detected a probable disconnection")
+			return nil, &arnetworkal.DisconnectedError{
+				Timeout: f.readTimeout,
+			}
 		}
 		return nil,
 			errors.Wrap(err, "error receiving datagram from d2c connection")
@@ -46,6 +59,12 @@ func (f *frameReceiver) Receive() ([]arnetworkal.Frame, error) {
 }
 
 func (f *frameReceiver) Close() {
+	f.closedLock.Lock()
+	defer f.closedLock.Unlock()
+	if f.closed {
+		return
+	}
+	f.closed = true
 	if f.conn != nil {
 		log.Debug("closing d2c connection")
 		if err := f.conn.Close(); err != nil {
@@ -54,3 +73,9 @@ func (f *frameReceiver) Close() {
 		log.Debug("closed d2c connection")
 	}
 }
+
+func (f *frameReceiver) isClosed() bool {
+	f.closedLock.RLock()
+	defer f.closedLock.RUnlock()
+	return f.closed
+}
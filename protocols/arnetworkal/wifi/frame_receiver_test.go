@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/krancour/go-parrot/protocols/arnetworkal"
+	"github.com/krancour/go-parrot/protocols/internal/udp"
 	"github.com/phayes/freeport"
 	"github.com/stretchr/testify/require"
 )
@@ -13,7 +14,8 @@ func TestReceiveFrame(t *testing.T) {
 	d2cPort, err := freeport.GetFreePort() // nolint: vetshadows
 	require.NoError(t, err)
 	frameReceiver := &frameReceiver{
-		datagramBuffer: make([]byte, maxUDPDataBytes),
+		readTimeout:    DefaultReadTimeout,
+		datagramBuffer: make([]byte, udp.MaxDataBytes),
 	}
 	frameReceiver.conn, err = defaultEstablishD2CConnection(d2cPort)
 	require.NoError(t, err)
@@ -43,3 +45,17 @@ func TestReceiveFrame(t *testing.T) {
 	_, err = frameReceiver.Receive()
 	require.NoError(t, err)
 }
+
+func TestReceiveFrameAfterClose(t *testing.T) {
+	d2cPort, err := freeport.GetFreePort() // nolint: vetshadows
+	require.NoError(t, err)
+	frameReceiver := &frameReceiver{
+		readTimeout:    DefaultReadTimeout,
+		datagramBuffer: make([]byte, udp.MaxDataBytes),
+	}
+	frameReceiver.conn, err = defaultEstablishD2CConnection(d2cPort)
+	require.NoError(t, err)
+	frameReceiver.Close()
+	_, err = frameReceiver.Receive()
+	require.Equal(t, arnetworkal.ErrClosed, err)
+}
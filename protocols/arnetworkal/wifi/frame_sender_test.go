@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/krancour/go-parrot/protocols/arnetworkal"
+	"github.com/krancour/go-parrot/protocols/internal/udp"
 	"github.com/phayes/freeport"
 	"github.com/stretchr/testify/require"
 )
@@ -38,7 +39,7 @@ func TestSendFrame(t *testing.T) {
 	require.NoError(t, err)
 
 	// Verify the mock device received the data
-	datagram := make([]byte, maxUDPDataBytes)
+	datagram := make([]byte, udp.MaxDataBytes)
 	bytesRead, _, err := mockDeviceC2DConn.ReadFromUDP(datagram)
 	require.NoError(t, err)
 	require.Equal(t, "foo", string(datagram[:bytesRead]))
@@ -8,7 +8,7 @@ import (
 )
 
 func TestEncodeFrame(t *testing.T) {
-	datagram, err := defaultEncodeFrame(
+	datagram, err := EncodeFrame(
 		arnetworkal.Frame{
 			Type: arnetworkal.FrameTypeAck,
 			ID:   186,
@@ -116,7 +116,7 @@ func TestDecodeDatagram(t *testing.T) {
 	}
 	for _, testCase := range testCases {
 		t.Run(testCase.name, func(t *testing.T) {
-			frames, err := defaultDecodeDatagram(testCase.datagram)
+			frames, err := DecodeDatagram(testCase.datagram)
 			testCase.assert(t, frames, err)
 		})
 	}
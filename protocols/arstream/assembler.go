@@ -0,0 +1,107 @@
+package arstream
+
+import (
+	log "github.com/Sirupsen/logrus"
+)
+
+// assembler reassembles frames from fragments and tracks which fragments of
+// the current frame have been received. Only one frame is assembled at a time.
+// The arrival of a fragment belonging to a newer frame causes an incomplete
+// current frame to be skipped; fragments belonging to older frames are
+// dropped.
+type assembler struct {
+	haveFrame         bool
+	frameNumber       uint16
+	frameFlags        uint8
+	fragmentsPerFrame uint8
+	fragments         [][]byte
+	fragmentsReceived int
+	ack               ack
+	// delivered indicates whether the current frame has already been returned
+	// by push(...), so that duplicate fragments received afterward do not cause
+	// it to be returned again
+	delivered bool
+}
+
+// push processes a single fragment. It returns a bool indicating whether the
+// fragment should be acknowledged and, if the fragment completed the current
+// frame, the completed frame.
+func (a *assembler) push(f fragment) (bool, *Frame) {
+	log := log.WithField(
+		"frameNumber", f.frameNumber,
+	).WithField(
+		"fragmentNumber", f.fragmentNumber,
+	)
+	if a.haveFrame {
+		// Interpreting the difference as signed accounts for rollover
+		switch diff := int16(f.frameNumber - a.frameNumber); {
+		case diff < 0:
+			log.WithField(
+				"currentFrameNumber", a.frameNumber,
+			).Debug("dropping fragment of stale frame")
+			return false, nil
+		case diff > 0:
+			if !a.delivered {
+				log.WithField(
+					"skippedFrameNumber", a.frameNumber,
+				).WithField(
+					"fragmentsReceived", a.fragmentsReceived,
+				).WithField(
+					"fragmentsPerFrame", a.fragmentsPerFrame,
+				).Debug("skipping incomplete frame")
+			}
+			a.startFrame(f)
+		default:
+			if f.fragmentsPerFrame != a.fragmentsPerFrame {
+				log.WithField(
+					"fragmentsPerFrame", f.fragmentsPerFrame,
+				).WithField(
+					"expectedFragmentsPerFrame", a.fragmentsPerFrame,
+				).Warn("dropping fragment with inconsistent fragments per frame")
+				return false, nil
+			}
+		}
+	} else {
+		a.startFrame(f)
+	}
+	if a.ack.isSet(f.fragmentNumber) {
+		// Still acknowledge duplicates, since the device presumably didn't
+		// receive the previous acknowledgement
+		log.Debug("received duplicate fragment")
+		return true, nil
+	}
+	a.ack.set(f.fragmentNumber)
+	// Copy, since the fragment's data may be reused
+	a.fragments[f.fragmentNumber] = append([]byte{}, f.data...)
+	a.fragmentsReceived++
+	if a.fragmentsReceived < int(a.fragmentsPerFrame) {
+		return true, nil
+	}
+	a.delivered = true
+	size := 0
+	for _, fragment := range a.fragments {
+		size += len(fragment)
+	}
+	data := make([]byte, 0, size)
+	for _, fragment := range a.fragments {
+		data = append(data, fragment...)
+	}
+	frame := &Frame{
+		Number:       a.frameNumber,
+		IsFlushFrame: a.frameFlags&frameFlagFlush != 0,
+		Data:         data,
+	}
+	a.fragments = nil
+	return true, frame
+}
+
+func (a *assembler) startFrame(f fragment) {
+	*a = assembler{
+		haveFrame:         true,
+		frameNumber:       f.frameNumber,
+		frameFlags:        f.frameFlags,
+		fragmentsPerFrame: f.fragmentsPerFrame,
+		fragments:         make([][]byte, f.fragmentsPerFrame),
+		ack:               ack{frameNumber: f.frameNumber},
+	}
+}
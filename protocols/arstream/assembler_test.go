@@ -0,0 +1,168 @@
+package arstream
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAssembler(t *testing.T) {
+	// frag is a helper for concisely creating fragments whose data is their
+	// own fragment number
+	frag := func(
+		frameNumber uint16,
+		fragmentNumber uint8,
+		fragmentsPerFrame uint8,
+	) fragment {
+		return fragment{
+			frameNumber:       frameNumber,
+			fragmentNumber:    fragmentNumber,
+			fragmentsPerFrame: fragmentsPerFrame,
+			data:              []byte{fragmentNumber},
+		}
+	}
+	flush := func(f fragment) fragment {
+		f.frameFlags = frameFlagFlush
+		return f
+	}
+
+	testCases := []struct {
+		name           string
+		fragments      []fragment
+		expectedAcks   []bool
+		expectedFrames []Frame
+	}{
+
+		{
+			name: "fragments in order",
+			fragments: []fragment{
+				flush(frag(1, 0, 3)),
+				flush(frag(1, 1, 3)),
+				flush(frag(1, 2, 3)),
+				frag(2, 0, 1),
+			},
+			expectedAcks: []bool{true, true, true, true},
+			expectedFrames: []Frame{
+				{Number: 1, IsFlushFrame: true, Data: []byte{0, 1, 2}},
+				{Number: 2, Data: []byte{0}},
+			},
+		},
+
+		{
+			name: "fragments out of order",
+			fragments: []fragment{
+				frag(1, 2, 3),
+				frag(1, 0, 3),
+				frag(1, 1, 3),
+			},
+			expectedAcks: []bool{true, true, true},
+			expectedFrames: []Frame{
+				{Number: 1, Data: []byte{0, 1, 2}},
+			},
+		},
+
+		{
+			name: "duplicate fragments are acknowledged but not reassembled twice",
+			fragments: []fragment{
+				frag(1, 0, 2),
+				frag(1, 0, 2),
+				frag(1, 1, 2),
+				frag(1, 1, 2),
+			},
+			expectedAcks: []bool{true, true, true, true},
+			expectedFrames: []Frame{
+				{Number: 1, Data: []byte{0, 1}},
+			},
+		},
+
+		{
+			name: "incomplete frame is skipped",
+			fragments: []fragment{
+				frag(1, 0, 2),
+				frag(2, 0, 2),
+				frag(2, 1, 2),
+			},
+			expectedAcks: []bool{true, true, true},
+			expectedFrames: []Frame{
+				{Number: 2, Data: []byte{0, 1}},
+			},
+		},
+
+		{
+			name: "fragments of stale frames are dropped",
+			fragments: []fragment{
+				frag(2, 0, 2),
+				frag(1, 1, 2),
+				frag(2, 1, 2),
+			},
+			expectedAcks: []bool{true, false, true},
+			expectedFrames: []Frame{
+				{Number: 2, Data: []byte{0, 1}},
+			},
+		},
+
+		{
+			name: "fragments with inconsistent fragments per frame are dropped",
+			fragments: []fragment{
+				frag(1, 0, 2),
+				frag(1, 2, 3),
+				frag(1, 1, 2),
+			},
+			expectedAcks: []bool{true, false, true},
+			expectedFrames: []Frame{
+				{Number: 1, Data: []byte{0, 1}},
+			},
+		},
+
+		{
+			name: "frame number rollover",
+			fragments: []fragment{
+				frag(65535, 0, 1),
+				frag(0, 0, 1),
+				frag(65535, 0, 1),
+				frag(1, 0, 1),
+			},
+			expectedAcks: []bool{true, true, false, true},
+			expectedFrames: []Frame{
+				{Number: 65535, Data: []byte{0}},
+				{Number: 0, Data: []byte{0}},
+				{Number: 1, Data: []byte{0}},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			a := assembler{}
+			acks := []bool{}
+			frames := []Frame{}
+			for _, f := range testCase.fragments {
+				shouldAck, frame := a.push(f)
+				acks = append(acks, shouldAck)
+				if frame != nil {
+					frames = append(frames, *frame)
+				}
+			}
+			require.Equal(t, testCase.expectedAcks, acks)
+			require.Equal(t, testCase.expectedFrames, frames)
+		})
+	}
+}
+
+func TestAssemblerAck(t *testing.T) {
+	a := assembler{}
+	a.push(fragment{frameNumber: 7, fragmentNumber: 0, fragmentsPerFrame: 100})
+	a.push(fragment{frameNumber: 7, fragmentNumber: 99, fragmentsPerFrame: 100})
+	require.Equal(
+		t,
+		ack{
+			frameNumber:    7,
+			lowPacketsAck:  1,
+			highPacketsAck: 1 << 35,
+		},
+		a.ack,
+	)
+	// Starting a new frame resets the acknowledgement
+	a.push(fragment{frameNumber: 8, fragmentNumber: 1, fragmentsPerFrame: 2})
+	require.Equal(t, ack{frameNumber: 8, lowPacketsAck: 2}, a.ack)
+}
@@ -1,3 +1,21 @@
 package arstream
 
-// TODO: Document this
+// The arstream package implements the client end of the original ARStream
+// protocol, which older Parrot devices use to stream video. Unlike ARStream2,
+// which is built on RTP / RTCP, ARStream transports video over a pair of
+// arnetwork buffers. Each video frame is split into fragments, which the
+// device sends over a d2c buffer of type arnetworkal.FrameTypeLowLatencyData.
+// The client acknowledges receipt of fragments by sending a bitfield of all
+// fragments received so far for the current frame over a c2d buffer of the
+// same type. The device uses these acknowledgements to decide whether to
+// retransmit missing fragments.
+//
+// The size of each fragment and the maximum number of fragments per frame are
+// communicated by the device during connection negotiation. See the
+// ARStreamFragmentSize and ARStreamFragmentMaximumNumber fields of the
+// arnetworkal/wifi package's ConnectionParams.
+//
+// The package can also record H.264 video to a file, either as fragmented MP4
+// or as a raw Annex B byte stream with a sidecar timestamp index. See
+// Recorder. Recording works equally well with frames reassembled by a Reader
+// and with access units received over the ARStream2 protocol.
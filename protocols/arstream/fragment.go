@@ -0,0 +1,126 @@
+package arstream
+
+import (
+	"encoding/binary"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// D2CDataBufferID is the ID of the d2c buffer conventionally used for
+	// transporting video fragments.
+	D2CDataBufferID uint8 = 125
+	// C2DAckBufferID is the ID of the c2d buffer conventionally used for
+	// transporting acknowledgements of video fragments.
+	C2DAckBufferID uint8 = 13
+	// DefaultFragmentSize is the maximum number of bytes of video data carried
+	// by a single fragment, for use when the device has not communicated a
+	// fragment size during connection negotiation.
+	DefaultFragmentSize = 1000
+	// DefaultMaxFragmentsPerFrame is the maximum number of fragments a single
+	// frame may be split into, for use when the device has not communicated a
+	// maximum during connection negotiation.
+	DefaultMaxFragmentsPerFrame = 128
+	// FragmentHeaderSize is the number of bytes that precede the video data in
+	// every fragment.
+	FragmentHeaderSize = 5
+	// AckSize is the number of bytes in an encoded acknowledgement.
+	AckSize = 18
+	// maxFragmentsPerFrame is the maximum number of fragments per frame that
+	// acknowledgements are able to account for.
+	maxFragmentsPerFrame = 128
+	// frameFlagFlush is the bit of a fragment's frame flags that indicates the
+	// frame is a flush (i.e. key) frame, which can be decoded without reference
+	// to any prior frame.
+	frameFlagFlush uint8 = 0x01
+)
+
+// fragment represents a single fragment of a video frame.
+type fragment struct {
+	frameNumber       uint16
+	frameFlags        uint8
+	fragmentNumber    uint8
+	fragmentsPerFrame uint8
+	data              []byte
+}
+
+// parseFragment parses a fragment from arnetwork frame data. The fragment's
+// data references the given byte slice; it is not copied.
+func parseFragment(data []byte) (fragment, error) {
+	if len(data) < FragmentHeaderSize {
+		return fragment{}, errors.Errorf(
+			"fragment of %d bytes is shorter than the %d byte header",
+			len(data),
+			FragmentHeaderSize,
+		)
+	}
+	f := fragment{
+		frameNumber:       binary.LittleEndian.Uint16(data[0:2]),
+		frameFlags:        data[2],
+		fragmentNumber:    data[3],
+		fragmentsPerFrame: data[4],
+		data:              data[FragmentHeaderSize:],
+	}
+	if f.fragmentsPerFrame == 0 || f.fragmentsPerFrame > maxFragmentsPerFrame {
+		return fragment{}, errors.Errorf(
+			"invalid number of fragments per frame %d",
+			f.fragmentsPerFrame,
+		)
+	}
+	if f.fragmentNumber >= f.fragmentsPerFrame {
+		return fragment{}, errors.Errorf(
+			"fragment number %d is out of range for a frame of %d fragments",
+			f.fragmentNumber,
+			f.fragmentsPerFrame,
+		)
+	}
+	return f, nil
+}
+
+// encode encodes the fragment. This is the inverse of parseFragment(...) and
+// is useful for implementing the device end of the protocol-- e.g. in
+// tests.
+func (f fragment) encode() []byte {
+	data := make([]byte, FragmentHeaderSize, FragmentHeaderSize+len(f.data))
+	binary.LittleEndian.PutUint16(data[0:2], f.frameNumber)
+	data[2] = f.frameFlags
+	data[3] = f.fragmentNumber
+	data[4] = f.fragmentsPerFrame
+	return append(data, f.data...)
+}
+
+// ack represents an acknowledgement of all fragments received so far for a
+// single frame. Fragments 0 through 63 are represented by the bits of
+// lowPacketsAck; fragments 64 through 127 by the bits of highPacketsAck.
+type ack struct {
+	frameNumber    uint16
+	highPacketsAck uint64
+	lowPacketsAck  uint64
+}
+
+// set marks the given fragment as received.
+func (a *ack) set(fragmentNumber uint8) {
+	if fragmentNumber < 64 {
+		a.lowPacketsAck |= 1 << fragmentNumber
+	} else {
+		a.highPacketsAck |= 1 << (fragmentNumber - 64)
+	}
+}
+
+// isSet returns a bool indicating whether the given fragment has been marked
+// as received.
+func (a ack) isSet(fragmentNumber uint8) bool {
+	if fragmentNumber < 64 {
+		return a.lowPacketsAck&(1<<fragmentNumber) != 0
+	}
+	return a.highPacketsAck&(1<<(fragmentNumber-64)) != 0
+}
+
+// encode encodes the acknowledgement as it is sent to the device.
+func (a ack) encode() []byte {
+	data := make([]byte, AckSize)
+	binary.LittleEndian.PutUint16(data[0:2], a.frameNumber)
+	binary.LittleEndian.PutUint64(data[2:10], a.highPacketsAck)
+	binary.LittleEndian.PutUint64(data[10:18], a.lowPacketsAck)
+	return data
+}
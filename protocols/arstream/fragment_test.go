@@ -0,0 +1,113 @@
+package arstream
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFragment(t *testing.T) {
+	testCases := []struct {
+		name       string
+		data       []byte
+		assertions func(*testing.T, fragment, error)
+	}{
+
+		{
+			name: "truncated header",
+			data: []byte{0x01, 0x00, 0x00},
+			assertions: func(t *testing.T, _ fragment, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "shorter than the 5 byte header")
+			},
+		},
+
+		{
+			name: "zero fragments per frame",
+			data: []byte{0x01, 0x00, 0x00, 0x00, 0x00},
+			assertions: func(t *testing.T, _ fragment, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "invalid number of fragments")
+			},
+		},
+
+		{
+			name: "too many fragments per frame",
+			data: []byte{0x01, 0x00, 0x00, 0x00, 129},
+			assertions: func(t *testing.T, _ fragment, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "invalid number of fragments")
+			},
+		},
+
+		{
+			name: "fragment number out of range",
+			data: []byte{0x01, 0x00, 0x00, 0x03, 0x03},
+			assertions: func(t *testing.T, _ fragment, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "out of range")
+			},
+		},
+
+		{
+			name: "valid fragment",
+			data: []byte{0x02, 0x01, 0x01, 0x01, 0x03, 0xaa, 0xbb},
+			assertions: func(t *testing.T, f fragment, err error) {
+				require.NoError(t, err)
+				require.Equal(
+					t,
+					fragment{
+						frameNumber:       258,
+						frameFlags:        frameFlagFlush,
+						fragmentNumber:    1,
+						fragmentsPerFrame: 3,
+						data:              []byte{0xaa, 0xbb},
+					},
+					f,
+				)
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			f, err := parseFragment(testCase.data)
+			testCase.assertions(t, f, err)
+		})
+	}
+}
+
+func TestFragmentEncode(t *testing.T) {
+	f := fragment{
+		frameNumber:       65535,
+		fragmentNumber:    2,
+		fragmentsPerFrame: 4,
+		data:              []byte{0x01, 0x02},
+	}
+	parsed, err := parseFragment(f.encode())
+	require.NoError(t, err)
+	require.Equal(t, f, parsed)
+}
+
+func TestAck(t *testing.T) {
+	a := ack{frameNumber: 513}
+	a.set(0)
+	a.set(63)
+	a.set(64)
+	a.set(127)
+	require.True(t, a.isSet(0))
+	require.True(t, a.isSet(63))
+	require.True(t, a.isSet(64))
+	require.True(t, a.isSet(127))
+	require.False(t, a.isSet(1))
+	require.False(t, a.isSet(65))
+	require.Equal(
+		t,
+		[]byte{
+			0x01, 0x02, // Frame number
+			0x01, 0, 0, 0, 0, 0, 0, 0x80, // High packets ack
+			0x01, 0, 0, 0, 0, 0, 0, 0x80, // Low packets ack
+		},
+		a.encode(),
+	)
+}
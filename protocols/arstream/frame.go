@@ -0,0 +1,15 @@
+package arstream
+
+// Frame represents a single, fully reassembled frame of video.
+type Frame struct {
+	// Number is the frame number assigned by the device. Frame numbers
+	// increase by one with each frame and roll over after 65535.
+	Number uint16
+	// IsFlushFrame indicates whether the frame is a flush (i.e. key) frame,
+	// which can be decoded without reference to any prior frame. Following the
+	// loss of a frame, decoding should resume with the next flush frame.
+	IsFlushFrame bool
+	// Data is the frame's encoded video data. For the Bebop, this is an H.264
+	// access unit in Annex B format.
+	Data []byte
+}
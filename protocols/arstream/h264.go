@@ -0,0 +1,233 @@
+package arstream
+
+import (
+	"bytes"
+
+	"github.com/pkg/errors"
+)
+
+// H.264 NAL unit types relevant to recording.
+const (
+	naluTypeIDR uint8 = 5
+	naluTypeSPS uint8 = 7
+	naluTypePPS uint8 = 8
+	naluTypeAUD uint8 = 9
+)
+
+// annexBStartCode precedes every NAL unit in an H.264 Annex B byte stream.
+var annexBStartCode = []byte{0x00, 0x00, 0x00, 0x01}
+
+// splitAnnexB splits an H.264 Annex B byte stream into its NAL units, without
+// start codes. Both three and four byte start codes are recognized. The
+// returned NAL units refer to the given data; they are not copied.
+func splitAnnexB(data []byte) [][]byte {
+	nalus := [][]byte{}
+	start := -1
+	for i := 0; i+2 < len(data); {
+		if data[i] != 0 || data[i+1] != 0 || data[i+2] != 1 {
+			i++
+			continue
+		}
+		if start >= 0 {
+			nalus = appendNALU(nalus, data[start:i])
+		}
+		i += 3
+		start = i
+	}
+	if start >= 0 {
+		nalus = appendNALU(nalus, data[start:])
+	}
+	return nalus
+}
+
+// appendNALU appends a NAL unit to the given slice, after trimming the
+// trailing zero bytes that belong to the next NAL unit's (four byte) start
+// code. Empty NAL units are discarded.
+func appendNALU(nalus [][]byte, nalu []byte) [][]byte {
+	nalu = bytes.TrimRight(nalu, "\x00")
+	if len(nalu) == 0 {
+		return nalus
+	}
+	return append(nalus, nalu)
+}
+
+func naluType(nalu []byte) uint8 {
+	return nalu[0] & 0x1f
+}
+
+// spsInfo represents the portions of an H.264 sequence parameter set relevant
+// to recording.
+type spsInfo struct {
+	profile       uint8
+	compatibility uint8
+	level         uint8
+	width         int
+	height        int
+}
+
+// parseSPS parses an H.264 sequence parameter set NAL unit. See section 7.3.2.1
+// of the H.264 specification.
+func parseSPS(nalu []byte) (spsInfo, error) {
+	if len(nalu) < 4 || naluType(nalu) != naluTypeSPS {
+		return spsInfo{}, errors.New("nal unit is not a sequence parameter set")
+	}
+	info := spsInfo{
+		profile:       nalu[1],
+		compatibility: nalu[2],
+		level:         nalu[3],
+	}
+	r := &bitReader{data: removeEmulationPrevention(nalu[4:])}
+	r.readUE() // seq_parameter_set_id
+	chromaFormatIDC := uint(1)
+	switch info.profile {
+	case 100, 110, 122, 244, 44, 83, 86, 118, 128, 138, 139, 134, 135:
+		chromaFormatIDC = r.readUE()
+		if chromaFormatIDC == 3 {
+			r.readBits(1) // separate_colour_plane_flag
+		}
+		r.readUE()    // bit_depth_luma_minus8
+		r.readUE()    // bit_depth_chroma_minus8
+		r.readBits(1) // qpprime_y_zero_transform_bypass_flag
+		if r.readBits(1) == 1 {
+			scalingLists := 8
+			if chromaFormatIDC == 3 {
+				scalingLists = 12
+			}
+			for i := 0; i < scalingLists; i++ {
+				if r.readBits(1) == 0 {
+					continue
+				}
+				size := 16
+				if i >= 6 {
+					size = 64
+				}
+				r.skipScalingList(size)
+			}
+		}
+	}
+	r.readUE() // log2_max_frame_num_minus4
+	switch picOrderCntType := r.readUE(); picOrderCntType {
+	case 0:
+		r.readUE() // log2_max_pic_order_cnt_lsb_minus4
+	case 1:
+		r.readBits(1) // delta_pic_order_always_zero_flag
+		r.readSE()    // offset_for_non_ref_pic
+		r.readSE()    // offset_for_top_to_bottom_field
+		for i := r.readUE(); i > 0 && r.err == nil; i-- {
+			r.readSE() // offset_for_ref_frame
+		}
+	}
+	r.readUE()    // max_num_ref_frames
+	r.readBits(1) // gaps_in_frame_num_value_allowed_flag
+	widthInMBs := int(r.readUE()) + 1
+	heightInMapUnits := int(r.readUE()) + 1
+	frameMBSOnly := int(r.readBits(1))
+	if frameMBSOnly == 0 {
+		r.readBits(1) // mb_adaptive_frame_field_flag
+	}
+	r.readBits(1) // direct_8x8_inference_flag
+	var cropLeft, cropRight, cropTop, cropBottom int
+	if r.readBits(1) == 1 {
+		cropLeft = int(r.readUE())
+		cropRight = int(r.readUE())
+		cropTop = int(r.readUE())
+		cropBottom = int(r.readUE())
+	}
+	if r.err != nil {
+		return spsInfo{}, errors.Wrap(r.err, "error parsing sequence parameter set")
+	}
+	// Cropping is expressed in units that depend on chroma subsampling
+	cropUnitX, cropUnitY := 1, 2-frameMBSOnly
+	switch chromaFormatIDC {
+	case 1:
+		cropUnitX, cropUnitY = 2, 2*(2-frameMBSOnly)
+	case 2:
+		cropUnitX = 2
+	}
+	info.width = widthInMBs*16 - (cropLeft+cropRight)*cropUnitX
+	info.height = (2-frameMBSOnly)*heightInMapUnits*16 -
+		(cropTop+cropBottom)*cropUnitY
+	return info, nil
+}
+
+// removeEmulationPrevention removes the emulation prevention bytes (a 0x03
+// following two 0x00 bytes) from a NAL unit's payload.
+func removeEmulationPrevention(data []byte) []byte {
+	rbsp := make([]byte, 0, len(data))
+	zeros := 0
+	for _, b := range data {
+		if zeros >= 2 && b == 0x03 {
+			zeros = 0
+			continue
+		}
+		if b == 0 {
+			zeros++
+		} else {
+			zeros = 0
+		}
+		rbsp = append(rbsp, b)
+	}
+	return rbsp
+}
+
+// bitReader reads big endian bit fields and exponential-Golomb codes. Once an
+// attempt is made to read past the end of the data, err is set and all
+// subsequent reads return zero.
+type bitReader struct {
+	data []byte
+	pos  int // In bits
+	err  error
+}
+
+func (b *bitReader) readBits(n int) uint {
+	var val uint
+	for i := 0; i < n; i++ {
+		if b.err != nil {
+			return 0
+		}
+		if b.pos >= len(b.data)*8 {
+			b.err = errors.New("unexpected end of data")
+			return 0
+		}
+		bit := b.data[b.pos/8] >> uint(7-b.pos%8) & 1
+		val = val<<1 | uint(bit)
+		b.pos++
+	}
+	return val
+}
+
+// readUE reads an unsigned exponential-Golomb code.
+func (b *bitReader) readUE() uint {
+	leadingZeros := 0
+	for b.readBits(1) == 0 {
+		if b.err != nil || leadingZeros > 31 {
+			if b.err == nil {
+				b.err = errors.New("invalid exponential-golomb code")
+			}
+			return 0
+		}
+		leadingZeros++
+	}
+	return 1<<uint(leadingZeros) - 1 + b.readBits(leadingZeros)
+}
+
+// readSE reads a signed exponential-Golomb code.
+func (b *bitReader) readSE() int {
+	val := b.readUE()
+	if val%2 == 0 {
+		return -int(val / 2)
+	}
+	return int(val+1) / 2
+}
+
+func (b *bitReader) skipScalingList(size int) {
+	lastScale, nextScale := 8, 8
+	for i := 0; i < size && b.err == nil; i++ {
+		if nextScale != 0 {
+			nextScale = (lastScale + b.readSE() + 256) % 256
+		}
+		if nextScale != 0 {
+			lastScale = nextScale
+		}
+	}
+}
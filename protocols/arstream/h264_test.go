@@ -0,0 +1,177 @@
+package arstream
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// bitWriter is a helper for encoding bit fields and exponential-Golomb codes.
+type bitWriter struct {
+	data []byte
+	pos  int
+}
+
+func (b *bitWriter) writeBits(val uint, n int) {
+	for i := n - 1; i >= 0; i-- {
+		if b.pos%8 == 0 {
+			b.data = append(b.data, 0)
+		}
+		b.data[len(b.data)-1] |= byte(val>>uint(i)&1) << uint(7-b.pos%8)
+		b.pos++
+	}
+}
+
+func (b *bitWriter) writeUE(val uint) {
+	bits := 0
+	for v := val + 1; v > 1; v >>= 1 {
+		bits++
+	}
+	b.writeBits(0, bits)
+	b.writeBits(val+1, bits+1)
+}
+
+// encodeSPS is a helper for encoding a minimal sequence parameter set for
+// video of the given dimensions, which must be multiples of 16, except that
+// height may be cropped by cropBottom pairs of rows.
+func encodeSPS(profile uint8, width, height, cropBottom uint) []byte {
+	b := &bitWriter{}
+	b.writeUE(0) // seq_parameter_set_id
+	if profile == 100 {
+		b.writeUE(1)      // chroma_format_idc
+		b.writeUE(0)      // bit_depth_luma_minus8
+		b.writeUE(0)      // bit_depth_chroma_minus8
+		b.writeBits(0, 1) // qpprime_y_zero_transform_bypass_flag
+		b.writeBits(0, 1) // seq_scaling_matrix_present_flag
+	}
+	b.writeUE(0)             // log2_max_frame_num_minus4
+	b.writeUE(0)             // pic_order_cnt_type
+	b.writeUE(0)             // log2_max_pic_order_cnt_lsb_minus4
+	b.writeUE(1)             // max_num_ref_frames
+	b.writeBits(0, 1)        // gaps_in_frame_num_value_allowed_flag
+	b.writeUE(width/16 - 1)  // pic_width_in_mbs_minus1
+	b.writeUE(height/16 - 1) // pic_height_in_map_units_minus1
+	b.writeBits(1, 1)        // frame_mbs_only_flag
+	b.writeBits(1, 1)        // direct_8x8_inference_flag
+	if cropBottom > 0 {
+		b.writeBits(1, 1) // frame_cropping_flag
+		b.writeUE(0)
+		b.writeUE(0)
+		b.writeUE(0)
+		b.writeUE(cropBottom)
+	} else {
+		b.writeBits(0, 1) // frame_cropping_flag
+	}
+	b.writeBits(0, 1) // vui_parameters_present_flag
+	b.writeBits(1, 1) // rbsp_stop_one_bit
+	return append([]byte{0x67, profile, 0x00, 0x1f}, b.data...)
+}
+
+func TestSplitAnnexB(t *testing.T) {
+	testCases := []struct {
+		name          string
+		data          []byte
+		expectedNALUs [][]byte
+	}{
+
+		{
+			name:          "no start codes",
+			data:          []byte{0x65, 0x01},
+			expectedNALUs: [][]byte{},
+		},
+
+		{
+			name: "four byte start codes",
+			data: []byte{
+				0x00, 0x00, 0x00, 0x01, 0x67, 0x01,
+				0x00, 0x00, 0x00, 0x01, 0x68, 0x02,
+			},
+			expectedNALUs: [][]byte{{0x67, 0x01}, {0x68, 0x02}},
+		},
+
+		{
+			name: "mixed start codes",
+			data: []byte{
+				0x00, 0x00, 0x01, 0x09, 0xf0,
+				0x00, 0x00, 0x00, 0x01, 0x65, 0x00, 0x03, 0x01,
+			},
+			expectedNALUs: [][]byte{{0x09, 0xf0}, {0x65, 0x00, 0x03, 0x01}},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			require.Equal(t, testCase.expectedNALUs, splitAnnexB(testCase.data))
+		})
+	}
+}
+
+func TestParseSPS(t *testing.T) {
+	testCases := []struct {
+		name       string
+		nalu       []byte
+		assertions func(*testing.T, spsInfo, error)
+	}{
+
+		{
+			name: "not an sps",
+			nalu: []byte{0x68, 0x01, 0x02, 0x03},
+			assertions: func(t *testing.T, _ spsInfo, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "not a sequence parameter set")
+			},
+		},
+
+		{
+			name: "truncated sps",
+			nalu: []byte{0x67, 0x42, 0x00, 0x1f, 0x80},
+			assertions: func(t *testing.T, _ spsInfo, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "unexpected end of data")
+			},
+		},
+
+		{
+			name: "baseline profile",
+			nalu: encodeSPS(66, 1280, 720, 0),
+			assertions: func(t *testing.T, info spsInfo, err error) {
+				require.NoError(t, err)
+				require.Equal(
+					t,
+					spsInfo{profile: 66, level: 0x1f, width: 1280, height: 720},
+					info,
+				)
+			},
+		},
+
+		{
+			name: "high profile with cropping",
+			nalu: encodeSPS(100, 1920, 1088, 4),
+			assertions: func(t *testing.T, info spsInfo, err error) {
+				require.NoError(t, err)
+				require.Equal(
+					t,
+					spsInfo{profile: 100, level: 0x1f, width: 1920, height: 1080},
+					info,
+				)
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			info, err := parseSPS(testCase.nalu)
+			testCase.assertions(t, info, err)
+		})
+	}
+}
+
+func TestRemoveEmulationPrevention(t *testing.T) {
+	require.Equal(
+		t,
+		[]byte{0x00, 0x00, 0x01, 0x00, 0x00, 0x03},
+		removeEmulationPrevention(
+			[]byte{0x00, 0x00, 0x03, 0x01, 0x00, 0x00, 0x03, 0x03},
+		),
+	)
+}
@@ -0,0 +1,282 @@
+package arstream
+
+import (
+	"encoding/binary"
+)
+
+const (
+	// mp4Timescale is the number of media time units per second. This matches
+	// the 90 kHz clock conventionally used for H.264 video.
+	mp4Timescale = 90000
+	// mp4TrackID is the ID of the one and only (video) track.
+	mp4TrackID = 1
+	// Sample flags, as they appear in a track run. See ISO/IEC 14496-12,
+	// section 8.8.3.1.
+	mp4SampleFlagsSync    uint32 = 0x02000000 // Depends on no other sample
+	mp4SampleFlagsNonSync uint32 = 0x01010000 // Depends on others; not sync
+)
+
+// mp4Sample represents a single video sample (i.e. access unit) awaiting
+// inclusion in a movie fragment.
+type mp4Sample struct {
+	// data is the sample's NAL units, each preceded by its 32 bit length
+	data       []byte
+	decodeTime uint64
+	duration   uint32
+	isSync     bool
+}
+
+// mp4Box encodes an ISO base media file format box of the given type, whose
+// payload is the concatenation of the given parts.
+func mp4Box(boxType string, parts ...[]byte) []byte {
+	size := 8
+	for _, part := range parts {
+		size += len(part)
+	}
+	box := make([]byte, 8, size)
+	binary.BigEndian.PutUint32(box[0:4], uint32(size))
+	copy(box[4:8], boxType)
+	for _, part := range parts {
+		box = append(box, part...)
+	}
+	return box
+}
+
+// mp4FullBox encodes a box that begins with a version and flags.
+func mp4FullBox(
+	boxType string,
+	version uint8,
+	flags uint32,
+	parts ...[]byte,
+) []byte {
+	header := make([]byte, 4)
+	binary.BigEndian.PutUint32(header, uint32(version)<<24|flags&0xffffff)
+	return mp4Box(boxType, append([][]byte{header}, parts...)...)
+}
+
+// u16, u32, and u64 encode big endian integers.
+func u16(val uint16) []byte {
+	b := make([]byte, 2)
+	binary.BigEndian.PutUint16(b, val)
+	return b
+}
+
+func u32(val uint32) []byte {
+	b := make([]byte, 4)
+	binary.BigEndian.PutUint32(b, val)
+	return b
+}
+
+func u64(val uint64) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, val)
+	return b
+}
+
+// mp4Matrix is the identity transformation matrix used by mvhd and tkhd
+// boxes.
+var mp4Matrix = []byte{
+	0x00, 0x01, 0x00, 0x00, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0x00, 0x01, 0x00, 0x00, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0x40, 0x00, 0x00, 0x00,
+}
+
+// mp4InitSegment encodes the ftyp and moov boxes that begin a fragmented MP4
+// file. The sample entry is of type avc3, which permits sequence and picture
+// parameter sets to be carried in-band and to change over the course of the
+// stream. The given parameter sets are nonetheless included in the decoder
+// configuration record, since some players require them.
+func mp4InitSegment(sps []byte, pps []byte, info spsInfo) []byte {
+	ftyp := mp4Box(
+		"ftyp",
+		[]byte("iso5"),
+		u32(512),
+		[]byte("iso5iso6avc1mp41"),
+	)
+	mvhd := mp4FullBox(
+		"mvhd",
+		0,
+		0,
+		u32(0), // Creation time
+		u32(0), // Modification time
+		u32(mp4Timescale),
+		u32(0),           // Duration is unknown
+		u32(0x00010000),  // Rate 1.0
+		u16(0x0100),      // Volume 1.0
+		make([]byte, 10), // Reserved
+		mp4Matrix,
+		make([]byte, 24),  // Pre-defined
+		u32(mp4TrackID+1), // Next track ID
+	)
+	tkhd := mp4FullBox(
+		"tkhd",
+		0,
+		0x000003, // Track enabled and in movie
+		u32(0),   // Creation time
+		u32(0),   // Modification time
+		u32(mp4TrackID),
+		u32(0),          // Reserved
+		u32(0),          // Duration is unknown
+		make([]byte, 8), // Reserved
+		u16(0),          // Layer
+		u16(0),          // Alternate group
+		u16(0),          // Volume
+		u16(0),          // Reserved
+		mp4Matrix,
+		u32(uint32(info.width)<<16),
+		u32(uint32(info.height)<<16),
+	)
+	mdhd := mp4FullBox(
+		"mdhd",
+		0,
+		0,
+		u32(0), // Creation time
+		u32(0), // Modification time
+		u32(mp4Timescale),
+		u32(0),      // Duration is unknown
+		u16(0x55c4), // Language "und"
+		u16(0),      // Pre-defined
+	)
+	hdlr := mp4FullBox(
+		"hdlr",
+		0,
+		0,
+		u32(0), // Pre-defined
+		[]byte("vide"),
+		make([]byte, 12), // Reserved
+		[]byte("VideoHandler\x00"),
+	)
+	avcC := mp4Box(
+		"avcC",
+		[]byte{
+			1, // Configuration version
+			info.profile,
+			info.compatibility,
+			info.level,
+			0xff, // NAL unit lengths are expressed in four bytes
+			0xe1, // One sequence parameter set
+		},
+		u16(uint16(len(sps))),
+		sps,
+		[]byte{1}, // One picture parameter set
+		u16(uint16(len(pps))),
+		pps,
+	)
+	avc3 := mp4Box(
+		"avc3",
+		make([]byte, 6),  // Reserved
+		u16(1),           // Data reference index
+		make([]byte, 16), // Pre-defined and reserved
+		u16(uint16(info.width)),
+		u16(uint16(info.height)),
+		u32(0x00480000),  // Horizontal resolution 72 dpi
+		u32(0x00480000),  // Vertical resolution 72 dpi
+		u32(0),           // Reserved
+		u16(1),           // Frame count
+		make([]byte, 32), // Compressor name
+		u16(0x0018),      // Depth
+		u16(0xffff),      // Pre-defined
+		avcC,
+	)
+	stbl := mp4Box(
+		"stbl",
+		mp4FullBox("stsd", 0, 0, u32(1), avc3),
+		// Samples are described by movie fragments rather than by these tables
+		mp4FullBox("stts", 0, 0, u32(0)),
+		mp4FullBox("stsc", 0, 0, u32(0)),
+		mp4FullBox("stsz", 0, 0, u32(0), u32(0)),
+		mp4FullBox("stco", 0, 0, u32(0)),
+	)
+	minf := mp4Box(
+		"minf",
+		mp4FullBox("vmhd", 0, 1, make([]byte, 8)),
+		mp4Box(
+			"dinf",
+			mp4FullBox(
+				"dref",
+				0,
+				0,
+				u32(1),
+				mp4FullBox("url ", 0, 1), // Media is in this file
+			),
+		),
+		stbl,
+	)
+	mvex := mp4Box(
+		"mvex",
+		mp4FullBox(
+			"trex",
+			0,
+			0,
+			u32(mp4TrackID),
+			u32(1), // Default sample description index
+			u32(0), // Default sample duration
+			u32(0), // Default sample size
+			u32(0), // Default sample flags
+		),
+	)
+	moov := mp4Box(
+		"moov",
+		mvhd,
+		mp4Box("trak", tkhd, mp4Box("mdia", mdhd, hdlr, minf)),
+		mvex,
+	)
+	return append(ftyp, moov...)
+}
+
+// mp4Fragment encodes a moof box describing the given samples, followed by an
+// mdat box containing them.
+func mp4Fragment(sequenceNumber uint32, samples []mp4Sample) []byte {
+	const (
+		trunFlagDataOffset     = 0x000001
+		trunFlagSampleDuration = 0x000100
+		trunFlagSampleSize     = 0x000200
+		trunFlagSampleFlags    = 0x000400
+	)
+	trunEntries := make([]byte, 0, 12*len(samples))
+	mdatSize := 0
+	for _, sample := range samples {
+		trunEntries = append(trunEntries, u32(sample.duration)...)
+		trunEntries = append(trunEntries, u32(uint32(len(sample.data)))...)
+		if sample.isSync {
+			trunEntries = append(trunEntries, u32(mp4SampleFlagsSync)...)
+		} else {
+			trunEntries = append(trunEntries, u32(mp4SampleFlagsNonSync)...)
+		}
+		mdatSize += len(sample.data)
+	}
+	// The data offset is relative to the start of the moof box and can only be
+	// filled in once the size of the moof box is known
+	dataOffset := u32(0)
+	moof := mp4Box(
+		"moof",
+		mp4FullBox("mfhd", 0, 0, u32(sequenceNumber)),
+		mp4Box(
+			"traf",
+			// Base data offsets are relative to the start of the moof box
+			mp4FullBox("tfhd", 0, 0x020000, u32(mp4TrackID)),
+			mp4FullBox("tfdt", 1, 0, u64(samples[0].decodeTime)),
+			mp4FullBox(
+				"trun",
+				0,
+				trunFlagDataOffset|trunFlagSampleDuration|trunFlagSampleSize|
+					trunFlagSampleFlags,
+				u32(uint32(len(samples))),
+				dataOffset,
+				trunEntries,
+			),
+		),
+	)
+	// The data offset is the last field before the trun box's entries
+	binary.BigEndian.PutUint32(
+		moof[len(moof)-len(trunEntries)-4:],
+		uint32(len(moof)+8),
+	)
+	mdat := make([]byte, 0, 8+mdatSize)
+	mdat = append(mdat, u32(uint32(8+mdatSize))...)
+	mdat = append(mdat, "mdat"...)
+	for _, sample := range samples {
+		mdat = append(mdat, sample.data...)
+	}
+	return append(moof, mdat...)
+}
@@ -0,0 +1,156 @@
+package arstream
+
+import (
+	"sync"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/krancour/go-parrot/protocols/arnetwork"
+)
+
+// framesChSize is the buffer size of the channel over which frames are
+// delivered.
+const framesChSize = 30
+
+// Reader is the interface for a component that reassembles video frames
+// received over the ARStream protocol.
+type Reader interface {
+	// FramesCh returns a channel over which reassembled frames are delivered in
+	// order. Frames that could not be reassembled because fragments were lost
+	// are skipped. The same channel is returned on every call. Delivery is
+	// non-blocking; if the channel's buffer is full, newly reassembled frames
+	// are dropped. The channel is closed when the Reader's data channel is
+	// closed or when the Reader is closed.
+	FramesCh() <-chan Frame
+	// Close stops the Reader. Once Close returns, the Reader is guaranteed not
+	// to write to its ack channel again, so the arnetwork.Buffers the channel
+	// came from may safely be closed. It is safe to call this more than once.
+	Close()
+}
+
+type reader struct {
+	dataCh         <-chan arnetwork.Frame
+	ackCh          chan<- arnetwork.Frame
+	maxAckInterval time.Duration
+	framesCh       chan Frame
+	assembler      assembler
+	closeCh        chan struct{}
+	closeOnce      sync.Once
+	doneCh         chan struct{}
+}
+
+// NewReader returns a Reader that reassembles frames from fragments received
+// over dataCh and acknowledges fragments over ackCh. These are, respectively,
+// the output channel of a d2c buffer and the input channel of a c2d buffer,
+// both of type arnetworkal.FrameTypeLowLatencyData, as returned from
+// arnetwork.Buffers. The Reader never closes ackCh. Callers must not write to
+// ackCh themselves. Every fragment received is acknowledged. If
+// maxAckInterval is greater than zero, the acknowledgement for an incomplete
+// frame is also re-sent whenever that long passes without one being sent, so
+// the device learns of fragments whose acknowledgements were lost. The device
+// communicates this interval during connection negotiation. See the
+// ARStreamMaxAckInterval() function of the arnetworkal/wifi package's
+// ConnectionParams.
+func NewReader(
+	dataCh <-chan arnetwork.Frame,
+	ackCh chan<- arnetwork.Frame,
+	maxAckInterval time.Duration,
+) Reader {
+	r := &reader{
+		dataCh:         dataCh,
+		ackCh:          ackCh,
+		maxAckInterval: maxAckInterval,
+		framesCh:       make(chan Frame, framesChSize),
+		closeCh:        make(chan struct{}),
+		doneCh:         make(chan struct{}),
+	}
+	go r.receiveFragments()
+	return r
+}
+
+func (r *reader) FramesCh() <-chan Frame {
+	return r.framesCh
+}
+
+func (r *reader) Close() {
+	r.closeOnce.Do(func() {
+		close(r.closeCh)
+	})
+	<-r.doneCh
+}
+
+func (r *reader) receiveFragments() {
+	// ackTimer, while not nil, fires when the acknowledgement for the current
+	// frame is due to be re-sent
+	var ackTimer *time.Timer
+	stopAckTimer := func() {
+		if ackTimer != nil {
+			ackTimer.Stop()
+			ackTimer = nil
+		}
+	}
+	defer func() {
+		stopAckTimer()
+		close(r.framesCh)
+		close(r.doneCh)
+		log.Debug("arstream reader stopped")
+	}()
+	// sendAck sends the acknowledgement for the current frame. It returns false
+	// if the Reader was closed before the acknowledgement could be sent.
+	sendAck := func() bool {
+		select {
+		case r.ackCh <- arnetwork.Frame{
+			Data: r.assembler.ack.encode(),
+		}:
+		case <-r.closeCh:
+			return false
+		}
+		stopAckTimer()
+		// A complete frame needs no further acknowledgement
+		if r.maxAckInterval > 0 && !r.assembler.delivered {
+			ackTimer = time.NewTimer(r.maxAckInterval)
+		}
+		return true
+	}
+	for {
+		var ackTimerCh <-chan time.Time
+		if ackTimer != nil {
+			ackTimerCh = ackTimer.C
+		}
+		var netFrame arnetwork.Frame
+		var ok bool
+		select {
+		case netFrame, ok = <-r.dataCh:
+			if !ok {
+				return
+			}
+		case <-ackTimerCh:
+			ackTimer = nil
+			if !sendAck() {
+				return
+			}
+			continue
+		case <-r.closeCh:
+			return
+		}
+		f, err := parseFragment(netFrame.Data)
+		if err != nil {
+			log.Warnf("ignoring malformed arstream fragment: %s", err)
+			continue
+		}
+		shouldAck, frame := r.assembler.push(f)
+		if shouldAck && !sendAck() {
+			return
+		}
+		if frame == nil {
+			continue
+		}
+		select {
+		case r.framesCh <- *frame:
+		default:
+			log.WithField(
+				"frameNumber", frame.Number,
+			).Warn("frames channel is full; dropping frame")
+		}
+	}
+}
@@ -0,0 +1,164 @@
+package arstream
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/krancour/go-parrot/protocols/arnetwork"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReader(t *testing.T) {
+	dataCh := make(chan arnetwork.Frame)
+	ackCh := make(chan arnetwork.Frame, 10)
+	r := NewReader(dataCh, ackCh, 0)
+
+	fragments := []fragment{
+		{
+			frameNumber:       1,
+			frameFlags:        frameFlagFlush,
+			fragmentNumber:    0,
+			fragmentsPerFrame: 2,
+			data:              []byte{0x00, 0x00},
+		},
+		{
+			frameNumber:       1,
+			frameFlags:        frameFlagFlush,
+			fragmentNumber:    1,
+			fragmentsPerFrame: 2,
+			data:              []byte{0x00, 0x01},
+		},
+	}
+	// A malformed fragment should be ignored without being acknowledged
+	dataCh <- arnetwork.Frame{Data: []byte{0x01}}
+	for _, f := range fragments {
+		dataCh <- arnetwork.Frame{Data: f.encode()}
+	}
+
+	select {
+	case frame := <-r.FramesCh():
+		require.Equal(
+			t,
+			Frame{
+				Number:       1,
+				IsFlushFrame: true,
+				Data:         []byte{0x00, 0x00, 0x00, 0x01},
+			},
+			frame,
+		)
+	case <-time.After(time.Second):
+		require.FailNow(t, "timed out waiting for frame")
+	}
+
+	// One ack is expected per valid fragment
+	expectedAcks := []ack{
+		{frameNumber: 1, lowPacketsAck: 1},
+		{frameNumber: 1, lowPacketsAck: 3},
+	}
+	for _, expectedAck := range expectedAcks {
+		require.Equal(t, expectedAck.encode(), (<-ackCh).Data)
+	}
+
+	// Closing the data channel should cause the reader to close the frames
+	// channel, but not the ack channel, which it doesn't own
+	close(dataCh)
+	select {
+	case _, ok := <-r.FramesCh():
+		require.False(t, ok)
+	case <-time.After(time.Second):
+		require.FailNow(t, "timed out waiting for frames channel to close")
+	}
+	select {
+	case <-ackCh:
+		require.Fail(t, "ack channel was written to or closed, but should not have been")
+	default:
+	}
+}
+
+func TestReaderMaxAckInterval(t *testing.T) {
+	const maxAckInterval = 20 * time.Millisecond
+	dataCh := make(chan arnetwork.Frame)
+	ackCh := make(chan arnetwork.Frame, 10)
+	r := NewReader(dataCh, ackCh, maxAckInterval)
+	defer r.Close()
+	f := fragment{
+		frameNumber:       1,
+		fragmentNumber:    0,
+		fragmentsPerFrame: 2,
+		data:              []byte{0x00, 0x00},
+	}
+
+	// While the frame is incomplete, its ack should be re-sent periodically
+	dataCh <- arnetwork.Frame{Data: f.encode()}
+	expectedAck := ack{frameNumber: 1, lowPacketsAck: 1}.encode()
+	for i := 0; i < 3; i++ {
+		select {
+		case a := <-ackCh:
+			require.Equal(t, expectedAck, a.Data)
+		case <-time.After(10 * maxAckInterval):
+			require.FailNow(t, "timed out waiting for ack")
+		}
+	}
+
+	// Once the frame is complete, acks should stop
+	f.fragmentNumber = 1
+	dataCh <- arnetwork.Frame{Data: f.encode()}
+	<-r.FramesCh()
+	// Drain acks sent before the frame was completed
+	expectedAck = ack{frameNumber: 1, lowPacketsAck: 3}.encode()
+	for a := range ackCh {
+		if bytes.Equal(expectedAck, a.Data) {
+			break
+		}
+	}
+	select {
+	case <-ackCh:
+		require.Fail(t, "ack was re-sent for a complete frame")
+	case <-time.After(3 * maxAckInterval):
+	}
+}
+
+func TestReaderClose(t *testing.T) {
+	dataCh := make(chan arnetwork.Frame)
+	ackCh := make(chan arnetwork.Frame)
+	r := NewReader(dataCh, ackCh, 0)
+	r.Close()
+	_, ok := <-r.FramesCh()
+	require.False(t, ok, "frames channel should have been closed")
+	// Once closed, the reader no longer receives fragments, so it can't possibly
+	// write to the ack channel
+	select {
+	case dataCh <- arnetwork.Frame{}:
+		require.Fail(t, "reader received a fragment after it was closed")
+	default:
+	}
+	// Closing a second time should be a no-op
+	r.Close()
+}
+
+func TestReaderCloseWhileAckBlocked(t *testing.T) {
+	dataCh := make(chan arnetwork.Frame)
+	// An unbuffered ack channel that is never read from simulates a c2d buffer
+	// that has stopped draining
+	ackCh := make(chan arnetwork.Frame)
+	r := NewReader(dataCh, ackCh, 0)
+	dataCh <- arnetwork.Frame{
+		Data: fragment{
+			frameNumber:       1,
+			fragmentNumber:    0,
+			fragmentsPerFrame: 2,
+			data:              []byte{0x00},
+		}.encode(),
+	}
+	closedCh := make(chan struct{})
+	go func() {
+		r.Close()
+		close(closedCh)
+	}()
+	select {
+	case <-closedCh:
+	case <-time.After(time.Second):
+		require.FailNow(t, "timed out waiting for Close to return")
+	}
+}
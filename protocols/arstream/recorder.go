@@ -0,0 +1,388 @@
+package arstream
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/pkg/errors"
+)
+
+// RecordingFormat represents a file format video can be recorded in.
+type RecordingFormat int
+
+const (
+	// RecordingFormatMP4 records video as a fragmented MP4 file. Fragments are
+	// written periodically, so if recording is interrupted abruptly (e.g. the
+	// process is killed), the file remains playable up to the last complete
+	// fragment.
+	RecordingFormatMP4 RecordingFormat = iota
+	// RecordingFormatAnnexB records video as a raw H.264 Annex B byte stream,
+	// accompanied by a sidecar index file recording the byte offset, size, and
+	// presentation time of every access unit. Raw byte streams carry no timing
+	// information of their own. The index file's path is the recording's path
+	// with AnnexBIndexSuffix appended.
+	RecordingFormatAnnexB
+)
+
+const (
+	// DefaultFragmentDuration is the approximate duration of video contained in
+	// each fragment of an MP4 recording, unless otherwise specified.
+	DefaultFragmentDuration = time.Second
+	// AnnexBIndexSuffix is appended to the path of an Annex B recording to
+	// obtain the path of its index file.
+	AnnexBIndexSuffix = ".index.csv"
+	// defaultSampleDuration is the duration, in units of the 90 kHz clock,
+	// assumed for a sample whose duration cannot be inferred from the
+	// timestamp of the sample that follows it. This corresponds to 30 frames
+	// per second.
+	defaultSampleDuration = mp4Timescale / 30
+)
+
+// RecorderConfig represents the configuration of a Recorder. The zero value
+// for any field other than Path selects a sensible default.
+type RecorderConfig struct {
+	// Path is the path of the file to record to. If the file exists, it is
+	// truncated.
+	Path string
+	// Format is the file format to record in. If not specified,
+	// RecordingFormatMP4 is used.
+	Format RecordingFormat
+	// FragmentDuration is the approximate duration of video contained in each
+	// fragment of an MP4 recording. Shorter fragments lose less video if
+	// recording is interrupted abruptly. If zero, DefaultFragmentDuration is
+	// used. This is ignored for other formats.
+	FragmentDuration time.Duration
+}
+
+// Recorder is the interface for a component that records H.264 video to a
+// file. Recording begins with the first access unit containing an IDR slice,
+// for which sequence and picture parameter sets are known; access units
+// written before then are discarded, since they could not be decoded.
+// Sequence and picture parameter sets are retained in the recorded stream, so
+// changes to them (e.g. a change in resolution) are faithfully recorded.
+type Recorder interface {
+	// WriteAccessUnit records a single access unit, encoded as an H.264 Annex B
+	// byte stream, with the given presentation time. Presentation times are
+	// relative to an arbitrary reference point, but must be consistent from
+	// one access unit to the next. Frames delivered by a Reader may be passed
+	// to this function directly. So may access units received over the
+	// ARStream2 protocol, using their AnnexB() and PTS values.
+	WriteAccessUnit(annexB []byte, pts time.Duration) error
+	// Close writes any buffered video and closes the file(s) being recorded
+	// to. Subsequent attempts to write access units will return an error.
+	Close() error
+}
+
+// RecordFrames records frames received over the given channel, as returned
+// from a Reader's FramesCh() function, until the channel is closed. Since
+// ARStream frames carry no timestamps, each frame's presentation time is the
+// time at which it was received. The recorder is closed when the channel is
+// closed.
+func RecordFrames(recorder Recorder, framesCh <-chan Frame) error {
+	var start time.Time
+	for frame := range framesCh {
+		now := time.Now()
+		if start.IsZero() {
+			start = now
+		}
+		err := recorder.WriteAccessUnit(frame.Data, now.Sub(start))
+		if err != nil {
+			recorder.Close() // nolint: errcheck
+			return err
+		}
+	}
+	return recorder.Close()
+}
+
+// sampleWriter is the interface for the format-specific portion of a
+// recorder. The recorder guarantees the first sample written is a sync sample
+// preceded by the sequence and picture parameter sets in effect.
+type sampleWriter interface {
+	writeSample(nalus [][]byte, pts time.Duration, isSync bool) error
+	close() error
+}
+
+type recorder struct {
+	writer sampleWriter
+	// sps and pps are the most recently received parameter sets
+	sps     []byte
+	pps     []byte
+	started bool
+	closed  bool
+	lock    sync.Mutex
+}
+
+// NewRecorder returns a Recorder that records to the file specified by the
+// given configuration.
+func NewRecorder(config RecorderConfig) (Recorder, error) {
+	if config.FragmentDuration == 0 {
+		config.FragmentDuration = DefaultFragmentDuration
+	}
+	file, err := os.Create(config.Path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error creating file %s", config.Path)
+	}
+	r := &recorder{}
+	switch config.Format {
+	case RecordingFormatMP4:
+		r.writer = &mp4Writer{
+			file:             file,
+			fragmentDuration: durationToTicks(config.FragmentDuration),
+		}
+	case RecordingFormatAnnexB:
+		indexPath := config.Path + AnnexBIndexSuffix
+		indexFile, err := os.Create(indexPath)
+		if err != nil {
+			file.Close() // nolint: errcheck
+			return nil, errors.Wrapf(err, "error creating file %s", indexPath)
+		}
+		if _, err := fmt.Fprintln(
+			indexFile,
+			"offset,size,pts_us,keyframe",
+		); err != nil {
+			file.Close()      // nolint: errcheck
+			indexFile.Close() // nolint: errcheck
+			return nil, errors.Wrapf(err, "error writing to file %s", indexPath)
+		}
+		r.writer = &annexBWriter{
+			file:      file,
+			indexFile: indexFile,
+		}
+	default:
+		file.Close() // nolint: errcheck
+		return nil,
+			errors.Errorf("unsupported recording format %d", config.Format)
+	}
+	log.WithField(
+		"path", config.Path,
+	).WithField(
+		"format", config.Format,
+	).Debug("started recording")
+	return r, nil
+}
+
+func (r *recorder) WriteAccessUnit(annexB []byte, pts time.Duration) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.closed {
+		return errors.New("recorder is closed")
+	}
+	nalus := [][]byte{}
+	var hasSPS, hasPPS, isSync bool
+	for _, nalu := range splitAnnexB(annexB) {
+		switch naluType(nalu) {
+		case naluTypeAUD:
+			// Access unit delimiters are superfluous once access units have been
+			// separated, and are not permitted inside MP4 samples
+			continue
+		case naluTypeSPS:
+			r.sps = nalu
+			hasSPS = true
+		case naluTypePPS:
+			r.pps = nalu
+			hasPPS = true
+		case naluTypeIDR:
+			isSync = true
+		}
+		nalus = append(nalus, nalu)
+	}
+	if len(nalus) == 0 {
+		return nil
+	}
+	if !r.started {
+		if !isSync || r.sps == nil || r.pps == nil {
+			log.Debug("waiting for a decodable access unit to begin recording")
+			return nil
+		}
+		r.started = true
+	}
+	// Ensure every sync sample carries the parameter sets in effect so that
+	// decoding can begin at any sync sample
+	if isSync && !hasPPS {
+		nalus = append([][]byte{r.pps}, nalus...)
+	}
+	if isSync && !hasSPS {
+		nalus = append([][]byte{r.sps}, nalus...)
+	}
+	return r.writer.writeSample(nalus, pts, isSync)
+}
+
+func (r *recorder) Close() error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.closed {
+		return nil
+	}
+	r.closed = true
+	log.Debug("stopped recording")
+	return r.writer.close()
+}
+
+// durationToTicks converts a duration to units of the 90 kHz clock.
+func durationToTicks(d time.Duration) int64 {
+	return int64(d * mp4Timescale / time.Second)
+}
+
+// annexBWriter writes samples to a raw H.264 Annex B byte stream and records
+// the timing of each sample in an index file.
+type annexBWriter struct {
+	file      *os.File
+	indexFile *os.File
+	offset    int64
+}
+
+func (a *annexBWriter) writeSample(
+	nalus [][]byte,
+	pts time.Duration,
+	isSync bool,
+) error {
+	data := []byte{}
+	for _, nalu := range nalus {
+		data = append(data, annexBStartCode...)
+		data = append(data, nalu...)
+	}
+	if _, err := a.file.Write(data); err != nil {
+		return errors.Wrap(err, "error writing access unit")
+	}
+	if _, err := fmt.Fprintf(
+		a.indexFile,
+		"%d,%d,%d,%t\n",
+		a.offset,
+		len(data),
+		pts/time.Microsecond,
+		isSync,
+	); err != nil {
+		return errors.Wrap(err, "error writing access unit index")
+	}
+	a.offset += int64(len(data))
+	return nil
+}
+
+func (a *annexBWriter) close() error {
+	err := a.file.Close()
+	if indexErr := a.indexFile.Close(); err == nil {
+		err = indexErr
+	}
+	return errors.Wrap(err, "error closing recording")
+}
+
+// mp4Writer writes samples to a fragmented MP4 file. The duration of each
+// sample is only known once the following sample arrives, so the most
+// recently written sample is always held back. Samples are written as a
+// movie fragment once the duration of those held back exceeds the fragment
+// duration.
+type mp4Writer struct {
+	file             *os.File
+	fragmentDuration int64
+	initialized      bool
+	// pending are samples not yet written to the file. The duration of the
+	// last pending sample is not yet known.
+	pending        []mp4Sample
+	pendingTicks   int64
+	lastPTS        int64
+	nextDecodeTime uint64
+	lastDuration   uint32
+	sequenceNumber uint32
+}
+
+func (m *mp4Writer) writeSample(
+	nalus [][]byte,
+	pts time.Duration,
+	isSync bool,
+) error {
+	if !m.initialized {
+		if err := m.writeInitSegment(nalus); err != nil {
+			return err
+		}
+		m.initialized = true
+		m.lastDuration = defaultSampleDuration
+	}
+	ticks := durationToTicks(pts)
+	if len(m.pending) > 0 {
+		duration := ticks - m.lastPTS
+		if duration <= 0 {
+			// Timestamps didn't advance; make a reasonable guess
+			duration = int64(m.lastDuration)
+		}
+		m.completeLastSample(uint32(duration))
+		if m.pendingTicks >= m.fragmentDuration {
+			if err := m.writeFragment(m.pending); err != nil {
+				return err
+			}
+			m.pending = nil
+			m.pendingTicks = 0
+		}
+	}
+	m.lastPTS = ticks
+	data := []byte{}
+	for _, nalu := range nalus {
+		data = append(data, u32(uint32(len(nalu)))...)
+		data = append(data, nalu...)
+	}
+	m.pending = append(
+		m.pending,
+		mp4Sample{
+			data:       data,
+			decodeTime: m.nextDecodeTime,
+			isSync:     isSync,
+		},
+	)
+	return nil
+}
+
+// writeInitSegment writes the ftyp and moov boxes, as derived from the
+// parameter sets found in the given NAL units. The recorder guarantees the
+// first sample includes these.
+func (m *mp4Writer) writeInitSegment(nalus [][]byte) error {
+	var sps, pps []byte
+	for _, nalu := range nalus {
+		switch naluType(nalu) {
+		case naluTypeSPS:
+			sps = nalu
+		case naluTypePPS:
+			pps = nalu
+		}
+	}
+	info, err := parseSPS(sps)
+	if err != nil {
+		return err
+	}
+	if _, err := m.file.Write(mp4InitSegment(sps, pps, info)); err != nil {
+		return errors.Wrap(err, "error writing mp4 initialization segment")
+	}
+	return nil
+}
+
+func (m *mp4Writer) completeLastSample(duration uint32) {
+	m.pending[len(m.pending)-1].duration = duration
+	m.pendingTicks += int64(duration)
+	m.nextDecodeTime += uint64(duration)
+	m.lastDuration = duration
+}
+
+func (m *mp4Writer) writeFragment(samples []mp4Sample) error {
+	m.sequenceNumber++
+	_, err := m.file.Write(mp4Fragment(m.sequenceNumber, samples))
+	if err != nil {
+		return errors.Wrap(err, "error writing mp4 fragment")
+	}
+	return nil
+}
+
+func (m *mp4Writer) close() error {
+	var err error
+	if len(m.pending) > 0 {
+		// The last sample's duration can't be known, so assume it's the same as
+		// the sample before it
+		m.completeLastSample(m.lastDuration)
+		err = m.writeFragment(m.pending)
+		m.pending = nil
+	}
+	if closeErr := m.file.Close(); err == nil && closeErr != nil {
+		err = errors.Wrap(closeErr, "error closing recording")
+	}
+	return err
+}
@@ -0,0 +1,264 @@
+package arstream
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// mp4TestBox represents a box parsed from an MP4 file by parseMP4Boxes.
+type mp4TestBox struct {
+	boxType string
+	payload []byte
+}
+
+// parseMP4Boxes is a helper for parsing the boxes contained in the given data.
+// It fails the test if the data does not consist entirely of complete boxes.
+func parseMP4Boxes(t *testing.T, data []byte) []mp4TestBox {
+	boxes := []mp4TestBox{}
+	for len(data) > 0 {
+		require.True(t, len(data) >= 8, "box header is truncated")
+		size := int(binary.BigEndian.Uint32(data[0:4]))
+		require.True(t, size >= 8 && size <= len(data), "box is truncated")
+		boxes = append(
+			boxes,
+			mp4TestBox{
+				boxType: string(data[4:8]),
+				payload: data[8:size],
+			},
+		)
+		data = data[size:]
+	}
+	return boxes
+}
+
+func mp4BoxTypes(boxes []mp4TestBox) []string {
+	boxTypes := []string{}
+	for _, box := range boxes {
+		boxTypes = append(boxTypes, box.boxType)
+	}
+	return boxTypes
+}
+
+// parseTrun is a helper for extracting sample durations and sizes from the
+// trun box of a moof box written by mp4Fragment(...).
+func parseTrun(t *testing.T, moof mp4TestBox) ([]uint32, []uint32) {
+	traf := parseMP4Boxes(t, moof.payload)[1]
+	trun := parseMP4Boxes(t, traf.payload)[2]
+	require.Equal(t, "trun", trun.boxType)
+	count := int(binary.BigEndian.Uint32(trun.payload[4:8]))
+	durations := []uint32{}
+	sizes := []uint32{}
+	entries := trun.payload[12:]
+	for i := 0; i < count; i++ {
+		durations = append(
+			durations,
+			binary.BigEndian.Uint32(entries[12*i:12*i+4]),
+		)
+		sizes = append(sizes, binary.BigEndian.Uint32(entries[12*i+4:12*i+8]))
+	}
+	return durations, sizes
+}
+
+func annexB(nalus ...[]byte) []byte {
+	data := []byte{}
+	for _, nalu := range nalus {
+		data = append(data, annexBStartCode...)
+		data = append(data, nalu...)
+	}
+	return data
+}
+
+func TestMP4Recorder(t *testing.T) {
+	dir, err := ioutil.TempDir("", "arstream")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "video.mp4")
+
+	r, err := NewRecorder(
+		RecorderConfig{
+			Path:             path,
+			FragmentDuration: 50 * time.Millisecond,
+		},
+	)
+	require.NoError(t, err)
+
+	aud := []byte{0x09, 0xf0}
+	sps := encodeSPS(66, 1280, 720, 0)
+	pps := []byte{0x68, 0xce, 0x38, 0x80}
+	idr := []byte{0x65, 0x88, 0x84}
+	nonIDR := []byte{0x41, 0x9a, 0x02}
+	newSPS := encodeSPS(66, 640, 480, 0)
+
+	frameInterval := 40 * time.Millisecond
+	accessUnits := [][]byte{
+		// Not decodable; should be discarded
+		annexB(aud, nonIDR),
+		annexB(aud, sps, pps, idr),
+		annexB(aud, nonIDR),
+		annexB(aud, nonIDR),
+		// Parameter sets change
+		annexB(aud, newSPS, pps, idr),
+		annexB(aud, nonIDR),
+	}
+	for i, au := range accessUnits {
+		require.NoError(
+			t,
+			r.WriteAccessUnit(au, time.Duration(i)*frameInterval),
+		)
+	}
+
+	// Even before the recorder is closed, the file should consist entirely of
+	// complete boxes
+	data, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	boxes := parseMP4Boxes(t, data)
+	require.Equal(
+		t,
+		[]string{"ftyp", "moov", "moof", "mdat", "moof", "mdat"},
+		mp4BoxTypes(boxes),
+	)
+
+	require.NoError(t, r.Close())
+	require.Error(t, r.WriteAccessUnit(annexB(nonIDR), time.Second))
+
+	data, err = ioutil.ReadFile(path)
+	require.NoError(t, err)
+	boxes = parseMP4Boxes(t, data)
+	require.Equal(
+		t,
+		[]string{"ftyp", "moov", "moof", "mdat", "moof", "mdat", "moof", "mdat"},
+		mp4BoxTypes(boxes),
+	)
+
+	// The sample entry should reflect the first sequence parameter set
+	require.True(t, bytes.Contains(boxes[1].payload, []byte("avc3")))
+	require.True(t, bytes.Contains(boxes[1].payload, sps))
+
+	// Access unit delimiters should have been removed from the samples and the
+	// new parameter sets should have been retained in-band
+	lengthPrefixed := func(nalus ...[]byte) []byte {
+		data := []byte{}
+		for _, nalu := range nalus {
+			data = append(data, u32(uint32(len(nalu)))...)
+			data = append(data, nalu...)
+		}
+		return data
+	}
+	expectedSamples := [][]byte{
+		lengthPrefixed(sps, pps, idr),
+		lengthPrefixed(nonIDR),
+		lengthPrefixed(nonIDR),
+		lengthPrefixed(newSPS, pps, idr),
+		lengthPrefixed(nonIDR),
+	}
+	ticksPerFrame := uint32(durationToTicks(frameInterval))
+	samples := [][]byte{}
+	durations := []uint32{}
+	for i := 2; i < len(boxes); i += 2 {
+		fragmentDurations, sizes := parseTrun(t, boxes[i])
+		durations = append(durations, fragmentDurations...)
+		mdat := boxes[i+1].payload
+		for _, size := range sizes {
+			samples = append(samples, mdat[:size])
+			mdat = mdat[size:]
+		}
+	}
+	require.Equal(t, expectedSamples, samples)
+	require.Equal(
+		t,
+		[]uint32{
+			ticksPerFrame,
+			ticksPerFrame,
+			ticksPerFrame,
+			ticksPerFrame,
+			ticksPerFrame, // Assumed to be the same as the previous sample
+		},
+		durations,
+	)
+}
+
+func TestAnnexBRecorder(t *testing.T) {
+	dir, err := ioutil.TempDir("", "arstream")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "video.h264")
+
+	r, err := NewRecorder(
+		RecorderConfig{
+			Path:   path,
+			Format: RecordingFormatAnnexB,
+		},
+	)
+	require.NoError(t, err)
+
+	sps := encodeSPS(66, 1280, 720, 0)
+	pps := []byte{0x68, 0xce, 0x38, 0x80}
+	idr := []byte{0x65, 0x88, 0x84}
+	nonIDR := []byte{0x41, 0x9a, 0x02}
+
+	// The first access unit isn't decodable, but its parameter sets should be
+	// retained and included with the next IDR slice
+	require.NoError(t, r.WriteAccessUnit(annexB(sps, pps, nonIDR), 0))
+	require.NoError(
+		t,
+		r.WriteAccessUnit(annexB(idr), 40*time.Millisecond),
+	)
+	require.NoError(
+		t,
+		r.WriteAccessUnit(annexB(nonIDR), 80*time.Millisecond),
+	)
+	require.NoError(t, r.Close())
+
+	expectedData := annexB(sps, pps, idr, nonIDR)
+	data, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, expectedData, data)
+
+	index, err := ioutil.ReadFile(path + AnnexBIndexSuffix)
+	require.NoError(t, err)
+	firstSize := len(annexB(sps, pps, idr))
+	require.Equal(
+		t,
+		"offset,size,pts_us,keyframe\n"+
+			fmt.Sprintf("0,%d,40000,true\n", firstSize)+
+			fmt.Sprintf("%d,7,80000,false\n", firstSize),
+		string(index),
+	)
+}
+
+func TestRecordFrames(t *testing.T) {
+	dir, err := ioutil.TempDir("", "arstream")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "video.h264")
+
+	r, err := NewRecorder(
+		RecorderConfig{
+			Path:   path,
+			Format: RecordingFormatAnnexB,
+		},
+	)
+	require.NoError(t, err)
+
+	sps := encodeSPS(66, 1280, 720, 0)
+	pps := []byte{0x68, 0xce, 0x38, 0x80}
+	idr := []byte{0x65, 0x88, 0x84}
+	framesCh := make(chan Frame, 1)
+	framesCh <- Frame{Number: 1, IsFlushFrame: true, Data: annexB(sps, pps, idr)}
+	close(framesCh)
+	require.NoError(t, RecordFrames(r, framesCh))
+
+	// The recorder should have been closed
+	require.Error(t, r.WriteAccessUnit(annexB(idr), time.Second))
+	data, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, annexB(sps, pps, idr), data)
+}
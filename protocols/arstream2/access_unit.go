@@ -0,0 +1,43 @@
+package arstream2
+
+import (
+	"bytes"
+	"time"
+)
+
+// annexBStartCode precedes every NAL unit in an H.264 Annex B byte stream.
+var annexBStartCode = []byte{0x00, 0x00, 0x00, 0x01}
+
+// AccessUnit represents a single H.264 access unit-- i.e. all the NAL units
+// that make up one frame of video.
+type AccessUnit struct {
+	// NALUs are the NAL units that make up the access unit, without start codes.
+	NALUs [][]byte
+	// Timestamp is the RTP timestamp of the access unit, expressed in units of
+	// the 90 kHz H.264 RTP clock.
+	Timestamp uint32
+	// PTS is the presentation time of the access unit relative to the first
+	// access unit received. Unlike Timestamp, this accounts for Timestamp
+	// rollover.
+	PTS time.Duration
+	// NTPTime is the wall clock time at which the device captured the access
+	// unit, as derived from RTCP sender reports. It is the zero time if no
+	// sender report has been received yet.
+	NTPTime time.Time
+	// Complete indicates whether every packet of the access unit was received.
+	// Incomplete access units may still be decodable, but are likely to produce
+	// visual artifacts.
+	Complete bool
+}
+
+// AnnexB returns the access unit encoded as an H.264 Annex B byte stream--
+// i.e. each NAL unit preceded by a start code. This is the format most
+// decoders and players expect.
+func (a AccessUnit) AnnexB() []byte {
+	buf := &bytes.Buffer{}
+	for _, nalu := range a.NALUs {
+		buf.Write(annexBStartCode)
+		buf.Write(nalu)
+	}
+	return buf.Bytes()
+}
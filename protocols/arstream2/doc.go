@@ -0,0 +1,14 @@
+package arstream2
+
+// The arstream2 package implements the client end of the ARStream2 protocol,
+// which Parrot devices (e.g. the Bebop 2) use to stream H.264 video. Unlike
+// the original ARStream protocol, which transports video over arnetwork
+// buffers, ARStream2 is built on standard RTP / RTCP over UDP.
+//
+// The ports the client listens on for RTP and RTCP data are communicated to
+// the device during connection negotiation. See the StreamPort and
+// StreamControlPort fields of the arnetworkal/wifi package's ConnectOptions.
+//
+// The package can also relay the stream to any number of local viewers over
+// RTSP, as an alternative to pointing a single viewer at a static SDP file.
+// See Relay.
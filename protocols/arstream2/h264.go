@@ -0,0 +1,192 @@
+package arstream2
+
+import (
+	"encoding/binary"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+)
+
+// H.264 NAL unit types relevant to RTP depacketization and to describing a
+// stream. See RFC 6184.
+const (
+	naluTypeSPS   uint8 = 7
+	naluTypePPS   uint8 = 8
+	naluTypeSTAPA uint8 = 24
+	naluTypeFUA   uint8 = 28
+	// h264ClockRate is the frequency of the H.264 RTP clock in Hz.
+	h264ClockRate = 90000
+)
+
+// depacketizer reassembles H.264 access units from RTP packets carrying
+// single NAL unit, STAP-A, and FU-A payloads, as described by RFC 6184.
+// Packets are expected to be pushed in the order they were received; packets
+// that arrive out of order are treated as lost.
+type depacketizer struct {
+	// The access unit currently being assembled
+	au       *AccessUnit
+	auTS     uint32
+	haveSeq  bool
+	lastSeq  uint16
+	haveTS   bool
+	lastTS   uint32
+	extTS    int64 // Timestamp relative to the first, accounting for rollover
+	fuBuf    []byte
+	fuActive bool
+}
+
+// push processes a single RTP packet. Any access units completed as a result
+// are returned.
+func (d *depacketizer) push(pkt rtpPacket) []AccessUnit {
+	aus := []AccessUnit{}
+	if d.haveSeq && pkt.seq != d.lastSeq+1 {
+		log.WithField(
+			"expectedSeq", d.lastSeq+1,
+		).WithField(
+			"seq", pkt.seq,
+		).Debug("rtp packet(s) lost")
+		// Whatever was being assembled is now missing data
+		d.fuActive = false
+		d.fuBuf = nil
+		if d.au != nil {
+			d.au.Complete = false
+		}
+	}
+	d.haveSeq = true
+	d.lastSeq = pkt.seq
+	// A change in timestamp marks the start of a new access unit, even if the
+	// packet bearing the marker bit for the previous access unit was lost.
+	if d.au != nil && pkt.timestamp != d.auTS {
+		aus = append(aus, d.flush())
+	}
+	if d.au == nil {
+		d.startAccessUnit(pkt.timestamp)
+	}
+	d.handlePayload(pkt.payload)
+	if pkt.marker {
+		aus = append(aus, d.flush())
+	}
+	return aus
+}
+
+func (d *depacketizer) startAccessUnit(timestamp uint32) {
+	if d.haveTS {
+		// Interpreting the difference as signed accounts for rollover
+		d.extTS += int64(int32(timestamp - d.lastTS))
+	}
+	d.haveTS = true
+	d.lastTS = timestamp
+	d.auTS = timestamp
+	d.au = &AccessUnit{
+		Timestamp: timestamp,
+		PTS:       time.Duration(d.extTS) * time.Second / h264ClockRate,
+		Complete:  true,
+	}
+}
+
+func (d *depacketizer) handlePayload(payload []byte) {
+	if len(payload) == 0 {
+		return
+	}
+	switch naluType := naluType(payload); {
+	case naluType >= 1 && naluType < naluTypeSTAPA:
+		d.addNALU(payload)
+	case naluType == naluTypeSTAPA:
+		d.handleSTAPA(payload[1:])
+	case naluType == naluTypeFUA:
+		d.handleFUA(payload)
+	default:
+		log.WithField(
+			"type", naluType,
+		).Warn("unsupported h264 rtp payload type")
+		d.au.Complete = false
+	}
+}
+
+// handleSTAPA unpacks a single-time aggregation packet.
+func (d *depacketizer) handleSTAPA(data []byte) {
+	nalus, ok := splitSTAPA(data)
+	for _, nalu := range nalus {
+		d.addNALU(nalu)
+	}
+	if !ok {
+		d.au.Complete = false
+	}
+}
+
+// handleFUA accumulates a fragmentation unit. A NAL unit fragmented across
+// several packets is only added to the access unit once its last fragment has
+// been received.
+func (d *depacketizer) handleFUA(payload []byte) {
+	if len(payload) < 2 {
+		d.au.Complete = false
+		return
+	}
+	indicator := payload[0]
+	header := payload[1]
+	isStart := header&0x80 != 0
+	isEnd := header&0x40 != 0
+	if isStart {
+		// Reconstruct the original NAL unit header from the indicator's F and
+		// NRI bits and the header's type bits
+		d.fuBuf = append([]byte{indicator&0xe0 | header&0x1f}, payload[2:]...)
+		d.fuActive = true
+	} else if d.fuActive {
+		d.fuBuf = append(d.fuBuf, payload[2:]...)
+	} else {
+		// The start of this NAL unit was lost
+		d.au.Complete = false
+		return
+	}
+	if isEnd {
+		d.addNALU(d.fuBuf)
+		d.fuBuf = nil
+		d.fuActive = false
+	}
+}
+
+func (d *depacketizer) addNALU(nalu []byte) {
+	// Copy, since the packet's payload buffer may be reused
+	d.au.NALUs = append(d.au.NALUs, append([]byte{}, nalu...))
+}
+
+// flush returns the access unit being assembled and resets state in
+// preparation for the next access unit.
+func (d *depacketizer) flush() AccessUnit {
+	if d.fuActive {
+		// The last fragment of a NAL unit never arrived
+		d.au.Complete = false
+		d.fuActive = false
+		d.fuBuf = nil
+	}
+	au := *d.au
+	d.au = nil
+	return au
+}
+
+func naluType(nalu []byte) uint8 {
+	return nalu[0] & 0x1f
+}
+
+// splitSTAPA splits the payload of a single-time aggregation packet, less its
+// STAP-A header, into the NAL units it carries, each of which is preceded by
+// its 16 bit size. The returned boolean is false if the payload is malformed,
+// in which case only the NAL units preceding the malformed portion are
+// returned. The returned NAL units refer to the given data; they are not
+// copied.
+func splitSTAPA(data []byte) ([][]byte, bool) {
+	nalus := [][]byte{}
+	for len(data) > 0 {
+		if len(data) < 2 {
+			return nalus, false
+		}
+		size := int(binary.BigEndian.Uint16(data[0:2]))
+		data = data[2:]
+		if size == 0 || size > len(data) {
+			return nalus, false
+		}
+		nalus = append(nalus, data[:size])
+		data = data[size:]
+	}
+	return nalus, true
+}
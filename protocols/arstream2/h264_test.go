@@ -0,0 +1,131 @@
+package arstream2
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDepacketizer(t *testing.T) {
+	sps := []byte{0x67, 0x01, 0x02}
+	pps := []byte{0x68, 0x03}
+	idr := []byte{0x65, 0x10, 0x11, 0x12, 0x13, 0x14}
+	// STAP-A carrying the SPS and PPS
+	stapA := append(
+		append([]byte{0x78, 0x00, byte(len(sps))}, sps...),
+		append([]byte{0x00, byte(len(pps))}, pps...)...,
+	)
+	// The IDR slice fragmented into three FU-A packets
+	fuA1 := []byte{0x7c, 0x85, 0x10, 0x11}
+	fuA2 := []byte{0x7c, 0x05, 0x12}
+	fuA3 := []byte{0x7c, 0x45, 0x13, 0x14}
+	nonIDR := []byte{0x41, 0x20}
+
+	testCases := []struct {
+		name       string
+		pkts       []rtpPacket
+		assertions func(*testing.T, []AccessUnit)
+	}{
+
+		{
+			name: "single nal unit, stap-a, and fu-a",
+			pkts: []rtpPacket{
+				{seq: 1, timestamp: 1000, payload: stapA},
+				{seq: 2, timestamp: 1000, payload: fuA1},
+				{seq: 3, timestamp: 1000, payload: fuA2},
+				{seq: 4, timestamp: 1000, payload: fuA3, marker: true},
+				{seq: 5, timestamp: 4000, payload: nonIDR, marker: true},
+			},
+			assertions: func(t *testing.T, aus []AccessUnit) {
+				require.Len(t, aus, 2)
+				require.Equal(
+					t,
+					AccessUnit{
+						NALUs:     [][]byte{sps, pps, idr},
+						Timestamp: 1000,
+						Complete:  true,
+					},
+					aus[0],
+				)
+				require.Equal(
+					t,
+					AccessUnit{
+						NALUs:     [][]byte{nonIDR},
+						Timestamp: 4000,
+						PTS:       3000 * time.Second / h264ClockRate,
+						Complete:  true,
+					},
+					aus[1],
+				)
+				require.Equal(
+					t,
+					append(
+						append(
+							append(append([]byte{0, 0, 0, 1}, sps...), 0, 0, 0, 1),
+							pps...,
+						),
+						append([]byte{0, 0, 0, 1}, idr...)...,
+					),
+					aus[0].AnnexB(),
+				)
+			},
+		},
+
+		{
+			name: "lost fragment",
+			pkts: []rtpPacket{
+				{seq: 1, timestamp: 1000, payload: nonIDR},
+				{seq: 2, timestamp: 1000, payload: fuA1},
+				// seq 3 is lost
+				{seq: 4, timestamp: 1000, payload: fuA3, marker: true},
+			},
+			assertions: func(t *testing.T, aus []AccessUnit) {
+				require.Len(t, aus, 1)
+				require.False(t, aus[0].Complete)
+				// The fragmented NAL unit should have been discarded
+				require.Equal(t, [][]byte{nonIDR}, aus[0].NALUs)
+			},
+		},
+
+		{
+			name: "lost marker",
+			pkts: []rtpPacket{
+				{seq: 1, timestamp: 1000, payload: nonIDR},
+				// seq 2, bearing the marker, is lost
+				{seq: 3, timestamp: 4000, payload: nonIDR, marker: true},
+			},
+			assertions: func(t *testing.T, aus []AccessUnit) {
+				require.Len(t, aus, 2)
+				require.Equal(t, uint32(1000), aus[0].Timestamp)
+				require.False(t, aus[0].Complete)
+				require.Equal(t, uint32(4000), aus[1].Timestamp)
+				require.True(t, aus[1].Complete)
+			},
+		},
+
+		{
+			name: "timestamp rollover",
+			pkts: []rtpPacket{
+				{seq: 65535, timestamp: 0xffffff00, payload: nonIDR, marker: true},
+				{seq: 0, timestamp: 0x00000100, payload: nonIDR, marker: true},
+			},
+			assertions: func(t *testing.T, aus []AccessUnit) {
+				require.Len(t, aus, 2)
+				require.True(t, aus[1].Complete)
+				require.Equal(t, 0x200*time.Second/h264ClockRate, aus[1].PTS)
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			d := &depacketizer{}
+			aus := []AccessUnit{}
+			for _, pkt := range testCase.pkts {
+				aus = append(aus, d.push(pkt)...)
+			}
+			testCase.assertions(t, aus)
+		})
+	}
+}
@@ -0,0 +1,311 @@
+package arstream2
+
+import (
+	"math/rand"
+	"net"
+	"sync"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/krancour/go-parrot/protocols/internal/udp"
+	"github.com/pkg/errors"
+)
+
+const (
+	// DefaultStreamPort is the UDP port on which the Receiver listens for RTP
+	// data, unless otherwise specified.
+	DefaultStreamPort = 55004
+	// DefaultControlPort is the UDP port on which the Receiver sends and
+	// receives RTCP data, unless otherwise specified.
+	DefaultControlPort = 55005
+	// accessUnitsChSize is the buffer size of the channel over which access
+	// units are delivered.
+	accessUnitsChSize = 30
+	// receiverReportInterval is the interval at which RTCP receiver reports are
+	// sent to the device.
+	receiverReportInterval = time.Second
+)
+
+// ReceiverConfig represents the configuration of a Receiver. The zero value
+// for any field selects a sensible default.
+type ReceiverConfig struct {
+	// StreamPort is the UDP port on which to listen for RTP data. If zero,
+	// DefaultStreamPort is used.
+	StreamPort int
+	// ControlPort is the UDP port on which to send and receive RTCP data. If
+	// zero, DefaultControlPort is used.
+	ControlPort int
+}
+
+// Receiver is the interface for a component that receives an ARStream2 video
+// stream.
+type Receiver interface {
+	// AccessUnitsCh returns a channel over which received H.264 access units
+	// are delivered in the order they were received. The same channel is
+	// returned on every call. Delivery is non-blocking; if the channel's buffer
+	// is full, newly completed access units are dropped. The channel is closed
+	// when the Receiver is closed.
+	AccessUnitsCh() <-chan AccessUnit
+	// Close stops receiving and releases the Receiver's ports.
+	Close()
+}
+
+type receiver struct {
+	rtpConn       *net.UDPConn
+	rtcpConn      *net.UDPConn
+	ssrc          uint32
+	accessUnitsCh chan AccessUnit
+	// The following are accessed by multiple goroutines and are protected by
+	// lock
+	stats        rtpStats
+	lastSR       *senderReport
+	lastSRTime   time.Time
+	serverAddr   *net.UDPAddr
+	lock         sync.Mutex
+	stopCh       chan struct{}
+	wg           sync.WaitGroup
+	closeOnce    sync.Once
+	depacketizer depacketizer
+	// packetHandler, if not nil, is invoked with every well formed RTP packet
+	// received, both raw and parsed. Neither refers to data that remains valid
+	// after packetHandler returns.
+	packetHandler func(data []byte, pkt rtpPacket)
+}
+
+// NewReceiver returns a Receiver that is listening for an ARStream2 video
+// stream.
+func NewReceiver(config ReceiverConfig) (Receiver, error) {
+	return newReceiver(config, nil)
+}
+
+// newReceiver returns a receiver that is listening for an ARStream2 video
+// stream and that invokes the given packetHandler, if any, with every RTP
+// packet received.
+func newReceiver(
+	config ReceiverConfig,
+	packetHandler func(data []byte, pkt rtpPacket),
+) (*receiver, error) {
+	if config.StreamPort == 0 {
+		config.StreamPort = DefaultStreamPort
+	}
+	if config.ControlPort == 0 {
+		config.ControlPort = DefaultControlPort
+	}
+	rtpConn, err := net.ListenUDP("udp", &net.UDPAddr{Port: config.StreamPort})
+	if err != nil {
+		return nil, errors.Wrap(err, "error listening for rtp data")
+	}
+	rtcpConn, err :=
+		net.ListenUDP("udp", &net.UDPAddr{Port: config.ControlPort})
+	if err != nil {
+		rtpConn.Close() // nolint: errcheck
+		return nil, errors.Wrap(err, "error listening for rtcp data")
+	}
+	r := &receiver{
+		rtpConn:       rtpConn,
+		rtcpConn:      rtcpConn,
+		ssrc:          rand.Uint32(),
+		accessUnitsCh: make(chan AccessUnit, accessUnitsChSize),
+		stopCh:        make(chan struct{}),
+		packetHandler: packetHandler,
+	}
+	r.wg.Add(3)
+	go r.receiveRTP()
+	go r.receiveRTCP()
+	go r.sendReceiverReports()
+	log.WithField(
+		"streamPort", config.StreamPort,
+	).WithField(
+		"controlPort", config.ControlPort,
+	).Debug("arstream2 receiver started")
+	return r, nil
+}
+
+func (r *receiver) AccessUnitsCh() <-chan AccessUnit {
+	return r.accessUnitsCh
+}
+
+func (r *receiver) Close() {
+	r.closeOnce.Do(func() {
+		log.Debug("closing arstream2 receiver")
+		close(r.stopCh)
+		if err := r.rtpConn.Close(); err != nil {
+			log.Errorf("error closing rtp connection: %s", err)
+		}
+		if err := r.rtcpConn.Close(); err != nil {
+			log.Errorf("error closing rtcp connection: %s", err)
+		}
+		r.wg.Wait()
+		close(r.accessUnitsCh)
+		log.Debug("closed arstream2 receiver")
+	})
+}
+
+func (r *receiver) isStopped() bool {
+	select {
+	case <-r.stopCh:
+		return true
+	default:
+		return false
+	}
+}
+
+func (r *receiver) receiveRTP() {
+	defer r.wg.Done()
+	buf := make([]byte, udp.MaxDataBytes)
+	for {
+		bytesRead, _, err := r.rtpConn.ReadFromUDP(buf)
+		if err != nil {
+			if !r.isStopped() {
+				log.Errorf("error receiving rtp data: %s", err)
+			}
+			return
+		}
+		pkt, err := parseRTPPacket(buf[:bytesRead])
+		if err != nil {
+			log.Warnf("ignoring malformed rtp packet: %s", err)
+			continue
+		}
+		if r.packetHandler != nil {
+			r.packetHandler(buf[:bytesRead], pkt)
+		}
+		r.lock.Lock()
+		r.stats.update(pkt)
+		lastSR := r.lastSR
+		r.lock.Unlock()
+		for _, au := range r.depacketizer.push(pkt) {
+			if len(au.NALUs) == 0 {
+				continue
+			}
+			if lastSR != nil {
+				au.NTPTime = lastSR.timeOf(au.Timestamp)
+			}
+			select {
+			case r.accessUnitsCh <- au:
+			default:
+				log.Warn("access units channel is full; dropping access unit")
+			}
+		}
+	}
+}
+
+func (r *receiver) receiveRTCP() {
+	defer r.wg.Done()
+	buf := make([]byte, udp.MaxDataBytes)
+	for {
+		bytesRead, addr, err := r.rtcpConn.ReadFromUDP(buf)
+		if err != nil {
+			if !r.isStopped() {
+				log.Errorf("error receiving rtcp data: %s", err)
+			}
+			return
+		}
+		srs, err := parseSenderReports(buf[:bytesRead])
+		if err != nil {
+			log.Warnf("ignoring malformed rtcp packet: %s", err)
+			continue
+		}
+		if len(srs) == 0 {
+			continue
+		}
+		r.lock.Lock()
+		r.lastSR = &srs[len(srs)-1]
+		r.lastSRTime = time.Now()
+		r.serverAddr = addr
+		r.lock.Unlock()
+	}
+}
+
+// sendReceiverReports periodically reports reception statistics to the
+// device. Reports are sent to the address sender reports are received from,
+// so none are sent until the first sender report is received.
+func (r *receiver) sendReceiverReports() {
+	defer r.wg.Done()
+	ticker := time.NewTicker(receiverReportInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+		case <-r.stopCh:
+			return
+		}
+		r.lock.Lock()
+		serverAddr := r.serverAddr
+		stats := r.stats.report()
+		if r.lastSR != nil {
+			stats.lastSR = uint32(r.lastSR.ntpTimestamp >> 16)
+			stats.delaySinceLastSR = time.Since(r.lastSRTime)
+		}
+		r.lock.Unlock()
+		if serverAddr == nil {
+			continue
+		}
+		if _, err := r.rtcpConn.WriteToUDP(
+			encodeReceiverReport(r.ssrc, stats),
+			serverAddr,
+		); err != nil {
+			log.Errorf("error sending rtcp receiver report: %s", err)
+		}
+	}
+}
+
+// rtpStats tracks reception statistics as described by RFC 3550, appendix A.
+type rtpStats struct {
+	initialized bool
+	ssrc        uint32
+	baseSeq     uint16
+	maxSeq      uint16
+	cycles      uint32
+	received    uint32
+	// expectedPrior and receivedPrior are as of the previous report
+	expectedPrior uint32
+	receivedPrior uint32
+}
+
+func (r *rtpStats) update(pkt rtpPacket) {
+	if !r.initialized || pkt.ssrc != r.ssrc {
+		*r = rtpStats{
+			initialized: true,
+			ssrc:        pkt.ssrc,
+			baseSeq:     pkt.seq,
+			maxSeq:      pkt.seq,
+		}
+	}
+	r.received++
+	// Interpreting the difference as signed distinguishes packets that are
+	// merely late from those that indicate a sequence number rollover
+	if delta := int16(pkt.seq - r.maxSeq); delta > 0 {
+		if pkt.seq < r.maxSeq {
+			r.cycles++
+		}
+		r.maxSeq = pkt.seq
+	}
+}
+
+func (r *rtpStats) report() receptionStats {
+	if !r.initialized {
+		return receptionStats{}
+	}
+	extHighestSeq := r.cycles<<16 | uint32(r.maxSeq)
+	expected := extHighestSeq - uint32(r.baseSeq) + 1
+	var lost uint32
+	if expected > r.received {
+		lost = expected - r.received
+	}
+	expectedInterval := expected - r.expectedPrior
+	receivedInterval := r.received - r.receivedPrior
+	r.expectedPrior = expected
+	r.receivedPrior = r.received
+	var fractionLost uint8
+	if expectedInterval > 0 && expectedInterval > receivedInterval {
+		fractionLost = uint8(
+			(expectedInterval - receivedInterval) << 8 / expectedInterval,
+		)
+	}
+	return receptionStats{
+		sourceSSRC:     r.ssrc,
+		extHighestSeq:  extHighestSeq,
+		cumulativeLost: lost,
+		fractionLost:   fractionLost,
+	}
+}
@@ -0,0 +1,88 @@
+package arstream2
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/krancour/go-parrot/protocols/internal/udp"
+	"github.com/phayes/freeport"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReceiver(t *testing.T) {
+	ports, err := freeport.GetFreePorts(2)
+	require.NoError(t, err)
+	r, err := NewReceiver(
+		ReceiverConfig{
+			StreamPort:  ports[0],
+			ControlPort: ports[1],
+		},
+	)
+	require.NoError(t, err)
+	defer r.Close()
+
+	// Mock out the device's end of the stream
+	serverConn, err := net.ListenUDP(
+		"udp",
+		&net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)},
+	)
+	require.NoError(t, err)
+	defer serverConn.Close()
+	sr := senderReport{
+		ssrc:         42,
+		ntpTimestamp: uint64(1539561600+ntpEpochOffset) << 32,
+		rtpTimestamp: 1000,
+	}
+	_, err = serverConn.WriteToUDP(
+		encodeSenderReport(sr),
+		&net.UDPAddr{IP: net.IPv4(127, 0, 0, 1), Port: ports[1]},
+	)
+	require.NoError(t, err)
+	// Give the sender report a moment to be processed
+	<-time.After(50 * time.Millisecond)
+	_, err = serverConn.WriteToUDP(
+		encodeRTPPacket(
+			rtpPacket{
+				marker:    true,
+				seq:       1,
+				timestamp: 1900,
+				ssrc:      42,
+				payload:   []byte{0x41, 0x20},
+			},
+		),
+		&net.UDPAddr{IP: net.IPv4(127, 0, 0, 1), Port: ports[0]},
+	)
+	require.NoError(t, err)
+
+	select {
+	case au := <-r.AccessUnitsCh():
+		require.Equal(t, [][]byte{{0x41, 0x20}}, au.NALUs)
+		require.Equal(t, uint32(1900), au.Timestamp)
+		require.True(t, au.Complete)
+		require.True(
+			t,
+			sr.ntpTime().Add(10*time.Millisecond).Equal(au.NTPTime),
+		)
+	case <-time.After(time.Second):
+		require.Fail(t, "timed out waiting for access unit")
+	}
+
+	// A receiver report should be sent to where the sender report came from
+	require.NoError(
+		t,
+		serverConn.SetReadDeadline(
+			time.Now().Add(2*receiverReportInterval),
+		),
+	)
+	buf := make([]byte, udp.MaxDataBytes)
+	bytesRead, _, err := serverConn.ReadFromUDP(buf)
+	require.NoError(t, err)
+	require.Equal(t, 32, bytesRead)
+	require.Equal(t, rtcpTypeRR, buf[1])
+
+	// Closing should close the access units channel
+	r.Close()
+	_, ok := <-r.AccessUnitsCh()
+	require.False(t, ok)
+}
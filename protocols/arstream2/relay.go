@@ -0,0 +1,442 @@
+package arstream2
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"math/rand"
+	"net"
+	"strings"
+	"sync"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/krancour/go-parrot/protocols/internal/udp"
+	"github.com/pkg/errors"
+)
+
+const (
+	// DefaultRelayRTSPAddr is the address on which the Relay serves RTSP,
+	// unless otherwise specified.
+	DefaultRelayRTSPAddr = ":8554"
+	// defaultPayloadType is the RTP payload type advertised to viewers until
+	// the first packet is received from the device. This is the payload type
+	// used by Parrot devices.
+	defaultPayloadType uint8 = 96
+	// viewerPacketsChSize is the number of packets that may be queued for
+	// delivery to each viewer. If a viewer falls further behind than this,
+	// packets are dropped for that viewer only.
+	viewerPacketsChSize = 256
+)
+
+// RelayConfig represents the configuration of a Relay. The zero value for any
+// field selects a sensible default.
+type RelayConfig struct {
+	// ReceiverConfig configures the Receiver the Relay uses to receive the
+	// stream from the device.
+	ReceiverConfig
+	// RTSPAddr is the TCP address on which to serve RTSP. If empty,
+	// DefaultRelayRTSPAddr is used.
+	RTSPAddr string
+}
+
+// Relay is the interface for a Receiver that also re-serves the RTP packets
+// it receives over RTSP so that any number of viewers (e.g. VLC, ffmpeg, or
+// GStreamer) may watch the stream simultaneously. Viewers may request RTP over
+// UDP or interleaved with RTSP over TCP. RTP packets are relayed unaltered.
+// Any URL served by the Relay describes the same stream. Reception statistics
+// are reported to the device by the Receiver; RTCP data from viewers is
+// discarded. Closing the Relay also disconnects all viewers.
+type Relay interface {
+	Receiver
+	// Addr returns the address on which RTSP is served.
+	Addr() net.Addr
+	// SDP returns a session description of the relayed stream. The
+	// description includes the most recently received sequence and picture
+	// parameter sets, if any.
+	SDP() string
+}
+
+type relay struct {
+	receiver     *receiver
+	listener     net.Listener
+	rtpConn      *net.UDPConn
+	rtcpConn     *net.UDPConn
+	sessionID    uint32
+	stopCh       chan struct{}
+	wg           sync.WaitGroup
+	closeOnce    sync.Once
+	viewers      map[*viewer]struct{}
+	viewersLock  sync.Mutex
+	sdpParams    sdpParams
+	sdpParamLock sync.RWMutex
+}
+
+// viewer represents a single RTSP connection.
+type viewer struct {
+	conn      net.Conn
+	writeLock sync.Mutex
+	sessionID string
+	transport rtspTransport
+	udpAddr   *net.UDPAddr
+	packetsCh chan []byte
+	playing   bool
+}
+
+// NewRelay returns a Relay that is listening for an ARStream2 video stream
+// and serving it over RTSP.
+func NewRelay(config RelayConfig) (Relay, error) {
+	if config.RTSPAddr == "" {
+		config.RTSPAddr = DefaultRelayRTSPAddr
+	}
+	rtpConn, rtcpConn, err := listenUDPPair()
+	if err != nil {
+		return nil, errors.Wrap(err, "error binding rtp / rtcp ports")
+	}
+	listener, err := net.Listen("tcp", config.RTSPAddr)
+	if err != nil {
+		rtpConn.Close()  // nolint: errcheck
+		rtcpConn.Close() // nolint: errcheck
+		return nil, errors.Wrap(err, "error listening for rtsp connections")
+	}
+	r := &relay{
+		listener:  listener,
+		rtpConn:   rtpConn,
+		rtcpConn:  rtcpConn,
+		sessionID: rand.Uint32(),
+		stopCh:    make(chan struct{}),
+		viewers:   map[*viewer]struct{}{},
+		sdpParams: sdpParams{
+			payloadType: defaultPayloadType,
+		},
+	}
+	r.sdpParams.sessionID = r.sessionID
+	r.receiver, err = newReceiver(config.ReceiverConfig, r.relayPacket)
+	if err != nil {
+		listener.Close() // nolint: errcheck
+		rtpConn.Close()  // nolint: errcheck
+		rtcpConn.Close() // nolint: errcheck
+		return nil, err
+	}
+	r.wg.Add(2)
+	go r.discardRTCP()
+	go r.acceptViewers()
+	log.WithField(
+		"rtspAddr", listener.Addr(),
+	).Debug("arstream2 relay started")
+	return r, nil
+}
+
+// listenUDPPair binds a pair of UDP ports for sending RTP and RTCP data to
+// viewers. By convention, the RTP port is even and the RTCP port is the next
+// port up.
+func listenUDPPair() (*net.UDPConn, *net.UDPConn, error) {
+	const attempts = 10
+	for i := 0; i < attempts; i++ {
+		rtpConn, err := net.ListenUDP("udp", &net.UDPAddr{})
+		if err != nil {
+			return nil, nil, err
+		}
+		port := rtpConn.LocalAddr().(*net.UDPAddr).Port
+		if port%2 == 0 {
+			rtcpConn, err := net.ListenUDP("udp", &net.UDPAddr{Port: port + 1})
+			if err == nil {
+				return rtpConn, rtcpConn, nil
+			}
+		}
+		rtpConn.Close() // nolint: errcheck
+	}
+	return nil, nil, errors.Errorf(
+		"no adjacent pair of ports found in %d attempts",
+		attempts,
+	)
+}
+
+func (r *relay) AccessUnitsCh() <-chan AccessUnit {
+	return r.receiver.AccessUnitsCh()
+}
+
+func (r *relay) Addr() net.Addr {
+	return r.listener.Addr()
+}
+
+func (r *relay) SDP() string {
+	r.sdpParamLock.RLock()
+	defer r.sdpParamLock.RUnlock()
+	return encodeSDP(r.sdpParams)
+}
+
+func (r *relay) Close() {
+	r.closeOnce.Do(func() {
+		log.Debug("closing arstream2 relay")
+		// Closing the receiver first guarantees no more packets are queued for
+		// viewers
+		r.receiver.Close()
+		close(r.stopCh)
+		if err := r.listener.Close(); err != nil {
+			log.Errorf("error closing rtsp listener: %s", err)
+		}
+		if err := r.rtcpConn.Close(); err != nil {
+			log.Errorf("error closing rtcp connection: %s", err)
+		}
+		r.viewersLock.Lock()
+		for v := range r.viewers {
+			if err := v.conn.Close(); err != nil {
+				log.Errorf("error closing rtsp connection: %s", err)
+			}
+		}
+		r.viewersLock.Unlock()
+		// This is still used for sending to viewers until all viewers have been
+		// disconnected
+		r.wg.Wait()
+		if err := r.rtpConn.Close(); err != nil {
+			log.Errorf("error closing rtp connection: %s", err)
+		}
+		log.Debug("closed arstream2 relay")
+	})
+}
+
+func (r *relay) isStopped() bool {
+	select {
+	case <-r.stopCh:
+		return true
+	default:
+		return false
+	}
+}
+
+// relayPacket queues an RTP packet received from the device for delivery to
+// every viewer that is playing the stream.
+func (r *relay) relayPacket(data []byte, pkt rtpPacket) {
+	r.updateSDPParams(pkt)
+	// Copy, since the buffer is reused, but the packet is not delivered
+	// synchronously
+	data = append([]byte{}, data...)
+	r.viewersLock.Lock()
+	defer r.viewersLock.Unlock()
+	for v := range r.viewers {
+		if !v.playing {
+			continue
+		}
+		select {
+		case v.packetsCh <- data:
+		default:
+			log.WithField(
+				"session", v.sessionID,
+			).Warn("viewer is falling behind; dropping rtp packet")
+		}
+	}
+}
+
+// updateSDPParams inspects an RTP packet for the stream's payload type and for
+// parameter sets carried as single NAL units or within STAP-A packets.
+func (r *relay) updateSDPParams(pkt rtpPacket) {
+	nalus := [][]byte{}
+	if len(pkt.payload) > 0 {
+		switch naluType(pkt.payload) {
+		case naluTypeSPS, naluTypePPS:
+			nalus = append(nalus, pkt.payload)
+		case naluTypeSTAPA:
+			// Parameter sets preceding any malformed portion are still usable
+			nalus, _ = splitSTAPA(pkt.payload[1:])
+		}
+	}
+	r.sdpParamLock.Lock()
+	defer r.sdpParamLock.Unlock()
+	r.sdpParams.payloadType = pkt.payloadType
+	for _, nalu := range nalus {
+		// Copy, since the packet's payload buffer is reused
+		switch naluType(nalu) {
+		case naluTypeSPS:
+			r.sdpParams.sps = append([]byte{}, nalu...)
+		case naluTypePPS:
+			r.sdpParams.pps = append([]byte{}, nalu...)
+		}
+	}
+}
+
+// discardRTCP receives and discards RTCP data (i.e. receiver reports) from
+// viewers. Reception statistics are reported to the device by the receiver
+// instead, since they describe the stream the device actually sent.
+func (r *relay) discardRTCP() {
+	defer r.wg.Done()
+	buf := make([]byte, udp.MaxDataBytes)
+	for {
+		if _, _, err := r.rtcpConn.ReadFromUDP(buf); err != nil {
+			if !r.isStopped() {
+				log.Errorf("error receiving rtcp data: %s", err)
+			}
+			return
+		}
+	}
+}
+
+func (r *relay) acceptViewers() {
+	defer r.wg.Done()
+	for {
+		conn, err := r.listener.Accept()
+		if err != nil {
+			if !r.isStopped() {
+				log.Errorf("error accepting rtsp connection: %s", err)
+			}
+			return
+		}
+		v := &viewer{
+			conn:      conn,
+			sessionID: fmt.Sprintf("%08x", rand.Uint32()),
+			packetsCh: make(chan []byte, viewerPacketsChSize),
+		}
+		r.viewersLock.Lock()
+		if r.isStopped() {
+			r.viewersLock.Unlock()
+			conn.Close() // nolint: errcheck
+			return
+		}
+		r.viewers[v] = struct{}{}
+		r.viewersLock.Unlock()
+		r.wg.Add(2)
+		go r.serveViewer(v)
+		go r.sendPackets(v)
+	}
+}
+
+// serveViewer handles RTSP requests from a single viewer until the viewer
+// disconnects.
+func (r *relay) serveViewer(v *viewer) {
+	defer r.wg.Done()
+	log := log.WithField("remoteAddr", v.conn.RemoteAddr())
+	log.Debug("rtsp viewer connected")
+	defer func() {
+		r.viewersLock.Lock()
+		delete(r.viewers, v)
+		r.viewersLock.Unlock()
+		close(v.packetsCh)
+		v.conn.Close() // nolint: errcheck
+		log.Debug("rtsp viewer disconnected")
+	}()
+	reader := bufio.NewReader(v.conn)
+	for {
+		req, err := readRTSPRequest(reader)
+		if err != nil {
+			if !r.isStopped() {
+				log.Debugf("error reading rtsp request: %s", err)
+			}
+			return
+		}
+		log.WithField(
+			"method", req.method,
+		).WithField(
+			"url", req.url,
+		).Debug("received rtsp request")
+		res := r.handleRequest(v, req)
+		v.writeLock.Lock()
+		_, err = v.conn.Write(res.encode(req))
+		v.writeLock.Unlock()
+		if err != nil {
+			log.Debugf("error writing rtsp response: %s", err)
+			return
+		}
+		if req.method == "TEARDOWN" {
+			return
+		}
+	}
+}
+
+func (r *relay) handleRequest(v *viewer, req rtspRequest) rtspResponse {
+	res := rtspResponse{
+		statusCode: 200,
+		reason:     "OK",
+		headers:    map[string]string{},
+	}
+	switch req.method {
+	case "OPTIONS":
+		res.headers["Public"] =
+			"OPTIONS, DESCRIBE, SETUP, PLAY, TEARDOWN, GET_PARAMETER"
+	case "DESCRIBE":
+		res.headers["Content-Type"] = "application/sdp"
+		res.headers["Content-Base"] = strings.TrimSuffix(req.url, "/") + "/"
+		res.body = r.SDP()
+	case "SETUP":
+		transport, err := parseRTSPTransport(req.headers.Get("Transport"))
+		if err != nil {
+			return rtspResponse{
+				statusCode: 461,
+				reason:     "Unsupported Transport",
+			}
+		}
+		r.viewersLock.Lock()
+		v.transport = transport
+		if !transport.interleaved {
+			v.udpAddr = &net.UDPAddr{
+				IP:   v.conn.RemoteAddr().(*net.TCPAddr).IP,
+				Port: transport.rtpPort,
+			}
+		}
+		r.viewersLock.Unlock()
+		if transport.interleaved {
+			res.headers["Transport"] = fmt.Sprintf(
+				"RTP/AVP/TCP;unicast;interleaved=%d-%d",
+				transport.rtpChannel,
+				transport.rtcpChannel,
+			)
+		} else {
+			serverPort := r.rtpConn.LocalAddr().(*net.UDPAddr).Port
+			res.headers["Transport"] = fmt.Sprintf(
+				"RTP/AVP;unicast;client_port=%d-%d;server_port=%d-%d",
+				transport.rtpPort,
+				transport.rtcpPort,
+				serverPort,
+				serverPort+1,
+			)
+		}
+		res.headers["Session"] = v.sessionID
+	case "PLAY":
+		r.viewersLock.Lock()
+		isSetUp := v.transport.interleaved || v.udpAddr != nil
+		v.playing = isSetUp
+		r.viewersLock.Unlock()
+		if !isSetUp {
+			return rtspResponse{
+				statusCode: 455,
+				reason:     "Method Not Valid in This State",
+			}
+		}
+		res.headers["Session"] = v.sessionID
+		res.headers["Range"] = "npt=0.000-"
+	case "TEARDOWN", "GET_PARAMETER":
+		res.headers["Session"] = v.sessionID
+	default:
+		return rtspResponse{
+			statusCode: 501,
+			reason:     "Not Implemented",
+		}
+	}
+	return res
+}
+
+// sendPackets delivers RTP packets queued for a single viewer until the
+// viewer disconnects.
+func (r *relay) sendPackets(v *viewer) {
+	defer r.wg.Done()
+	for pkt := range v.packetsCh {
+		r.viewersLock.Lock()
+		transport := v.transport
+		udpAddr := v.udpAddr
+		r.viewersLock.Unlock()
+		if !transport.interleaved {
+			if _, err := r.rtpConn.WriteToUDP(pkt, udpAddr); err != nil {
+				log.Debugf("error sending rtp packet to viewer: %s", err)
+			}
+			continue
+		}
+		// Interleaved data is a '$', a one byte channel, and a two byte length,
+		// followed by the data itself
+		header := []byte{'$', transport.rtpChannel, 0, 0}
+		binary.BigEndian.PutUint16(header[2:4], uint16(len(pkt)))
+		v.writeLock.Lock()
+		_, err := v.conn.Write(append(header, pkt...))
+		v.writeLock.Unlock()
+		if err != nil {
+			log.Debugf("error sending rtp packet to viewer: %s", err)
+		}
+	}
+}
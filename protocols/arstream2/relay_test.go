@@ -0,0 +1,369 @@
+package arstream2
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/krancour/go-parrot/protocols/internal/udp"
+	"github.com/phayes/freeport"
+	"github.com/stretchr/testify/require"
+)
+
+// testViewer is a minimal RTSP client for testing the relay.
+type testViewer struct {
+	t      *testing.T
+	conn   net.Conn
+	reader *bufio.Reader
+	cseq   int
+}
+
+func newTestViewer(t *testing.T, addr net.Addr) *testViewer {
+	conn, err := net.Dial("tcp", addr.String())
+	require.NoError(t, err)
+	return &testViewer{
+		t:      t,
+		conn:   conn,
+		reader: bufio.NewReader(conn),
+	}
+}
+
+// do sends a request and returns the status code, headers, and body of the
+// response.
+func (v *testViewer) do(
+	method string,
+	url string,
+	headers map[string]string,
+) (int, textproto.MIMEHeader, string) {
+	v.cseq++
+	req := fmt.Sprintf("%s %s RTSP/1.0\r\nCSeq: %d\r\n", method, url, v.cseq)
+	for name, value := range headers {
+		req += fmt.Sprintf("%s: %s\r\n", name, value)
+	}
+	_, err := v.conn.Write([]byte(req + "\r\n"))
+	require.NoError(v.t, err)
+	require.NoError(
+		v.t,
+		v.conn.SetReadDeadline(time.Now().Add(time.Second)),
+	)
+	tr := textproto.NewReader(v.reader)
+	statusLine, err := tr.ReadLine()
+	require.NoError(v.t, err)
+	statusCode, err := strconv.Atoi(strings.Fields(statusLine)[1])
+	require.NoError(v.t, err)
+	resHeaders, err := tr.ReadMIMEHeader()
+	require.NoError(v.t, err)
+	require.Equal(v.t, strconv.Itoa(v.cseq), resHeaders.Get("CSeq"))
+	body := ""
+	if contentLength := resHeaders.Get("Content-Length"); contentLength != "" {
+		length, err := strconv.Atoi(contentLength)
+		require.NoError(v.t, err)
+		bodyBytes, err := ioutil.ReadAll(io.LimitReader(v.reader, int64(length)))
+		require.NoError(v.t, err)
+		body = string(bodyBytes)
+	}
+	return statusCode, resHeaders, body
+}
+
+func TestRelay(t *testing.T) {
+	ports, err := freeport.GetFreePorts(2)
+	require.NoError(t, err)
+	r, err := NewRelay(
+		RelayConfig{
+			ReceiverConfig: ReceiverConfig{
+				StreamPort:  ports[0],
+				ControlPort: ports[1],
+			},
+			RTSPAddr: "127.0.0.1:0",
+		},
+	)
+	require.NoError(t, err)
+	defer r.Close()
+
+	// Mock out the device's end of the stream
+	deviceConn, err := net.Dial(
+		"udp",
+		fmt.Sprintf("127.0.0.1:%d", ports[0]),
+	)
+	require.NoError(t, err)
+	defer deviceConn.Close()
+	sendRTP := func(seq uint16, payload []byte) {
+		_, err := deviceConn.Write(
+			encodeRTPPacket(
+				rtpPacket{
+					marker:      true,
+					payloadType: 97,
+					seq:         seq,
+					timestamp:   uint32(seq) * 3000,
+					payload:     payload,
+				},
+			),
+		)
+		require.NoError(t, err)
+	}
+
+	// The device sends parameter sets in a STAP-A packet, which should be
+	// reflected in the SDP
+	sps := []byte{0x67, 0x42, 0x00, 0x1f, 0xe9, 0x02, 0x80, 0x2d, 0xc8}
+	pps := []byte{0x68, 0xce, 0x38, 0x80}
+	stapA := []byte{0x78, 0x00, byte(len(sps))}
+	stapA = append(stapA, sps...)
+	stapA = append(stapA, 0x00, byte(len(pps)))
+	stapA = append(stapA, pps...)
+	sendRTP(1, stapA)
+	timeoutCh := time.After(time.Second)
+	for !strings.Contains(r.SDP(), "sprop-parameter-sets") {
+		select {
+		case <-time.After(10 * time.Millisecond):
+		case <-timeoutCh:
+			require.FailNow(t, "timed out waiting for parameter sets")
+		}
+	}
+
+	url := fmt.Sprintf("rtsp://%s/live", r.Addr())
+
+	// A viewer that receives RTP over UDP
+	udpViewer := newTestViewer(t, r.Addr())
+	defer udpViewer.conn.Close()
+	status, headers, _ := udpViewer.do("OPTIONS", url, nil)
+	require.Equal(t, 200, status)
+	require.Contains(t, headers.Get("Public"), "DESCRIBE")
+	status, headers, body := udpViewer.do("DESCRIBE", url, nil)
+	require.Equal(t, 200, status)
+	require.Equal(t, "application/sdp", headers.Get("Content-Type"))
+	require.Equal(t, r.SDP(), body)
+	require.Contains(t, body, "m=video 0 RTP/AVP 97\r\n")
+	require.Contains(t, body, "a=rtpmap:97 H264/90000\r\n")
+	require.Contains(t, body, "profile-level-id=42001f")
+	// PLAY is not valid before SETUP
+	status, _, _ = udpViewer.do("PLAY", url, nil)
+	require.Equal(t, 455, status)
+	udpViewerConn, err := net.ListenUDP(
+		"udp",
+		&net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)},
+	)
+	require.NoError(t, err)
+	defer udpViewerConn.Close()
+	clientPort := udpViewerConn.LocalAddr().(*net.UDPAddr).Port
+	status, headers, _ = udpViewer.do(
+		"SETUP",
+		url+"/"+relayControl,
+		map[string]string{
+			"Transport": fmt.Sprintf(
+				"RTP/AVP;unicast;client_port=%d-%d",
+				clientPort,
+				clientPort+1,
+			),
+		},
+	)
+	require.Equal(t, 200, status)
+	require.Contains(
+		t,
+		headers.Get("Transport"),
+		fmt.Sprintf("client_port=%d-%d", clientPort, clientPort+1),
+	)
+	session := headers.Get("Session")
+	require.NotEmpty(t, session)
+	status, _, _ = udpViewer.do(
+		"PLAY",
+		url,
+		map[string]string{"Session": session},
+	)
+	require.Equal(t, 200, status)
+
+	// A viewer that receives RTP interleaved over TCP
+	tcpViewer := newTestViewer(t, r.Addr())
+	defer tcpViewer.conn.Close()
+	status, headers, _ = tcpViewer.do(
+		"SETUP",
+		url+"/"+relayControl,
+		map[string]string{
+			"Transport": "RTP/AVP/TCP;unicast;interleaved=2-3",
+		},
+	)
+	require.Equal(t, 200, status)
+	require.Equal(
+		t,
+		"RTP/AVP/TCP;unicast;interleaved=2-3",
+		headers.Get("Transport"),
+	)
+	status, _, _ = tcpViewer.do(
+		"PLAY",
+		url,
+		map[string]string{"Session": headers.Get("Session")},
+	)
+	require.Equal(t, 200, status)
+
+	// An unsupported transport should be rejected
+	otherViewer := newTestViewer(t, r.Addr())
+	defer otherViewer.conn.Close()
+	status, _, _ = otherViewer.do(
+		"SETUP",
+		url+"/"+relayControl,
+		map[string]string{"Transport": "RTP/AVP;multicast"},
+	)
+	require.Equal(t, 461, status)
+
+	// Both viewers should receive packets from the device, unaltered
+	idr := []byte{0x65, 0x88, 0x84}
+	sendRTP(2, idr)
+	require.NoError(
+		t,
+		udpViewerConn.SetReadDeadline(time.Now().Add(time.Second)),
+	)
+	buf := make([]byte, udp.MaxDataBytes)
+	bytesRead, _, err := udpViewerConn.ReadFromUDP(buf)
+	require.NoError(t, err)
+	require.Equal(t, rtpHeaderBytesLength+len(idr), bytesRead)
+	require.Equal(t, idr, buf[rtpHeaderBytesLength:bytesRead])
+	require.NoError(
+		t,
+		tcpViewer.conn.SetReadDeadline(time.Now().Add(time.Second)),
+	)
+	header := make([]byte, 4)
+	_, err = io.ReadFull(tcpViewer.reader, header)
+	require.NoError(t, err)
+	require.Equal(t, byte('$'), header[0])
+	require.Equal(t, uint8(2), header[1])
+	pkt := make([]byte, binary.BigEndian.Uint16(header[2:4]))
+	_, err = io.ReadFull(tcpViewer.reader, pkt)
+	require.NoError(t, err)
+	require.Equal(t, idr, pkt[rtpHeaderBytesLength:])
+
+	// Access units should be delivered just as by any Receiver
+	for _, nalus := range [][][]byte{{sps, pps}, {idr}} {
+		select {
+		case au := <-r.AccessUnitsCh():
+			require.Equal(t, nalus, au.NALUs)
+		case <-time.After(time.Second):
+			require.FailNow(t, "timed out waiting for access unit")
+		}
+	}
+
+	// After TEARDOWN, the relay should close the connection
+	status, _, _ = tcpViewer.do("TEARDOWN", url, nil)
+	require.Equal(t, 200, status)
+	_, err = tcpViewer.reader.ReadByte()
+	require.Equal(t, io.EOF, err)
+
+	// Closing the relay should disconnect remaining viewers
+	r.Close()
+	require.NoError(
+		t,
+		udpViewer.conn.SetReadDeadline(time.Now().Add(time.Second)),
+	)
+	_, err = udpViewer.reader.ReadByte()
+	require.Equal(t, io.EOF, err)
+}
+
+func TestParseRTSPTransport(t *testing.T) {
+	testCases := []struct {
+		name       string
+		header     string
+		assertions func(*testing.T, rtspTransport, error)
+	}{
+
+		{
+			name:   "udp",
+			header: "RTP/AVP;unicast;client_port=5000-5001",
+			assertions: func(t *testing.T, transport rtspTransport, err error) {
+				require.NoError(t, err)
+				require.Equal(
+					t,
+					rtspTransport{rtpPort: 5000, rtcpPort: 5001},
+					transport,
+				)
+			},
+		},
+
+		{
+			name:   "interleaved",
+			header: "RTP/AVP/TCP;unicast;interleaved=0-1",
+			assertions: func(t *testing.T, transport rtspTransport, err error) {
+				require.NoError(t, err)
+				require.Equal(
+					t,
+					rtspTransport{interleaved: true, rtcpChannel: 1},
+					transport,
+				)
+			},
+		},
+
+		{
+			name:   "first supported transport is chosen",
+			header: "RTP/AVP;multicast, RTP/AVP;unicast;client_port=6000",
+			assertions: func(t *testing.T, transport rtspTransport, err error) {
+				require.NoError(t, err)
+				require.Equal(
+					t,
+					rtspTransport{rtpPort: 6000, rtcpPort: 6001},
+					transport,
+				)
+			},
+		},
+
+		{
+			name:   "udp without client port",
+			header: "RTP/AVP;unicast",
+			assertions: func(t *testing.T, _ rtspTransport, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "no supported transport")
+			},
+		},
+
+		{
+			name:   "unsupported profile",
+			header: "RTP/SAVP;unicast;client_port=5000-5001",
+			assertions: func(t *testing.T, _ rtspTransport, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "no supported transport")
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			transport, err := parseRTSPTransport(testCase.header)
+			testCase.assertions(t, transport, err)
+		})
+	}
+}
+
+func TestEncodeSDP(t *testing.T) {
+	// Without parameter sets
+	require.Equal(
+		t,
+		"v=0\r\n"+
+			"o=- 42 1 IN IP4 0.0.0.0\r\n"+
+			"s=go-parrot\r\n"+
+			"c=IN IP4 0.0.0.0\r\n"+
+			"t=0 0\r\n"+
+			"m=video 0 RTP/AVP 96\r\n"+
+			"a=rtpmap:96 H264/90000\r\n"+
+			"a=fmtp:96 packetization-mode=1\r\n"+
+			"a=control:trackID=0\r\n",
+		encodeSDP(sdpParams{sessionID: 42, payloadType: 96}),
+	)
+	// With parameter sets
+	require.Contains(
+		t,
+		encodeSDP(
+			sdpParams{
+				sessionID:   42,
+				payloadType: 96,
+				sps:         []byte{0x67, 0x42, 0xc0, 0x1f},
+				pps:         []byte{0x68, 0xce},
+			},
+		),
+		"a=fmtp:96 packetization-mode=1;profile-level-id=42c01f;"+
+			"sprop-parameter-sets=Z0LAHw==,aM4=\r\n",
+	)
+}
@@ -0,0 +1,124 @@
+package arstream2
+
+import (
+	"encoding/binary"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// RTCP packet types. See RFC 3550.
+const (
+	rtcpTypeSR uint8 = 200
+	rtcpTypeRR uint8 = 201
+)
+
+// ntpEpochOffset is the number of seconds between the NTP epoch (1900) and
+// the Unix epoch (1970).
+const ntpEpochOffset = 2208988800
+
+// senderReport represents the portions of an RTCP sender report relevant to
+// the receiver.
+type senderReport struct {
+	ssrc uint32
+	// ntpTimestamp is the 64 bit NTP timestamp exactly as it appeared in the
+	// report
+	ntpTimestamp uint64
+	rtpTimestamp uint32
+}
+
+// ntpTime returns the report's NTP timestamp as a time.Time.
+func (s senderReport) ntpTime() time.Time {
+	seconds := int64(s.ntpTimestamp>>32) - ntpEpochOffset
+	fraction := s.ntpTimestamp & 0xffffffff
+	nanos := int64(fraction * uint64(time.Second) >> 32)
+	return time.Unix(seconds, nanos)
+}
+
+// timeOf returns the wall clock time corresponding to the given RTP
+// timestamp, as extrapolated from the report.
+func (s senderReport) timeOf(rtpTimestamp uint32) time.Time {
+	// Interpreting the difference as signed accounts for rollover
+	diff := int64(int32(rtpTimestamp - s.rtpTimestamp))
+	return s.ntpTime().Add(time.Duration(diff) * time.Second / h264ClockRate)
+}
+
+// parseSenderReports returns all sender reports found in a (possibly
+// compound) RTCP packet. RTCP packets of other types are skipped.
+func parseSenderReports(data []byte) ([]senderReport, error) {
+	srs := []senderReport{}
+	for len(data) > 0 {
+		if len(data) < 4 {
+			return nil, errors.New("rtcp packet is shorter than its header")
+		}
+		if version := data[0] >> 6; version != rtpVersion {
+			return nil, errors.Errorf("unsupported rtcp version %d", version)
+		}
+		// Length is expressed in 32 bit words, minus one
+		length := 4 * (int(binary.BigEndian.Uint16(data[2:4])) + 1)
+		if length > len(data) {
+			return nil, errors.New("rtcp packet is truncated")
+		}
+		if data[1] == rtcpTypeSR {
+			if length < 20 {
+				return nil, errors.New("rtcp sender report is truncated")
+			}
+			srs = append(
+				srs,
+				senderReport{
+					ssrc:         binary.BigEndian.Uint32(data[4:8]),
+					ntpTimestamp: binary.BigEndian.Uint64(data[8:16]),
+					rtpTimestamp: binary.BigEndian.Uint32(data[16:20]),
+				},
+			)
+		}
+		data = data[length:]
+	}
+	return srs, nil
+}
+
+// receptionStats are statistics reported to the sender in RTCP receiver
+// reports.
+type receptionStats struct {
+	sourceSSRC uint32
+	// extHighestSeq is the highest sequence number received, extended with the
+	// number of sequence number cycles in the high 16 bits
+	extHighestSeq uint32
+	// cumulativeLost is the total number of packets lost
+	cumulativeLost uint32
+	// fractionLost is the fraction of packets lost since the previous report,
+	// expressed as a fixed point number with the binary point at the left edge
+	fractionLost uint8
+	// lastSR is the middle 32 bits of the NTP timestamp of the last sender
+	// report received, or zero if none has been received
+	lastSR uint32
+	// delaySinceLastSR is the delay between receiving the last sender report
+	// and sending this receiver report, or zero if no sender report has been
+	// received
+	delaySinceLastSR time.Duration
+}
+
+// encodeReceiverReport encodes an RTCP receiver report with a single report
+// block. Interarrival jitter is always reported as zero.
+func encodeReceiverReport(ssrc uint32, stats receptionStats) []byte {
+	data := make([]byte, 32)
+	data[0] = rtpVersion<<6 | 1 // Version and report count
+	data[1] = rtcpTypeRR
+	binary.BigEndian.PutUint16(data[2:4], uint16(len(data)/4-1))
+	binary.BigEndian.PutUint32(data[4:8], ssrc)
+	binary.BigEndian.PutUint32(data[8:12], stats.sourceSSRC)
+	// Fraction lost (8 bits) followed by cumulative number lost (24 bits)
+	binary.BigEndian.PutUint32(
+		data[12:16],
+		uint32(stats.fractionLost)<<24|stats.cumulativeLost&0xffffff,
+	)
+	binary.BigEndian.PutUint32(data[16:20], stats.extHighestSeq)
+	// Bytes 20 through 23 are interarrival jitter, which is left as zero
+	binary.BigEndian.PutUint32(data[24:28], stats.lastSR)
+	// Delay since last SR is expressed in units of 1/65536 seconds
+	binary.BigEndian.PutUint32(
+		data[28:32],
+		uint32(stats.delaySinceLastSR*65536/time.Second),
+	)
+	return data
+}
@@ -0,0 +1,89 @@
+package arstream2
+
+import (
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// encodeSenderReport is a helper for encoding RTCP sender reports with no
+// report blocks.
+func encodeSenderReport(sr senderReport) []byte {
+	data := make([]byte, 28)
+	data[0] = rtpVersion << 6
+	data[1] = rtcpTypeSR
+	binary.BigEndian.PutUint16(data[2:4], uint16(len(data)/4-1))
+	binary.BigEndian.PutUint32(data[4:8], sr.ssrc)
+	binary.BigEndian.PutUint64(data[8:16], sr.ntpTimestamp)
+	binary.BigEndian.PutUint32(data[16:20], sr.rtpTimestamp)
+	return data
+}
+
+func TestParseSenderReports(t *testing.T) {
+	sr := senderReport{
+		ssrc: 42,
+		// 2018-10-15 00:00:00.5 UTC
+		ntpTimestamp: uint64(1539561600+ntpEpochOffset)<<32 | 1<<31,
+		rtpTimestamp: 9000,
+	}
+	// A compound packet consisting of a receiver report and a sender report
+	data := append(
+		encodeReceiverReport(1, receptionStats{}),
+		encodeSenderReport(sr)...,
+	)
+	srs, err := parseSenderReports(data)
+	require.NoError(t, err)
+	require.Equal(t, []senderReport{sr}, srs)
+	expectedTime := time.Date(2018, 10, 15, 0, 0, 0, 500000000, time.UTC)
+	require.True(t, expectedTime.Equal(sr.ntpTime()))
+	require.True(
+		t,
+		expectedTime.Add(100*time.Millisecond).Equal(sr.timeOf(18000)),
+	)
+
+	_, err = parseSenderReports(data[:len(data)-4])
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "truncated")
+}
+
+func TestEncodeReceiverReport(t *testing.T) {
+	data := encodeReceiverReport(
+		1,
+		receptionStats{
+			sourceSSRC:       2,
+			extHighestSeq:    0x00010005,
+			cumulativeLost:   3,
+			fractionLost:     64,
+			lastSR:           4,
+			delaySinceLastSR: 2 * time.Second,
+		},
+	)
+	require.Equal(
+		t,
+		[]byte{
+			0x81, 201, 0x00, 0x07, // Header
+			0x00, 0x00, 0x00, 0x01, // SSRC of receiver
+			0x00, 0x00, 0x00, 0x02, // SSRC of source
+			64, 0x00, 0x00, 0x03, // Fraction lost + cumulative lost
+			0x00, 0x01, 0x00, 0x05, // Extended highest sequence number
+			0x00, 0x00, 0x00, 0x00, // Jitter
+			0x00, 0x00, 0x00, 0x04, // Last SR
+			0x00, 0x02, 0x00, 0x00, // Delay since last SR
+		},
+		data,
+	)
+}
+
+func TestRTPStats(t *testing.T) {
+	stats := rtpStats{}
+	for _, seq := range []uint16{65534, 65535, 1, 2} { // seq 0 is lost
+		stats.update(rtpPacket{ssrc: 7, seq: seq})
+	}
+	report := stats.report()
+	require.Equal(t, uint32(7), report.sourceSSRC)
+	require.Equal(t, uint32(0x00010002), report.extHighestSeq)
+	require.Equal(t, uint32(1), report.cumulativeLost)
+	require.Equal(t, uint8(256/5), report.fractionLost)
+}
@@ -0,0 +1,67 @@
+package arstream2
+
+import (
+	"encoding/binary"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	rtpVersion = 2
+	// rtpHeaderBytesLength is the length of the fixed portion of an RTP header
+	// in bytes.
+	rtpHeaderBytesLength = 12
+)
+
+// rtpPacket represents an RTP packet. Only the attributes of the header
+// relevant to depacketization are retained.
+type rtpPacket struct {
+	marker      bool
+	payloadType uint8
+	seq         uint16
+	timestamp   uint32
+	ssrc        uint32
+	payload     []byte
+}
+
+// parseRTPPacket parses an RTP packet. CSRCs, header extensions, and padding
+// are skipped. The returned packet's payload refers to the given data; it is
+// not copied.
+func parseRTPPacket(data []byte) (rtpPacket, error) {
+	pkt := rtpPacket{}
+	if len(data) < rtpHeaderBytesLength {
+		return pkt, errors.New("rtp packet is shorter than its header")
+	}
+	if version := data[0] >> 6; version != rtpVersion {
+		return pkt, errors.Errorf("unsupported rtp version %d", version)
+	}
+	hasPadding := data[0]&0x20 != 0
+	hasExtension := data[0]&0x10 != 0
+	csrcCount := int(data[0] & 0x0f)
+	pkt.marker = data[1]&0x80 != 0
+	pkt.payloadType = data[1] & 0x7f
+	pkt.seq = binary.BigEndian.Uint16(data[2:4])
+	pkt.timestamp = binary.BigEndian.Uint32(data[4:8])
+	pkt.ssrc = binary.BigEndian.Uint32(data[8:12])
+	offset := rtpHeaderBytesLength + 4*csrcCount
+	if hasExtension {
+		if offset+4 > len(data) {
+			return pkt, errors.New("rtp header extension is truncated")
+		}
+		// The extension length is expressed in 32 bit words and excludes the
+		// 4 byte extension header itself
+		offset += 4 + 4*int(binary.BigEndian.Uint16(data[offset+2:offset+4]))
+	}
+	end := len(data)
+	if hasPadding {
+		if end == 0 {
+			return pkt, errors.New("rtp packet padding is invalid")
+		}
+		end -= int(data[end-1])
+	}
+	if offset > end {
+		return pkt, errors.New("rtp packet is truncated")
+	}
+	pkt.payload = data[offset:end]
+	return pkt, nil
+}
@@ -0,0 +1,126 @@
+package arstream2
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// encodeRTPPacket is a helper for encoding RTP packets with no CSRCs,
+// extensions, or padding.
+func encodeRTPPacket(pkt rtpPacket) []byte {
+	data := make(
+		[]byte,
+		rtpHeaderBytesLength,
+		rtpHeaderBytesLength+len(pkt.payload),
+	)
+	data[0] = rtpVersion << 6
+	data[1] = pkt.payloadType
+	if pkt.marker {
+		data[1] |= 0x80
+	}
+	binary.BigEndian.PutUint16(data[2:4], pkt.seq)
+	binary.BigEndian.PutUint32(data[4:8], pkt.timestamp)
+	binary.BigEndian.PutUint32(data[8:12], pkt.ssrc)
+	return append(data, pkt.payload...)
+}
+
+func TestParseRTPPacket(t *testing.T) {
+	testCases := []struct {
+		name       string
+		data       []byte
+		assertions func(*testing.T, rtpPacket, error)
+	}{
+
+		{
+			name: "truncated header",
+			data: []byte{0x80, 0x60, 0x00},
+			assertions: func(t *testing.T, _ rtpPacket, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "shorter than its header")
+			},
+		},
+
+		{
+			name: "unsupported version",
+			data: []byte{
+				0x40, 0x60, 0x00, 0x01,
+				0x00, 0x00, 0x00, 0x02,
+				0x00, 0x00, 0x00, 0x03,
+			},
+			assertions: func(t *testing.T, _ rtpPacket, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "unsupported rtp version")
+			},
+		},
+
+		{
+			name: "simple packet",
+			data: []byte{
+				0x80,       // Version 2
+				0xe0,       // Marker + payload type 96
+				0x00, 0x01, // Seq
+				0x00, 0x00, 0x00, 0x02, // Timestamp
+				0x00, 0x00, 0x00, 0x03, // SSRC
+				0x41, 0x42, // Payload
+			},
+			assertions: func(t *testing.T, pkt rtpPacket, err error) {
+				require.NoError(t, err)
+				require.Equal(
+					t,
+					rtpPacket{
+						marker:      true,
+						payloadType: 96,
+						seq:         1,
+						timestamp:   2,
+						ssrc:        3,
+						payload:     []byte{0x41, 0x42},
+					},
+					pkt,
+				)
+			},
+		},
+
+		{
+			name: "packet with csrc, extension, and padding",
+			data: []byte{
+				0xb1,       // Version 2, padding, extension, 1 CSRC
+				0x60,       // Payload type 96
+				0x00, 0x01, // Seq
+				0x00, 0x00, 0x00, 0x02, // Timestamp
+				0x00, 0x00, 0x00, 0x03, // SSRC
+				0x00, 0x00, 0x00, 0x04, // CSRC
+				0xbe, 0xde, 0x00, 0x01, // Extension header; length 1 word
+				0x01, 0x02, 0x03, 0x04, // Extension
+				0x41, 0x42, // Payload
+				0x00, 0x02, // Padding
+			},
+			assertions: func(t *testing.T, pkt rtpPacket, err error) {
+				require.NoError(t, err)
+				require.Equal(t, []byte{0x41, 0x42}, pkt.payload)
+			},
+		},
+
+		{
+			name: "truncated extension",
+			data: []byte{
+				0x90, 0x60, 0x00, 0x01,
+				0x00, 0x00, 0x00, 0x02,
+				0x00, 0x00, 0x00, 0x03,
+				0xbe, 0xde, 0x00, 0x04, // Claims 4 words of extension
+			},
+			assertions: func(t *testing.T, _ rtpPacket, err error) {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "truncated")
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			pkt, err := parseRTPPacket(testCase.data)
+			testCase.assertions(t, pkt, err)
+		})
+	}
+}
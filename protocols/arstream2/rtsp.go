@@ -0,0 +1,190 @@
+package arstream2
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/textproto"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// rtspRequest represents an RTSP request (RFC 2326). Only the attributes
+// relevant to the relay are retained.
+type rtspRequest struct {
+	method  string
+	url     string
+	headers textproto.MIMEHeader
+}
+
+// rtspResponse represents an RTSP response.
+type rtspResponse struct {
+	statusCode int
+	reason     string
+	headers    map[string]string
+	body       string
+}
+
+// readRTSPRequest reads a single RTSP request. Clients using interleaved
+// transport may send RTP / RTCP data (typically RTCP receiver reports) over
+// the same connection as requests; any such data preceding the request is
+// discarded.
+func readRTSPRequest(r *bufio.Reader) (rtspRequest, error) {
+	req := rtspRequest{}
+	for {
+		b, err := r.Peek(1)
+		if err != nil {
+			return req, err
+		}
+		if b[0] != '$' {
+			break
+		}
+		// Interleaved data is a '$', a one byte channel, and a two byte length,
+		// followed by the data itself
+		header := make([]byte, 4)
+		if _, err := io.ReadFull(r, header); err != nil {
+			return req, err
+		}
+		length := int64(header[2])<<8 | int64(header[3])
+		if _, err := io.CopyN(ioutil.Discard, r, length); err != nil {
+			return req, err
+		}
+	}
+	tr := textproto.NewReader(r)
+	requestLine, err := tr.ReadLine()
+	if err != nil {
+		return req, err
+	}
+	parts := strings.Fields(requestLine)
+	if len(parts) != 3 || !strings.HasPrefix(parts[2], "RTSP/") {
+		return req, errors.Errorf("malformed rtsp request line %q", requestLine)
+	}
+	req.method = parts[0]
+	req.url = parts[1]
+	if req.headers, err = tr.ReadMIMEHeader(); err != nil && err != io.EOF {
+		return req, errors.Wrap(err, "error reading rtsp request headers")
+	}
+	// Requests the relay handles have no body, but discard any that's sent
+	if contentLength := req.headers.Get("Content-Length"); contentLength != "" {
+		length, err := strconv.ParseInt(contentLength, 10, 64)
+		if err != nil {
+			return req, errors.Errorf("invalid content length %q", contentLength)
+		}
+		if _, err := io.CopyN(ioutil.Discard, r, length); err != nil {
+			return req, err
+		}
+	}
+	return req, nil
+}
+
+// encode encodes the response as a reply to the given request.
+func (r rtspResponse) encode(req rtspRequest) []byte {
+	buf := &strings.Builder{}
+	fmt.Fprintf(buf, "RTSP/1.0 %d %s\r\n", r.statusCode, r.reason)
+	fmt.Fprintf(buf, "CSeq: %s\r\n", req.headers.Get("CSeq"))
+	// Sort headers for the sake of deterministic output
+	names := make([]string, 0, len(r.headers))
+	for name := range r.headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(buf, "%s: %s\r\n", name, r.headers[name])
+	}
+	if r.body != "" {
+		fmt.Fprintf(buf, "Content-Length: %d\r\n", len(r.body))
+	}
+	buf.WriteString("\r\n")
+	buf.WriteString(r.body)
+	return []byte(buf.String())
+}
+
+// rtspTransport represents the portions of an RTSP Transport header relevant
+// to the relay.
+type rtspTransport struct {
+	// interleaved indicates whether RTP data is to be interleaved with RTSP
+	// requests and responses over the RTSP connection. If not, RTP data is to
+	// be sent over UDP.
+	interleaved bool
+	// rtpChannel and rtcpChannel are the interleaved channels for RTP and RTCP
+	// data, respectively
+	rtpChannel  uint8
+	rtcpChannel uint8
+	// rtpPort and rtcpPort are the UDP ports on which the client receives RTP
+	// and RTCP data, respectively
+	rtpPort  int
+	rtcpPort int
+}
+
+// parseRTSPTransport parses an RTSP Transport header. Clients may list
+// several acceptable transports; the first supported one is chosen.
+func parseRTSPTransport(header string) (rtspTransport, error) {
+	for _, spec := range strings.Split(header, ",") {
+		params := strings.Split(strings.TrimSpace(spec), ";")
+		t := rtspTransport{}
+		switch params[0] {
+		case "RTP/AVP", "RTP/AVP/UDP":
+		case "RTP/AVP/TCP":
+			t.interleaved = true
+		default:
+			continue
+		}
+		supported := true
+		for _, param := range params[1:] {
+			nameAndValue := strings.SplitN(param, "=", 2)
+			switch nameAndValue[0] {
+			case "multicast":
+				supported = false
+			case "client_port":
+				if len(nameAndValue) < 2 {
+					supported = false
+					break
+				}
+				rtpPort, rtcpPort, err := parseRange(nameAndValue[1])
+				if err != nil {
+					supported = false
+					break
+				}
+				t.rtpPort, t.rtcpPort = rtpPort, rtcpPort
+			case "interleaved":
+				if len(nameAndValue) < 2 {
+					supported = false
+					break
+				}
+				rtpChannel, rtcpChannel, err := parseRange(nameAndValue[1])
+				if err != nil || rtpChannel > 255 || rtcpChannel > 255 {
+					supported = false
+					break
+				}
+				t.rtpChannel, t.rtcpChannel = uint8(rtpChannel), uint8(rtcpChannel)
+			}
+		}
+		if !supported || (!t.interleaved && t.rtpPort == 0) {
+			continue
+		}
+		return t, nil
+	}
+	return rtspTransport{}, errors.Errorf("no supported transport in %q", header)
+}
+
+// parseRange parses a range of the form "a-b" or "a". In the latter case, b
+// is assumed to be a+1.
+func parseRange(str string) (int, int, error) {
+	bounds := strings.SplitN(str, "-", 2)
+	low, err := strconv.Atoi(bounds[0])
+	if err != nil || low < 0 {
+		return 0, 0, errors.Errorf("invalid range %q", str)
+	}
+	if len(bounds) == 1 {
+		return low, low + 1, nil
+	}
+	high, err := strconv.Atoi(bounds[1])
+	if err != nil || high < 0 {
+		return 0, 0, errors.Errorf("invalid range %q", str)
+	}
+	return low, high, nil
+}
@@ -0,0 +1,56 @@
+package arstream2
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+)
+
+// relayControl is the SDP control attribute of the relay's one and only
+// (video) track. Clients append it to the base URL when setting up the track.
+const relayControl = "trackID=0"
+
+// sdpParams represents the parameters of the SDP that describes a relayed
+// stream.
+type sdpParams struct {
+	sessionID   uint32
+	payloadType uint8
+	// sps and pps are the most recently received parameter sets, if any
+	sps []byte
+	pps []byte
+}
+
+// encodeSDP encodes an SDP session description (RFC 4566) for a relayed H.264
+// stream (RFC 6184). If parameter sets are known, they are included so that
+// viewers can begin decoding without waiting for them to be repeated in-band.
+func encodeSDP(params sdpParams) string {
+	fmtp := []string{"packetization-mode=1"}
+	if len(params.sps) >= 4 {
+		fmtp = append(
+			fmtp,
+			fmt.Sprintf("profile-level-id=%x", params.sps[1:4]),
+		)
+		if params.pps != nil {
+			fmtp = append(
+				fmtp,
+				fmt.Sprintf(
+					"sprop-parameter-sets=%s,%s",
+					base64.StdEncoding.EncodeToString(params.sps),
+					base64.StdEncoding.EncodeToString(params.pps),
+				),
+			)
+		}
+	}
+	lines := []string{
+		"v=0",
+		fmt.Sprintf("o=- %d 1 IN IP4 0.0.0.0", params.sessionID),
+		"s=go-parrot",
+		"c=IN IP4 0.0.0.0",
+		"t=0 0",
+		fmt.Sprintf("m=video 0 RTP/AVP %d", params.payloadType),
+		fmt.Sprintf("a=rtpmap:%d H264/%d", params.payloadType, h264ClockRate),
+		fmt.Sprintf("a=fmtp:%d %s", params.payloadType, strings.Join(fmtp, ";")),
+		"a=control:" + relayControl,
+	}
+	return strings.Join(lines, "\r\n") + "\r\n"
+}
@@ -0,0 +1,4 @@
+package udp
+
+// The udp package holds definitions shared by the packages that implement
+// protocols over UDP.
@@ -0,0 +1,5 @@
+package udp
+
+// MaxDataBytes represents the practical maximum numbers of data bytes in a UDP
+// datagram.
+const MaxDataBytes = 65507
@@ -1,5 +1,10 @@
 package ptr
 
+// ToBool returns a pointer to a bool.
+func ToBool(val bool) *bool {
+	return &val
+}
+
 // ToString returns a pointer to a string.
 func ToString(val string) *string {
 	return &val
@@ -12,7 +12,9 @@ GO111MODULE=on go mod vendor
 
 GO111MODULE=off \
   golangci-lint run \
+	./cmd/... \
 	./examples/... \
 	./features/... \
   ./products/... \
-  ./protocols/...
+  ./protocols/... \
+  ./simulator/...
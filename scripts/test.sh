@@ -6,7 +6,9 @@ set -euxo pipefail
 
 GO111MODULE=on \
     go test -timeout 30s -race -coverprofile=coverage.txt -covermode=atomic \
+    ./cmd/... \
     ./examples/... \
     ./features/... \
     ./products/... \
-    ./protocols/...
+    ./protocols/... \
+    ./simulator/...
@@ -0,0 +1,93 @@
+package simulator
+
+import (
+	"time"
+
+	"github.com/krancour/go-parrot/protocols/arcommands"
+)
+
+// Command represents a command to be sent from the simulator to the client.
+type Command struct {
+	// BufferID is the ID of the d2c buffer the command is sent on. Commands sent
+	// on arcommands.D2CAckBufferID are sent with a request for acknowledgement.
+	BufferID  uint8
+	FeatureID uint8
+	ClassID   uint8
+	CommandID uint16
+	// Args must be of the exact types expected by the client for the command in
+	// question. See arcommands.C2DCommandClient's SendCommand function.
+	Args []interface{}
+}
+
+// Step is a single step of a script the simulator plays for the client once
+// connected.
+type Step struct {
+	// Delay is how long the simulator waits, after the previous step (or after
+	// the client connects, in the case of the first step), before sending the
+	// command. Note that clients typically begin listening for data only after
+	// connection negotiation completes, so a first step with no delay may be
+	// sent before the client is ready to receive it.
+	Delay   time.Duration
+	Command Command
+}
+
+// ReceivedCommand represents a command the simulator received from the client.
+type ReceivedCommand struct {
+	BufferID  uint8
+	FeatureID uint8
+	ClassID   uint8
+	CommandID uint16
+	// Data is the entire encoded command, including feature, class, and command
+	// IDs.
+	Data []byte
+}
+
+// FlyingStateChanged returns the ardrone3 PilotingState FlyingStateChanged
+// command with the given state.
+func FlyingStateChanged(state int32) Command {
+	return Command{
+		BufferID:  arcommands.D2CAckBufferID,
+		FeatureID: 1,
+		ClassID:   4,
+		CommandID: 1,
+		Args:      []interface{}{state},
+	}
+}
+
+// GpsLocationChanged returns the ardrone3 PilotingState GpsLocationChanged
+// command with the given location and accuracy.
+func GpsLocationChanged(
+	latitude float64,
+	longitude float64,
+	altitude float64,
+	latitudeAccuracy int8,
+	longitudeAccuracy int8,
+	altitudeAccuracy int8,
+) Command {
+	return Command{
+		BufferID:  arcommands.D2CNonAckBufferID,
+		FeatureID: 1,
+		ClassID:   4,
+		CommandID: 9,
+		Args: []interface{}{
+			latitude,
+			longitude,
+			altitude,
+			latitudeAccuracy,
+			longitudeAccuracy,
+			altitudeAccuracy,
+		},
+	}
+}
+
+// BatteryStateChanged returns the common CommonState BatteryStateChanged
+// command with the given battery percentage.
+func BatteryStateChanged(percent uint8) Command {
+	return Command{
+		BufferID:  arcommands.D2CAckBufferID,
+		FeatureID: 0,
+		ClassID:   5,
+		CommandID: 1,
+		Args:      []interface{}{percent},
+	}
+}
@@ -0,0 +1,11 @@
+package simulator
+
+// The simulator package implements the device end of the ARDiscovery
+// connection negotiation and of the ARNetworkAL protocol (as implemented over
+// UDP/IP by the arnetworkal/wifi package). It is intended to facilitate
+// integration testing of controllers on localhost, without a real device.
+//
+// The simulator answers connection negotiation requests, acknowledges frames
+// received on buffers that require acknowledgement, sends pings, records
+// commands received from the client, and sends scripted commands to the
+// client. It does not simulate flight.
@@ -0,0 +1,248 @@
+package simulator
+
+import (
+	"bytes"
+	"encoding/binary"
+	"net"
+	"sync"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/krancour/go-parrot/protocols/arcommands"
+	"github.com/krancour/go-parrot/protocols/arnetworkal"
+	"github.com/krancour/go-parrot/protocols/arnetworkal/wifi"
+	"github.com/pkg/errors"
+)
+
+const (
+	// pingBufferID is the ID of the buffer the device sends pings on.
+	pingBufferID uint8 = 0
+	// pongBufferID is the ID of the buffer the client sends pongs on.
+	pongBufferID uint8 = 1
+	// ackBufferOffset is added to the ID of a buffer that requires
+	// acknowledgement to obtain the ID of the buffer acknowledgements are sent
+	// on.
+	ackBufferOffset uint8 = 128
+	// maxUDPDataBytes represents the practical maximum numbers of data bytes in a
+	// UDP datagram.
+	maxUDPDataBytes = 65507
+)
+
+// session represents the simulator's end of a single connection with a
+// client.
+type session struct {
+	conn               *net.UDPConn
+	d2cAddr            *net.UDPAddr
+	pingInterval       time.Duration
+	script             []Step
+	receivedCommandsCh chan<- ReceivedCommand
+	// seqs tracks the sequence number of the last frame sent on each buffer
+	seqs     map[uint8]uint8
+	seqsLock sync.Mutex
+	// receivedSeqs tracks the sequence number of the last frame received on
+	// each buffer that requires acknowledgement
+	receivedSeqs map[uint8]uint8
+	stopCh       chan struct{}
+	stopOnce     sync.Once
+	wg           sync.WaitGroup
+}
+
+func newSession(
+	conn *net.UDPConn,
+	d2cAddr *net.UDPAddr,
+	pingInterval time.Duration,
+	script []Step,
+	receivedCommandsCh chan<- ReceivedCommand,
+) *session {
+	return &session{
+		conn:               conn,
+		d2cAddr:            d2cAddr,
+		pingInterval:       pingInterval,
+		script:             script,
+		receivedCommandsCh: receivedCommandsCh,
+		seqs:               map[uint8]uint8{},
+		receivedSeqs:       map[uint8]uint8{},
+		stopCh:             make(chan struct{}),
+	}
+}
+
+func (s *session) start() {
+	s.wg.Add(3)
+	go s.receiveFrames()
+	go s.sendPings()
+	go s.playScript()
+}
+
+// stop stops the session and waits for all of its goroutines to finish. The
+// client is not notified.
+func (s *session) stop() {
+	s.stopOnce.Do(func() {
+		close(s.stopCh)
+		if err := s.conn.Close(); err != nil {
+			log.Errorf("error closing simulator c2d connection: %s", err)
+		}
+		s.wg.Wait()
+		log.Debug("simulator session stopped")
+	})
+}
+
+func (s *session) receiveFrames() {
+	defer s.wg.Done()
+	datagramBuffer := make([]byte, maxUDPDataBytes)
+	for {
+		bytesRead, _, err := s.conn.ReadFromUDP(datagramBuffer)
+		if err != nil {
+			select {
+			case <-s.stopCh:
+			default:
+				log.Errorf("error receiving datagram from client: %s", err)
+			}
+			return
+		}
+		data := make([]byte, bytesRead)
+		copy(data, datagramBuffer[:bytesRead])
+		frames, err := wifi.DecodeDatagram(data)
+		if err != nil {
+			log.Errorf("error decoding datagram from client: %s", err)
+			continue
+		}
+		for _, frame := range frames {
+			s.handleFrame(frame)
+		}
+	}
+}
+
+func (s *session) handleFrame(frame arnetworkal.Frame) {
+	log := log.WithField(
+		"buffer", frame.ID,
+	).WithField(
+		"type", frame.Type,
+	).WithField(
+		"seq", frame.Seq,
+	)
+	switch {
+	case frame.Type == arnetworkal.FrameTypeAck:
+		log.Debug("simulator received acknowledgement")
+		return
+	case frame.ID == pongBufferID:
+		log.Debug("simulator received pong")
+		return
+	case frame.Type == arnetworkal.FrameTypeDataWithAck:
+		if err := s.send(
+			arnetworkal.FrameTypeAck,
+			frame.ID+ackBufferOffset,
+			[]byte{frame.Seq},
+		); err != nil {
+			log.Errorf("error acknowledging frame: %s", err)
+		}
+		if lastSeq, ok := s.receivedSeqs[frame.ID]; ok && lastSeq == frame.Seq {
+			log.Debug("simulator received retransmitted frame; ignoring it")
+			return
+		}
+		s.receivedSeqs[frame.ID] = frame.Seq
+	}
+	featureID, classID, commandID, err := arcommands.ParseIDs(frame.Data)
+	if err != nil {
+		log.Errorf("error parsing command received by simulator: %s", err)
+		return
+	}
+	select {
+	case s.receivedCommandsCh <- ReceivedCommand{
+		BufferID:  frame.ID,
+		FeatureID: featureID,
+		ClassID:   classID,
+		CommandID: commandID,
+		Data:      frame.Data,
+	}:
+	default:
+		log.Warn("simulator received commands channel is full; dropping command")
+	}
+}
+
+// sendPings pings the client periodically. The client is expected to echo the
+// data of each ping on the pong buffer.
+func (s *session) sendPings() {
+	defer s.wg.Done()
+	ticker := time.NewTicker(s.pingInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case now := <-ticker.C:
+			// A ping carries a timespec: seconds and nanoseconds
+			buf := &bytes.Buffer{}
+			if err := binary.Write(
+				buf,
+				binary.LittleEndian,
+				[]int32{int32(now.Unix()), int32(now.Nanosecond())},
+			); err != nil {
+				log.Errorf("error encoding ping: %s", err)
+				continue
+			}
+			if err := s.send(
+				arnetworkal.FrameTypeData,
+				pingBufferID,
+				buf.Bytes(),
+			); err != nil {
+				log.Errorf("error sending ping: %s", err)
+			}
+		case <-s.stopCh:
+			return
+		}
+	}
+}
+
+func (s *session) playScript() {
+	defer s.wg.Done()
+	for _, step := range s.script {
+		select {
+		case <-time.After(step.Delay):
+		case <-s.stopCh:
+			return
+		}
+		if err := s.sendCommand(step.Command); err != nil {
+			log.Errorf("error sending scripted command: %s", err)
+		}
+	}
+}
+
+func (s *session) sendCommand(command Command) error {
+	data, err := arcommands.EncodeCommand(
+		command.FeatureID,
+		command.ClassID,
+		command.CommandID,
+		command.Args,
+	)
+	if err != nil {
+		return errors.Wrap(err, "error encoding command")
+	}
+	frameType := arnetworkal.FrameTypeData
+	if command.BufferID == arcommands.D2CAckBufferID {
+		frameType = arnetworkal.FrameTypeDataWithAck
+	}
+	return s.send(frameType, command.BufferID, data)
+}
+
+func (s *session) send(
+	frameType arnetworkal.FrameType,
+	bufferID uint8,
+	data []byte,
+) error {
+	s.seqsLock.Lock()
+	defer s.seqsLock.Unlock()
+	s.seqs[bufferID]++
+	datagram, err := wifi.EncodeFrame(
+		arnetworkal.Frame{
+			Type: frameType,
+			ID:   bufferID,
+			Seq:  s.seqs[bufferID],
+			Data: data,
+		},
+	)
+	if err != nil {
+		return errors.Wrap(err, "error encoding frame")
+	}
+	if _, err := s.conn.WriteToUDP(datagram, s.d2cAddr); err != nil {
+		return errors.Wrap(err, "error sending datagram")
+	}
+	return nil
+}
@@ -0,0 +1,248 @@
+package simulator
+
+import (
+	"bufio"
+	"encoding/json"
+	"net"
+	"sync"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/krancour/go-parrot/protocols/arnetworkal/wifi"
+	"github.com/pkg/errors"
+)
+
+const (
+	// DefaultPingInterval is the interval at which the simulator pings the
+	// client, unless otherwise specified.
+	DefaultPingInterval = 500 * time.Millisecond
+	// receivedCommandsChSize is the buffer size of the channel over which
+	// commands received from the client are delivered.
+	receivedCommandsChSize = 100
+)
+
+// Config represents the configuration of a simulator. The zero value for any
+// field selects a sensible default.
+type Config struct {
+	// IP is the IP address the simulator listens on. If nil, 127.0.0.1 is used.
+	IP net.IP
+	// DiscoveryPort is the TCP port on which the simulator listens for
+	// connection negotiation requests. If zero, an available port is selected
+	// automatically.
+	DiscoveryPort int
+	// PingInterval is the interval at which the simulator pings the client. If
+	// zero, DefaultPingInterval is used.
+	PingInterval time.Duration
+	// ConnectionParams are communicated to the client during connection
+	// negotiation. The C2DPort is ignored, as the simulator always selects an
+	// available port.
+	ConnectionParams wifi.ConnectionParams
+	// Script is played for the client every time it connects.
+	Script []Step
+}
+
+// Simulator is the interface for a simulated device.
+type Simulator interface {
+	// ConnectOptions returns options a client can use to connect to the
+	// simulator.
+	ConnectOptions() wifi.ConnectOptions
+	// SendCommand sends the given command to the currently connected client.
+	// Commands sent on a buffer that requires acknowledgement are sent once and
+	// never retried. An error is returned if no client is connected.
+	SendCommand(command Command) error
+	// ReceivedCommandsCh returns a channel over which commands received from the
+	// client are delivered. Frames the client retransmits because an
+	// acknowledgement was lost are delivered only once. Delivery is
+	// non-blocking; if the channel's buffer is full, commands are dropped. The
+	// channel is closed when the simulator is closed.
+	ReceivedCommandsCh() <-chan ReceivedCommand
+	// Disconnect abruptly stops all communication with the currently connected
+	// client, without notice, as if the device had gone out of range. The
+	// simulator continues to accept new connections.
+	Disconnect()
+	// Close stops the simulator.
+	Close()
+}
+
+type simulator struct {
+	config             Config
+	listener           *net.TCPListener
+	receivedCommandsCh chan ReceivedCommand
+	session            *session
+	sessionLock        sync.Mutex
+	doneCh             chan struct{}
+	closeOnce          sync.Once
+}
+
+type connectionNegotiationRequest struct {
+	D2CPort        int    `json:"d2c_port"`
+	ControllerType string `json:"controller_type"`
+	ControllerName string `json:"controller_name"`
+}
+
+type connectionNegotiationResponse struct {
+	Status int `json:"status"`
+	wifi.ConnectionParams
+}
+
+// NewSimulator returns a Simulator that is listening for connection
+// negotiation requests.
+func NewSimulator(config Config) (Simulator, error) {
+	if config.IP == nil {
+		config.IP = net.IPv4(127, 0, 0, 1)
+	}
+	if config.PingInterval == 0 {
+		config.PingInterval = DefaultPingInterval
+	}
+	if config.PingInterval < 0 {
+		return nil,
+			errors.Errorf("invalid ping interval %s", config.PingInterval)
+	}
+	listener, err := net.ListenTCP(
+		"tcp",
+		&net.TCPAddr{
+			IP:   config.IP,
+			Port: config.DiscoveryPort,
+		},
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "error listening for connection negotiation")
+	}
+	config.DiscoveryPort = listener.Addr().(*net.TCPAddr).Port
+	s := &simulator{
+		config:             config,
+		listener:           listener,
+		receivedCommandsCh: make(chan ReceivedCommand, receivedCommandsChSize),
+		doneCh:             make(chan struct{}),
+	}
+	go s.acceptConnections()
+	log.WithField(
+		"ip", config.IP,
+	).WithField(
+		"discoveryPort", config.DiscoveryPort,
+	).Debug("simulator is listening for connection negotiation requests")
+	return s, nil
+}
+
+func (s *simulator) ConnectOptions() wifi.ConnectOptions {
+	return wifi.ConnectOptions{
+		DeviceIP:      s.config.IP,
+		DiscoveryPort: s.config.DiscoveryPort,
+	}
+}
+
+func (s *simulator) SendCommand(command Command) error {
+	s.sessionLock.Lock()
+	sess := s.session
+	s.sessionLock.Unlock()
+	if sess == nil {
+		return errors.New("no client is connected")
+	}
+	return sess.sendCommand(command)
+}
+
+func (s *simulator) ReceivedCommandsCh() <-chan ReceivedCommand {
+	return s.receivedCommandsCh
+}
+
+func (s *simulator) Disconnect() {
+	s.setSession(nil)
+}
+
+func (s *simulator) Close() {
+	s.closeOnce.Do(func() {
+		log.Debug("closing simulator")
+		if err := s.listener.Close(); err != nil {
+			log.Errorf("error closing simulator listener: %s", err)
+		}
+		<-s.doneCh
+		s.setSession(nil)
+		close(s.receivedCommandsCh)
+		log.Debug("closed simulator")
+	})
+}
+
+// setSession replaces the current session, if any, with the given one. The
+// replaced session is stopped.
+func (s *simulator) setSession(sess *session) {
+	s.sessionLock.Lock()
+	oldSess := s.session
+	s.session = sess
+	s.sessionLock.Unlock()
+	if oldSess != nil {
+		oldSess.stop()
+	}
+}
+
+// acceptConnections handles connection negotiation requests until the
+// listener is closed. Each successful negotiation starts a new session,
+// replacing any existing one.
+func (s *simulator) acceptConnections() {
+	defer close(s.doneCh)
+	for {
+		conn, err := s.listener.AcceptTCP()
+		if err != nil {
+			log.Debugf("simulator no longer accepting connections: %s", err)
+			return
+		}
+		sess, err := s.negotiateConnection(conn)
+		if err != nil {
+			log.Errorf("simulator connection negotiation failed: %s", err)
+			continue
+		}
+		s.setSession(sess)
+		sess.start()
+	}
+}
+
+func (s *simulator) negotiateConnection(conn *net.TCPConn) (*session, error) {
+	defer conn.Close() // nolint: errcheck
+	data, err := bufio.NewReader(conn).ReadBytes(0x00)
+	if err != nil {
+		return nil,
+			errors.Wrap(err, "error receiving connection negotiation request")
+	}
+	var req connectionNegotiationRequest
+	if err = json.Unmarshal(data[:len(data)-1], &req); err != nil {
+		return nil,
+			errors.Wrap(err, "error unmarshaling connection negotiation request")
+	}
+	c2dConn, err := net.ListenUDP("udp", &net.UDPAddr{IP: s.config.IP})
+	if err != nil {
+		return nil, errors.Wrap(err, "error establishing c2d connection")
+	}
+	res := connectionNegotiationResponse{
+		ConnectionParams: s.config.ConnectionParams,
+	}
+	res.C2DPort = c2dConn.LocalAddr().(*net.UDPAddr).Port
+	jsonBytes, err := json.Marshal(res)
+	if err != nil {
+		c2dConn.Close() // nolint: errcheck
+		return nil,
+			errors.Wrap(err, "error marshaling connection negotiation response")
+	}
+	// Use a null character to terminate the response
+	if _, err = conn.Write(append(jsonBytes, 0x00)); err != nil {
+		c2dConn.Close() // nolint: errcheck
+		return nil,
+			errors.Wrap(err, "error sending connection negotiation response")
+	}
+	d2cAddr := &net.UDPAddr{
+		IP:   conn.RemoteAddr().(*net.TCPAddr).IP,
+		Port: req.D2CPort,
+	}
+	log.WithField(
+		"controllerName", req.ControllerName,
+	).WithField(
+		"c2dPort", res.C2DPort,
+	).WithField(
+		"d2cAddr", d2cAddr,
+	).Debug("simulator negotiated connection")
+	return newSession(
+		c2dConn,
+		d2cAddr,
+		s.config.PingInterval,
+		s.config.Script,
+		s.receivedCommandsCh,
+	), nil
+}
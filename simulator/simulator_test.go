@@ -0,0 +1,128 @@
+package simulator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/krancour/go-parrot/protocols/arcommands"
+	"github.com/krancour/go-parrot/protocols/arnetworkal"
+	"github.com/krancour/go-parrot/protocols/arnetworkal/wifi"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSimulator(t *testing.T) {
+	sim, err := NewSimulator(
+		Config{
+			PingInterval: 20 * time.Millisecond,
+			ConnectionParams: wifi.ConnectionParams{
+				ARStreamFragmentSize: 1000,
+			},
+			Script: []Step{
+				{
+					// Give the client a moment to start listening
+					Delay:   100 * time.Millisecond,
+					Command: BatteryStateChanged(42),
+				},
+			},
+		},
+	)
+	require.NoError(t, err)
+	defer sim.Close()
+
+	connectOpts := sim.ConnectOptions()
+	connectOpts.ReadTimeout = time.Second
+	frameSender, frameReceiver, connectionParams, err :=
+		wifi.Connect(connectOpts)
+	require.NoError(t, err)
+	defer frameSender.Close()
+	defer frameReceiver.Close()
+	require.Equal(t, 1000, connectionParams.ARStreamFragmentSize)
+
+	// receiveFrame receives frames until one satisfies the given predicate
+	receiveFrame := func(
+		predicate func(arnetworkal.Frame) bool,
+	) arnetworkal.Frame {
+		deadline := time.Now().Add(time.Second)
+		for time.Now().Before(deadline) {
+			frames, err := frameReceiver.Receive() // nolint: vetshadow
+			require.NoError(t, err)
+			for _, frame := range frames {
+				if predicate(frame) {
+					return frame
+				}
+			}
+		}
+		require.FailNow(t, "timed out waiting for frame")
+		return arnetworkal.Frame{}
+	}
+
+	// The scripted command should arrive, requesting acknowledgement
+	frame := receiveFrame(func(frame arnetworkal.Frame) bool {
+		return frame.ID == arcommands.D2CAckBufferID
+	})
+	require.Equal(t, arnetworkal.FrameTypeDataWithAck, frame.Type)
+	expectedData, err :=
+		arcommands.EncodeCommand(0, 5, 1, []interface{}{uint8(42)})
+	require.NoError(t, err)
+	require.Equal(t, expectedData, frame.Data)
+
+	// Pings should arrive
+	frame = receiveFrame(func(frame arnetworkal.Frame) bool {
+		return frame.ID == pingBufferID
+	})
+	require.Len(t, frame.Data, 8)
+
+	// Frames requiring acknowledgement should be acknowledged and retransmitted
+	// frames should only be delivered once
+	data, err := arcommands.EncodeCommand(0, 4, 0, nil)
+	require.NoError(t, err)
+	for i := 0; i < 2; i++ {
+		err = frameSender.Send(
+			arnetworkal.Frame{
+				Type: arnetworkal.FrameTypeDataWithAck,
+				ID:   arcommands.C2DAckBufferID,
+				Seq:  7,
+				Data: data,
+			},
+		)
+		require.NoError(t, err)
+		frame = receiveFrame(func(frame arnetworkal.Frame) bool {
+			return frame.Type == arnetworkal.FrameTypeAck
+		})
+		require.Equal(t, arcommands.C2DAckBufferID+ackBufferOffset, frame.ID)
+		require.Equal(t, []byte{7}, frame.Data)
+	}
+	select {
+	case cmd := <-sim.ReceivedCommandsCh():
+		require.Equal(
+			t,
+			ReceivedCommand{
+				BufferID:  arcommands.C2DAckBufferID,
+				FeatureID: 0,
+				ClassID:   4,
+				CommandID: 0,
+				Data:      data,
+			},
+			cmd,
+		)
+	case <-time.After(time.Second):
+		require.Fail(t, "timed out waiting for received command")
+	}
+	select {
+	case cmd := <-sim.ReceivedCommandsCh():
+		require.Fail(t, "received unexpected command", "%v", cmd)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	// Once disconnected, the simulator should stop talking to the client
+	sim.Disconnect()
+	err = sim.SendCommand(FlyingStateChanged(1))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "no client is connected")
+}
+
+func TestNewSimulatorWithInvalidPingInterval(t *testing.T) {
+	_, err := NewSimulator(Config{PingInterval: -time.Second})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid ping interval")
+}